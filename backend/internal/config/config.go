@@ -3,12 +3,20 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Server   ServerConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	Server         ServerConfig
+	Reservations   ReservationConfig
+	Receiving      ReceivingConfig
+	Matching       MatchingConfig
+	Approvals      ApprovalConfig
+	Alerts         AlertConfig
+	Reconciliation ReconciliationConfig
+	Snapshots      SnapshotConfig
 }
 
 type DatabaseConfig struct {
@@ -32,6 +40,51 @@ type ServerConfig struct {
 	Host string
 }
 
+type ReservationConfig struct {
+	SweepInterval int // seconds between releases of expired reservations
+}
+
+type ReceivingConfig struct {
+	OverReceiptTolerance  int // percent a PO line may be received above its ordered quantity
+	UnderReceiptTolerance int // percent a PO line may fall short and still count as fully received
+}
+
+type MatchingConfig struct {
+	QuantityTolerance int // percent an invoiced quantity may exceed the received or ordered quantity
+	PriceTolerance    int // percent an invoiced unit price may differ from the PO unit price
+}
+
+type ApprovalConfig struct {
+	ManagerThreshold int // PO total above which a manager or admin must approve
+	AdminThreshold   int // PO total above which an admin must approve
+}
+
+type AlertConfig struct {
+	EvaluateInterval int // seconds between full evaluations of every stock level
+	RealertWindow    int // minutes after an alert resolves before the same alert can be raised again
+
+	// Email notifications are sent when a host and recipients are set
+	SMTPHost        string
+	SMTPPort        string
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPFrom        string
+	EmailRecipients []string
+
+	// Webhook notifications are posted when a URL is set, signed with
+	// HMAC-SHA256 when a secret is set
+	WebhookURL    string
+	WebhookSecret string
+}
+
+type ReconciliationConfig struct {
+	Interval int // seconds between reports of stock balances that disagree with the movement ledger
+}
+
+type SnapshotConfig struct {
+	Interval int // seconds between stock snapshots used by point-in-time stock queries
+}
+
 func Load() *Config {
 	return &Config{
 		Database: DatabaseConfig{
@@ -52,6 +105,39 @@ func Load() *Config {
 			Port: getEnv("SERVER_PORT", "8080"),
 			Host: getEnv("SERVER_HOST", "0.0.0.0"),
 		},
+		Reservations: ReservationConfig{
+			SweepInterval: getEnvAsPositiveInt("RESERVATION_SWEEP_INTERVAL", 60), // 1 minute
+		},
+		Receiving: ReceivingConfig{
+			OverReceiptTolerance:  getEnvAsInt("RECEIVING_OVER_TOLERANCE_PERCENT", 0),
+			UnderReceiptTolerance: getEnvAsInt("RECEIVING_UNDER_TOLERANCE_PERCENT", 0),
+		},
+		Matching: MatchingConfig{
+			QuantityTolerance: getEnvAsInt("INVOICE_QUANTITY_TOLERANCE_PERCENT", 0),
+			PriceTolerance:    getEnvAsInt("INVOICE_PRICE_TOLERANCE_PERCENT", 0),
+		},
+		Approvals: ApprovalConfig{
+			ManagerThreshold: getEnvAsInt("PO_APPROVAL_MANAGER_THRESHOLD", 1000),
+			AdminThreshold:   getEnvAsInt("PO_APPROVAL_ADMIN_THRESHOLD", 10000),
+		},
+		Alerts: AlertConfig{
+			EvaluateInterval: getEnvAsPositiveInt("ALERT_EVALUATE_INTERVAL", 300), // 5 minutes
+			RealertWindow:    getEnvAsInt("ALERT_REALERT_WINDOW_MINUTES", 60),
+			SMTPHost:         getEnv("ALERT_SMTP_HOST", ""),
+			SMTPPort:         getEnv("ALERT_SMTP_PORT", "587"),
+			SMTPUsername:     getEnv("ALERT_SMTP_USERNAME", ""),
+			SMTPPassword:     getEnv("ALERT_SMTP_PASSWORD", ""),
+			SMTPFrom:         getEnv("ALERT_SMTP_FROM", "inventory@localhost"),
+			EmailRecipients:  getEnvAsList("ALERT_EMAIL_RECIPIENTS"),
+			WebhookURL:       getEnv("ALERT_WEBHOOK_URL", ""),
+			WebhookSecret:    getEnv("ALERT_WEBHOOK_SECRET", ""),
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval: getEnvAsPositiveInt("STOCK_RECONCILIATION_INTERVAL", 86400), // 1 day
+		},
+		Snapshots: SnapshotConfig{
+			Interval: getEnvAsPositiveInt("STOCK_SNAPSHOT_INTERVAL", 86400), // 1 day
+		},
 	}
 }
 
@@ -71,6 +157,27 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsPositiveInt is getEnvAsInt for values that must be above zero,
+// such as intervals; zero and negative values fall back to the default
+func getEnvAsPositiveInt(key string, defaultValue int) int {
+	if value := getEnvAsInt(key, defaultValue); value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsList splits a comma-separated variable, dropping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+
 
 
 
@@ -0,0 +1,163 @@
+// Package costing values stock as it moves, under either FIFO or moving
+// weighted average costing. It holds no state of its own: callers load a
+// Position, apply movements to it in processed order and persist the result.
+package costing
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Costing methods
+const (
+	MethodFIFO    = "fifo"
+	MethodAverage = "average"
+)
+
+// ValidMethod reports whether method is a known costing method
+func ValidMethod(method string) bool {
+	return method == MethodFIFO || method == MethodAverage
+}
+
+// Layer is the unconsumed part of a receipt under FIFO costing
+type Layer struct {
+	// MovementID is the receipt, or uuid.Nil for stock a position was opened with
+	MovementID uuid.UUID
+	ReceivedAt time.Time
+	Quantity   int // quantity originally received
+	Remaining  int
+	UnitCost   float64
+}
+
+// Position is the costed stock of one product. Under FIFO the quantity and
+// value are the sum of the open layers; under average costing only the totals
+// are kept.
+type Position struct {
+	Method   string
+	Quantity int
+	Value    float64
+	// UnitCost is the current unit cost, kept at its last value once the
+	// position is empty so later issues and uncosted receipts can be valued
+	UnitCost float64
+	Layers   []Layer
+}
+
+// NewPosition returns an empty position costed under method
+func NewPosition(method string) *Position {
+	return &Position{Method: method}
+}
+
+// Receive adds quantity units at unitCost
+func (p *Position) Receive(movementID uuid.UUID, receivedAt time.Time, quantity int, unitCost float64) {
+	if quantity <= 0 {
+		return
+	}
+	if p.Method == MethodFIFO {
+		p.Layers = append(p.Layers, Layer{
+			MovementID: movementID,
+			ReceivedAt: receivedAt,
+			Quantity:   quantity,
+			Remaining:  quantity,
+			UnitCost:   unitCost,
+		})
+		p.sumLayers()
+		return
+	}
+
+	p.Quantity += quantity
+	p.Value += float64(quantity) * unitCost
+	p.UnitCost = p.Value / float64(p.Quantity)
+}
+
+// Open puts quantity units worth value into an empty position, such as the
+// stock recorded at the end of a closed period. Under FIFO they form one
+// layer at their average cost that belongs to no receipt.
+func (p *Position) Open(at time.Time, quantity int, value float64) {
+	if quantity <= 0 {
+		return
+	}
+	p.Receive(uuid.Nil, at, quantity, value/float64(quantity))
+}
+
+// Issue takes quantity units out of the position and returns their cost.
+// FIFO consumes the oldest layers first; average costing issues at the
+// current unit cost. Units beyond the costed quantity, such as stock that was
+// on hand before costing began, are valued at the current unit cost.
+func (p *Position) Issue(quantity int) float64 {
+	if quantity <= 0 {
+		return 0
+	}
+	if p.Method != MethodFIFO {
+		p.Quantity = max(p.Quantity-quantity, 0)
+		p.Value = float64(p.Quantity) * p.UnitCost
+		return float64(quantity) * p.UnitCost
+	}
+
+	var cost float64
+	remaining := quantity
+	for remaining > 0 && len(p.Layers) > 0 {
+		layer := &p.Layers[0]
+		take := min(remaining, layer.Remaining)
+		cost += float64(take) * layer.UnitCost
+		layer.Remaining -= take
+		remaining -= take
+		if layer.Remaining == 0 {
+			p.Layers = p.Layers[1:]
+		}
+	}
+	cost += float64(remaining) * p.UnitCost
+	p.sumLayers()
+	return cost
+}
+
+// sumLayers recomputes the totals from the open FIFO layers
+func (p *Position) sumLayers() {
+	p.Quantity = 0
+	p.Value = 0
+	for _, layer := range p.Layers {
+		p.Quantity += layer.Remaining
+		p.Value += float64(layer.Remaining) * layer.UnitCost
+	}
+	if p.Quantity > 0 {
+		p.UnitCost = p.Value / float64(p.Quantity)
+	}
+}
+
+// Round rounds a cost to the four decimal places it is stored with
+func Round(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+// Allocate splits total across shares in proportion to their weights, such as
+// the components a kit is broken into. Shares are rounded like costs and the
+// rounding difference is put on the last share so they add up to total. With
+// no positive weights the total is split evenly.
+func Allocate(total float64, weights []float64) []float64 {
+	shares := make([]float64, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+
+	var sum float64
+	for _, weight := range weights {
+		if weight > 0 {
+			sum += weight
+		}
+	}
+
+	var allocated float64
+	for i, weight := range weights {
+		switch {
+		case sum == 0:
+			shares[i] = Round(total / float64(len(weights)))
+		case weight > 0:
+			shares[i] = Round(total * weight / sum)
+		}
+		allocated += shares[i]
+	}
+	last := len(shares) - 1
+	shares[last] = Round(shares[last] + total - allocated)
+	return shares
+}
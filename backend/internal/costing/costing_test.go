@@ -0,0 +1,114 @@
+package costing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFIFOIssueConsumesOldestLayers(t *testing.T) {
+	p := NewPosition(MethodFIFO)
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.Receive(uuid.New(), day, 10, 2)
+	p.Receive(uuid.New(), day.AddDate(0, 0, 1), 10, 3)
+
+	if cost := p.Issue(15); cost != 35 {
+		t.Errorf("Issue(15) = %v, want 35", cost)
+	}
+	if p.Quantity != 5 || p.Value != 15 || p.UnitCost != 3 {
+		t.Errorf("position = %d/%v/%v, want 5/15/3", p.Quantity, p.Value, p.UnitCost)
+	}
+	if len(p.Layers) != 1 || p.Layers[0].Remaining != 5 || p.Layers[0].Quantity != 10 {
+		t.Errorf("layers = %+v, want one layer with 5 of 10 remaining", p.Layers)
+	}
+}
+
+func TestFIFOIssueBeyondLayersUsesLastUnitCost(t *testing.T) {
+	p := NewPosition(MethodFIFO)
+	p.Receive(uuid.New(), time.Now(), 4, 5)
+
+	if cost := p.Issue(6); cost != 30 {
+		t.Errorf("Issue(6) = %v, want 30", cost)
+	}
+	if p.Quantity != 0 || p.Value != 0 || p.UnitCost != 5 {
+		t.Errorf("position = %d/%v/%v, want 0/0/5", p.Quantity, p.Value, p.UnitCost)
+	}
+}
+
+func TestOpenStartsFromRecordedBalance(t *testing.T) {
+	at := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	fifo := NewPosition(MethodFIFO)
+	fifo.Open(at, 10, 25)
+	if len(fifo.Layers) != 1 || fifo.Layers[0].MovementID != uuid.Nil || fifo.Layers[0].UnitCost != 2.5 {
+		t.Errorf("layers = %+v, want one opening layer at 2.5", fifo.Layers)
+	}
+	if cost := fifo.Issue(4); cost != 10 {
+		t.Errorf("Issue(4) = %v, want 10", cost)
+	}
+
+	average := NewPosition(MethodAverage)
+	average.Open(at, 10, 25)
+	if average.Quantity != 10 || average.Value != 25 || average.UnitCost != 2.5 {
+		t.Errorf("position = %d/%v/%v, want 10/25/2.5", average.Quantity, average.Value, average.UnitCost)
+	}
+
+	empty := NewPosition(MethodAverage)
+	empty.Open(at, 0, 0)
+	if empty.Quantity != 0 || empty.Value != 0 {
+		t.Errorf("position = %d/%v, want empty", empty.Quantity, empty.Value)
+	}
+}
+
+func TestAverageIssueUsesMovingAverage(t *testing.T) {
+	p := NewPosition(MethodAverage)
+	p.Receive(uuid.New(), time.Now(), 10, 2)
+	p.Receive(uuid.New(), time.Now(), 10, 4)
+
+	if cost := p.Issue(5); cost != 15 {
+		t.Errorf("Issue(5) = %v, want 15", cost)
+	}
+	p.Receive(uuid.New(), time.Now(), 5, 9)
+	if p.Quantity != 20 || p.Value != 90 || p.UnitCost != 4.5 {
+		t.Errorf("position = %d/%v/%v, want 20/90/4.5", p.Quantity, p.Value, p.UnitCost)
+	}
+	if len(p.Layers) != 0 {
+		t.Errorf("average costing kept %d layers", len(p.Layers))
+	}
+}
+
+func TestRound(t *testing.T) {
+	if got := Round(10.0 / 3); got != 3.3333 {
+		t.Errorf("Round(10/3) = %v, want 3.3333", got)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   float64
+		weights []float64
+		want    []float64
+	}{
+		{"proportional", 100, []float64{30, 10}, []float64{75, 25}},
+		{"rounding goes to last share", 10, []float64{1, 1, 1}, []float64{3.3333, 3.3333, 3.3334}},
+		{"no weights splits evenly", 9, []float64{0, 0, 0}, []float64{3, 3, 3}},
+		{"zero weight gets nothing", 12, []float64{0, 4}, []float64{0, 12}},
+		{"empty", 5, nil, []float64{}},
+	}
+
+	for _, tt := range tests {
+		got := Allocate(tt.total, tt.weights)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Allocate() = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: Allocate() = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
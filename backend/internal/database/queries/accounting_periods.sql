@@ -0,0 +1,77 @@
+-- name: CreateAccountingPeriod :one
+INSERT INTO accounting_periods (name, starts_at, ends_at, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAccountingPeriod :one
+SELECT * FROM accounting_periods
+WHERE id = $1;
+
+-- name: ListAccountingPeriodsForUpdate :many
+SELECT * FROM accounting_periods
+ORDER BY starts_at
+FOR UPDATE;
+
+-- name: ListAccountingPeriodsEndingFrom :many
+SELECT * FROM accounting_periods
+WHERE ends_at > $1
+ORDER BY starts_at
+FOR SHARE;
+
+-- name: GetLatestClosedAccountingPeriod :one
+SELECT * FROM accounting_periods
+WHERE status = 'closed'
+ORDER BY ends_at DESC
+LIMIT 1
+FOR SHARE;
+
+-- name: ListAccountingPeriods :many
+SELECT * FROM accounting_periods
+WHERE (NULLIF($1::text, '') IS NULL OR status = $1)
+ORDER BY starts_at DESC;
+
+-- name: CloseAccountingPeriod :one
+UPDATE accounting_periods
+SET status = 'closed', closed_by = $2, closed_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ReopenAccountingPeriod :one
+UPDATE accounting_periods
+SET status = 'open', reopened_by = $2, reopened_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateAccountingPeriodBalances :exec
+INSERT INTO accounting_period_balances (period_id, product_id, warehouse_id, quantity, total_value)
+SELECT $1, sm.product_id, sm.warehouse_id,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END),
+       SUM(CASE WHEN sm.movement_type = 'out' THEN -1 ELSE 1 END
+           * COALESCE(sm.total_cost, sm.quantity * sm.cost_price, 0))
+FROM stock_movements sm
+WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+  AND COALESCE(sm.processed_date, sm.created_at) < $2::timestamptz
+GROUP BY sm.product_id, sm.warehouse_id
+HAVING SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END) <> 0
+    OR SUM(CASE WHEN sm.movement_type = 'out' THEN -1 ELSE 1 END
+           * COALESCE(sm.total_cost, sm.quantity * sm.cost_price, 0)) <> 0;
+
+-- name: DeleteAccountingPeriodBalances :exec
+DELETE FROM accounting_period_balances
+WHERE period_id = $1;
+
+-- name: ListAccountingPeriodBalances :many
+SELECT apb.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM accounting_period_balances apb
+JOIN products p ON apb.product_id = p.id
+JOIN warehouses w ON apb.warehouse_id = w.id
+WHERE apb.period_id = $1
+  AND ($2::uuid IS NULL OR apb.product_id = $2)
+  AND ($3::uuid IS NULL OR apb.warehouse_id = $3)
+ORDER BY p.name, w.name;
+
+-- name: GetAccountingPeriodProductBalance :one
+SELECT COALESCE(SUM(quantity), 0)::int as quantity,
+       COALESCE(SUM(total_value), 0)::numeric as total_value
+FROM accounting_period_balances
+WHERE period_id = $1 AND product_id = $2;
@@ -0,0 +1,28 @@
+-- name: CreateAdjustmentReasonCode :one
+INSERT INTO adjustment_reason_codes (code, name, description, is_active)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAdjustmentReasonCode :one
+SELECT * FROM adjustment_reason_codes
+WHERE id = $1;
+
+-- name: GetAdjustmentReasonCodeByCode :one
+SELECT * FROM adjustment_reason_codes
+WHERE code = $1;
+
+-- name: ListAdjustmentReasonCodes :many
+SELECT * FROM adjustment_reason_codes
+WHERE ($1::boolean OR is_active = true)
+ORDER BY name;
+
+-- name: UpdateAdjustmentReasonCode :one
+UPDATE adjustment_reason_codes
+SET name = $2, description = $3, is_active = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAdjustmentReasonCode :exec
+UPDATE adjustment_reason_codes
+SET is_active = false, updated_at = NOW()
+WHERE id = $1;
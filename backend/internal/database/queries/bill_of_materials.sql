@@ -0,0 +1,104 @@
+-- name: ListBOMComponents :many
+SELECT bc.*, p.name as component_name, p.sku as component_sku,
+       COALESCE(pc.unit_cost, 0)::numeric as unit_cost
+FROM bom_components bc
+JOIN products p ON bc.component_id = p.id
+LEFT JOIN product_costs pc ON pc.product_id = bc.component_id
+WHERE bc.product_id = $1
+ORDER BY p.sku;
+
+-- name: DeleteBOMComponents :exec
+DELETE FROM bom_components
+WHERE product_id = $1;
+
+-- name: CreateBOMComponent :one
+INSERT INTO bom_components (product_id, component_id, quantity)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListBOMDescendants :many
+WITH RECURSIVE tree AS (
+    SELECT bc.component_id FROM bom_components bc WHERE bc.product_id = $1
+    UNION
+    SELECT bc.component_id FROM bom_components bc JOIN tree t ON bc.product_id = t.component_id
+)
+SELECT component_id FROM tree;
+
+-- name: ListKitComponentStock :many
+SELECT w.id as warehouse_id, w.name as warehouse_name,
+       bc.component_id, bc.quantity as quantity_per,
+       p.name as component_name, p.sku as component_sku,
+       COALESCE(sl.quantity, 0)::int as on_hand,
+       COALESCE(sl.reserved_quantity, 0)::int as reserved
+FROM bom_components bc
+JOIN products p ON bc.component_id = p.id
+CROSS JOIN warehouses w
+LEFT JOIN stock_levels sl ON sl.product_id = bc.component_id AND sl.warehouse_id = w.id
+WHERE bc.product_id = $1
+  AND w.is_active = true
+  AND ($2::uuid IS NULL OR w.id = $2)
+ORDER BY w.name, p.sku;
+
+-- name: CreateAssemblyOrder :one
+INSERT INTO assembly_orders (order_number, order_type, product_id, warehouse_id, quantity, notes, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAssemblyOrder :one
+SELECT ao.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM assembly_orders ao
+JOIN products p ON ao.product_id = p.id
+JOIN warehouses w ON ao.warehouse_id = w.id
+WHERE ao.id = $1;
+
+-- name: GetAssemblyOrderForUpdate :one
+SELECT * FROM assembly_orders WHERE id = $1 FOR UPDATE;
+
+-- name: ListAssemblyOrdersWithFilter :many
+SELECT ao.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM assembly_orders ao
+JOIN products p ON ao.product_id = p.id
+JOIN warehouses w ON ao.warehouse_id = w.id
+WHERE (NULLIF($1::text, '') IS NULL OR ao.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR ao.order_type = $2)
+  AND ($3::uuid IS NULL OR ao.product_id = $3)
+  AND ($4::uuid IS NULL OR ao.warehouse_id = $4)
+ORDER BY ao.created_at DESC
+LIMIT $5 OFFSET $6;
+
+-- name: CountAssemblyOrdersWithFilter :one
+SELECT COUNT(*)
+FROM assembly_orders ao
+WHERE (NULLIF($1::text, '') IS NULL OR ao.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR ao.order_type = $2)
+  AND ($3::uuid IS NULL OR ao.product_id = $3)
+  AND ($4::uuid IS NULL OR ao.warehouse_id = $4);
+
+-- name: UpdateAssemblyOrderStatus :one
+UPDATE assembly_orders
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkAssemblyOrderCompleted :one
+UPDATE assembly_orders
+SET status = 'completed', unit_cost = $2, total_cost = $3, completed_by = $4, completed_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateAssemblyOrderComponent :one
+INSERT INTO assembly_order_components (assembly_order_id, component_id, quantity_per, quantity)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListAssemblyOrderComponents :many
+SELECT aoc.*, p.name as component_name, p.sku as component_sku
+FROM assembly_order_components aoc
+JOIN products p ON aoc.component_id = p.id
+WHERE aoc.assembly_order_id = $1
+ORDER BY p.sku;
+
+-- name: UpdateAssemblyOrderComponentCost :exec
+UPDATE assembly_order_components
+SET unit_cost = $2, total_cost = $3
+WHERE id = $1;
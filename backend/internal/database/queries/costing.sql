@@ -0,0 +1,66 @@
+-- name: GetCostingSettings :one
+SELECT * FROM costing_settings WHERE id;
+
+-- name: GetCostingSettingsForShare :one
+SELECT * FROM costing_settings WHERE id FOR SHARE;
+
+-- name: UpdateCostingMethod :one
+UPDATE costing_settings
+SET method = $1, updated_by = $2
+WHERE id
+RETURNING *;
+
+-- name: EnsureProductCost :exec
+INSERT INTO product_costs (product_id)
+VALUES ($1)
+ON CONFLICT (product_id) DO NOTHING;
+
+-- name: GetProductCostForUpdate :one
+SELECT * FROM product_costs WHERE product_id = $1 FOR UPDATE;
+
+-- name: GetProductCost :one
+SELECT pc.*, p.name as product_name, p.sku
+FROM product_costs pc
+JOIN products p ON pc.product_id = p.id
+WHERE pc.product_id = $1;
+
+-- name: UpdateProductCost :one
+UPDATE product_costs
+SET method = $2, quantity = $3, total_value = $4, unit_cost = $5, costed_through = $6
+WHERE product_id = $1
+RETURNING *;
+
+-- name: ListCostLayers :many
+SELECT cl.*
+FROM cost_layers cl
+LEFT JOIN stock_movements sm ON cl.stock_movement_id = sm.id
+WHERE cl.product_id = $1
+ORDER BY cl.received_date, sm.created_at NULLS FIRST, sm.id;
+
+-- name: CreateCostLayer :exec
+INSERT INTO cost_layers (product_id, stock_movement_id, received_date, quantity, remaining_quantity, unit_cost)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteCostLayers :exec
+DELETE FROM cost_layers WHERE product_id = $1;
+
+-- name: ListCostingMovements :many
+SELECT * FROM stock_movements
+WHERE product_id = $1
+  AND ($2::timestamptz IS NULL OR COALESCE(processed_date, created_at) >= $2)
+ORDER BY COALESCE(processed_date, created_at), created_at,
+         CASE WHEN movement_type = 'out' THEN 0 ELSE 1 END, id;
+
+-- name: UpdateStockMovementCost :exec
+UPDATE stock_movements
+SET unit_cost = $2, total_cost = $3
+WHERE id = $1;
+
+-- name: GetTransferIssueCost :one
+SELECT COALESCE(SUM(quantity), 0)::bigint as quantity,
+       COALESCE(SUM(total_cost), 0)::numeric as total_cost
+FROM stock_movements
+WHERE reference_type = $1 AND reference_id = $2 AND product_id = $3 AND movement_type = 'out';
+
+-- name: ListCostedProductIDs :many
+SELECT DISTINCT product_id FROM stock_movements ORDER BY product_id;
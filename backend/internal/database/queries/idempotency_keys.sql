@@ -0,0 +1,14 @@
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (idempotency_key, user_id, endpoint, request_hash)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, endpoint, idempotency_key) DO NOTHING
+RETURNING id;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = $1 AND endpoint = $2 AND idempotency_key = $3;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response_body = $2
+WHERE id = $1;
@@ -1,6 +1,6 @@
 -- name: CreateProduct :one
-INSERT INTO products (sku, name, description, category_id, supplier_id, unit_price, min_stock_level)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO products (sku, name, description, category_id, supplier_id, unit_price, min_stock_level, tracks_expiry, serialized)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetProduct :one
@@ -40,7 +40,7 @@ LIMIT $4 OFFSET $5;
 
 -- name: UpdateProduct :one
 UPDATE products
-SET sku = $2, name = $3, description = $4, category_id = $5, supplier_id = $6, unit_price = $7, min_stock_level = $8, updated_at = NOW()
+SET sku = $2, name = $3, description = $4, category_id = $5, supplier_id = $6, unit_price = $7, min_stock_level = $8, tracks_expiry = $9, serialized = $10, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
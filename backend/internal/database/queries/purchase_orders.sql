@@ -1,6 +1,6 @@
 -- name: CreatePurchaseOrder :one
-INSERT INTO purchase_orders (po_number, supplier_name, supplier_contact, order_date, expected_delivery_date, notes, created_by)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO purchase_orders (po_number, supplier_name, supplier_contact, order_date, expected_delivery_date, notes, created_by, status, warehouse_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetPurchaseOrder :one
@@ -27,6 +27,11 @@ WHERE ($1::text IS NULL OR po.status = $1)
 ORDER BY po.order_date DESC, po.created_at DESC
 LIMIT $5 OFFSET $6;
 
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE id = $1
+FOR UPDATE;
+
 -- name: UpdatePurchaseOrder :one
 UPDATE purchase_orders
 SET supplier_name = $2, supplier_contact = $3, status = $4, expected_delivery_date = $5, received_date = $6, notes = $7, updated_at = NOW()
@@ -50,8 +55,48 @@ WHERE ($1::text IS NULL OR po.status = $1)
   AND ($3::date IS NULL OR po.order_date >= $3)
   AND ($4::date IS NULL OR po.order_date <= $4);
 
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity, unit_price, total_price, uom_id, uom_quantity, uom_factor)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListPurchaseOrderItems :many
+SELECT poi.*, p.name as product_name, p.sku, uom.code as uom
+FROM purchase_order_items poi
+JOIN products p ON poi.product_id = p.id
+LEFT JOIN units_of_measure uom ON poi.uom_id = uom.id
+WHERE poi.purchase_order_id = $1
+ORDER BY poi.created_at, p.name;
 
+-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items
+WHERE purchase_order_id = $1;
 
+-- name: UpdatePurchaseOrderItemReceivedQuantity :one
+UPDATE purchase_order_items
+SET received_quantity = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
 
+-- name: GetPurchaseOrderItemsTotal :one
+SELECT COALESCE(SUM(total_price), 0)::numeric(12,2) as total_amount
+FROM purchase_order_items
+WHERE purchase_order_id = $1;
 
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_orders
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreatePurchaseOrderApproval :one
+INSERT INTO purchase_order_approvals (purchase_order_id, action, required_role, total_amount, comment, acted_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
 
+-- name: ListPurchaseOrderApprovals :many
+SELECT poa.*, u.first_name, u.last_name
+FROM purchase_order_approvals poa
+JOIN users u ON poa.acted_by = u.id
+WHERE poa.purchase_order_id = $1
+ORDER BY poa.created_at;
@@ -0,0 +1,77 @@
+-- name: UpsertReorderRule :one
+INSERT INTO reorder_rules (product_id, warehouse_id, reorder_point, reorder_quantity, is_active)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (product_id, warehouse_id) DO UPDATE
+SET reorder_point = EXCLUDED.reorder_point,
+    reorder_quantity = EXCLUDED.reorder_quantity,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetReorderRule :one
+SELECT rr.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM reorder_rules rr
+JOIN products p ON rr.product_id = p.id
+JOIN warehouses w ON rr.warehouse_id = w.id
+WHERE rr.id = $1;
+
+-- name: UpdateReorderRule :one
+UPDATE reorder_rules
+SET reorder_point = $2, reorder_quantity = $3, is_active = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteReorderRule :exec
+DELETE FROM reorder_rules
+WHERE id = $1;
+
+-- name: ListReorderRulesWithFilter :many
+SELECT rr.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM reorder_rules rr
+JOIN products p ON rr.product_id = p.id
+JOIN warehouses w ON rr.warehouse_id = w.id
+WHERE ($1::uuid IS NULL OR rr.product_id = $1)
+  AND ($2::uuid IS NULL OR rr.warehouse_id = $2)
+ORDER BY p.name, w.name
+LIMIT $3 OFFSET $4;
+
+-- name: CountReorderRulesWithFilter :one
+SELECT COUNT(*)
+FROM reorder_rules rr
+WHERE ($1::uuid IS NULL OR rr.product_id = $1)
+  AND ($2::uuid IS NULL OR rr.warehouse_id = $2);
+
+-- name: ListReorderCandidates :many
+SELECT rr.id as rule_id, rr.product_id, rr.warehouse_id, rr.reorder_point, rr.reorder_quantity,
+       p.name as product_name, p.sku, p.supplier_id, s.name as supplier_name,
+       s.contact_person as supplier_contact, COALESCE(s.lead_time_days, 0)::integer as lead_time_days,
+       w.name as warehouse_name,
+       COALESCE(sl.quantity, 0)::integer as quantity,
+       COALESCE(sl.reserved_quantity, 0)::integer as reserved_quantity,
+       COALESCE((SELECT SUM(poi.quantity - COALESCE(poi.received_quantity, 0))
+                 FROM purchase_order_items poi
+                 JOIN purchase_orders po ON poi.purchase_order_id = po.id
+                 WHERE poi.product_id = rr.product_id AND po.warehouse_id = rr.warehouse_id
+                   AND po.status IN ('draft', 'pending', 'approved', 'ordered', 'partially_received')
+                   AND poi.quantity > COALESCE(poi.received_quantity, 0)), 0)::integer as on_order_quantity,
+       COALESCE((SELECT poi.unit_price
+                 FROM purchase_order_items poi
+                 JOIN purchase_orders po ON poi.purchase_order_id = po.id
+                 WHERE poi.product_id = rr.product_id AND po.status NOT IN ('rejected', 'cancelled')
+                 ORDER BY po.order_date DESC, po.created_at DESC
+                 LIMIT 1), pc.unit_cost, 0)::numeric as unit_price
+FROM reorder_rules rr
+JOIN products p ON rr.product_id = p.id
+JOIN warehouses w ON rr.warehouse_id = w.id
+LEFT JOIN suppliers s ON p.supplier_id = s.id
+LEFT JOIN stock_levels sl ON sl.product_id = rr.product_id AND sl.warehouse_id = rr.warehouse_id
+LEFT JOIN product_costs pc ON pc.product_id = rr.product_id
+WHERE rr.is_active AND p.is_active = true
+  AND ($1::uuid IS NULL OR rr.warehouse_id = $1)
+  AND ($2::uuid IS NULL OR p.supplier_id = $2)
+ORDER BY s.name NULLS LAST, w.name, p.name;
+
+-- name: LockReorderRules :exec
+SELECT id FROM reorder_rules
+WHERE is_active
+FOR UPDATE;
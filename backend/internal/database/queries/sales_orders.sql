@@ -20,13 +20,18 @@ LIMIT $1 OFFSET $2;
 SELECT so.*, u.first_name, u.last_name
 FROM sales_orders so
 JOIN users u ON so.created_by = u.id
-WHERE ($1::text IS NULL OR so.status = $1)
-  AND ($2::text IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
+WHERE (NULLIF($1::text, '') IS NULL OR so.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
   AND ($3::date IS NULL OR so.order_date >= $3)
   AND ($4::date IS NULL OR so.order_date <= $4)
 ORDER BY so.order_date DESC, so.created_at DESC
 LIMIT $5 OFFSET $6;
 
+-- name: GetSalesOrderForUpdate :one
+SELECT * FROM sales_orders
+WHERE id = $1
+FOR UPDATE;
+
 -- name: UpdateSalesOrder :one
 UPDATE sales_orders
 SET customer_name = $2, customer_contact = $3, status = $4, expected_delivery_date = $5, shipped_date = $6, delivered_date = $7, notes = $8, updated_at = NOW()
@@ -45,13 +50,40 @@ SELECT COUNT(*) FROM sales_orders;
 -- name: CountSalesOrdersWithFilter :one
 SELECT COUNT(*)
 FROM sales_orders so
-WHERE ($1::text IS NULL OR so.status = $1)
-  AND ($2::text IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
+WHERE (NULLIF($1::text, '') IS NULL OR so.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
   AND ($3::date IS NULL OR so.order_date >= $3)
   AND ($4::date IS NULL OR so.order_date <= $4);
 
+-- name: DeleteSalesOrder :exec
+DELETE FROM sales_orders
+WHERE id = $1;
 
+-- name: CreateSalesOrderItem :one
+INSERT INTO sales_order_items (sales_order_id, product_id, warehouse_id, quantity, unit_price, total_price, uom_id, uom_quantity, uom_factor)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
 
+-- name: ListSalesOrderItems :many
+SELECT soi.*, p.name as product_name, p.sku, w.name as warehouse_name, uom.code as uom
+FROM sales_order_items soi
+JOIN products p ON soi.product_id = p.id
+JOIN warehouses w ON soi.warehouse_id = w.id
+LEFT JOIN units_of_measure uom ON soi.uom_id = uom.id
+WHERE soi.sales_order_id = $1
+ORDER BY soi.created_at, p.name;
 
+-- name: DeleteSalesOrderItems :exec
+DELETE FROM sales_order_items
+WHERE sales_order_id = $1;
 
+-- name: UpdateSalesOrderItemShippedQuantity :one
+UPDATE sales_order_items
+SET shipped_quantity = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
 
+-- name: GetSalesOrderItemsTotal :one
+SELECT COALESCE(SUM(total_price), 0)::numeric(12,2) as total_amount
+FROM sales_order_items
+WHERE sales_order_id = $1;
@@ -0,0 +1,66 @@
+-- name: CreateSerialNumber :one
+INSERT INTO serial_numbers (product_id, serial_number, status, warehouse_id, last_movement_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetSerialNumberForUpdate :one
+SELECT sn.*, sm.reference_type as last_reference_type, sm.reference_id as last_reference_id
+FROM serial_numbers sn
+LEFT JOIN stock_movements sm ON sn.last_movement_id = sm.id
+WHERE sn.product_id = $1 AND sn.serial_number = $2
+FOR UPDATE OF sn;
+
+-- name: UpdateSerialNumberLocation :one
+UPDATE serial_numbers
+SET status = $2, warehouse_id = $3, last_movement_id = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListSerialNumbersBySerial :many
+SELECT sn.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM serial_numbers sn
+JOIN products p ON sn.product_id = p.id
+LEFT JOIN warehouses w ON sn.warehouse_id = w.id
+WHERE sn.serial_number = $1
+  AND ($2::uuid IS NULL OR sn.product_id = $2)
+ORDER BY p.name;
+
+-- name: ListSerialNumbersWithFilter :many
+SELECT sn.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM serial_numbers sn
+JOIN products p ON sn.product_id = p.id
+LEFT JOIN warehouses w ON sn.warehouse_id = w.id
+WHERE ($1::uuid IS NULL OR sn.product_id = $1)
+  AND ($2::uuid IS NULL OR sn.warehouse_id = $2)
+  AND (NULLIF($3::text, '') IS NULL OR sn.status = $3)
+  AND (NULLIF($4::text, '') IS NULL OR sn.serial_number ILIKE '%' || $4 || '%')
+ORDER BY p.name, sn.serial_number
+LIMIT $5 OFFSET $6;
+
+-- name: CountSerialNumbersWithFilter :one
+SELECT COUNT(*) FROM serial_numbers sn
+WHERE ($1::uuid IS NULL OR sn.product_id = $1)
+  AND ($2::uuid IS NULL OR sn.warehouse_id = $2)
+  AND (NULLIF($3::text, '') IS NULL OR sn.status = $3)
+  AND (NULLIF($4::text, '') IS NULL OR sn.serial_number ILIKE '%' || $4 || '%');
+
+-- name: CreateStockMovementSerial :one
+INSERT INTO stock_movement_serials (stock_movement_id, serial_number_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: ListStockMovementSerials :many
+SELECT sn.serial_number
+FROM stock_movement_serials sms
+JOIN serial_numbers sn ON sms.serial_number_id = sn.id
+WHERE sms.stock_movement_id = $1
+ORDER BY sn.serial_number;
+
+-- name: ListSerialNumberMovements :many
+SELECT sm.id, sm.movement_type, sm.warehouse_id, sm.reference_type, sm.reference_id, sm.reference_number,
+       sm.processed_date, sm.created_at, w.name as warehouse_name
+FROM stock_movement_serials sms
+JOIN stock_movements sm ON sms.stock_movement_id = sm.id
+JOIN warehouses w ON sm.warehouse_id = w.id
+WHERE sms.serial_number_id = $1
+ORDER BY sm.created_at, CASE WHEN sm.movement_type = 'out' THEN 0 ELSE 1 END;
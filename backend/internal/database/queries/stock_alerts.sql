@@ -0,0 +1,104 @@
+-- name: ListStockAlertLevels :many
+SELECT sl.product_id, sl.warehouse_id, sl.quantity, sl.reserved_quantity,
+       COALESCE(NULLIF(sl.min_stock_level, 0), p.min_stock_level)::int AS min_stock_level,
+       sl.max_stock_level, p.name AS product_name, p.sku, w.name AS warehouse_name
+FROM stock_levels sl
+JOIN products p ON sl.product_id = p.id
+JOIN warehouses w ON sl.warehouse_id = w.id
+WHERE ($1::uuid IS NULL OR sl.product_id = $1)
+  AND ($2::uuid IS NULL OR sl.warehouse_id = $2)
+  AND p.is_active = true
+ORDER BY p.name, w.name;
+
+-- name: ListUnresolvedStockAlertTypes :many
+SELECT alert_type FROM stock_alerts
+WHERE product_id = $1 AND warehouse_id = $2 AND status <> 'resolved';
+
+-- name: CreateStockAlert :one
+INSERT INTO stock_alerts (alert_type, product_id, warehouse_id, quantity, threshold, message)
+SELECT $1, $2, $3, $4, $5, $6
+WHERE NOT EXISTS (
+    SELECT 1 FROM stock_alerts
+    WHERE alert_type = $1 AND product_id = $2 AND warehouse_id = $3
+      AND resolved_at > NOW() - make_interval(mins => $7::int)
+)
+ON CONFLICT (alert_type, product_id, warehouse_id) WHERE status <> 'resolved' DO NOTHING
+RETURNING *;
+
+-- name: RepeatStockAlert :exec
+UPDATE stock_alerts
+SET occurrences = occurrences + 1, quantity = $4, last_triggered_at = NOW()
+WHERE alert_type = $1 AND product_id = $2 AND warehouse_id = $3 AND status <> 'resolved';
+
+-- name: ResolveClearedStockAlerts :exec
+UPDATE stock_alerts
+SET status = 'resolved', resolved_at = NOW()
+WHERE product_id = $1 AND warehouse_id = $2 AND status <> 'resolved'
+  AND alert_type <> 'negative_attempt'
+  AND NOT (alert_type = ANY($3::text[]));
+
+-- name: GetStockAlert :one
+SELECT sa.*, p.name AS product_name, p.sku, w.name AS warehouse_name
+FROM stock_alerts sa
+JOIN products p ON sa.product_id = p.id
+JOIN warehouses w ON sa.warehouse_id = w.id
+WHERE sa.id = $1;
+
+-- name: ListStockAlertsWithFilter :many
+SELECT sa.*, p.name AS product_name, p.sku, w.name AS warehouse_name
+FROM stock_alerts sa
+JOIN products p ON sa.product_id = p.id
+JOIN warehouses w ON sa.warehouse_id = w.id
+WHERE (NULLIF($1::text, '') IS NULL OR sa.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR sa.alert_type = $2)
+  AND ($3::uuid IS NULL OR sa.product_id = $3)
+  AND ($4::uuid IS NULL OR sa.warehouse_id = $4)
+ORDER BY sa.last_triggered_at DESC
+LIMIT $5 OFFSET $6;
+
+-- name: CountStockAlertsWithFilter :one
+SELECT COUNT(*)
+FROM stock_alerts sa
+WHERE (NULLIF($1::text, '') IS NULL OR sa.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR sa.alert_type = $2)
+  AND ($3::uuid IS NULL OR sa.product_id = $3)
+  AND ($4::uuid IS NULL OR sa.warehouse_id = $4);
+
+-- name: AcknowledgeStockAlert :one
+UPDATE stock_alerts
+SET status = $2, acknowledged_by = $3, acknowledged_at = NOW(),
+    resolved_at = CASE WHEN $2 = 'resolved' THEN NOW() ELSE resolved_at END
+WHERE id = $1 AND status = 'open'
+RETURNING *;
+
+-- name: CreateAlertNotifications :exec
+INSERT INTO user_notifications (user_id, stock_alert_id, title, message)
+SELECT id, $1, $2, $3 FROM users
+WHERE is_active = true AND role IN ('admin', 'manager');
+
+-- name: ListUserNotifications :many
+SELECT * FROM user_notifications
+WHERE user_id = $1
+  AND ($2::boolean IS NOT TRUE OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountUserNotifications :one
+SELECT COUNT(*) FROM user_notifications
+WHERE user_id = $1
+  AND ($2::boolean IS NOT TRUE OR read_at IS NULL);
+
+-- name: CountUnreadUserNotifications :one
+SELECT COUNT(*) FROM user_notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkUserNotificationRead :one
+UPDATE user_notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: MarkAllUserNotificationsRead :exec
+UPDATE user_notifications
+SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
@@ -61,16 +61,18 @@ WHERE ($1::uuid IS NULL OR sl.product_id = $1)
   AND ($3::text IS NULL OR p.name ILIKE '%' || $3 || '%')
   AND ($4::text IS NULL OR p.sku ILIKE '%' || $4 || '%');
 
--- name: GetLowStockItems :many
-SELECT sl.*, p.name as product_name, p.sku, w.name as warehouse_name
-FROM stock_levels sl
-JOIN products p ON sl.product_id = p.id
-JOIN warehouses w ON sl.warehouse_id = w.id
-WHERE sl.available_quantity <= sl.min_stock_level
-ORDER BY sl.available_quantity ASC;
 
 
 
 
 
 
+-- name: EnsureStockLevel :exec
+INSERT INTO stock_levels (product_id, warehouse_id, quantity, reserved_quantity, min_stock_level, max_stock_level)
+VALUES ($1, $2, 0, 0, 0, 0)
+ON CONFLICT (product_id, warehouse_id) DO NOTHING;
+
+-- name: GetStockLevelForUpdate :one
+SELECT * FROM stock_levels
+WHERE product_id = $1 AND warehouse_id = $2
+FOR UPDATE;
@@ -0,0 +1,124 @@
+-- name: CreateStockLot :one
+INSERT INTO stock_lots (product_id, lot_number, manufacture_date, expiry_date)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetStockLot :one
+SELECT sl.*, p.name as product_name, p.sku
+FROM stock_lots sl
+JOIN products p ON sl.product_id = p.id
+WHERE sl.id = $1;
+
+-- name: GetStockLotByNumber :one
+SELECT * FROM stock_lots
+WHERE product_id = $1 AND lot_number = $2;
+
+-- name: UpdateStockLotDates :one
+UPDATE stock_lots
+SET manufacture_date = $2, expiry_date = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListStockLotsWithFilter :many
+SELECT sl.*, p.name as product_name, p.sku,
+       COALESCE((SELECT SUM(sll.quantity) FROM stock_lot_levels sll WHERE sll.lot_id = sl.id), 0)::integer as quantity
+FROM stock_lots sl
+JOIN products p ON sl.product_id = p.id
+WHERE ($1::uuid IS NULL OR sl.product_id = $1)
+  AND (NULLIF($2::text, '') IS NULL OR sl.lot_number ILIKE '%' || $2 || '%')
+  AND ($3::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM stock_lot_levels sll
+        WHERE sll.lot_id = sl.id AND sll.warehouse_id = $3 AND sll.quantity > 0))
+ORDER BY sl.expiry_date NULLS LAST, sl.lot_number
+LIMIT $4 OFFSET $5;
+
+-- name: CountStockLotsWithFilter :one
+SELECT COUNT(*)
+FROM stock_lots sl
+WHERE ($1::uuid IS NULL OR sl.product_id = $1)
+  AND (NULLIF($2::text, '') IS NULL OR sl.lot_number ILIKE '%' || $2 || '%')
+  AND ($3::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM stock_lot_levels sll
+        WHERE sll.lot_id = sl.id AND sll.warehouse_id = $3 AND sll.quantity > 0));
+
+-- name: EnsureStockLotLevel :exec
+INSERT INTO stock_lot_levels (lot_id, warehouse_id, quantity)
+VALUES ($1, $2, 0)
+ON CONFLICT (lot_id, warehouse_id) DO NOTHING;
+
+-- name: GetStockLotLevelForUpdate :one
+SELECT * FROM stock_lot_levels
+WHERE lot_id = $1 AND warehouse_id = $2
+FOR UPDATE;
+
+-- name: UpdateStockLotLevelQuantity :one
+UPDATE stock_lot_levels
+SET quantity = $3, updated_at = NOW()
+WHERE lot_id = $1 AND warehouse_id = $2
+RETURNING *;
+
+-- name: GetLottedStockQuantity :one
+SELECT COALESCE(SUM(sll.quantity), 0)::integer as quantity
+FROM stock_lot_levels sll
+JOIN stock_lots sl ON sll.lot_id = sl.id
+WHERE sl.product_id = $1 AND sll.warehouse_id = $2;
+
+-- name: ListStockLotLevelsForAllocation :many
+SELECT sll.lot_id, sll.quantity, sl.lot_number, sl.expiry_date
+FROM stock_lot_levels sll
+JOIN stock_lots sl ON sll.lot_id = sl.id
+WHERE sl.product_id = $1 AND sll.warehouse_id = $2 AND sll.quantity > 0
+ORDER BY CASE WHEN $3::bool THEN sl.expiry_date END NULLS LAST, sl.created_at, sl.lot_number;
+
+-- name: ListStockLotLevels :many
+SELECT sll.*, w.name as warehouse_name
+FROM stock_lot_levels sll
+JOIN warehouses w ON sll.warehouse_id = w.id
+WHERE sll.lot_id = $1 AND sll.quantity > 0
+ORDER BY w.name;
+
+-- name: CreateStockMovementLot :one
+INSERT INTO stock_movement_lots (stock_movement_id, lot_id, quantity)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListStockMovementLots :many
+SELECT sml.*, sl.lot_number, sl.expiry_date
+FROM stock_movement_lots sml
+JOIN stock_lots sl ON sml.lot_id = sl.id
+WHERE sml.stock_movement_id = $1
+ORDER BY sml.created_at, sl.lot_number;
+
+-- name: ListUnreceivedReferenceLots :many
+SELECT sml.lot_id,
+       SUM(CASE sm.movement_type WHEN 'out' THEN sml.quantity ELSE -sml.quantity END)::integer as quantity
+FROM stock_movement_lots sml
+JOIN stock_movements sm ON sml.stock_movement_id = sm.id
+JOIN stock_lots sl ON sml.lot_id = sl.id
+WHERE sm.reference_type = $1 AND sm.reference_id = $2 AND sm.product_id = $3
+GROUP BY sml.lot_id, sl.expiry_date, sl.lot_number
+HAVING SUM(CASE sm.movement_type WHEN 'out' THEN sml.quantity ELSE -sml.quantity END) > 0
+ORDER BY sl.expiry_date NULLS LAST, sl.lot_number;
+
+-- name: ListStockLotMovements :many
+SELECT sm.id, sm.movement_type, sm.warehouse_id, sm.reference_type, sm.reference_id, sm.reference_number,
+       sm.processed_date, sm.created_at, sml.quantity, w.name as warehouse_name
+FROM stock_movement_lots sml
+JOIN stock_movements sm ON sml.stock_movement_id = sm.id
+JOIN warehouses w ON sm.warehouse_id = w.id
+WHERE sml.lot_id = $1
+ORDER BY sm.created_at, sm.id;
+
+-- name: ListExpiringStockLots :many
+SELECT sll.lot_id, sll.warehouse_id, sll.quantity, sl.product_id, sl.lot_number, sl.expiry_date,
+       (sl.expiry_date - CURRENT_DATE)::int as days_to_expiry,
+       p.name as product_name, p.sku, p.unit_price, w.name as warehouse_name
+FROM stock_lot_levels sll
+JOIN stock_lots sl ON sll.lot_id = sl.id
+JOIN products p ON sl.product_id = p.id
+JOIN warehouses w ON sll.warehouse_id = w.id
+WHERE sll.quantity > 0
+  AND sl.expiry_date IS NOT NULL
+  AND sl.expiry_date <= CURRENT_DATE + $1::int
+  AND ($2::uuid IS NULL OR sll.warehouse_id = $2)
+ORDER BY w.name, sl.expiry_date, p.name, sl.lot_number;
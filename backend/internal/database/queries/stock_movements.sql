@@ -1,38 +1,41 @@
 -- name: CreateStockMovement :one
-INSERT INTO stock_movements (product_id, warehouse_id, movement_type, quantity, cost_price, total_amount, reference_type, reference_id, reference_number, reason, user_id, processed_by, processed_date)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+INSERT INTO stock_movements (product_id, warehouse_id, movement_type, quantity, cost_price, total_amount, reference_type, reference_id, reference_number, reason, user_id, processed_by, processed_date, reason_code, from_location_id, to_location_id, uom_id, uom_quantity, uom_factor, reversal_of_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 RETURNING *;
 
 -- name: ListStockMovements :many
 SELECT sm.*, p.name as product_name, p.sku, w.name as warehouse_name, u.first_name, u.last_name, 
        pb.first_name as processed_by_first_name, pb.last_name as processed_by_last_name,
-       po.supplier_name
+       po.supplier_name, uom.code as uom
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.id
 JOIN warehouses w ON sm.warehouse_id = w.id
 LEFT JOIN users u ON sm.user_id = u.id
 LEFT JOIN users pb ON sm.processed_by = pb.id
 LEFT JOIN purchase_orders po ON sm.reference_id = po.id
+LEFT JOIN units_of_measure uom ON sm.uom_id = uom.id
 ORDER BY sm.created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: ListStockMovementsWithFilter :many
 SELECT sm.*, p.name as product_name, p.sku, w.name as warehouse_name, u.first_name, u.last_name,
        pb.first_name as processed_by_first_name, pb.last_name as processed_by_last_name,
-       po.supplier_name
+       po.supplier_name, uom.code as uom
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.id
 JOIN warehouses w ON sm.warehouse_id = w.id
 LEFT JOIN users u ON sm.user_id = u.id
 LEFT JOIN users pb ON sm.processed_by = pb.id
 LEFT JOIN purchase_orders po ON sm.reference_id = po.id
+LEFT JOIN units_of_measure uom ON sm.uom_id = uom.id
 WHERE ($1::uuid IS NULL OR sm.product_id = $1)
   AND ($2::uuid IS NULL OR sm.warehouse_id = $2)
-  AND ($3::text IS NULL OR sm.movement_type = $3)
+  AND (NULLIF($3::text, '') IS NULL OR sm.movement_type = $3)
   AND ($4::timestamp IS NULL OR sm.created_at >= $4)
   AND ($5::timestamp IS NULL OR sm.created_at <= $5)
+  AND (NULLIF($6::text, '') IS NULL OR sm.reason_code = $6)
 ORDER BY sm.created_at DESC
-LIMIT $6 OFFSET $7;
+LIMIT $7 OFFSET $8;
 
 -- name: CountStockMovements :one
 SELECT COUNT(*) FROM stock_movements;
@@ -44,9 +47,10 @@ JOIN products p ON sm.product_id = p.id
 JOIN warehouses w ON sm.warehouse_id = w.id
 WHERE ($1::uuid IS NULL OR sm.product_id = $1)
   AND ($2::uuid IS NULL OR sm.warehouse_id = $2)
-  AND ($3::text IS NULL OR sm.movement_type = $3)
+  AND (NULLIF($3::text, '') IS NULL OR sm.movement_type = $3)
   AND ($4::timestamp IS NULL OR sm.created_at >= $4)
-  AND ($5::timestamp IS NULL OR sm.created_at <= $5);
+  AND ($5::timestamp IS NULL OR sm.created_at <= $5)
+  AND (NULLIF($6::text, '') IS NULL OR sm.reason_code = $6);
 
 -- name: ListStockInTransactions :many
 SELECT 
@@ -86,3 +90,38 @@ LEFT JOIN users u ON sm.user_id = u.id
 LEFT JOIN users pb ON sm.processed_by = pb.id
 WHERE sm.reference_id = $1
 ORDER BY sm.created_at;
+
+-- name: GetStockValuationAsOf :many
+SELECT sm.product_id, p.name as product_name, p.sku, p.category_id, c.name as category_name,
+       sm.warehouse_id, w.name as warehouse_name,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END)::bigint as quantity,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN -1 ELSE 1 END
+           * COALESCE(sm.total_cost, sm.quantity * sm.cost_price, 0))::numeric as total_value
+FROM stock_movements sm
+JOIN products p ON sm.product_id = p.id
+JOIN warehouses w ON sm.warehouse_id = w.id
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+  AND COALESCE(sm.processed_date, sm.created_at) <= $1::timestamptz
+  AND ($2::uuid IS NULL OR sm.warehouse_id = $2)
+  AND ($3::uuid IS NULL OR p.category_id = $3)
+GROUP BY sm.product_id, p.name, p.sku, p.category_id, c.name, sm.warehouse_id, w.name
+HAVING SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END) <> 0
+    OR SUM(CASE WHEN sm.movement_type = 'out' THEN -1 ELSE 1 END
+           * COALESCE(sm.total_cost, sm.quantity * sm.cost_price, 0)) <> 0
+ORDER BY c.name NULLS LAST, p.name, w.name;
+
+-- name: GetStockMovementForUpdate :one
+SELECT * FROM stock_movements
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListStockMovementsByReferenceForUpdate :many
+SELECT * FROM stock_movements
+WHERE reference_id = $1
+ORDER BY created_at, id
+FOR UPDATE;
+
+-- name: GetStockMovementReversal :one
+SELECT * FROM stock_movements
+WHERE reversal_of_id = $1;
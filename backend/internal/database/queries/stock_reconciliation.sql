@@ -0,0 +1,36 @@
+-- name: ListStockLedgerDrift :many
+WITH ledger AS (
+    SELECT sm.product_id, sm.warehouse_id,
+           SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END) as quantity
+    FROM stock_movements sm
+    WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+    GROUP BY sm.product_id, sm.warehouse_id
+)
+SELECT p.id as product_id, p.name as product_name, p.sku,
+       w.id as warehouse_id, w.name as warehouse_name,
+       COALESCE(sl.quantity, 0)::int as stored_quantity,
+       COALESCE(l.quantity, 0)::int as ledger_quantity
+FROM stock_levels sl
+FULL OUTER JOIN ledger l ON l.product_id = sl.product_id AND l.warehouse_id = sl.warehouse_id
+JOIN products p ON p.id = COALESCE(sl.product_id, l.product_id)
+JOIN warehouses w ON w.id = COALESCE(sl.warehouse_id, l.warehouse_id)
+WHERE ($1::uuid IS NULL OR p.id = $1)
+  AND ($2::uuid IS NULL OR w.id = $2)
+  AND COALESCE(sl.quantity, 0) <> COALESCE(l.quantity, 0)
+ORDER BY p.name, w.name;
+
+-- name: CountReconciledBalances :one
+SELECT COUNT(*)
+FROM (
+    SELECT sl.product_id, sl.warehouse_id FROM stock_levels sl
+    UNION
+    SELECT sm.product_id, sm.warehouse_id FROM stock_movements sm
+) b
+WHERE ($1::uuid IS NULL OR b.product_id = $1)
+  AND ($2::uuid IS NULL OR b.warehouse_id = $2);
+
+-- name: GetStockLedgerBalance :one
+SELECT COALESCE(SUM(CASE WHEN movement_type = 'out' THEN -quantity ELSE quantity END), 0)::int as quantity
+FROM stock_movements
+WHERE product_id = $1 AND warehouse_id = $2
+  AND movement_type IN ('in', 'out', 'adjustment');
@@ -0,0 +1,69 @@
+-- name: CreateStockReservation :one
+INSERT INTO stock_reservations (product_id, warehouse_id, quantity, owner_type, owner_id, expires_at, notes, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetStockReservation :one
+SELECT sr.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM stock_reservations sr
+JOIN products p ON sr.product_id = p.id
+JOIN warehouses w ON sr.warehouse_id = w.id
+WHERE sr.id = $1;
+
+-- name: GetStockReservationForUpdate :one
+SELECT * FROM stock_reservations
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListStockReservationsWithFilter :many
+SELECT sr.*, p.name as product_name, p.sku, w.name as warehouse_name
+FROM stock_reservations sr
+JOIN products p ON sr.product_id = p.id
+JOIN warehouses w ON sr.warehouse_id = w.id
+WHERE ($1::uuid IS NULL OR sr.product_id = $1)
+  AND ($2::uuid IS NULL OR sr.warehouse_id = $2)
+  AND (NULLIF($3::text, '') IS NULL OR sr.status = $3)
+  AND (NULLIF($4::text, '') IS NULL OR sr.owner_type = $4)
+  AND ($5::uuid IS NULL OR sr.owner_id = $5)
+ORDER BY sr.created_at DESC
+LIMIT $6 OFFSET $7;
+
+-- name: CountStockReservationsWithFilter :one
+SELECT COUNT(*)
+FROM stock_reservations sr
+WHERE ($1::uuid IS NULL OR sr.product_id = $1)
+  AND ($2::uuid IS NULL OR sr.warehouse_id = $2)
+  AND (NULLIF($3::text, '') IS NULL OR sr.status = $3)
+  AND (NULLIF($4::text, '') IS NULL OR sr.owner_type = $4)
+  AND ($5::uuid IS NULL OR sr.owner_id = $5);
+
+-- name: ListActiveStockReservationsByOwner :many
+SELECT * FROM stock_reservations
+WHERE owner_type = $1 AND owner_id = $2 AND status = 'active'
+ORDER BY created_at
+FOR UPDATE;
+
+-- name: ListConsumedStockReservationQuantitiesByOwner :many
+SELECT product_id, warehouse_id, SUM(consumed_quantity)::integer as consumed_quantity
+FROM stock_reservations
+WHERE owner_type = $1 AND owner_id = $2 AND consumed_quantity > 0
+GROUP BY product_id, warehouse_id;
+
+-- name: UpdateStockReservationExpiry :one
+UPDATE stock_reservations
+SET expires_at = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateStockReservationQuantities :one
+UPDATE stock_reservations
+SET quantity = $2, consumed_quantity = $3, status = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListExpiredStockReservationsForUpdate :many
+SELECT * FROM stock_reservations
+WHERE status = 'active' AND expires_at <= NOW()
+ORDER BY expires_at
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
@@ -0,0 +1,105 @@
+-- Balances as of $1 start from each balance's latest snapshot at or before
+-- it and add the movements that took effect after that snapshot, up to $1.
+-- Balances without a snapshot replay their whole ledger.
+
+-- name: ListStockLevelsAsOf :many
+WITH base AS (
+    SELECT DISTINCT ON (ss.product_id, ss.warehouse_id) ss.product_id, ss.warehouse_id, ss.snapshot_at, ss.quantity
+    FROM stock_snapshots ss
+    WHERE ss.snapshot_at <= $1::timestamptz
+      AND ($2::uuid IS NULL OR ss.product_id = $2)
+      AND ($3::uuid IS NULL OR ss.warehouse_id = $3)
+    ORDER BY ss.product_id, ss.warehouse_id, ss.snapshot_at DESC
+),
+tail AS (
+    SELECT sm.product_id, sm.warehouse_id,
+           SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END) as quantity
+    FROM stock_movements sm
+    LEFT JOIN base b ON b.product_id = sm.product_id AND b.warehouse_id = sm.warehouse_id
+    WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+      AND COALESCE(sm.processed_date, sm.created_at) <= $1::timestamptz
+      AND (b.snapshot_at IS NULL OR COALESCE(sm.processed_date, sm.created_at) > b.snapshot_at)
+      AND ($2::uuid IS NULL OR sm.product_id = $2)
+      AND ($3::uuid IS NULL OR sm.warehouse_id = $3)
+    GROUP BY sm.product_id, sm.warehouse_id
+),
+balances AS (
+    SELECT COALESCE(b.product_id, t.product_id) as product_id,
+           COALESCE(b.warehouse_id, t.warehouse_id) as warehouse_id,
+           COALESCE(b.quantity, 0) + COALESCE(t.quantity, 0) as quantity
+    FROM base b
+    FULL OUTER JOIN tail t ON t.product_id = b.product_id AND t.warehouse_id = b.warehouse_id
+)
+SELECT bal.product_id, bal.warehouse_id, bal.quantity::int as quantity,
+       p.name as product_name, p.sku, w.name as warehouse_name
+FROM balances bal
+JOIN products p ON bal.product_id = p.id
+JOIN warehouses w ON bal.warehouse_id = w.id
+WHERE ($4::text IS NULL OR p.name ILIKE '%' || $4 || '%')
+  AND ($5::text IS NULL OR p.sku ILIKE '%' || $5 || '%')
+ORDER BY p.name, w.name
+LIMIT $6 OFFSET $7;
+
+-- name: CountStockLevelsAsOf :one
+WITH base AS (
+    SELECT DISTINCT ON (ss.product_id, ss.warehouse_id) ss.product_id, ss.warehouse_id
+    FROM stock_snapshots ss
+    WHERE ss.snapshot_at <= $1::timestamptz
+      AND ($2::uuid IS NULL OR ss.product_id = $2)
+      AND ($3::uuid IS NULL OR ss.warehouse_id = $3)
+),
+keys AS (
+    SELECT product_id, warehouse_id FROM base
+    UNION
+    SELECT sm.product_id, sm.warehouse_id
+    FROM stock_movements sm
+    WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+      AND COALESCE(sm.processed_date, sm.created_at) <= $1::timestamptz
+      AND ($2::uuid IS NULL OR sm.product_id = $2)
+      AND ($3::uuid IS NULL OR sm.warehouse_id = $3)
+)
+SELECT COUNT(*)
+FROM keys k
+JOIN products p ON k.product_id = p.id
+JOIN warehouses w ON k.warehouse_id = w.id
+WHERE ($4::text IS NULL OR p.name ILIKE '%' || $4 || '%')
+  AND ($5::text IS NULL OR p.sku ILIKE '%' || $5 || '%');
+
+-- name: CreateStockSnapshot :exec
+WITH base AS (
+    SELECT DISTINCT ON (ss.product_id, ss.warehouse_id) ss.product_id, ss.warehouse_id, ss.snapshot_at, ss.quantity
+    FROM stock_snapshots ss
+    WHERE ss.snapshot_at <= $1::timestamptz
+    ORDER BY ss.product_id, ss.warehouse_id, ss.snapshot_at DESC
+),
+tail AS (
+    SELECT sm.product_id, sm.warehouse_id,
+           SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END) as quantity
+    FROM stock_movements sm
+    LEFT JOIN base b ON b.product_id = sm.product_id AND b.warehouse_id = sm.warehouse_id
+    WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+      AND COALESCE(sm.processed_date, sm.created_at) <= $1::timestamptz
+      AND (b.snapshot_at IS NULL OR COALESCE(sm.processed_date, sm.created_at) > b.snapshot_at)
+    GROUP BY sm.product_id, sm.warehouse_id
+)
+INSERT INTO stock_snapshots (snapshot_at, product_id, warehouse_id, quantity)
+SELECT $1::timestamptz,
+       COALESCE(b.product_id, t.product_id),
+       COALESCE(b.warehouse_id, t.warehouse_id),
+       COALESCE(b.quantity, 0) + COALESCE(t.quantity, 0)
+FROM base b
+FULL OUTER JOIN tail t ON t.product_id = b.product_id AND t.warehouse_id = b.warehouse_id
+ON CONFLICT (product_id, warehouse_id, snapshot_at) DO NOTHING;
+
+-- name: GetLatestStockSnapshotAt :one
+SELECT MAX(snapshot_at)::timestamptz FROM stock_snapshots;
+
+-- name: DeleteStockSnapshotsFrom :exec
+DELETE FROM stock_snapshots
+WHERE product_id = $1 AND warehouse_id = $2 AND snapshot_at >= $3;
+
+-- name: LockStockSnapshots :exec
+SELECT pg_advisory_xact_lock(hashtext('stock_snapshots'));
+
+-- name: LockStockSnapshotsShared :exec
+SELECT pg_advisory_xact_lock_shared(hashtext('stock_snapshots'));
@@ -0,0 +1,108 @@
+-- name: CreateStockTransfer :one
+INSERT INTO stock_transfers (transfer_number, from_warehouse_id, to_warehouse_id, notes, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetStockTransfer :one
+SELECT st.*, fw.name as from_warehouse_name, tw.name as to_warehouse_name
+FROM stock_transfers st
+JOIN warehouses fw ON st.from_warehouse_id = fw.id
+JOIN warehouses tw ON st.to_warehouse_id = tw.id
+WHERE st.id = $1;
+
+-- name: GetStockTransferForUpdate :one
+SELECT * FROM stock_transfers
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListStockTransfersWithFilter :many
+SELECT st.*, fw.name as from_warehouse_name, tw.name as to_warehouse_name
+FROM stock_transfers st
+JOIN warehouses fw ON st.from_warehouse_id = fw.id
+JOIN warehouses tw ON st.to_warehouse_id = tw.id
+WHERE (NULLIF($1::text, '') IS NULL OR st.status = $1)
+  AND ($2::uuid IS NULL OR st.from_warehouse_id = $2)
+  AND ($3::uuid IS NULL OR st.to_warehouse_id = $3)
+ORDER BY st.created_at DESC
+LIMIT $4 OFFSET $5;
+
+-- name: CountStockTransfersWithFilter :one
+SELECT COUNT(*)
+FROM stock_transfers st
+WHERE (NULLIF($1::text, '') IS NULL OR st.status = $1)
+  AND ($2::uuid IS NULL OR st.from_warehouse_id = $2)
+  AND ($3::uuid IS NULL OR st.to_warehouse_id = $3);
+
+-- name: UpdateStockTransferNotes :one
+UPDATE stock_transfers
+SET notes = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateStockTransferStatus :one
+UPDATE stock_transfers
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkStockTransferDispatched :one
+UPDATE stock_transfers
+SET status = 'dispatched', dispatched_by = $2, dispatched_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkStockTransferReceived :one
+UPDATE stock_transfers
+SET status = $2, received_by = $3,
+    received_at = CASE WHEN $2 = 'received' THEN NOW() ELSE received_at END,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateStockTransferItem :one
+INSERT INTO stock_transfer_items (transfer_id, product_id, quantity, serial_numbers)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListStockTransferItems :many
+SELECT sti.*, p.name as product_name, p.sku
+FROM stock_transfer_items sti
+JOIN products p ON sti.product_id = p.id
+WHERE sti.transfer_id = $1
+ORDER BY p.name;
+
+-- name: DeleteStockTransferItems :exec
+DELETE FROM stock_transfer_items
+WHERE transfer_id = $1;
+
+-- name: UpdateStockTransferItemReceipt :one
+UPDATE stock_transfer_items
+SET received_quantity = $2, discrepancy_quantity = $3, discrepancy_reason = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListInTransitQuantities :many
+SELECT st.to_warehouse_id as warehouse_id, sti.product_id,
+       SUM(GREATEST(sti.quantity - sti.received_quantity, 0))::integer as in_transit_quantity
+FROM stock_transfer_items sti
+JOIN stock_transfers st ON sti.transfer_id = st.id
+WHERE st.status IN ('dispatched', 'partially_received')
+GROUP BY st.to_warehouse_id, sti.product_id;
+
+-- name: ListInTransitValuationAsOf :many
+SELECT st.to_warehouse_id as warehouse_id, w.name as warehouse_name,
+       sm.product_id, p.name as product_name, p.sku, p.category_id, c.name as category_name,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN sm.quantity ELSE -sm.quantity END)::bigint as quantity,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN 1 ELSE -1 END * COALESCE(sm.total_cost, 0))::numeric as total_value
+FROM stock_movements sm
+JOIN stock_transfers st ON sm.reference_type = 'transfer' AND sm.reference_id = st.id
+JOIN products p ON sm.product_id = p.id
+JOIN warehouses w ON st.to_warehouse_id = w.id
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE COALESCE(sm.processed_date, sm.created_at) <= $1::timestamptz
+  AND st.dispatched_at <= $1
+  AND NOT (st.status = 'received' AND st.received_at <= $1)
+  AND ($2::uuid IS NULL OR st.to_warehouse_id = $2)
+  AND ($3::uuid IS NULL OR p.category_id = $3)
+GROUP BY st.to_warehouse_id, w.name, sm.product_id, p.name, p.sku, p.category_id, c.name
+HAVING SUM(CASE WHEN sm.movement_type = 'out' THEN sm.quantity ELSE -sm.quantity END) > 0;
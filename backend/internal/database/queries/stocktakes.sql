@@ -0,0 +1,104 @@
+-- name: CreateStocktake :one
+INSERT INTO stocktakes (stocktake_number, warehouse_id, category_id, notes, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetStocktake :one
+SELECT st.*, w.name as warehouse_name, c.name as category_name
+FROM stocktakes st
+JOIN warehouses w ON st.warehouse_id = w.id
+LEFT JOIN categories c ON st.category_id = c.id
+WHERE st.id = $1;
+
+-- name: GetStocktakeForUpdate :one
+SELECT * FROM stocktakes WHERE id = $1 FOR UPDATE;
+
+-- name: ListStocktakesWithFilter :many
+SELECT st.*, w.name as warehouse_name, c.name as category_name
+FROM stocktakes st
+JOIN warehouses w ON st.warehouse_id = w.id
+LEFT JOIN categories c ON st.category_id = c.id
+WHERE (NULLIF($1::text, '') IS NULL OR st.status = $1)
+  AND ($2::uuid IS NULL OR st.warehouse_id = $2)
+ORDER BY st.created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountStocktakesWithFilter :one
+SELECT COUNT(*)
+FROM stocktakes st
+WHERE (NULLIF($1::text, '') IS NULL OR st.status = $1)
+  AND ($2::uuid IS NULL OR st.warehouse_id = $2);
+
+-- name: UpdateStocktakeStatus :one
+UPDATE stocktakes
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkStocktakeSubmitted :one
+UPDATE stocktakes
+SET status = 'review', submitted_by = $2, submitted_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkStocktakeApproved :one
+UPDATE stocktakes
+SET status = 'approved', approved_by = $2, approved_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListStocktakeCandidates :many
+SELECT sl.product_id, sl.quantity,
+       ARRAY(SELECT sn.serial_number FROM serial_numbers sn
+             WHERE sn.product_id = sl.product_id AND sn.warehouse_id = sl.warehouse_id AND sn.status = 'in_stock'
+             ORDER BY sn.serial_number)::text[] as expected_serials
+FROM stock_levels sl
+JOIN products p ON sl.product_id = p.id
+WHERE sl.warehouse_id = $1
+  AND p.is_active = true
+  AND ($2::uuid IS NULL OR p.category_id = $2)
+ORDER BY p.name;
+
+-- name: CreateStocktakeItem :exec
+INSERT INTO stocktake_items (stocktake_id, product_id, expected_quantity, expected_serials)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListStocktakeItems :many
+SELECT sti.*, p.name as product_name, p.sku, p.serialized,
+       COALESCE(pc.unit_cost, 0)::numeric as unit_cost
+FROM stocktake_items sti
+JOIN products p ON sti.product_id = p.id
+LEFT JOIN product_costs pc ON sti.product_id = pc.product_id
+WHERE sti.stocktake_id = $1
+ORDER BY p.name;
+
+-- name: UpdateStocktakeItemCount :one
+UPDATE stocktake_items
+SET counted_quantity = $2, counted_serials = $3, resolved_by = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ClearStocktakeItemCounts :exec
+UPDATE stocktake_items
+SET counted_quantity = NULL, counted_serials = '{}', resolved_by = NULL
+WHERE stocktake_id = $1;
+
+-- name: UpdateStocktakeItemAdjustment :exec
+UPDATE stocktake_items
+SET adjusted_quantity = $2
+WHERE id = $1;
+
+-- name: UpsertStocktakeCount :one
+INSERT INTO stocktake_counts (stocktake_item_id, counted_by, quantity, serial_numbers, notes)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (stocktake_item_id, counted_by)
+DO UPDATE SET quantity = EXCLUDED.quantity, serial_numbers = EXCLUDED.serial_numbers, notes = EXCLUDED.notes
+RETURNING *;
+
+-- name: ListStocktakeCounts :many
+SELECT sc.*, u.first_name, u.last_name
+FROM stocktake_counts sc
+JOIN stocktake_items sti ON sc.stocktake_item_id = sti.id
+JOIN users u ON sc.counted_by = u.id
+WHERE sti.stocktake_id = $1
+ORDER BY sc.created_at;
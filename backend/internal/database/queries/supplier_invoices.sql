@@ -0,0 +1,85 @@
+-- name: CreateSupplierInvoice :one
+INSERT INTO supplier_invoices (invoice_number, purchase_order_id, supplier_id, invoice_date, due_date, notes, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetSupplierInvoice :one
+SELECT si.*, po.po_number, s.name as supplier_name
+FROM supplier_invoices si
+JOIN purchase_orders po ON si.purchase_order_id = po.id
+LEFT JOIN suppliers s ON si.supplier_id = s.id
+WHERE si.id = $1;
+
+-- name: GetSupplierInvoiceForUpdate :one
+SELECT * FROM supplier_invoices
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSupplierInvoicesWithFilter :many
+SELECT si.*, po.po_number, s.name as supplier_name
+FROM supplier_invoices si
+JOIN purchase_orders po ON si.purchase_order_id = po.id
+LEFT JOIN suppliers s ON si.supplier_id = s.id
+WHERE ($1::uuid IS NULL OR si.purchase_order_id = $1)
+  AND (NULLIF($2::text, '') IS NULL OR si.status = $2)
+ORDER BY si.invoice_date DESC, si.created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountSupplierInvoicesWithFilter :one
+SELECT COUNT(*)
+FROM supplier_invoices si
+WHERE ($1::uuid IS NULL OR si.purchase_order_id = $1)
+  AND (NULLIF($2::text, '') IS NULL OR si.status = $2);
+
+-- name: UpdateSupplierInvoiceTotal :one
+UPDATE supplier_invoices
+SET total_amount = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateSupplierInvoiceStatus :one
+UPDATE supplier_invoices
+SET status = $2, matched_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateSupplierInvoiceItem :one
+INSERT INTO supplier_invoice_items (invoice_id, purchase_order_item_id, quantity, unit_price, total_price)
+VALUES ($1, $2, $3, $4, $3 * $4)
+RETURNING *;
+
+-- name: ListSupplierInvoiceItems :many
+SELECT sii.*, poi.product_id, p.name as product_name, p.sku
+FROM supplier_invoice_items sii
+JOIN purchase_order_items poi ON sii.purchase_order_item_id = poi.id
+JOIN products p ON poi.product_id = p.id
+WHERE sii.invoice_id = $1
+ORDER BY sii.created_at, p.name;
+
+-- name: UpdateSupplierInvoiceItemMatch :one
+UPDATE supplier_invoice_items
+SET ordered_quantity = $2, received_quantity = $3, previously_invoiced_quantity = $4, po_unit_price = $5,
+    quantity_variance = $6, price_variance = $7, match_status = $8, match_notes = $9, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetSupplierInvoiceItemsTotal :one
+SELECT COALESCE(SUM(total_price), 0)::numeric(12,2) as total_amount
+FROM supplier_invoice_items
+WHERE invoice_id = $1;
+
+-- name: ListInvoicedQuantitiesForPurchaseOrder :many
+SELECT sii.purchase_order_item_id, SUM(sii.quantity)::integer as invoiced_quantity
+FROM supplier_invoice_items sii
+JOIN supplier_invoices si ON sii.invoice_id = si.id
+WHERE si.purchase_order_id = $1 AND si.id <> $2
+GROUP BY sii.purchase_order_item_id;
+
+-- name: ListPurchaseOrderReceiptQuantities :many
+SELECT sm.product_id,
+       SUM(CASE sm.movement_type WHEN 'in' THEN sm.quantity WHEN 'out' THEN -sm.quantity ELSE 0 END)::integer as received_quantity
+FROM stock_movements sm
+LEFT JOIN stock_movements original ON sm.reversal_of_id = original.id
+WHERE (sm.reference_type = 'purchase_order' AND sm.reference_id = $1)
+   OR (original.reference_type = 'purchase_order' AND original.reference_id = $1)
+GROUP BY sm.product_id;
@@ -1,6 +1,6 @@
 -- name: CreateSupplier :one
-INSERT INTO suppliers (name, contact_person, email, phone, address, city, state, country, postal_code, is_active)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+INSERT INTO suppliers (name, contact_person, email, phone, address, city, state, country, postal_code, is_active, lead_time_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING *;
 
 -- name: GetSupplier :one
@@ -41,7 +41,7 @@ WHERE ($1::text IS NULL OR name ILIKE '%' || $1 || '%')
 -- name: UpdateSupplier :one
 UPDATE suppliers
 SET name = $2, contact_person = $3, email = $4, phone = $5, address = $6, 
-    city = $7, state = $8, country = $9, postal_code = $10, is_active = $11, updated_at = NOW()
+    city = $7, state = $8, country = $9, postal_code = $10, is_active = $11, lead_time_days = $12, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -0,0 +1,44 @@
+-- name: CreateUnitOfMeasure :one
+INSERT INTO units_of_measure (code, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetUnitOfMeasure :one
+SELECT * FROM units_of_measure
+WHERE id = $1;
+
+-- name: GetUnitOfMeasureByCode :one
+SELECT * FROM units_of_measure
+WHERE code = $1;
+
+-- name: ListUnitsOfMeasure :many
+SELECT * FROM units_of_measure
+ORDER BY code;
+
+-- name: UpdateUnitOfMeasure :one
+UPDATE units_of_measure
+SET name = $2, is_active = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListProductUOMs :many
+SELECT pu.*, u.code, u.name as uom_name
+FROM product_uoms pu
+JOIN units_of_measure u ON pu.uom_id = u.id
+WHERE pu.product_id = $1
+ORDER BY pu.factor, u.code;
+
+-- name: GetProductUOMByCode :one
+SELECT pu.*, u.code, u.name as uom_name
+FROM product_uoms pu
+JOIN units_of_measure u ON pu.uom_id = u.id
+WHERE pu.product_id = $1 AND u.code = $2 AND u.is_active = true;
+
+-- name: DeleteProductUOMs :exec
+DELETE FROM product_uoms
+WHERE product_id = $1;
+
+-- name: CreateProductUOM :one
+INSERT INTO product_uoms (product_id, uom_id, factor, is_base)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
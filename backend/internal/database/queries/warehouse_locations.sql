@@ -0,0 +1,83 @@
+-- name: CreateWarehouseLocation :one
+INSERT INTO warehouse_locations (warehouse_id, parent_id, location_type, code, name)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetWarehouseLocation :one
+SELECT * FROM warehouse_locations
+WHERE id = $1;
+
+-- name: ListWarehouseLocations :many
+SELECT wl.*,
+       COALESCE((SELECT SUM(bsl.quantity) FROM bin_stock_levels bsl WHERE bsl.location_id = wl.id), 0)::integer as quantity
+FROM warehouse_locations wl
+WHERE wl.warehouse_id = $1
+  AND ($2::boolean OR wl.is_active)
+ORDER BY wl.code;
+
+-- name: UpdateWarehouseLocation :one
+UPDATE warehouse_locations
+SET name = $2, is_active = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CountActiveChildLocations :one
+SELECT COUNT(*) FROM warehouse_locations
+WHERE parent_id = $1 AND is_active;
+
+-- name: GetLocationStockQuantity :one
+SELECT COALESCE(SUM(quantity), 0)::integer as quantity
+FROM bin_stock_levels
+WHERE location_id = $1;
+
+-- name: EnsureBinStockLevel :exec
+INSERT INTO bin_stock_levels (location_id, product_id, quantity)
+VALUES ($1, $2, 0)
+ON CONFLICT (location_id, product_id) DO NOTHING;
+
+-- name: GetBinStockLevelForUpdate :one
+SELECT * FROM bin_stock_levels
+WHERE location_id = $1 AND product_id = $2
+FOR UPDATE;
+
+-- name: UpdateBinStockLevelQuantity :one
+UPDATE bin_stock_levels
+SET quantity = $3, updated_at = NOW()
+WHERE location_id = $1 AND product_id = $2
+RETURNING *;
+
+-- name: GetBinnedStockQuantity :one
+SELECT COALESCE(SUM(bsl.quantity), 0)::integer as quantity
+FROM bin_stock_levels bsl
+JOIN warehouse_locations wl ON bsl.location_id = wl.id
+WHERE bsl.product_id = $1 AND wl.warehouse_id = $2;
+
+-- name: ListBinStockLevelsForAllocation :many
+SELECT bsl.location_id, bsl.quantity, wl.code
+FROM bin_stock_levels bsl
+JOIN warehouse_locations wl ON bsl.location_id = wl.id
+WHERE bsl.product_id = $1 AND wl.warehouse_id = $2 AND bsl.quantity > 0
+ORDER BY wl.code;
+
+-- name: ListBinStockLevels :many
+SELECT bsl.*, wl.warehouse_id, wl.code, p.name as product_name, p.sku
+FROM bin_stock_levels bsl
+JOIN warehouse_locations wl ON bsl.location_id = wl.id
+JOIN products p ON bsl.product_id = p.id
+WHERE ($1::uuid IS NULL OR wl.warehouse_id = $1)
+  AND ($2::uuid IS NULL OR bsl.location_id = $2)
+  AND ($3::uuid IS NULL OR bsl.product_id = $3)
+  AND bsl.quantity > 0
+ORDER BY wl.code, p.name;
+
+-- name: CreateStockMovementLocation :one
+INSERT INTO stock_movement_locations (stock_movement_id, location_id, quantity)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListStockMovementLocations :many
+SELECT sml.*, wl.code
+FROM stock_movement_locations sml
+JOIN warehouse_locations wl ON sml.location_id = wl.id
+WHERE sml.stock_movement_id = $1
+ORDER BY sml.created_at, wl.code;
@@ -8,6 +8,89 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountingPeriod struct {
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	StartsAt   pgtype.Timestamptz `json:"starts_at"`
+	EndsAt     pgtype.Timestamptz `json:"ends_at"`
+	Status     string             `json:"status"`
+	ClosedBy   pgtype.UUID        `json:"closed_by"`
+	ClosedAt   pgtype.Timestamptz `json:"closed_at"`
+	ReopenedBy pgtype.UUID        `json:"reopened_by"`
+	ReopenedAt pgtype.Timestamptz `json:"reopened_at"`
+	CreatedBy  pgtype.UUID        `json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AccountingPeriodBalance struct {
+	ID          pgtype.UUID        `json:"id"`
+	PeriodID    pgtype.UUID        `json:"period_id"`
+	ProductID   pgtype.UUID        `json:"product_id"`
+	WarehouseID pgtype.UUID        `json:"warehouse_id"`
+	Quantity    int32              `json:"quantity"`
+	TotalValue  pgtype.Numeric     `json:"total_value"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type AdjustmentReasonCode struct {
+	ID          pgtype.UUID        `json:"id"`
+	Code        string             `json:"code"`
+	Name        string             `json:"name"`
+	Description *string            `json:"description"`
+	IsActive    *bool              `json:"is_active"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AssemblyOrder struct {
+	ID          pgtype.UUID        `json:"id"`
+	OrderNumber string             `json:"order_number"`
+	OrderType   string             `json:"order_type"`
+	ProductID   pgtype.UUID        `json:"product_id"`
+	WarehouseID pgtype.UUID        `json:"warehouse_id"`
+	Quantity    int32              `json:"quantity"`
+	Status      string             `json:"status"`
+	UnitCost    pgtype.Numeric     `json:"unit_cost"`
+	TotalCost   pgtype.Numeric     `json:"total_cost"`
+	Notes       *string            `json:"notes"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	CompletedBy pgtype.UUID        `json:"completed_by"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AssemblyOrderComponent struct {
+	ID              pgtype.UUID        `json:"id"`
+	AssemblyOrderID pgtype.UUID        `json:"assembly_order_id"`
+	ComponentID     pgtype.UUID        `json:"component_id"`
+	QuantityPer     int32              `json:"quantity_per"`
+	Quantity        int32              `json:"quantity"`
+	UnitCost        pgtype.Numeric     `json:"unit_cost"`
+	TotalCost       pgtype.Numeric     `json:"total_cost"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BinStockLevel struct {
+	ID         pgtype.UUID        `json:"id"`
+	LocationID pgtype.UUID        `json:"location_id"`
+	ProductID  pgtype.UUID        `json:"product_id"`
+	Quantity   int32              `json:"quantity"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BomComponent struct {
+	ID          pgtype.UUID        `json:"id"`
+	ProductID   pgtype.UUID        `json:"product_id"`
+	ComponentID pgtype.UUID        `json:"component_id"`
+	Quantity    int32              `json:"quantity"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Category struct {
 	ID          pgtype.UUID        `json:"id"`
 	Name        string             `json:"name"`
@@ -17,6 +100,25 @@ type Category struct {
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
+type CostLayer struct {
+	ID                pgtype.UUID        `json:"id"`
+	ProductID         pgtype.UUID        `json:"product_id"`
+	StockMovementID   pgtype.UUID        `json:"stock_movement_id"`
+	ReceivedDate      pgtype.Timestamptz `json:"received_date"`
+	Quantity          int32              `json:"quantity"`
+	RemainingQuantity int32              `json:"remaining_quantity"`
+	UnitCost          pgtype.Numeric     `json:"unit_cost"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+type CostingSetting struct {
+	ID        bool               `json:"id"`
+	Method    string             `json:"method"`
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Document struct {
 	ID               pgtype.UUID        `json:"id"`
 	PurchaseOrderID  pgtype.UUID        `json:"purchase_order_id"`
@@ -33,6 +135,16 @@ type Document struct {
 	ValidationNotes  *string            `json:"validation_notes"`
 }
 
+type IdempotencyKey struct {
+	ID             pgtype.UUID        `json:"id"`
+	IdempotencyKey string             `json:"idempotency_key"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Endpoint       string             `json:"endpoint"`
+	RequestHash    string             `json:"request_hash"`
+	ResponseBody   []byte             `json:"response_body"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
 type Product struct {
 	ID            pgtype.UUID        `json:"id"`
 	Sku           string             `json:"sku"`
@@ -46,6 +158,29 @@ type Product struct {
 	CategoryID    pgtype.UUID        `json:"category_id"`
 	SupplierID    pgtype.UUID        `json:"supplier_id"`
 	MinStockLevel int32              `json:"min_stock_level"`
+	TracksExpiry  bool               `json:"tracks_expiry"`
+	Serialized    bool               `json:"serialized"`
+}
+
+type ProductCost struct {
+	ProductID     pgtype.UUID        `json:"product_id"`
+	Method        string             `json:"method"`
+	Quantity      int32              `json:"quantity"`
+	TotalValue    pgtype.Numeric     `json:"total_value"`
+	UnitCost      pgtype.Numeric     `json:"unit_cost"`
+	CostedThrough pgtype.Timestamptz `json:"costed_through"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ProductUom struct {
+	ID        pgtype.UUID        `json:"id"`
+	ProductID pgtype.UUID        `json:"product_id"`
+	UomID     pgtype.UUID        `json:"uom_id"`
+	Factor    int32              `json:"factor"`
+	IsBase    bool               `json:"is_base"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 type PurchaseOrder struct {
@@ -62,6 +197,18 @@ type PurchaseOrder struct {
 	CreatedBy            pgtype.UUID        `json:"created_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	WarehouseID          pgtype.UUID        `json:"warehouse_id"`
+}
+
+type PurchaseOrderApproval struct {
+	ID              pgtype.UUID        `json:"id"`
+	PurchaseOrderID pgtype.UUID        `json:"purchase_order_id"`
+	Action          string             `json:"action"`
+	RequiredRole    *string            `json:"required_role"`
+	TotalAmount     pgtype.Numeric     `json:"total_amount"`
+	Comment         *string            `json:"comment"`
+	ActedBy         pgtype.UUID        `json:"acted_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 }
 
 type PurchaseOrderItem struct {
@@ -74,6 +221,20 @@ type PurchaseOrderItem struct {
 	ReceivedQuantity *int32             `json:"received_quantity"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	UomID            pgtype.UUID        `json:"uom_id"`
+	UomQuantity      *int32             `json:"uom_quantity"`
+	UomFactor        *int32             `json:"uom_factor"`
+}
+
+type ReorderRule struct {
+	ID              pgtype.UUID        `json:"id"`
+	ProductID       pgtype.UUID        `json:"product_id"`
+	WarehouseID     pgtype.UUID        `json:"warehouse_id"`
+	ReorderPoint    int32              `json:"reorder_point"`
+	ReorderQuantity int32              `json:"reorder_quantity"`
+	IsActive        bool               `json:"is_active"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 }
 
 type SalesOrder struct {
@@ -104,6 +265,38 @@ type SalesOrderItem struct {
 	ShippedQuantity *int32             `json:"shipped_quantity"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	UomID           pgtype.UUID        `json:"uom_id"`
+	UomQuantity     *int32             `json:"uom_quantity"`
+	UomFactor       *int32             `json:"uom_factor"`
+}
+
+type SerialNumber struct {
+	ID             pgtype.UUID        `json:"id"`
+	ProductID      pgtype.UUID        `json:"product_id"`
+	SerialNumber   string             `json:"serial_number"`
+	Status         string             `json:"status"`
+	WarehouseID    pgtype.UUID        `json:"warehouse_id"`
+	LastMovementID pgtype.UUID        `json:"last_movement_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockAlert struct {
+	ID              pgtype.UUID        `json:"id"`
+	AlertType       string             `json:"alert_type"`
+	Status          string             `json:"status"`
+	ProductID       pgtype.UUID        `json:"product_id"`
+	WarehouseID     pgtype.UUID        `json:"warehouse_id"`
+	Quantity        int32              `json:"quantity"`
+	Threshold       *int32             `json:"threshold"`
+	Message         string             `json:"message"`
+	Occurrences     int32              `json:"occurrences"`
+	LastTriggeredAt pgtype.Timestamptz `json:"last_triggered_at"`
+	AcknowledgedBy  pgtype.UUID        `json:"acknowledged_by"`
+	AcknowledgedAt  pgtype.Timestamptz `json:"acknowledged_at"`
+	ResolvedAt      pgtype.Timestamptz `json:"resolved_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 }
 
 type StockLevel struct {
@@ -120,6 +313,25 @@ type StockLevel struct {
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
 
+type StockLot struct {
+	ID              pgtype.UUID        `json:"id"`
+	ProductID       pgtype.UUID        `json:"product_id"`
+	LotNumber       string             `json:"lot_number"`
+	ManufactureDate pgtype.Date        `json:"manufacture_date"`
+	ExpiryDate      pgtype.Date        `json:"expiry_date"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockLotLevel struct {
+	ID          pgtype.UUID        `json:"id"`
+	LotID       pgtype.UUID        `json:"lot_id"`
+	WarehouseID pgtype.UUID        `json:"warehouse_id"`
+	Quantity    int32              `json:"quantity"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type StockMovement struct {
 	ID              pgtype.UUID        `json:"id"`
 	ProductID       pgtype.UUID        `json:"product_id"`
@@ -136,6 +348,133 @@ type StockMovement struct {
 	CostPrice       pgtype.Numeric     `json:"cost_price"`
 	TotalAmount     pgtype.Numeric     `json:"total_amount"`
 	ReferenceNumber *string            `json:"reference_number"`
+	ReasonCode      *string            `json:"reason_code"`
+	UnitCost        pgtype.Numeric     `json:"unit_cost"`
+	TotalCost       pgtype.Numeric     `json:"total_cost"`
+	FromLocationID  pgtype.UUID        `json:"from_location_id"`
+	ToLocationID    pgtype.UUID        `json:"to_location_id"`
+	UomID           pgtype.UUID        `json:"uom_id"`
+	UomQuantity     *int32             `json:"uom_quantity"`
+	UomFactor       *int32             `json:"uom_factor"`
+	ReversalOfID    pgtype.UUID        `json:"reversal_of_id"`
+}
+
+type StockMovementLocation struct {
+	ID              pgtype.UUID        `json:"id"`
+	StockMovementID pgtype.UUID        `json:"stock_movement_id"`
+	LocationID      pgtype.UUID        `json:"location_id"`
+	Quantity        int32              `json:"quantity"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type StockMovementLot struct {
+	ID              pgtype.UUID        `json:"id"`
+	StockMovementID pgtype.UUID        `json:"stock_movement_id"`
+	LotID           pgtype.UUID        `json:"lot_id"`
+	Quantity        int32              `json:"quantity"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type StockMovementSerial struct {
+	ID              pgtype.UUID        `json:"id"`
+	StockMovementID pgtype.UUID        `json:"stock_movement_id"`
+	SerialNumberID  pgtype.UUID        `json:"serial_number_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type StockReservation struct {
+	ID               pgtype.UUID        `json:"id"`
+	ProductID        pgtype.UUID        `json:"product_id"`
+	WarehouseID      pgtype.UUID        `json:"warehouse_id"`
+	Quantity         int32              `json:"quantity"`
+	ConsumedQuantity int32              `json:"consumed_quantity"`
+	Status           string             `json:"status"`
+	OwnerType        *string            `json:"owner_type"`
+	OwnerID          pgtype.UUID        `json:"owner_id"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	Notes            *string            `json:"notes"`
+	CreatedBy        pgtype.UUID        `json:"created_by"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockSnapshot struct {
+	ID          pgtype.UUID        `json:"id"`
+	SnapshotAt  pgtype.Timestamptz `json:"snapshot_at"`
+	ProductID   pgtype.UUID        `json:"product_id"`
+	WarehouseID pgtype.UUID        `json:"warehouse_id"`
+	Quantity    int32              `json:"quantity"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type StockTransfer struct {
+	ID              pgtype.UUID        `json:"id"`
+	TransferNumber  string             `json:"transfer_number"`
+	FromWarehouseID pgtype.UUID        `json:"from_warehouse_id"`
+	ToWarehouseID   pgtype.UUID        `json:"to_warehouse_id"`
+	Status          string             `json:"status"`
+	Notes           *string            `json:"notes"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	DispatchedBy    pgtype.UUID        `json:"dispatched_by"`
+	DispatchedAt    pgtype.Timestamptz `json:"dispatched_at"`
+	ReceivedBy      pgtype.UUID        `json:"received_by"`
+	ReceivedAt      pgtype.Timestamptz `json:"received_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StockTransferItem struct {
+	ID                  pgtype.UUID        `json:"id"`
+	TransferID          pgtype.UUID        `json:"transfer_id"`
+	ProductID           pgtype.UUID        `json:"product_id"`
+	Quantity            int32              `json:"quantity"`
+	ReceivedQuantity    int32              `json:"received_quantity"`
+	DiscrepancyQuantity int32              `json:"discrepancy_quantity"`
+	DiscrepancyReason   *string            `json:"discrepancy_reason"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	SerialNumbers       []string           `json:"serial_numbers"`
+}
+
+type Stocktake struct {
+	ID              pgtype.UUID        `json:"id"`
+	StocktakeNumber string             `json:"stocktake_number"`
+	WarehouseID     pgtype.UUID        `json:"warehouse_id"`
+	CategoryID      pgtype.UUID        `json:"category_id"`
+	Status          string             `json:"status"`
+	Notes           *string            `json:"notes"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	SubmittedBy     pgtype.UUID        `json:"submitted_by"`
+	SubmittedAt     pgtype.Timestamptz `json:"submitted_at"`
+	ApprovedBy      pgtype.UUID        `json:"approved_by"`
+	ApprovedAt      pgtype.Timestamptz `json:"approved_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StocktakeCount struct {
+	ID              pgtype.UUID        `json:"id"`
+	StocktakeItemID pgtype.UUID        `json:"stocktake_item_id"`
+	CountedBy       pgtype.UUID        `json:"counted_by"`
+	Quantity        int32              `json:"quantity"`
+	SerialNumbers   []string           `json:"serial_numbers"`
+	Notes           *string            `json:"notes"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type StocktakeItem struct {
+	ID               pgtype.UUID        `json:"id"`
+	StocktakeID      pgtype.UUID        `json:"stocktake_id"`
+	ProductID        pgtype.UUID        `json:"product_id"`
+	ExpectedQuantity int32              `json:"expected_quantity"`
+	ExpectedSerials  []string           `json:"expected_serials"`
+	CountedQuantity  *int32             `json:"counted_quantity"`
+	CountedSerials   []string           `json:"counted_serials"`
+	ResolvedBy       pgtype.UUID        `json:"resolved_by"`
+	AdjustedQuantity *int32             `json:"adjusted_quantity"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Supplier struct {
@@ -152,6 +491,51 @@ type Supplier struct {
 	IsActive      *bool              `json:"is_active"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	LeadTimeDays  int32              `json:"lead_time_days"`
+}
+
+type SupplierInvoice struct {
+	ID              pgtype.UUID        `json:"id"`
+	InvoiceNumber   string             `json:"invoice_number"`
+	PurchaseOrderID pgtype.UUID        `json:"purchase_order_id"`
+	SupplierID      pgtype.UUID        `json:"supplier_id"`
+	InvoiceDate     pgtype.Date        `json:"invoice_date"`
+	DueDate         pgtype.Date        `json:"due_date"`
+	TotalAmount     pgtype.Numeric     `json:"total_amount"`
+	Status          string             `json:"status"`
+	Notes           *string            `json:"notes"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	MatchedAt       pgtype.Timestamptz `json:"matched_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SupplierInvoiceItem struct {
+	ID                         pgtype.UUID        `json:"id"`
+	InvoiceID                  pgtype.UUID        `json:"invoice_id"`
+	PurchaseOrderItemID        pgtype.UUID        `json:"purchase_order_item_id"`
+	Quantity                   int32              `json:"quantity"`
+	UnitPrice                  pgtype.Numeric     `json:"unit_price"`
+	TotalPrice                 pgtype.Numeric     `json:"total_price"`
+	OrderedQuantity            int32              `json:"ordered_quantity"`
+	ReceivedQuantity           int32              `json:"received_quantity"`
+	PreviouslyInvoicedQuantity int32              `json:"previously_invoiced_quantity"`
+	PoUnitPrice                pgtype.Numeric     `json:"po_unit_price"`
+	QuantityVariance           int32              `json:"quantity_variance"`
+	PriceVariance              pgtype.Numeric     `json:"price_variance"`
+	MatchStatus                string             `json:"match_status"`
+	MatchNotes                 *string            `json:"match_notes"`
+	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UnitsOfMeasure struct {
+	ID        pgtype.UUID        `json:"id"`
+	Code      string             `json:"code"`
+	Name      string             `json:"name"`
+	IsActive  bool               `json:"is_active"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 type User struct {
@@ -166,6 +550,16 @@ type User struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
+type UserNotification struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	StockAlertID pgtype.UUID        `json:"stock_alert_id"`
+	Title        string             `json:"title"`
+	Message      string             `json:"message"`
+	ReadAt       pgtype.Timestamptz `json:"read_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type Warehouse struct {
 	ID            pgtype.UUID        `json:"id"`
 	Name          string             `json:"name"`
@@ -177,3 +571,15 @@ type Warehouse struct {
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
+
+type WarehouseLocation struct {
+	ID           pgtype.UUID        `json:"id"`
+	WarehouseID  pgtype.UUID        `json:"warehouse_id"`
+	ParentID     pgtype.UUID        `json:"parent_id"`
+	LocationType string             `json:"location_type"`
+	Code         string             `json:"code"`
+	Name         *string            `json:"name"`
+	IsActive     bool               `json:"is_active"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
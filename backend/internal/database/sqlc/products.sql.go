@@ -45,9 +45,9 @@ func (q *Queries) CountProductsWithFilter(ctx context.Context, arg *CountProduct
 }
 
 const CreateProduct = `-- name: CreateProduct :one
-INSERT INTO products (sku, name, description, category_id, supplier_id, unit_price, min_stock_level)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level
+INSERT INTO products (sku, name, description, category_id, supplier_id, unit_price, min_stock_level, tracks_expiry, serialized)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level, tracks_expiry, serialized
 `
 
 type CreateProductParams struct {
@@ -58,6 +58,8 @@ type CreateProductParams struct {
 	SupplierID    pgtype.UUID    `json:"supplier_id"`
 	UnitPrice     pgtype.Numeric `json:"unit_price"`
 	MinStockLevel int32          `json:"min_stock_level"`
+	TracksExpiry  bool           `json:"tracks_expiry"`
+	Serialized    bool           `json:"serialized"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg *CreateProductParams) (*Product, error) {
@@ -69,6 +71,8 @@ func (q *Queries) CreateProduct(ctx context.Context, arg *CreateProductParams) (
 		arg.SupplierID,
 		arg.UnitPrice,
 		arg.MinStockLevel,
+		arg.TracksExpiry,
+		arg.Serialized,
 	)
 	var i Product
 	err := row.Scan(
@@ -84,6 +88,8 @@ func (q *Queries) CreateProduct(ctx context.Context, arg *CreateProductParams) (
 		&i.CategoryID,
 		&i.SupplierID,
 		&i.MinStockLevel,
+		&i.TracksExpiry,
+		&i.Serialized,
 	)
 	return &i, err
 }
@@ -100,7 +106,7 @@ func (q *Queries) DeleteProduct(ctx context.Context, id pgtype.UUID) error {
 }
 
 const GetProduct = `-- name: GetProduct :one
-SELECT id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level FROM products
+SELECT id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level, tracks_expiry, serialized FROM products
 WHERE id = $1
 `
 
@@ -120,12 +126,14 @@ func (q *Queries) GetProduct(ctx context.Context, id pgtype.UUID) (*Product, err
 		&i.CategoryID,
 		&i.SupplierID,
 		&i.MinStockLevel,
+		&i.TracksExpiry,
+		&i.Serialized,
 	)
 	return &i, err
 }
 
 const GetProductBySKU = `-- name: GetProductBySKU :one
-SELECT id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level FROM products
+SELECT id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level, tracks_expiry, serialized FROM products
 WHERE sku = $1
 `
 
@@ -145,12 +153,14 @@ func (q *Queries) GetProductBySKU(ctx context.Context, sku string) (*Product, er
 		&i.CategoryID,
 		&i.SupplierID,
 		&i.MinStockLevel,
+		&i.TracksExpiry,
+		&i.Serialized,
 	)
 	return &i, err
 }
 
 const GetProductsBySupplier = `-- name: GetProductsBySupplier :many
-SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, c.name as category_name, s.name as supplier_name
+SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, p.tracks_expiry, p.serialized, c.name as category_name, s.name as supplier_name
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 LEFT JOIN suppliers s ON p.supplier_id = s.id
@@ -171,6 +181,8 @@ type GetProductsBySupplierRow struct {
 	CategoryID    pgtype.UUID        `json:"category_id"`
 	SupplierID    pgtype.UUID        `json:"supplier_id"`
 	MinStockLevel int32              `json:"min_stock_level"`
+	TracksExpiry  bool               `json:"tracks_expiry"`
+	Serialized    bool               `json:"serialized"`
 	CategoryName  *string            `json:"category_name"`
 	SupplierName  *string            `json:"supplier_name"`
 }
@@ -197,6 +209,8 @@ func (q *Queries) GetProductsBySupplier(ctx context.Context, supplierID pgtype.U
 			&i.CategoryID,
 			&i.SupplierID,
 			&i.MinStockLevel,
+			&i.TracksExpiry,
+			&i.Serialized,
 			&i.CategoryName,
 			&i.SupplierName,
 		); err != nil {
@@ -211,7 +225,7 @@ func (q *Queries) GetProductsBySupplier(ctx context.Context, supplierID pgtype.U
 }
 
 const ListProducts = `-- name: ListProducts :many
-SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, c.name as category_name, s.name as supplier_name
+SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, p.tracks_expiry, p.serialized, c.name as category_name, s.name as supplier_name
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 LEFT JOIN suppliers s ON p.supplier_id = s.id
@@ -238,6 +252,8 @@ type ListProductsRow struct {
 	CategoryID    pgtype.UUID        `json:"category_id"`
 	SupplierID    pgtype.UUID        `json:"supplier_id"`
 	MinStockLevel int32              `json:"min_stock_level"`
+	TracksExpiry  bool               `json:"tracks_expiry"`
+	Serialized    bool               `json:"serialized"`
 	CategoryName  *string            `json:"category_name"`
 	SupplierName  *string            `json:"supplier_name"`
 }
@@ -264,6 +280,8 @@ func (q *Queries) ListProducts(ctx context.Context, arg *ListProductsParams) ([]
 			&i.CategoryID,
 			&i.SupplierID,
 			&i.MinStockLevel,
+			&i.TracksExpiry,
+			&i.Serialized,
 			&i.CategoryName,
 			&i.SupplierName,
 		); err != nil {
@@ -278,7 +296,7 @@ func (q *Queries) ListProducts(ctx context.Context, arg *ListProductsParams) ([]
 }
 
 const ListProductsWithFilter = `-- name: ListProductsWithFilter :many
-SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, c.name as category_name, s.name as supplier_name
+SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, p.tracks_expiry, p.serialized, c.name as category_name, s.name as supplier_name
 FROM products p
 LEFT JOIN categories c ON p.category_id = c.id
 LEFT JOIN suppliers s ON p.supplier_id = s.id
@@ -319,6 +337,8 @@ type ListProductsWithFilterRow struct {
 	CategoryID    pgtype.UUID        `json:"category_id"`
 	SupplierID    pgtype.UUID        `json:"supplier_id"`
 	MinStockLevel int32              `json:"min_stock_level"`
+	TracksExpiry  bool               `json:"tracks_expiry"`
+	Serialized    bool               `json:"serialized"`
 	CategoryName  *string            `json:"category_name"`
 	SupplierName  *string            `json:"supplier_name"`
 }
@@ -353,6 +373,8 @@ func (q *Queries) ListProductsWithFilter(ctx context.Context, arg *ListProductsW
 			&i.CategoryID,
 			&i.SupplierID,
 			&i.MinStockLevel,
+			&i.TracksExpiry,
+			&i.Serialized,
 			&i.CategoryName,
 			&i.SupplierName,
 		); err != nil {
@@ -367,7 +389,7 @@ func (q *Queries) ListProductsWithFilter(ctx context.Context, arg *ListProductsW
 }
 
 const ListProductsWithStock = `-- name: ListProductsWithStock :many
-SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, c.name as category_name, s.name as supplier_name,
+SELECT p.id, p.sku, p.name, p.description, p.category, p.unit_price, p.is_active, p.created_at, p.updated_at, p.category_id, p.supplier_id, p.min_stock_level, p.tracks_expiry, p.serialized, c.name as category_name, s.name as supplier_name,
        COALESCE(SUM(sl.quantity), 0) as total_stock,
        COALESCE(SUM(sl.reserved_quantity), 0) as total_reserved,
        COALESCE(SUM(sl.available_quantity), 0) as total_available
@@ -413,6 +435,8 @@ type ListProductsWithStockRow struct {
 	CategoryID     pgtype.UUID        `json:"category_id"`
 	SupplierID     pgtype.UUID        `json:"supplier_id"`
 	MinStockLevel  int32              `json:"min_stock_level"`
+	TracksExpiry   bool               `json:"tracks_expiry"`
+	Serialized     bool               `json:"serialized"`
 	CategoryName   *string            `json:"category_name"`
 	SupplierName   *string            `json:"supplier_name"`
 	TotalStock     interface{}        `json:"total_stock"`
@@ -450,6 +474,8 @@ func (q *Queries) ListProductsWithStock(ctx context.Context, arg *ListProductsWi
 			&i.CategoryID,
 			&i.SupplierID,
 			&i.MinStockLevel,
+			&i.TracksExpiry,
+			&i.Serialized,
 			&i.CategoryName,
 			&i.SupplierName,
 			&i.TotalStock,
@@ -468,9 +494,9 @@ func (q *Queries) ListProductsWithStock(ctx context.Context, arg *ListProductsWi
 
 const UpdateProduct = `-- name: UpdateProduct :one
 UPDATE products
-SET sku = $2, name = $3, description = $4, category_id = $5, supplier_id = $6, unit_price = $7, min_stock_level = $8, updated_at = NOW()
+SET sku = $2, name = $3, description = $4, category_id = $5, supplier_id = $6, unit_price = $7, min_stock_level = $8, tracks_expiry = $9, serialized = $10, updated_at = NOW()
 WHERE id = $1
-RETURNING id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level
+RETURNING id, sku, name, description, category, unit_price, is_active, created_at, updated_at, category_id, supplier_id, min_stock_level, tracks_expiry, serialized
 `
 
 type UpdateProductParams struct {
@@ -482,6 +508,8 @@ type UpdateProductParams struct {
 	SupplierID    pgtype.UUID    `json:"supplier_id"`
 	UnitPrice     pgtype.Numeric `json:"unit_price"`
 	MinStockLevel int32          `json:"min_stock_level"`
+	TracksExpiry  bool           `json:"tracks_expiry"`
+	Serialized    bool           `json:"serialized"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg *UpdateProductParams) (*Product, error) {
@@ -494,6 +522,8 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg *UpdateProductParams) (
 		arg.SupplierID,
 		arg.UnitPrice,
 		arg.MinStockLevel,
+		arg.TracksExpiry,
+		arg.Serialized,
 	)
 	var i Product
 	err := row.Scan(
@@ -509,6 +539,8 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg *UpdateProductParams) (
 		&i.CategoryID,
 		&i.SupplierID,
 		&i.MinStockLevel,
+		&i.TracksExpiry,
+		&i.Serialized,
 	)
 	return &i, err
 }
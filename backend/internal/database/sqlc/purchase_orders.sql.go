@@ -51,9 +51,9 @@ func (q *Queries) CountPurchaseOrdersWithFilter(ctx context.Context, arg *CountP
 }
 
 const CreatePurchaseOrder = `-- name: CreatePurchaseOrder :one
-INSERT INTO purchase_orders (po_number, supplier_name, supplier_contact, order_date, expected_delivery_date, notes, created_by)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at
+INSERT INTO purchase_orders (po_number, supplier_name, supplier_contact, order_date, expected_delivery_date, notes, created_by, status, warehouse_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at, warehouse_id
 `
 
 type CreatePurchaseOrderParams struct {
@@ -64,6 +64,8 @@ type CreatePurchaseOrderParams struct {
 	ExpectedDeliveryDate pgtype.Date `json:"expected_delivery_date"`
 	Notes                *string     `json:"notes"`
 	CreatedBy            pgtype.UUID `json:"created_by"`
+	Status               string      `json:"status"`
+	WarehouseID          pgtype.UUID `json:"warehouse_id"`
 }
 
 func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg *CreatePurchaseOrderParams) (*PurchaseOrder, error) {
@@ -75,6 +77,8 @@ func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg *CreatePurchaseOr
 		arg.ExpectedDeliveryDate,
 		arg.Notes,
 		arg.CreatedBy,
+		arg.Status,
+		arg.WarehouseID,
 	)
 	var i PurchaseOrder
 	err := row.Scan(
@@ -91,12 +95,107 @@ func (q *Queries) CreatePurchaseOrder(ctx context.Context, arg *CreatePurchaseOr
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WarehouseID,
 	)
 	return &i, err
 }
 
+const CreatePurchaseOrderApproval = `-- name: CreatePurchaseOrderApproval :one
+INSERT INTO purchase_order_approvals (purchase_order_id, action, required_role, total_amount, comment, acted_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, purchase_order_id, action, required_role, total_amount, comment, acted_by, created_at
+`
+
+type CreatePurchaseOrderApprovalParams struct {
+	PurchaseOrderID pgtype.UUID    `json:"purchase_order_id"`
+	Action          string         `json:"action"`
+	RequiredRole    *string        `json:"required_role"`
+	TotalAmount     pgtype.Numeric `json:"total_amount"`
+	Comment         *string        `json:"comment"`
+	ActedBy         pgtype.UUID    `json:"acted_by"`
+}
+
+func (q *Queries) CreatePurchaseOrderApproval(ctx context.Context, arg *CreatePurchaseOrderApprovalParams) (*PurchaseOrderApproval, error) {
+	row := q.db.QueryRow(ctx, CreatePurchaseOrderApproval,
+		arg.PurchaseOrderID,
+		arg.Action,
+		arg.RequiredRole,
+		arg.TotalAmount,
+		arg.Comment,
+		arg.ActedBy,
+	)
+	var i PurchaseOrderApproval
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.Action,
+		&i.RequiredRole,
+		&i.TotalAmount,
+		&i.Comment,
+		&i.ActedBy,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const CreatePurchaseOrderItem = `-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (purchase_order_id, product_id, quantity, unit_price, total_price, uom_id, uom_quantity, uom_factor)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, purchase_order_id, product_id, quantity, unit_price, total_price, received_quantity, created_at, updated_at, uom_id, uom_quantity, uom_factor
+`
+
+type CreatePurchaseOrderItemParams struct {
+	PurchaseOrderID pgtype.UUID    `json:"purchase_order_id"`
+	ProductID       pgtype.UUID    `json:"product_id"`
+	Quantity        int32          `json:"quantity"`
+	UnitPrice       pgtype.Numeric `json:"unit_price"`
+	TotalPrice      pgtype.Numeric `json:"total_price"`
+	UomID           pgtype.UUID    `json:"uom_id"`
+	UomQuantity     *int32         `json:"uom_quantity"`
+	UomFactor       *int32         `json:"uom_factor"`
+}
+
+func (q *Queries) CreatePurchaseOrderItem(ctx context.Context, arg *CreatePurchaseOrderItemParams) (*PurchaseOrderItem, error) {
+	row := q.db.QueryRow(ctx, CreatePurchaseOrderItem,
+		arg.PurchaseOrderID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.UnitPrice,
+		arg.TotalPrice,
+		arg.UomID,
+		arg.UomQuantity,
+		arg.UomFactor,
+	)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.ProductID,
+		&i.Quantity,
+		&i.UnitPrice,
+		&i.TotalPrice,
+		&i.ReceivedQuantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+	)
+	return &i, err
+}
+
+const DeletePurchaseOrderItems = `-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items
+WHERE purchase_order_id = $1
+`
+
+func (q *Queries) DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, DeletePurchaseOrderItems, purchaseOrderID)
+	return err
+}
+
 const GetPurchaseOrder = `-- name: GetPurchaseOrder :one
-SELECT po.id, po.po_number, po.supplier_name, po.supplier_contact, po.total_amount, po.status, po.order_date, po.expected_delivery_date, po.received_date, po.notes, po.created_by, po.created_at, po.updated_at, u.first_name, u.last_name
+SELECT po.id, po.po_number, po.supplier_name, po.supplier_contact, po.total_amount, po.status, po.order_date, po.expected_delivery_date, po.received_date, po.notes, po.created_by, po.created_at, po.updated_at, po.warehouse_id, u.first_name, u.last_name
 FROM purchase_orders po
 JOIN users u ON po.created_by = u.id
 WHERE po.id = $1
@@ -116,6 +215,7 @@ type GetPurchaseOrderRow struct {
 	CreatedBy            pgtype.UUID        `json:"created_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	WarehouseID          pgtype.UUID        `json:"warehouse_id"`
 	FirstName            string             `json:"first_name"`
 	LastName             string             `json:"last_name"`
 }
@@ -137,14 +237,171 @@ func (q *Queries) GetPurchaseOrder(ctx context.Context, id pgtype.UUID) (*GetPur
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WarehouseID,
 		&i.FirstName,
 		&i.LastName,
 	)
 	return &i, err
 }
 
+const GetPurchaseOrderForUpdate = `-- name: GetPurchaseOrderForUpdate :one
+SELECT id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at, warehouse_id FROM purchase_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetPurchaseOrderForUpdate(ctx context.Context, id pgtype.UUID) (*PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, GetPurchaseOrderForUpdate, id)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.PoNumber,
+		&i.SupplierName,
+		&i.SupplierContact,
+		&i.TotalAmount,
+		&i.Status,
+		&i.OrderDate,
+		&i.ExpectedDeliveryDate,
+		&i.ReceivedDate,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
+	)
+	return &i, err
+}
+
+const GetPurchaseOrderItemsTotal = `-- name: GetPurchaseOrderItemsTotal :one
+SELECT COALESCE(SUM(total_price), 0)::numeric(12,2) as total_amount
+FROM purchase_order_items
+WHERE purchase_order_id = $1
+`
+
+func (q *Queries) GetPurchaseOrderItemsTotal(ctx context.Context, purchaseOrderID pgtype.UUID) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, GetPurchaseOrderItemsTotal, purchaseOrderID)
+	var total_amount pgtype.Numeric
+	err := row.Scan(&total_amount)
+	return total_amount, err
+}
+
+const ListPurchaseOrderApprovals = `-- name: ListPurchaseOrderApprovals :many
+SELECT poa.id, poa.purchase_order_id, poa.action, poa.required_role, poa.total_amount, poa.comment, poa.acted_by, poa.created_at, u.first_name, u.last_name
+FROM purchase_order_approvals poa
+JOIN users u ON poa.acted_by = u.id
+WHERE poa.purchase_order_id = $1
+ORDER BY poa.created_at
+`
+
+type ListPurchaseOrderApprovalsRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	PurchaseOrderID pgtype.UUID        `json:"purchase_order_id"`
+	Action          string             `json:"action"`
+	RequiredRole    *string            `json:"required_role"`
+	TotalAmount     pgtype.Numeric     `json:"total_amount"`
+	Comment         *string            `json:"comment"`
+	ActedBy         pgtype.UUID        `json:"acted_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	FirstName       string             `json:"first_name"`
+	LastName        string             `json:"last_name"`
+}
+
+func (q *Queries) ListPurchaseOrderApprovals(ctx context.Context, purchaseOrderID pgtype.UUID) ([]*ListPurchaseOrderApprovalsRow, error) {
+	rows, err := q.db.Query(ctx, ListPurchaseOrderApprovals, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListPurchaseOrderApprovalsRow{}
+	for rows.Next() {
+		var i ListPurchaseOrderApprovalsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PurchaseOrderID,
+			&i.Action,
+			&i.RequiredRole,
+			&i.TotalAmount,
+			&i.Comment,
+			&i.ActedBy,
+			&i.CreatedAt,
+			&i.FirstName,
+			&i.LastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListPurchaseOrderItems = `-- name: ListPurchaseOrderItems :many
+SELECT poi.id, poi.purchase_order_id, poi.product_id, poi.quantity, poi.unit_price, poi.total_price, poi.received_quantity, poi.created_at, poi.updated_at, poi.uom_id, poi.uom_quantity, poi.uom_factor, p.name as product_name, p.sku, uom.code as uom
+FROM purchase_order_items poi
+JOIN products p ON poi.product_id = p.id
+LEFT JOIN units_of_measure uom ON poi.uom_id = uom.id
+WHERE poi.purchase_order_id = $1
+ORDER BY poi.created_at, p.name
+`
+
+type ListPurchaseOrderItemsRow struct {
+	ID               pgtype.UUID        `json:"id"`
+	PurchaseOrderID  pgtype.UUID        `json:"purchase_order_id"`
+	ProductID        pgtype.UUID        `json:"product_id"`
+	Quantity         int32              `json:"quantity"`
+	UnitPrice        pgtype.Numeric     `json:"unit_price"`
+	TotalPrice       pgtype.Numeric     `json:"total_price"`
+	ReceivedQuantity *int32             `json:"received_quantity"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	UomID            pgtype.UUID        `json:"uom_id"`
+	UomQuantity      *int32             `json:"uom_quantity"`
+	UomFactor        *int32             `json:"uom_factor"`
+	ProductName      string             `json:"product_name"`
+	Sku              string             `json:"sku"`
+	Uom              *string            `json:"uom"`
+}
+
+func (q *Queries) ListPurchaseOrderItems(ctx context.Context, purchaseOrderID pgtype.UUID) ([]*ListPurchaseOrderItemsRow, error) {
+	rows, err := q.db.Query(ctx, ListPurchaseOrderItems, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListPurchaseOrderItemsRow{}
+	for rows.Next() {
+		var i ListPurchaseOrderItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PurchaseOrderID,
+			&i.ProductID,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.TotalPrice,
+			&i.ReceivedQuantity,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UomID,
+			&i.UomQuantity,
+			&i.UomFactor,
+			&i.ProductName,
+			&i.Sku,
+			&i.Uom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListPurchaseOrders = `-- name: ListPurchaseOrders :many
-SELECT po.id, po.po_number, po.supplier_name, po.supplier_contact, po.total_amount, po.status, po.order_date, po.expected_delivery_date, po.received_date, po.notes, po.created_by, po.created_at, po.updated_at, u.first_name, u.last_name
+SELECT po.id, po.po_number, po.supplier_name, po.supplier_contact, po.total_amount, po.status, po.order_date, po.expected_delivery_date, po.received_date, po.notes, po.created_by, po.created_at, po.updated_at, po.warehouse_id, u.first_name, u.last_name
 FROM purchase_orders po
 JOIN users u ON po.created_by = u.id
 ORDER BY po.order_date DESC, po.created_at DESC
@@ -170,6 +427,7 @@ type ListPurchaseOrdersRow struct {
 	CreatedBy            pgtype.UUID        `json:"created_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	WarehouseID          pgtype.UUID        `json:"warehouse_id"`
 	FirstName            string             `json:"first_name"`
 	LastName             string             `json:"last_name"`
 }
@@ -197,6 +455,7 @@ func (q *Queries) ListPurchaseOrders(ctx context.Context, arg *ListPurchaseOrder
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.WarehouseID,
 			&i.FirstName,
 			&i.LastName,
 		); err != nil {
@@ -211,7 +470,7 @@ func (q *Queries) ListPurchaseOrders(ctx context.Context, arg *ListPurchaseOrder
 }
 
 const ListPurchaseOrdersWithFilter = `-- name: ListPurchaseOrdersWithFilter :many
-SELECT po.id, po.po_number, po.supplier_name, po.supplier_contact, po.total_amount, po.status, po.order_date, po.expected_delivery_date, po.received_date, po.notes, po.created_by, po.created_at, po.updated_at, u.first_name, u.last_name
+SELECT po.id, po.po_number, po.supplier_name, po.supplier_contact, po.total_amount, po.status, po.order_date, po.expected_delivery_date, po.received_date, po.notes, po.created_by, po.created_at, po.updated_at, po.warehouse_id, u.first_name, u.last_name
 FROM purchase_orders po
 JOIN users u ON po.created_by = u.id
 WHERE ($1::text IS NULL OR po.status = $1)
@@ -245,6 +504,7 @@ type ListPurchaseOrdersWithFilterRow struct {
 	CreatedBy            pgtype.UUID        `json:"created_by"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	WarehouseID          pgtype.UUID        `json:"warehouse_id"`
 	FirstName            string             `json:"first_name"`
 	LastName             string             `json:"last_name"`
 }
@@ -279,6 +539,7 @@ func (q *Queries) ListPurchaseOrdersWithFilter(ctx context.Context, arg *ListPur
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.WarehouseID,
 			&i.FirstName,
 			&i.LastName,
 		); err != nil {
@@ -296,7 +557,7 @@ const UpdatePurchaseOrder = `-- name: UpdatePurchaseOrder :one
 UPDATE purchase_orders
 SET supplier_name = $2, supplier_contact = $3, status = $4, expected_delivery_date = $5, received_date = $6, notes = $7, updated_at = NOW()
 WHERE id = $1
-RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at
+RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at, warehouse_id
 `
 
 type UpdatePurchaseOrderParams struct {
@@ -334,6 +595,73 @@ func (q *Queries) UpdatePurchaseOrder(ctx context.Context, arg *UpdatePurchaseOr
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WarehouseID,
+	)
+	return &i, err
+}
+
+const UpdatePurchaseOrderItemReceivedQuantity = `-- name: UpdatePurchaseOrderItemReceivedQuantity :one
+UPDATE purchase_order_items
+SET received_quantity = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, purchase_order_id, product_id, quantity, unit_price, total_price, received_quantity, created_at, updated_at, uom_id, uom_quantity, uom_factor
+`
+
+type UpdatePurchaseOrderItemReceivedQuantityParams struct {
+	ID               pgtype.UUID `json:"id"`
+	ReceivedQuantity *int32      `json:"received_quantity"`
+}
+
+func (q *Queries) UpdatePurchaseOrderItemReceivedQuantity(ctx context.Context, arg *UpdatePurchaseOrderItemReceivedQuantityParams) (*PurchaseOrderItem, error) {
+	row := q.db.QueryRow(ctx, UpdatePurchaseOrderItemReceivedQuantity, arg.ID, arg.ReceivedQuantity)
+	var i PurchaseOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.PurchaseOrderID,
+		&i.ProductID,
+		&i.Quantity,
+		&i.UnitPrice,
+		&i.TotalPrice,
+		&i.ReceivedQuantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+	)
+	return &i, err
+}
+
+const UpdatePurchaseOrderStatus = `-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_orders
+SET status = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at, warehouse_id
+`
+
+type UpdatePurchaseOrderStatusParams struct {
+	ID     pgtype.UUID `json:"id"`
+	Status string      `json:"status"`
+}
+
+func (q *Queries) UpdatePurchaseOrderStatus(ctx context.Context, arg *UpdatePurchaseOrderStatusParams) (*PurchaseOrder, error) {
+	row := q.db.QueryRow(ctx, UpdatePurchaseOrderStatus, arg.ID, arg.Status)
+	var i PurchaseOrder
+	err := row.Scan(
+		&i.ID,
+		&i.PoNumber,
+		&i.SupplierName,
+		&i.SupplierContact,
+		&i.TotalAmount,
+		&i.Status,
+		&i.OrderDate,
+		&i.ExpectedDeliveryDate,
+		&i.ReceivedDate,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.WarehouseID,
 	)
 	return &i, err
 }
@@ -342,7 +670,7 @@ const UpdatePurchaseOrderTotal = `-- name: UpdatePurchaseOrderTotal :one
 UPDATE purchase_orders
 SET total_amount = $2, updated_at = NOW()
 WHERE id = $1
-RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at
+RETURNING id, po_number, supplier_name, supplier_contact, total_amount, status, order_date, expected_delivery_date, received_date, notes, created_by, created_at, updated_at, warehouse_id
 `
 
 type UpdatePurchaseOrderTotalParams struct {
@@ -367,6 +695,7 @@ func (q *Queries) UpdatePurchaseOrderTotal(ctx context.Context, arg *UpdatePurch
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WarehouseID,
 	)
 	return &i, err
 }
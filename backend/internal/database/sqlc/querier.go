@@ -11,84 +11,291 @@ import (
 )
 
 type Querier interface {
+	AcknowledgeStockAlert(ctx context.Context, arg *AcknowledgeStockAlertParams) (*StockAlert, error)
+	ClaimIdempotencyKey(ctx context.Context, arg *ClaimIdempotencyKeyParams) (pgtype.UUID, error)
+	ClearStocktakeItemCounts(ctx context.Context, stocktakeID pgtype.UUID) error
+	CloseAccountingPeriod(ctx context.Context, arg *CloseAccountingPeriodParams) (*AccountingPeriod, error)
+	CompleteIdempotencyKey(ctx context.Context, arg *CompleteIdempotencyKeyParams) error
+	CountActiveChildLocations(ctx context.Context, parentID pgtype.UUID) (int64, error)
+	CountAssemblyOrdersWithFilter(ctx context.Context, arg *CountAssemblyOrdersWithFilterParams) (int64, error)
 	CountCategoriesWithFilter(ctx context.Context, arg *CountCategoriesWithFilterParams) (int64, error)
 	CountProducts(ctx context.Context) (int64, error)
 	CountProductsWithFilter(ctx context.Context, arg *CountProductsWithFilterParams) (int64, error)
 	CountPurchaseOrders(ctx context.Context) (int64, error)
 	CountPurchaseOrdersWithFilter(ctx context.Context, arg *CountPurchaseOrdersWithFilterParams) (int64, error)
+	CountReconciledBalances(ctx context.Context, arg *CountReconciledBalancesParams) (int64, error)
+	CountReorderRulesWithFilter(ctx context.Context, arg *CountReorderRulesWithFilterParams) (int64, error)
 	CountSalesOrders(ctx context.Context) (int64, error)
 	CountSalesOrdersWithFilter(ctx context.Context, arg *CountSalesOrdersWithFilterParams) (int64, error)
+	CountSerialNumbersWithFilter(ctx context.Context, arg *CountSerialNumbersWithFilterParams) (int64, error)
+	CountStockAlertsWithFilter(ctx context.Context, arg *CountStockAlertsWithFilterParams) (int64, error)
 	CountStockLevels(ctx context.Context) (int64, error)
+	CountStockLevelsAsOf(ctx context.Context, arg *CountStockLevelsAsOfParams) (int64, error)
 	CountStockLevelsWithFilter(ctx context.Context, arg *CountStockLevelsWithFilterParams) (int64, error)
+	CountStockLotsWithFilter(ctx context.Context, arg *CountStockLotsWithFilterParams) (int64, error)
 	CountStockMovements(ctx context.Context) (int64, error)
 	CountStockMovementsWithFilter(ctx context.Context, arg *CountStockMovementsWithFilterParams) (int64, error)
+	CountStockReservationsWithFilter(ctx context.Context, arg *CountStockReservationsWithFilterParams) (int64, error)
+	CountStockTransfersWithFilter(ctx context.Context, arg *CountStockTransfersWithFilterParams) (int64, error)
+	CountStocktakesWithFilter(ctx context.Context, arg *CountStocktakesWithFilterParams) (int64, error)
+	CountSupplierInvoicesWithFilter(ctx context.Context, arg *CountSupplierInvoicesWithFilterParams) (int64, error)
 	CountSuppliersWithFilter(ctx context.Context, arg *CountSuppliersWithFilterParams) (int64, error)
+	CountUnreadUserNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUserNotifications(ctx context.Context, arg *CountUserNotificationsParams) (int64, error)
 	CountWarehouses(ctx context.Context, arg *CountWarehousesParams) (int64, error)
+	CreateAccountingPeriod(ctx context.Context, arg *CreateAccountingPeriodParams) (*AccountingPeriod, error)
+	CreateAccountingPeriodBalances(ctx context.Context, arg *CreateAccountingPeriodBalancesParams) error
+	CreateAdjustmentReasonCode(ctx context.Context, arg *CreateAdjustmentReasonCodeParams) (*AdjustmentReasonCode, error)
+	CreateAlertNotifications(ctx context.Context, arg *CreateAlertNotificationsParams) error
+	CreateAssemblyOrder(ctx context.Context, arg *CreateAssemblyOrderParams) (*AssemblyOrder, error)
+	CreateAssemblyOrderComponent(ctx context.Context, arg *CreateAssemblyOrderComponentParams) (*AssemblyOrderComponent, error)
+	CreateBOMComponent(ctx context.Context, arg *CreateBOMComponentParams) (*BomComponent, error)
 	CreateCategory(ctx context.Context, arg *CreateCategoryParams) (*Category, error)
+	CreateCostLayer(ctx context.Context, arg *CreateCostLayerParams) error
 	CreateDocument(ctx context.Context, arg *CreateDocumentParams) (*Document, error)
 	CreateProduct(ctx context.Context, arg *CreateProductParams) (*Product, error)
+	CreateProductUOM(ctx context.Context, arg *CreateProductUOMParams) (*ProductUom, error)
 	CreatePurchaseOrder(ctx context.Context, arg *CreatePurchaseOrderParams) (*PurchaseOrder, error)
+	CreatePurchaseOrderApproval(ctx context.Context, arg *CreatePurchaseOrderApprovalParams) (*PurchaseOrderApproval, error)
+	CreatePurchaseOrderItem(ctx context.Context, arg *CreatePurchaseOrderItemParams) (*PurchaseOrderItem, error)
 	CreateSalesOrder(ctx context.Context, arg *CreateSalesOrderParams) (*SalesOrder, error)
+	CreateSalesOrderItem(ctx context.Context, arg *CreateSalesOrderItemParams) (*SalesOrderItem, error)
+	CreateSerialNumber(ctx context.Context, arg *CreateSerialNumberParams) (*SerialNumber, error)
+	CreateStockAlert(ctx context.Context, arg *CreateStockAlertParams) (*StockAlert, error)
 	CreateStockLevel(ctx context.Context, arg *CreateStockLevelParams) (*StockLevel, error)
+	CreateStockLot(ctx context.Context, arg *CreateStockLotParams) (*StockLot, error)
 	CreateStockMovement(ctx context.Context, arg *CreateStockMovementParams) (*StockMovement, error)
+	CreateStockMovementLocation(ctx context.Context, arg *CreateStockMovementLocationParams) (*StockMovementLocation, error)
+	CreateStockMovementLot(ctx context.Context, arg *CreateStockMovementLotParams) (*StockMovementLot, error)
+	CreateStockMovementSerial(ctx context.Context, arg *CreateStockMovementSerialParams) (*StockMovementSerial, error)
+	CreateStockReservation(ctx context.Context, arg *CreateStockReservationParams) (*StockReservation, error)
+	CreateStockSnapshot(ctx context.Context, column1 pgtype.Timestamptz) error
+	CreateStockTransfer(ctx context.Context, arg *CreateStockTransferParams) (*StockTransfer, error)
+	CreateStockTransferItem(ctx context.Context, arg *CreateStockTransferItemParams) (*StockTransferItem, error)
+	CreateStocktake(ctx context.Context, arg *CreateStocktakeParams) (*Stocktake, error)
+	CreateStocktakeItem(ctx context.Context, arg *CreateStocktakeItemParams) error
 	CreateSupplier(ctx context.Context, arg *CreateSupplierParams) (*Supplier, error)
+	CreateSupplierInvoice(ctx context.Context, arg *CreateSupplierInvoiceParams) (*SupplierInvoice, error)
+	CreateSupplierInvoiceItem(ctx context.Context, arg *CreateSupplierInvoiceItemParams) (*SupplierInvoiceItem, error)
+	CreateUnitOfMeasure(ctx context.Context, arg *CreateUnitOfMeasureParams) (*UnitsOfMeasure, error)
 	CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error)
 	CreateWarehouse(ctx context.Context, arg *CreateWarehouseParams) (*Warehouse, error)
+	CreateWarehouseLocation(ctx context.Context, arg *CreateWarehouseLocationParams) (*WarehouseLocation, error)
+	DeleteAccountingPeriodBalances(ctx context.Context, periodID pgtype.UUID) error
+	DeleteAdjustmentReasonCode(ctx context.Context, id pgtype.UUID) error
+	DeleteBOMComponents(ctx context.Context, productID pgtype.UUID) error
 	DeleteCategory(ctx context.Context, id pgtype.UUID) error
+	DeleteCostLayers(ctx context.Context, productID pgtype.UUID) error
 	DeleteDocument(ctx context.Context, id pgtype.UUID) error
 	DeleteProduct(ctx context.Context, id pgtype.UUID) error
+	DeleteProductUOMs(ctx context.Context, productID pgtype.UUID) error
+	DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID pgtype.UUID) error
+	DeleteReorderRule(ctx context.Context, id pgtype.UUID) error
+	DeleteSalesOrder(ctx context.Context, id pgtype.UUID) error
+	DeleteSalesOrderItems(ctx context.Context, salesOrderID pgtype.UUID) error
+	DeleteStockSnapshotsFrom(ctx context.Context, arg *DeleteStockSnapshotsFromParams) error
+	DeleteStockTransferItems(ctx context.Context, transferID pgtype.UUID) error
 	DeleteSupplier(ctx context.Context, id pgtype.UUID) error
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
 	DeleteWarehouse(ctx context.Context, id pgtype.UUID) error
+	EnsureBinStockLevel(ctx context.Context, arg *EnsureBinStockLevelParams) error
+	EnsureProductCost(ctx context.Context, productID pgtype.UUID) error
+	EnsureStockLevel(ctx context.Context, arg *EnsureStockLevelParams) error
+	EnsureStockLotLevel(ctx context.Context, arg *EnsureStockLotLevelParams) error
+	GetAccountingPeriod(ctx context.Context, id pgtype.UUID) (*AccountingPeriod, error)
+	GetAccountingPeriodProductBalance(ctx context.Context, arg *GetAccountingPeriodProductBalanceParams) (*GetAccountingPeriodProductBalanceRow, error)
+	GetAdjustmentReasonCode(ctx context.Context, id pgtype.UUID) (*AdjustmentReasonCode, error)
+	GetAdjustmentReasonCodeByCode(ctx context.Context, code string) (*AdjustmentReasonCode, error)
+	GetAssemblyOrder(ctx context.Context, id pgtype.UUID) (*GetAssemblyOrderRow, error)
+	GetAssemblyOrderForUpdate(ctx context.Context, id pgtype.UUID) (*AssemblyOrder, error)
+	GetBinStockLevelForUpdate(ctx context.Context, arg *GetBinStockLevelForUpdateParams) (*BinStockLevel, error)
+	GetBinnedStockQuantity(ctx context.Context, arg *GetBinnedStockQuantityParams) (int32, error)
 	GetCategory(ctx context.Context, id pgtype.UUID) (*Category, error)
 	GetCategoryByName(ctx context.Context, name string) (*Category, error)
+	GetCostingSettings(ctx context.Context) (*CostingSetting, error)
+	GetCostingSettingsForShare(ctx context.Context) (*CostingSetting, error)
 	GetDocumentByID(ctx context.Context, id pgtype.UUID) (*Document, error)
 	GetDocumentsByPurchaseOrder(ctx context.Context, purchaseOrderID pgtype.UUID) ([]*Document, error)
-	GetLowStockItems(ctx context.Context) ([]*GetLowStockItemsRow, error)
+	GetIdempotencyKey(ctx context.Context, arg *GetIdempotencyKeyParams) (*IdempotencyKey, error)
+	GetLatestClosedAccountingPeriod(ctx context.Context) (*AccountingPeriod, error)
+	GetLatestStockSnapshotAt(ctx context.Context) (pgtype.Timestamptz, error)
+	GetLocationStockQuantity(ctx context.Context, locationID pgtype.UUID) (int32, error)
+	GetLottedStockQuantity(ctx context.Context, arg *GetLottedStockQuantityParams) (int32, error)
 	GetProduct(ctx context.Context, id pgtype.UUID) (*Product, error)
 	GetProductBySKU(ctx context.Context, sku string) (*Product, error)
+	GetProductCost(ctx context.Context, productID pgtype.UUID) (*GetProductCostRow, error)
+	GetProductCostForUpdate(ctx context.Context, productID pgtype.UUID) (*ProductCost, error)
+	GetProductUOMByCode(ctx context.Context, arg *GetProductUOMByCodeParams) (*GetProductUOMByCodeRow, error)
 	GetProductsBySupplier(ctx context.Context, supplierID pgtype.UUID) ([]*GetProductsBySupplierRow, error)
 	GetPurchaseOrder(ctx context.Context, id pgtype.UUID) (*GetPurchaseOrderRow, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, id pgtype.UUID) (*PurchaseOrder, error)
+	GetPurchaseOrderItemsTotal(ctx context.Context, purchaseOrderID pgtype.UUID) (pgtype.Numeric, error)
+	GetReorderRule(ctx context.Context, id pgtype.UUID) (*GetReorderRuleRow, error)
 	GetSalesOrder(ctx context.Context, id pgtype.UUID) (*GetSalesOrderRow, error)
+	GetSalesOrderForUpdate(ctx context.Context, id pgtype.UUID) (*SalesOrder, error)
+	GetSalesOrderItemsTotal(ctx context.Context, salesOrderID pgtype.UUID) (pgtype.Numeric, error)
+	GetSerialNumberForUpdate(ctx context.Context, arg *GetSerialNumberForUpdateParams) (*GetSerialNumberForUpdateRow, error)
+	GetStockAlert(ctx context.Context, id pgtype.UUID) (*GetStockAlertRow, error)
 	GetStockInTransactionDetails(ctx context.Context, referenceID pgtype.UUID) ([]*GetStockInTransactionDetailsRow, error)
+	GetStockLedgerBalance(ctx context.Context, arg *GetStockLedgerBalanceParams) (int32, error)
 	GetStockLevel(ctx context.Context, arg *GetStockLevelParams) (*GetStockLevelRow, error)
+	GetStockLevelForUpdate(ctx context.Context, arg *GetStockLevelForUpdateParams) (*StockLevel, error)
+	GetStockLot(ctx context.Context, id pgtype.UUID) (*GetStockLotRow, error)
+	GetStockLotByNumber(ctx context.Context, arg *GetStockLotByNumberParams) (*StockLot, error)
+	GetStockLotLevelForUpdate(ctx context.Context, arg *GetStockLotLevelForUpdateParams) (*StockLotLevel, error)
+	GetStockMovementForUpdate(ctx context.Context, id pgtype.UUID) (*StockMovement, error)
+	GetStockMovementReversal(ctx context.Context, reversalOfID pgtype.UUID) (*StockMovement, error)
+	GetStockReservation(ctx context.Context, id pgtype.UUID) (*GetStockReservationRow, error)
+	GetStockReservationForUpdate(ctx context.Context, id pgtype.UUID) (*StockReservation, error)
+	GetStockTransfer(ctx context.Context, id pgtype.UUID) (*GetStockTransferRow, error)
+	GetStockTransferForUpdate(ctx context.Context, id pgtype.UUID) (*StockTransfer, error)
+	GetStockValuationAsOf(ctx context.Context, arg *GetStockValuationAsOfParams) ([]*GetStockValuationAsOfRow, error)
+	GetStocktake(ctx context.Context, id pgtype.UUID) (*GetStocktakeRow, error)
+	GetStocktakeForUpdate(ctx context.Context, id pgtype.UUID) (*Stocktake, error)
 	GetSupplier(ctx context.Context, id pgtype.UUID) (*Supplier, error)
 	GetSupplierByName(ctx context.Context, name string) (*Supplier, error)
+	GetSupplierInvoice(ctx context.Context, id pgtype.UUID) (*GetSupplierInvoiceRow, error)
+	GetSupplierInvoiceForUpdate(ctx context.Context, id pgtype.UUID) (*SupplierInvoice, error)
+	GetSupplierInvoiceItemsTotal(ctx context.Context, invoiceID pgtype.UUID) (pgtype.Numeric, error)
+	GetTransferIssueCost(ctx context.Context, arg *GetTransferIssueCostParams) (*GetTransferIssueCostRow, error)
+	GetUnitOfMeasure(ctx context.Context, id pgtype.UUID) (*UnitsOfMeasure, error)
+	GetUnitOfMeasureByCode(ctx context.Context, code string) (*UnitsOfMeasure, error)
 	GetUser(ctx context.Context, id pgtype.UUID) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetWarehouse(ctx context.Context, id pgtype.UUID) (*Warehouse, error)
+	GetWarehouseLocation(ctx context.Context, id pgtype.UUID) (*WarehouseLocation, error)
+	ListAccountingPeriodBalances(ctx context.Context, arg *ListAccountingPeriodBalancesParams) ([]*ListAccountingPeriodBalancesRow, error)
+	ListAccountingPeriods(ctx context.Context, column1 string) ([]*AccountingPeriod, error)
+	ListAccountingPeriodsEndingFrom(ctx context.Context, endsAt pgtype.Timestamptz) ([]*AccountingPeriod, error)
+	ListAccountingPeriodsForUpdate(ctx context.Context) ([]*AccountingPeriod, error)
+	ListActiveStockReservationsByOwner(ctx context.Context, arg *ListActiveStockReservationsByOwnerParams) ([]*StockReservation, error)
+	ListAdjustmentReasonCodes(ctx context.Context, column1 bool) ([]*AdjustmentReasonCode, error)
+	ListAssemblyOrderComponents(ctx context.Context, assemblyOrderID pgtype.UUID) ([]*ListAssemblyOrderComponentsRow, error)
+	ListAssemblyOrdersWithFilter(ctx context.Context, arg *ListAssemblyOrdersWithFilterParams) ([]*ListAssemblyOrdersWithFilterRow, error)
+	ListBOMComponents(ctx context.Context, productID pgtype.UUID) ([]*ListBOMComponentsRow, error)
+	ListBOMDescendants(ctx context.Context, productID pgtype.UUID) ([]pgtype.UUID, error)
+	ListBinStockLevels(ctx context.Context, arg *ListBinStockLevelsParams) ([]*ListBinStockLevelsRow, error)
+	ListBinStockLevelsForAllocation(ctx context.Context, arg *ListBinStockLevelsForAllocationParams) ([]*ListBinStockLevelsForAllocationRow, error)
 	ListCategories(ctx context.Context) ([]*Category, error)
 	ListCategoriesWithFilter(ctx context.Context, arg *ListCategoriesWithFilterParams) ([]*Category, error)
+	ListConsumedStockReservationQuantitiesByOwner(ctx context.Context, arg *ListConsumedStockReservationQuantitiesByOwnerParams) ([]*ListConsumedStockReservationQuantitiesByOwnerRow, error)
+	ListCostLayers(ctx context.Context, productID pgtype.UUID) ([]*CostLayer, error)
+	ListCostedProductIDs(ctx context.Context) ([]pgtype.UUID, error)
+	ListCostingMovements(ctx context.Context, arg *ListCostingMovementsParams) ([]*StockMovement, error)
+	ListExpiredStockReservationsForUpdate(ctx context.Context, limit int32) ([]*StockReservation, error)
+	ListExpiringStockLots(ctx context.Context, arg *ListExpiringStockLotsParams) ([]*ListExpiringStockLotsRow, error)
+	ListInTransitQuantities(ctx context.Context) ([]*ListInTransitQuantitiesRow, error)
+	ListInTransitValuationAsOf(ctx context.Context, arg *ListInTransitValuationAsOfParams) ([]*ListInTransitValuationAsOfRow, error)
+	ListInvoicedQuantitiesForPurchaseOrder(ctx context.Context, arg *ListInvoicedQuantitiesForPurchaseOrderParams) ([]*ListInvoicedQuantitiesForPurchaseOrderRow, error)
+	ListKitComponentStock(ctx context.Context, arg *ListKitComponentStockParams) ([]*ListKitComponentStockRow, error)
+	ListProductUOMs(ctx context.Context, productID pgtype.UUID) ([]*ListProductUOMsRow, error)
 	ListProducts(ctx context.Context, arg *ListProductsParams) ([]*ListProductsRow, error)
 	ListProductsWithFilter(ctx context.Context, arg *ListProductsWithFilterParams) ([]*ListProductsWithFilterRow, error)
 	ListProductsWithStock(ctx context.Context, arg *ListProductsWithStockParams) ([]*ListProductsWithStockRow, error)
+	ListPurchaseOrderApprovals(ctx context.Context, purchaseOrderID pgtype.UUID) ([]*ListPurchaseOrderApprovalsRow, error)
+	ListPurchaseOrderItems(ctx context.Context, purchaseOrderID pgtype.UUID) ([]*ListPurchaseOrderItemsRow, error)
+	ListPurchaseOrderReceiptQuantities(ctx context.Context, referenceID pgtype.UUID) ([]*ListPurchaseOrderReceiptQuantitiesRow, error)
 	ListPurchaseOrders(ctx context.Context, arg *ListPurchaseOrdersParams) ([]*ListPurchaseOrdersRow, error)
 	ListPurchaseOrdersWithFilter(ctx context.Context, arg *ListPurchaseOrdersWithFilterParams) ([]*ListPurchaseOrdersWithFilterRow, error)
+	ListReorderCandidates(ctx context.Context, arg *ListReorderCandidatesParams) ([]*ListReorderCandidatesRow, error)
+	ListReorderRulesWithFilter(ctx context.Context, arg *ListReorderRulesWithFilterParams) ([]*ListReorderRulesWithFilterRow, error)
+	ListSalesOrderItems(ctx context.Context, salesOrderID pgtype.UUID) ([]*ListSalesOrderItemsRow, error)
 	ListSalesOrders(ctx context.Context, arg *ListSalesOrdersParams) ([]*ListSalesOrdersRow, error)
 	ListSalesOrdersWithFilter(ctx context.Context, arg *ListSalesOrdersWithFilterParams) ([]*ListSalesOrdersWithFilterRow, error)
+	ListSerialNumberMovements(ctx context.Context, serialNumberID pgtype.UUID) ([]*ListSerialNumberMovementsRow, error)
+	ListSerialNumbersBySerial(ctx context.Context, arg *ListSerialNumbersBySerialParams) ([]*ListSerialNumbersBySerialRow, error)
+	ListSerialNumbersWithFilter(ctx context.Context, arg *ListSerialNumbersWithFilterParams) ([]*ListSerialNumbersWithFilterRow, error)
+	ListStockAlertLevels(ctx context.Context, arg *ListStockAlertLevelsParams) ([]*ListStockAlertLevelsRow, error)
+	ListStockAlertsWithFilter(ctx context.Context, arg *ListStockAlertsWithFilterParams) ([]*ListStockAlertsWithFilterRow, error)
 	ListStockInTransactions(ctx context.Context, arg *ListStockInTransactionsParams) ([]*ListStockInTransactionsRow, error)
+	ListStockLedgerDrift(ctx context.Context, arg *ListStockLedgerDriftParams) ([]*ListStockLedgerDriftRow, error)
 	ListStockLevels(ctx context.Context, arg *ListStockLevelsParams) ([]*ListStockLevelsRow, error)
+	ListStockLevelsAsOf(ctx context.Context, arg *ListStockLevelsAsOfParams) ([]*ListStockLevelsAsOfRow, error)
 	ListStockLevelsWithFilter(ctx context.Context, arg *ListStockLevelsWithFilterParams) ([]*ListStockLevelsWithFilterRow, error)
+	ListStockLotLevels(ctx context.Context, lotID pgtype.UUID) ([]*ListStockLotLevelsRow, error)
+	ListStockLotLevelsForAllocation(ctx context.Context, arg *ListStockLotLevelsForAllocationParams) ([]*ListStockLotLevelsForAllocationRow, error)
+	ListStockLotMovements(ctx context.Context, lotID pgtype.UUID) ([]*ListStockLotMovementsRow, error)
+	ListStockLotsWithFilter(ctx context.Context, arg *ListStockLotsWithFilterParams) ([]*ListStockLotsWithFilterRow, error)
+	ListStockMovementLocations(ctx context.Context, stockMovementID pgtype.UUID) ([]*ListStockMovementLocationsRow, error)
+	ListStockMovementLots(ctx context.Context, stockMovementID pgtype.UUID) ([]*ListStockMovementLotsRow, error)
+	ListStockMovementSerials(ctx context.Context, stockMovementID pgtype.UUID) ([]string, error)
 	ListStockMovements(ctx context.Context, arg *ListStockMovementsParams) ([]*ListStockMovementsRow, error)
+	ListStockMovementsByReferenceForUpdate(ctx context.Context, referenceID pgtype.UUID) ([]*StockMovement, error)
 	ListStockMovementsWithFilter(ctx context.Context, arg *ListStockMovementsWithFilterParams) ([]*ListStockMovementsWithFilterRow, error)
+	ListStockReservationsWithFilter(ctx context.Context, arg *ListStockReservationsWithFilterParams) ([]*ListStockReservationsWithFilterRow, error)
+	ListStockTransferItems(ctx context.Context, transferID pgtype.UUID) ([]*ListStockTransferItemsRow, error)
+	ListStockTransfersWithFilter(ctx context.Context, arg *ListStockTransfersWithFilterParams) ([]*ListStockTransfersWithFilterRow, error)
+	ListStocktakeCandidates(ctx context.Context, arg *ListStocktakeCandidatesParams) ([]*ListStocktakeCandidatesRow, error)
+	ListStocktakeCounts(ctx context.Context, stocktakeID pgtype.UUID) ([]*ListStocktakeCountsRow, error)
+	ListStocktakeItems(ctx context.Context, stocktakeID pgtype.UUID) ([]*ListStocktakeItemsRow, error)
+	ListStocktakesWithFilter(ctx context.Context, arg *ListStocktakesWithFilterParams) ([]*ListStocktakesWithFilterRow, error)
+	ListSupplierInvoiceItems(ctx context.Context, invoiceID pgtype.UUID) ([]*ListSupplierInvoiceItemsRow, error)
+	ListSupplierInvoicesWithFilter(ctx context.Context, arg *ListSupplierInvoicesWithFilterParams) ([]*ListSupplierInvoicesWithFilterRow, error)
 	ListSuppliers(ctx context.Context) ([]*Supplier, error)
 	ListSuppliersWithFilter(ctx context.Context, arg *ListSuppliersWithFilterParams) ([]*Supplier, error)
+	ListUnitsOfMeasure(ctx context.Context) ([]*UnitsOfMeasure, error)
+	ListUnreceivedReferenceLots(ctx context.Context, arg *ListUnreceivedReferenceLotsParams) ([]*ListUnreceivedReferenceLotsRow, error)
+	ListUnresolvedStockAlertTypes(ctx context.Context, arg *ListUnresolvedStockAlertTypesParams) ([]string, error)
+	ListUserNotifications(ctx context.Context, arg *ListUserNotificationsParams) ([]*UserNotification, error)
 	ListUsers(ctx context.Context) ([]*User, error)
+	ListWarehouseLocations(ctx context.Context, arg *ListWarehouseLocationsParams) ([]*ListWarehouseLocationsRow, error)
 	ListWarehouses(ctx context.Context, arg *ListWarehousesParams) ([]*Warehouse, error)
+	LockReorderRules(ctx context.Context) error
+	LockStockSnapshots(ctx context.Context) error
+	LockStockSnapshotsShared(ctx context.Context) error
+	MarkAllUserNotificationsRead(ctx context.Context, userID pgtype.UUID) error
+	MarkAssemblyOrderCompleted(ctx context.Context, arg *MarkAssemblyOrderCompletedParams) (*AssemblyOrder, error)
+	MarkStockTransferDispatched(ctx context.Context, arg *MarkStockTransferDispatchedParams) (*StockTransfer, error)
+	MarkStockTransferReceived(ctx context.Context, arg *MarkStockTransferReceivedParams) (*StockTransfer, error)
+	MarkStocktakeApproved(ctx context.Context, arg *MarkStocktakeApprovedParams) (*Stocktake, error)
+	MarkStocktakeSubmitted(ctx context.Context, arg *MarkStocktakeSubmittedParams) (*Stocktake, error)
+	MarkUserNotificationRead(ctx context.Context, arg *MarkUserNotificationReadParams) (*UserNotification, error)
+	ReopenAccountingPeriod(ctx context.Context, arg *ReopenAccountingPeriodParams) (*AccountingPeriod, error)
+	RepeatStockAlert(ctx context.Context, arg *RepeatStockAlertParams) error
+	ResolveClearedStockAlerts(ctx context.Context, arg *ResolveClearedStockAlertsParams) error
+	UpdateAdjustmentReasonCode(ctx context.Context, arg *UpdateAdjustmentReasonCodeParams) (*AdjustmentReasonCode, error)
+	UpdateAssemblyOrderComponentCost(ctx context.Context, arg *UpdateAssemblyOrderComponentCostParams) error
+	UpdateAssemblyOrderStatus(ctx context.Context, arg *UpdateAssemblyOrderStatusParams) (*AssemblyOrder, error)
+	UpdateBinStockLevelQuantity(ctx context.Context, arg *UpdateBinStockLevelQuantityParams) (*BinStockLevel, error)
 	UpdateCategory(ctx context.Context, arg *UpdateCategoryParams) (*Category, error)
+	UpdateCostingMethod(ctx context.Context, arg *UpdateCostingMethodParams) (*CostingSetting, error)
 	UpdateDocumentValidation(ctx context.Context, arg *UpdateDocumentValidationParams) (*Document, error)
 	UpdateProduct(ctx context.Context, arg *UpdateProductParams) (*Product, error)
+	UpdateProductCost(ctx context.Context, arg *UpdateProductCostParams) (*ProductCost, error)
 	UpdatePurchaseOrder(ctx context.Context, arg *UpdatePurchaseOrderParams) (*PurchaseOrder, error)
+	UpdatePurchaseOrderItemReceivedQuantity(ctx context.Context, arg *UpdatePurchaseOrderItemReceivedQuantityParams) (*PurchaseOrderItem, error)
+	UpdatePurchaseOrderStatus(ctx context.Context, arg *UpdatePurchaseOrderStatusParams) (*PurchaseOrder, error)
 	UpdatePurchaseOrderTotal(ctx context.Context, arg *UpdatePurchaseOrderTotalParams) (*PurchaseOrder, error)
+	UpdateReorderRule(ctx context.Context, arg *UpdateReorderRuleParams) (*ReorderRule, error)
 	UpdateReservedQuantity(ctx context.Context, arg *UpdateReservedQuantityParams) (*StockLevel, error)
 	UpdateSalesOrder(ctx context.Context, arg *UpdateSalesOrderParams) (*SalesOrder, error)
+	UpdateSalesOrderItemShippedQuantity(ctx context.Context, arg *UpdateSalesOrderItemShippedQuantityParams) (*SalesOrderItem, error)
 	UpdateSalesOrderTotal(ctx context.Context, arg *UpdateSalesOrderTotalParams) (*SalesOrder, error)
+	UpdateSerialNumberLocation(ctx context.Context, arg *UpdateSerialNumberLocationParams) (*SerialNumber, error)
 	UpdateStockLevel(ctx context.Context, arg *UpdateStockLevelParams) (*StockLevel, error)
+	UpdateStockLotDates(ctx context.Context, arg *UpdateStockLotDatesParams) (*StockLot, error)
+	UpdateStockLotLevelQuantity(ctx context.Context, arg *UpdateStockLotLevelQuantityParams) (*StockLotLevel, error)
+	UpdateStockMovementCost(ctx context.Context, arg *UpdateStockMovementCostParams) error
 	UpdateStockQuantity(ctx context.Context, arg *UpdateStockQuantityParams) (*StockLevel, error)
+	UpdateStockReservationExpiry(ctx context.Context, arg *UpdateStockReservationExpiryParams) (*StockReservation, error)
+	UpdateStockReservationQuantities(ctx context.Context, arg *UpdateStockReservationQuantitiesParams) (*StockReservation, error)
+	UpdateStockTransferItemReceipt(ctx context.Context, arg *UpdateStockTransferItemReceiptParams) (*StockTransferItem, error)
+	UpdateStockTransferNotes(ctx context.Context, arg *UpdateStockTransferNotesParams) (*StockTransfer, error)
+	UpdateStockTransferStatus(ctx context.Context, arg *UpdateStockTransferStatusParams) (*StockTransfer, error)
+	UpdateStocktakeItemAdjustment(ctx context.Context, arg *UpdateStocktakeItemAdjustmentParams) error
+	UpdateStocktakeItemCount(ctx context.Context, arg *UpdateStocktakeItemCountParams) (*StocktakeItem, error)
+	UpdateStocktakeStatus(ctx context.Context, arg *UpdateStocktakeStatusParams) (*Stocktake, error)
 	UpdateSupplier(ctx context.Context, arg *UpdateSupplierParams) (*Supplier, error)
+	UpdateSupplierInvoiceItemMatch(ctx context.Context, arg *UpdateSupplierInvoiceItemMatchParams) (*SupplierInvoiceItem, error)
+	UpdateSupplierInvoiceStatus(ctx context.Context, arg *UpdateSupplierInvoiceStatusParams) (*SupplierInvoice, error)
+	UpdateSupplierInvoiceTotal(ctx context.Context, arg *UpdateSupplierInvoiceTotalParams) (*SupplierInvoice, error)
+	UpdateUnitOfMeasure(ctx context.Context, arg *UpdateUnitOfMeasureParams) (*UnitsOfMeasure, error)
 	UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error)
 	UpdateUserPassword(ctx context.Context, arg *UpdateUserPasswordParams) (*User, error)
 	UpdateWarehouse(ctx context.Context, arg *UpdateWarehouseParams) (*Warehouse, error)
+	UpdateWarehouseLocation(ctx context.Context, arg *UpdateWarehouseLocationParams) (*WarehouseLocation, error)
+	UpsertReorderRule(ctx context.Context, arg *UpsertReorderRuleParams) (*ReorderRule, error)
+	UpsertStocktakeCount(ctx context.Context, arg *UpsertStocktakeCountParams) (*StocktakeCount, error)
 }
 
 var _ Querier = (*Queries)(nil)
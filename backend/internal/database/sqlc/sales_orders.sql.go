@@ -25,8 +25,8 @@ func (q *Queries) CountSalesOrders(ctx context.Context) (int64, error) {
 const CountSalesOrdersWithFilter = `-- name: CountSalesOrdersWithFilter :one
 SELECT COUNT(*)
 FROM sales_orders so
-WHERE ($1::text IS NULL OR so.status = $1)
-  AND ($2::text IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
+WHERE (NULLIF($1::text, '') IS NULL OR so.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
   AND ($3::date IS NULL OR so.order_date >= $3)
   AND ($4::date IS NULL OR so.order_date <= $4)
 `
@@ -96,6 +96,75 @@ func (q *Queries) CreateSalesOrder(ctx context.Context, arg *CreateSalesOrderPar
 	return &i, err
 }
 
+const CreateSalesOrderItem = `-- name: CreateSalesOrderItem :one
+INSERT INTO sales_order_items (sales_order_id, product_id, warehouse_id, quantity, unit_price, total_price, uom_id, uom_quantity, uom_factor)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, sales_order_id, product_id, warehouse_id, quantity, unit_price, total_price, shipped_quantity, created_at, updated_at, uom_id, uom_quantity, uom_factor
+`
+
+type CreateSalesOrderItemParams struct {
+	SalesOrderID pgtype.UUID    `json:"sales_order_id"`
+	ProductID    pgtype.UUID    `json:"product_id"`
+	WarehouseID  pgtype.UUID    `json:"warehouse_id"`
+	Quantity     int32          `json:"quantity"`
+	UnitPrice    pgtype.Numeric `json:"unit_price"`
+	TotalPrice   pgtype.Numeric `json:"total_price"`
+	UomID        pgtype.UUID    `json:"uom_id"`
+	UomQuantity  *int32         `json:"uom_quantity"`
+	UomFactor    *int32         `json:"uom_factor"`
+}
+
+func (q *Queries) CreateSalesOrderItem(ctx context.Context, arg *CreateSalesOrderItemParams) (*SalesOrderItem, error) {
+	row := q.db.QueryRow(ctx, CreateSalesOrderItem,
+		arg.SalesOrderID,
+		arg.ProductID,
+		arg.WarehouseID,
+		arg.Quantity,
+		arg.UnitPrice,
+		arg.TotalPrice,
+		arg.UomID,
+		arg.UomQuantity,
+		arg.UomFactor,
+	)
+	var i SalesOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.SalesOrderID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.Quantity,
+		&i.UnitPrice,
+		&i.TotalPrice,
+		&i.ShippedQuantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+	)
+	return &i, err
+}
+
+const DeleteSalesOrder = `-- name: DeleteSalesOrder :exec
+DELETE FROM sales_orders
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSalesOrder(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteSalesOrder, id)
+	return err
+}
+
+const DeleteSalesOrderItems = `-- name: DeleteSalesOrderItems :exec
+DELETE FROM sales_order_items
+WHERE sales_order_id = $1
+`
+
+func (q *Queries) DeleteSalesOrderItems(ctx context.Context, salesOrderID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, DeleteSalesOrderItems, salesOrderID)
+	return err
+}
+
 const GetSalesOrder = `-- name: GetSalesOrder :one
 SELECT so.id, so.so_number, so.customer_name, so.customer_contact, so.total_amount, so.status, so.order_date, so.expected_delivery_date, so.shipped_date, so.delivered_date, so.notes, so.created_by, so.created_at, so.updated_at, u.first_name, u.last_name
 FROM sales_orders so
@@ -146,6 +215,115 @@ func (q *Queries) GetSalesOrder(ctx context.Context, id pgtype.UUID) (*GetSalesO
 	return &i, err
 }
 
+const GetSalesOrderForUpdate = `-- name: GetSalesOrderForUpdate :one
+SELECT id, so_number, customer_name, customer_contact, total_amount, status, order_date, expected_delivery_date, shipped_date, delivered_date, notes, created_by, created_at, updated_at FROM sales_orders
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetSalesOrderForUpdate(ctx context.Context, id pgtype.UUID) (*SalesOrder, error) {
+	row := q.db.QueryRow(ctx, GetSalesOrderForUpdate, id)
+	var i SalesOrder
+	err := row.Scan(
+		&i.ID,
+		&i.SoNumber,
+		&i.CustomerName,
+		&i.CustomerContact,
+		&i.TotalAmount,
+		&i.Status,
+		&i.OrderDate,
+		&i.ExpectedDeliveryDate,
+		&i.ShippedDate,
+		&i.DeliveredDate,
+		&i.Notes,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const GetSalesOrderItemsTotal = `-- name: GetSalesOrderItemsTotal :one
+SELECT COALESCE(SUM(total_price), 0)::numeric(12,2) as total_amount
+FROM sales_order_items
+WHERE sales_order_id = $1
+`
+
+func (q *Queries) GetSalesOrderItemsTotal(ctx context.Context, salesOrderID pgtype.UUID) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, GetSalesOrderItemsTotal, salesOrderID)
+	var total_amount pgtype.Numeric
+	err := row.Scan(&total_amount)
+	return total_amount, err
+}
+
+const ListSalesOrderItems = `-- name: ListSalesOrderItems :many
+SELECT soi.id, soi.sales_order_id, soi.product_id, soi.warehouse_id, soi.quantity, soi.unit_price, soi.total_price, soi.shipped_quantity, soi.created_at, soi.updated_at, soi.uom_id, soi.uom_quantity, soi.uom_factor, p.name as product_name, p.sku, w.name as warehouse_name, uom.code as uom
+FROM sales_order_items soi
+JOIN products p ON soi.product_id = p.id
+JOIN warehouses w ON soi.warehouse_id = w.id
+LEFT JOIN units_of_measure uom ON soi.uom_id = uom.id
+WHERE soi.sales_order_id = $1
+ORDER BY soi.created_at, p.name
+`
+
+type ListSalesOrderItemsRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	SalesOrderID    pgtype.UUID        `json:"sales_order_id"`
+	ProductID       pgtype.UUID        `json:"product_id"`
+	WarehouseID     pgtype.UUID        `json:"warehouse_id"`
+	Quantity        int32              `json:"quantity"`
+	UnitPrice       pgtype.Numeric     `json:"unit_price"`
+	TotalPrice      pgtype.Numeric     `json:"total_price"`
+	ShippedQuantity *int32             `json:"shipped_quantity"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	UomID           pgtype.UUID        `json:"uom_id"`
+	UomQuantity     *int32             `json:"uom_quantity"`
+	UomFactor       *int32             `json:"uom_factor"`
+	ProductName     string             `json:"product_name"`
+	Sku             string             `json:"sku"`
+	WarehouseName   string             `json:"warehouse_name"`
+	Uom             *string            `json:"uom"`
+}
+
+func (q *Queries) ListSalesOrderItems(ctx context.Context, salesOrderID pgtype.UUID) ([]*ListSalesOrderItemsRow, error) {
+	rows, err := q.db.Query(ctx, ListSalesOrderItems, salesOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListSalesOrderItemsRow{}
+	for rows.Next() {
+		var i ListSalesOrderItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SalesOrderID,
+			&i.ProductID,
+			&i.WarehouseID,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.TotalPrice,
+			&i.ShippedQuantity,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UomID,
+			&i.UomQuantity,
+			&i.UomFactor,
+			&i.ProductName,
+			&i.Sku,
+			&i.WarehouseName,
+			&i.Uom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListSalesOrders = `-- name: ListSalesOrders :many
 SELECT so.id, so.so_number, so.customer_name, so.customer_contact, so.total_amount, so.status, so.order_date, so.expected_delivery_date, so.shipped_date, so.delivered_date, so.notes, so.created_by, so.created_at, so.updated_at, u.first_name, u.last_name
 FROM sales_orders so
@@ -219,8 +397,8 @@ const ListSalesOrdersWithFilter = `-- name: ListSalesOrdersWithFilter :many
 SELECT so.id, so.so_number, so.customer_name, so.customer_contact, so.total_amount, so.status, so.order_date, so.expected_delivery_date, so.shipped_date, so.delivered_date, so.notes, so.created_by, so.created_at, so.updated_at, u.first_name, u.last_name
 FROM sales_orders so
 JOIN users u ON so.created_by = u.id
-WHERE ($1::text IS NULL OR so.status = $1)
-  AND ($2::text IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
+WHERE (NULLIF($1::text, '') IS NULL OR so.status = $1)
+  AND (NULLIF($2::text, '') IS NULL OR so.customer_name ILIKE '%' || $2 || '%')
   AND ($3::date IS NULL OR so.order_date >= $3)
   AND ($4::date IS NULL OR so.order_date <= $4)
 ORDER BY so.order_date DESC, so.created_at DESC
@@ -348,6 +526,39 @@ func (q *Queries) UpdateSalesOrder(ctx context.Context, arg *UpdateSalesOrderPar
 	return &i, err
 }
 
+const UpdateSalesOrderItemShippedQuantity = `-- name: UpdateSalesOrderItemShippedQuantity :one
+UPDATE sales_order_items
+SET shipped_quantity = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, sales_order_id, product_id, warehouse_id, quantity, unit_price, total_price, shipped_quantity, created_at, updated_at, uom_id, uom_quantity, uom_factor
+`
+
+type UpdateSalesOrderItemShippedQuantityParams struct {
+	ID              pgtype.UUID `json:"id"`
+	ShippedQuantity *int32      `json:"shipped_quantity"`
+}
+
+func (q *Queries) UpdateSalesOrderItemShippedQuantity(ctx context.Context, arg *UpdateSalesOrderItemShippedQuantityParams) (*SalesOrderItem, error) {
+	row := q.db.QueryRow(ctx, UpdateSalesOrderItemShippedQuantity, arg.ID, arg.ShippedQuantity)
+	var i SalesOrderItem
+	err := row.Scan(
+		&i.ID,
+		&i.SalesOrderID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.Quantity,
+		&i.UnitPrice,
+		&i.TotalPrice,
+		&i.ShippedQuantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+	)
+	return &i, err
+}
+
 const UpdateSalesOrderTotal = `-- name: UpdateSalesOrderTotal :one
 UPDATE sales_orders
 SET total_amount = $2, updated_at = NOW()
@@ -93,65 +93,20 @@ func (q *Queries) CreateStockLevel(ctx context.Context, arg *CreateStockLevelPar
 	return &i, err
 }
 
-const GetLowStockItems = `-- name: GetLowStockItems :many
-SELECT sl.id, sl.product_id, sl.warehouse_id, sl.quantity, sl.reserved_quantity, sl.available_quantity, sl.min_stock_level, sl.max_stock_level, sl.last_updated, sl.created_at, sl.updated_at, p.name as product_name, p.sku, w.name as warehouse_name
-FROM stock_levels sl
-JOIN products p ON sl.product_id = p.id
-JOIN warehouses w ON sl.warehouse_id = w.id
-WHERE sl.available_quantity <= sl.min_stock_level
-ORDER BY sl.available_quantity ASC
+const EnsureStockLevel = `-- name: EnsureStockLevel :exec
+INSERT INTO stock_levels (product_id, warehouse_id, quantity, reserved_quantity, min_stock_level, max_stock_level)
+VALUES ($1, $2, 0, 0, 0, 0)
+ON CONFLICT (product_id, warehouse_id) DO NOTHING
 `
 
-type GetLowStockItemsRow struct {
-	ID                pgtype.UUID        `json:"id"`
-	ProductID         pgtype.UUID        `json:"product_id"`
-	WarehouseID       pgtype.UUID        `json:"warehouse_id"`
-	Quantity          int32              `json:"quantity"`
-	ReservedQuantity  int32              `json:"reserved_quantity"`
-	AvailableQuantity *int32             `json:"available_quantity"`
-	MinStockLevel     *int32             `json:"min_stock_level"`
-	MaxStockLevel     *int32             `json:"max_stock_level"`
-	LastUpdated       pgtype.Timestamptz `json:"last_updated"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	ProductName       string             `json:"product_name"`
-	Sku               string             `json:"sku"`
-	WarehouseName     string             `json:"warehouse_name"`
+type EnsureStockLevelParams struct {
+	ProductID   pgtype.UUID `json:"product_id"`
+	WarehouseID pgtype.UUID `json:"warehouse_id"`
 }
 
-func (q *Queries) GetLowStockItems(ctx context.Context) ([]*GetLowStockItemsRow, error) {
-	rows, err := q.db.Query(ctx, GetLowStockItems)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []*GetLowStockItemsRow{}
-	for rows.Next() {
-		var i GetLowStockItemsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.ProductID,
-			&i.WarehouseID,
-			&i.Quantity,
-			&i.ReservedQuantity,
-			&i.AvailableQuantity,
-			&i.MinStockLevel,
-			&i.MaxStockLevel,
-			&i.LastUpdated,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.ProductName,
-			&i.Sku,
-			&i.WarehouseName,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, &i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) EnsureStockLevel(ctx context.Context, arg *EnsureStockLevelParams) error {
+	_, err := q.db.Exec(ctx, EnsureStockLevel, arg.ProductID, arg.WarehouseID)
+	return err
 }
 
 const GetStockLevel = `-- name: GetStockLevel :one
@@ -206,6 +161,36 @@ func (q *Queries) GetStockLevel(ctx context.Context, arg *GetStockLevelParams) (
 	return &i, err
 }
 
+const GetStockLevelForUpdate = `-- name: GetStockLevelForUpdate :one
+SELECT id, product_id, warehouse_id, quantity, reserved_quantity, available_quantity, min_stock_level, max_stock_level, last_updated, created_at, updated_at FROM stock_levels
+WHERE product_id = $1 AND warehouse_id = $2
+FOR UPDATE
+`
+
+type GetStockLevelForUpdateParams struct {
+	ProductID   pgtype.UUID `json:"product_id"`
+	WarehouseID pgtype.UUID `json:"warehouse_id"`
+}
+
+func (q *Queries) GetStockLevelForUpdate(ctx context.Context, arg *GetStockLevelForUpdateParams) (*StockLevel, error) {
+	row := q.db.QueryRow(ctx, GetStockLevelForUpdate, arg.ProductID, arg.WarehouseID)
+	var i StockLevel
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.Quantity,
+		&i.ReservedQuantity,
+		&i.AvailableQuantity,
+		&i.MinStockLevel,
+		&i.MaxStockLevel,
+		&i.LastUpdated,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const ListStockLevels = `-- name: ListStockLevels :many
 SELECT sl.id, sl.product_id, sl.warehouse_id, sl.quantity, sl.reserved_quantity, sl.available_quantity, sl.min_stock_level, sl.max_stock_level, sl.last_updated, sl.created_at, sl.updated_at, p.name as product_name, p.sku, w.name as warehouse_name
 FROM stock_levels sl
@@ -29,9 +29,10 @@ JOIN products p ON sm.product_id = p.id
 JOIN warehouses w ON sm.warehouse_id = w.id
 WHERE ($1::uuid IS NULL OR sm.product_id = $1)
   AND ($2::uuid IS NULL OR sm.warehouse_id = $2)
-  AND ($3::text IS NULL OR sm.movement_type = $3)
+  AND (NULLIF($3::text, '') IS NULL OR sm.movement_type = $3)
   AND ($4::timestamp IS NULL OR sm.created_at >= $4)
   AND ($5::timestamp IS NULL OR sm.created_at <= $5)
+  AND (NULLIF($6::text, '') IS NULL OR sm.reason_code = $6)
 `
 
 type CountStockMovementsWithFilterParams struct {
@@ -40,6 +41,7 @@ type CountStockMovementsWithFilterParams struct {
 	Column3 string           `json:"column_3"`
 	Column4 pgtype.Timestamp `json:"column_4"`
 	Column5 pgtype.Timestamp `json:"column_5"`
+	Column6 string           `json:"column_6"`
 }
 
 func (q *Queries) CountStockMovementsWithFilter(ctx context.Context, arg *CountStockMovementsWithFilterParams) (int64, error) {
@@ -49,6 +51,7 @@ func (q *Queries) CountStockMovementsWithFilter(ctx context.Context, arg *CountS
 		arg.Column3,
 		arg.Column4,
 		arg.Column5,
+		arg.Column6,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -56,9 +59,9 @@ func (q *Queries) CountStockMovementsWithFilter(ctx context.Context, arg *CountS
 }
 
 const CreateStockMovement = `-- name: CreateStockMovement :one
-INSERT INTO stock_movements (product_id, warehouse_id, movement_type, quantity, cost_price, total_amount, reference_type, reference_id, reference_number, reason, user_id, processed_by, processed_date)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-RETURNING id, product_id, warehouse_id, movement_type, quantity, reference_type, reference_id, reason, user_id, created_at, processed_by, processed_date, cost_price, total_amount, reference_number
+INSERT INTO stock_movements (product_id, warehouse_id, movement_type, quantity, cost_price, total_amount, reference_type, reference_id, reference_number, reason, user_id, processed_by, processed_date, reason_code, from_location_id, to_location_id, uom_id, uom_quantity, uom_factor, reversal_of_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+RETURNING id, product_id, warehouse_id, movement_type, quantity, reference_type, reference_id, reason, user_id, created_at, processed_by, processed_date, cost_price, total_amount, reference_number, reason_code, unit_cost, total_cost, from_location_id, to_location_id, uom_id, uom_quantity, uom_factor, reversal_of_id
 `
 
 type CreateStockMovementParams struct {
@@ -75,6 +78,13 @@ type CreateStockMovementParams struct {
 	UserID          pgtype.UUID        `json:"user_id"`
 	ProcessedBy     pgtype.UUID        `json:"processed_by"`
 	ProcessedDate   pgtype.Timestamptz `json:"processed_date"`
+	ReasonCode      *string            `json:"reason_code"`
+	FromLocationID  pgtype.UUID        `json:"from_location_id"`
+	ToLocationID    pgtype.UUID        `json:"to_location_id"`
+	UomID           pgtype.UUID        `json:"uom_id"`
+	UomQuantity     *int32             `json:"uom_quantity"`
+	UomFactor       *int32             `json:"uom_factor"`
+	ReversalOfID    pgtype.UUID        `json:"reversal_of_id"`
 }
 
 func (q *Queries) CreateStockMovement(ctx context.Context, arg *CreateStockMovementParams) (*StockMovement, error) {
@@ -92,6 +102,13 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg *CreateStockMovem
 		arg.UserID,
 		arg.ProcessedBy,
 		arg.ProcessedDate,
+		arg.ReasonCode,
+		arg.FromLocationID,
+		arg.ToLocationID,
+		arg.UomID,
+		arg.UomQuantity,
+		arg.UomFactor,
+		arg.ReversalOfID,
 	)
 	var i StockMovement
 	err := row.Scan(
@@ -110,13 +127,22 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg *CreateStockMovem
 		&i.CostPrice,
 		&i.TotalAmount,
 		&i.ReferenceNumber,
+		&i.ReasonCode,
+		&i.UnitCost,
+		&i.TotalCost,
+		&i.FromLocationID,
+		&i.ToLocationID,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+		&i.ReversalOfID,
 	)
 	return &i, err
 }
 
 const GetStockInTransactionDetails = `-- name: GetStockInTransactionDetails :many
 SELECT 
-    sm.id, sm.product_id, sm.warehouse_id, sm.movement_type, sm.quantity, sm.reference_type, sm.reference_id, sm.reason, sm.user_id, sm.created_at, sm.processed_by, sm.processed_date, sm.cost_price, sm.total_amount, sm.reference_number,
+    sm.id, sm.product_id, sm.warehouse_id, sm.movement_type, sm.quantity, sm.reference_type, sm.reference_id, sm.reason, sm.user_id, sm.created_at, sm.processed_by, sm.processed_date, sm.cost_price, sm.total_amount, sm.reference_number, sm.reason_code, sm.unit_cost, sm.total_cost, sm.from_location_id, sm.to_location_id, sm.uom_id, sm.uom_quantity, sm.uom_factor, sm.reversal_of_id,
     p.name as product_name,
     p.sku,
     w.name as warehouse_name,
@@ -151,6 +177,15 @@ type GetStockInTransactionDetailsRow struct {
 	CostPrice            pgtype.Numeric     `json:"cost_price"`
 	TotalAmount          pgtype.Numeric     `json:"total_amount"`
 	ReferenceNumber      *string            `json:"reference_number"`
+	ReasonCode           *string            `json:"reason_code"`
+	UnitCost             pgtype.Numeric     `json:"unit_cost"`
+	TotalCost            pgtype.Numeric     `json:"total_cost"`
+	FromLocationID       pgtype.UUID        `json:"from_location_id"`
+	ToLocationID         pgtype.UUID        `json:"to_location_id"`
+	UomID                pgtype.UUID        `json:"uom_id"`
+	UomQuantity          *int32             `json:"uom_quantity"`
+	UomFactor            *int32             `json:"uom_factor"`
+	ReversalOfID         pgtype.UUID        `json:"reversal_of_id"`
 	ProductName          string             `json:"product_name"`
 	Sku                  string             `json:"sku"`
 	WarehouseName        string             `json:"warehouse_name"`
@@ -186,6 +221,15 @@ func (q *Queries) GetStockInTransactionDetails(ctx context.Context, referenceID
 			&i.CostPrice,
 			&i.TotalAmount,
 			&i.ReferenceNumber,
+			&i.ReasonCode,
+			&i.UnitCost,
+			&i.TotalCost,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.UomID,
+			&i.UomQuantity,
+			&i.UomFactor,
+			&i.ReversalOfID,
 			&i.ProductName,
 			&i.Sku,
 			&i.WarehouseName,
@@ -205,6 +249,150 @@ func (q *Queries) GetStockInTransactionDetails(ctx context.Context, referenceID
 	return items, nil
 }
 
+const GetStockMovementForUpdate = `-- name: GetStockMovementForUpdate :one
+SELECT id, product_id, warehouse_id, movement_type, quantity, reference_type, reference_id, reason, user_id, created_at, processed_by, processed_date, cost_price, total_amount, reference_number, reason_code, unit_cost, total_cost, from_location_id, to_location_id, uom_id, uom_quantity, uom_factor, reversal_of_id FROM stock_movements
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetStockMovementForUpdate(ctx context.Context, id pgtype.UUID) (*StockMovement, error) {
+	row := q.db.QueryRow(ctx, GetStockMovementForUpdate, id)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.MovementType,
+		&i.Quantity,
+		&i.ReferenceType,
+		&i.ReferenceID,
+		&i.Reason,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ProcessedBy,
+		&i.ProcessedDate,
+		&i.CostPrice,
+		&i.TotalAmount,
+		&i.ReferenceNumber,
+		&i.ReasonCode,
+		&i.UnitCost,
+		&i.TotalCost,
+		&i.FromLocationID,
+		&i.ToLocationID,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+		&i.ReversalOfID,
+	)
+	return &i, err
+}
+
+const GetStockMovementReversal = `-- name: GetStockMovementReversal :one
+SELECT id, product_id, warehouse_id, movement_type, quantity, reference_type, reference_id, reason, user_id, created_at, processed_by, processed_date, cost_price, total_amount, reference_number, reason_code, unit_cost, total_cost, from_location_id, to_location_id, uom_id, uom_quantity, uom_factor, reversal_of_id FROM stock_movements
+WHERE reversal_of_id = $1
+`
+
+func (q *Queries) GetStockMovementReversal(ctx context.Context, reversalOfID pgtype.UUID) (*StockMovement, error) {
+	row := q.db.QueryRow(ctx, GetStockMovementReversal, reversalOfID)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.WarehouseID,
+		&i.MovementType,
+		&i.Quantity,
+		&i.ReferenceType,
+		&i.ReferenceID,
+		&i.Reason,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ProcessedBy,
+		&i.ProcessedDate,
+		&i.CostPrice,
+		&i.TotalAmount,
+		&i.ReferenceNumber,
+		&i.ReasonCode,
+		&i.UnitCost,
+		&i.TotalCost,
+		&i.FromLocationID,
+		&i.ToLocationID,
+		&i.UomID,
+		&i.UomQuantity,
+		&i.UomFactor,
+		&i.ReversalOfID,
+	)
+	return &i, err
+}
+
+const GetStockValuationAsOf = `-- name: GetStockValuationAsOf :many
+SELECT sm.product_id, p.name as product_name, p.sku, p.category_id, c.name as category_name,
+       sm.warehouse_id, w.name as warehouse_name,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END)::bigint as quantity,
+       SUM(CASE WHEN sm.movement_type = 'out' THEN -1 ELSE 1 END
+           * COALESCE(sm.total_cost, sm.quantity * sm.cost_price, 0))::numeric as total_value
+FROM stock_movements sm
+JOIN products p ON sm.product_id = p.id
+JOIN warehouses w ON sm.warehouse_id = w.id
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE sm.movement_type IN ('in', 'out', 'adjustment')
+  AND COALESCE(sm.processed_date, sm.created_at) <= $1::timestamptz
+  AND ($2::uuid IS NULL OR sm.warehouse_id = $2)
+  AND ($3::uuid IS NULL OR p.category_id = $3)
+GROUP BY sm.product_id, p.name, p.sku, p.category_id, c.name, sm.warehouse_id, w.name
+HAVING SUM(CASE WHEN sm.movement_type = 'out' THEN -sm.quantity ELSE sm.quantity END) <> 0
+    OR SUM(CASE WHEN sm.movement_type = 'out' THEN -1 ELSE 1 END
+           * COALESCE(sm.total_cost, sm.quantity * sm.cost_price, 0)) <> 0
+ORDER BY c.name NULLS LAST, p.name, w.name
+`
+
+type GetStockValuationAsOfParams struct {
+	Column1 pgtype.Timestamptz `json:"column_1"`
+	Column2 pgtype.UUID        `json:"column_2"`
+	Column3 pgtype.UUID        `json:"column_3"`
+}
+
+type GetStockValuationAsOfRow struct {
+	ProductID     pgtype.UUID    `json:"product_id"`
+	ProductName   string         `json:"product_name"`
+	Sku           string         `json:"sku"`
+	CategoryID    pgtype.UUID    `json:"category_id"`
+	CategoryName  *string        `json:"category_name"`
+	WarehouseID   pgtype.UUID    `json:"warehouse_id"`
+	WarehouseName string         `json:"warehouse_name"`
+	Quantity      int64          `json:"quantity"`
+	TotalValue    pgtype.Numeric `json:"total_value"`
+}
+
+func (q *Queries) GetStockValuationAsOf(ctx context.Context, arg *GetStockValuationAsOfParams) ([]*GetStockValuationAsOfRow, error) {
+	rows, err := q.db.Query(ctx, GetStockValuationAsOf, arg.Column1, arg.Column2, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetStockValuationAsOfRow{}
+	for rows.Next() {
+		var i GetStockValuationAsOfRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Sku,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.WarehouseID,
+			&i.WarehouseName,
+			&i.Quantity,
+			&i.TotalValue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListStockInTransactions = `-- name: ListStockInTransactions :many
 SELECT 
     sm.reference_id,
@@ -273,15 +461,16 @@ func (q *Queries) ListStockInTransactions(ctx context.Context, arg *ListStockInT
 }
 
 const ListStockMovements = `-- name: ListStockMovements :many
-SELECT sm.id, sm.product_id, sm.warehouse_id, sm.movement_type, sm.quantity, sm.reference_type, sm.reference_id, sm.reason, sm.user_id, sm.created_at, sm.processed_by, sm.processed_date, sm.cost_price, sm.total_amount, sm.reference_number, p.name as product_name, p.sku, w.name as warehouse_name, u.first_name, u.last_name, 
+SELECT sm.id, sm.product_id, sm.warehouse_id, sm.movement_type, sm.quantity, sm.reference_type, sm.reference_id, sm.reason, sm.user_id, sm.created_at, sm.processed_by, sm.processed_date, sm.cost_price, sm.total_amount, sm.reference_number, sm.reason_code, sm.unit_cost, sm.total_cost, sm.from_location_id, sm.to_location_id, sm.uom_id, sm.uom_quantity, sm.uom_factor, sm.reversal_of_id, p.name as product_name, p.sku, w.name as warehouse_name, u.first_name, u.last_name, 
        pb.first_name as processed_by_first_name, pb.last_name as processed_by_last_name,
-       po.supplier_name
+       po.supplier_name, uom.code as uom
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.id
 JOIN warehouses w ON sm.warehouse_id = w.id
 LEFT JOIN users u ON sm.user_id = u.id
 LEFT JOIN users pb ON sm.processed_by = pb.id
 LEFT JOIN purchase_orders po ON sm.reference_id = po.id
+LEFT JOIN units_of_measure uom ON sm.uom_id = uom.id
 ORDER BY sm.created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -307,6 +496,15 @@ type ListStockMovementsRow struct {
 	CostPrice            pgtype.Numeric     `json:"cost_price"`
 	TotalAmount          pgtype.Numeric     `json:"total_amount"`
 	ReferenceNumber      *string            `json:"reference_number"`
+	ReasonCode           *string            `json:"reason_code"`
+	UnitCost             pgtype.Numeric     `json:"unit_cost"`
+	TotalCost            pgtype.Numeric     `json:"total_cost"`
+	FromLocationID       pgtype.UUID        `json:"from_location_id"`
+	ToLocationID         pgtype.UUID        `json:"to_location_id"`
+	UomID                pgtype.UUID        `json:"uom_id"`
+	UomQuantity          *int32             `json:"uom_quantity"`
+	UomFactor            *int32             `json:"uom_factor"`
+	ReversalOfID         pgtype.UUID        `json:"reversal_of_id"`
 	ProductName          string             `json:"product_name"`
 	Sku                  string             `json:"sku"`
 	WarehouseName        string             `json:"warehouse_name"`
@@ -315,6 +513,7 @@ type ListStockMovementsRow struct {
 	ProcessedByFirstName *string            `json:"processed_by_first_name"`
 	ProcessedByLastName  *string            `json:"processed_by_last_name"`
 	SupplierName         *string            `json:"supplier_name"`
+	Uom                  *string            `json:"uom"`
 }
 
 func (q *Queries) ListStockMovements(ctx context.Context, arg *ListStockMovementsParams) ([]*ListStockMovementsRow, error) {
@@ -342,6 +541,15 @@ func (q *Queries) ListStockMovements(ctx context.Context, arg *ListStockMovement
 			&i.CostPrice,
 			&i.TotalAmount,
 			&i.ReferenceNumber,
+			&i.ReasonCode,
+			&i.UnitCost,
+			&i.TotalCost,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.UomID,
+			&i.UomQuantity,
+			&i.UomFactor,
+			&i.ReversalOfID,
 			&i.ProductName,
 			&i.Sku,
 			&i.WarehouseName,
@@ -350,6 +558,59 @@ func (q *Queries) ListStockMovements(ctx context.Context, arg *ListStockMovement
 			&i.ProcessedByFirstName,
 			&i.ProcessedByLastName,
 			&i.SupplierName,
+			&i.Uom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListStockMovementsByReferenceForUpdate = `-- name: ListStockMovementsByReferenceForUpdate :many
+SELECT id, product_id, warehouse_id, movement_type, quantity, reference_type, reference_id, reason, user_id, created_at, processed_by, processed_date, cost_price, total_amount, reference_number, reason_code, unit_cost, total_cost, from_location_id, to_location_id, uom_id, uom_quantity, uom_factor, reversal_of_id FROM stock_movements
+WHERE reference_id = $1
+ORDER BY created_at, id
+FOR UPDATE
+`
+
+func (q *Queries) ListStockMovementsByReferenceForUpdate(ctx context.Context, referenceID pgtype.UUID) ([]*StockMovement, error) {
+	rows, err := q.db.Query(ctx, ListStockMovementsByReferenceForUpdate, referenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*StockMovement{}
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.WarehouseID,
+			&i.MovementType,
+			&i.Quantity,
+			&i.ReferenceType,
+			&i.ReferenceID,
+			&i.Reason,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.ProcessedBy,
+			&i.ProcessedDate,
+			&i.CostPrice,
+			&i.TotalAmount,
+			&i.ReferenceNumber,
+			&i.ReasonCode,
+			&i.UnitCost,
+			&i.TotalCost,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.UomID,
+			&i.UomQuantity,
+			&i.UomFactor,
+			&i.ReversalOfID,
 		); err != nil {
 			return nil, err
 		}
@@ -362,22 +623,24 @@ func (q *Queries) ListStockMovements(ctx context.Context, arg *ListStockMovement
 }
 
 const ListStockMovementsWithFilter = `-- name: ListStockMovementsWithFilter :many
-SELECT sm.id, sm.product_id, sm.warehouse_id, sm.movement_type, sm.quantity, sm.reference_type, sm.reference_id, sm.reason, sm.user_id, sm.created_at, sm.processed_by, sm.processed_date, sm.cost_price, sm.total_amount, sm.reference_number, p.name as product_name, p.sku, w.name as warehouse_name, u.first_name, u.last_name,
+SELECT sm.id, sm.product_id, sm.warehouse_id, sm.movement_type, sm.quantity, sm.reference_type, sm.reference_id, sm.reason, sm.user_id, sm.created_at, sm.processed_by, sm.processed_date, sm.cost_price, sm.total_amount, sm.reference_number, sm.reason_code, sm.unit_cost, sm.total_cost, sm.from_location_id, sm.to_location_id, sm.uom_id, sm.uom_quantity, sm.uom_factor, sm.reversal_of_id, p.name as product_name, p.sku, w.name as warehouse_name, u.first_name, u.last_name,
        pb.first_name as processed_by_first_name, pb.last_name as processed_by_last_name,
-       po.supplier_name
+       po.supplier_name, uom.code as uom
 FROM stock_movements sm
 JOIN products p ON sm.product_id = p.id
 JOIN warehouses w ON sm.warehouse_id = w.id
 LEFT JOIN users u ON sm.user_id = u.id
 LEFT JOIN users pb ON sm.processed_by = pb.id
 LEFT JOIN purchase_orders po ON sm.reference_id = po.id
+LEFT JOIN units_of_measure uom ON sm.uom_id = uom.id
 WHERE ($1::uuid IS NULL OR sm.product_id = $1)
   AND ($2::uuid IS NULL OR sm.warehouse_id = $2)
-  AND ($3::text IS NULL OR sm.movement_type = $3)
+  AND (NULLIF($3::text, '') IS NULL OR sm.movement_type = $3)
   AND ($4::timestamp IS NULL OR sm.created_at >= $4)
   AND ($5::timestamp IS NULL OR sm.created_at <= $5)
+  AND (NULLIF($6::text, '') IS NULL OR sm.reason_code = $6)
 ORDER BY sm.created_at DESC
-LIMIT $6 OFFSET $7
+LIMIT $7 OFFSET $8
 `
 
 type ListStockMovementsWithFilterParams struct {
@@ -386,6 +649,7 @@ type ListStockMovementsWithFilterParams struct {
 	Column3 string           `json:"column_3"`
 	Column4 pgtype.Timestamp `json:"column_4"`
 	Column5 pgtype.Timestamp `json:"column_5"`
+	Column6 string           `json:"column_6"`
 	Limit   int32            `json:"limit"`
 	Offset  int32            `json:"offset"`
 }
@@ -406,6 +670,15 @@ type ListStockMovementsWithFilterRow struct {
 	CostPrice            pgtype.Numeric     `json:"cost_price"`
 	TotalAmount          pgtype.Numeric     `json:"total_amount"`
 	ReferenceNumber      *string            `json:"reference_number"`
+	ReasonCode           *string            `json:"reason_code"`
+	UnitCost             pgtype.Numeric     `json:"unit_cost"`
+	TotalCost            pgtype.Numeric     `json:"total_cost"`
+	FromLocationID       pgtype.UUID        `json:"from_location_id"`
+	ToLocationID         pgtype.UUID        `json:"to_location_id"`
+	UomID                pgtype.UUID        `json:"uom_id"`
+	UomQuantity          *int32             `json:"uom_quantity"`
+	UomFactor            *int32             `json:"uom_factor"`
+	ReversalOfID         pgtype.UUID        `json:"reversal_of_id"`
 	ProductName          string             `json:"product_name"`
 	Sku                  string             `json:"sku"`
 	WarehouseName        string             `json:"warehouse_name"`
@@ -414,6 +687,7 @@ type ListStockMovementsWithFilterRow struct {
 	ProcessedByFirstName *string            `json:"processed_by_first_name"`
 	ProcessedByLastName  *string            `json:"processed_by_last_name"`
 	SupplierName         *string            `json:"supplier_name"`
+	Uom                  *string            `json:"uom"`
 }
 
 func (q *Queries) ListStockMovementsWithFilter(ctx context.Context, arg *ListStockMovementsWithFilterParams) ([]*ListStockMovementsWithFilterRow, error) {
@@ -423,6 +697,7 @@ func (q *Queries) ListStockMovementsWithFilter(ctx context.Context, arg *ListSto
 		arg.Column3,
 		arg.Column4,
 		arg.Column5,
+		arg.Column6,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -449,6 +724,15 @@ func (q *Queries) ListStockMovementsWithFilter(ctx context.Context, arg *ListSto
 			&i.CostPrice,
 			&i.TotalAmount,
 			&i.ReferenceNumber,
+			&i.ReasonCode,
+			&i.UnitCost,
+			&i.TotalCost,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.UomID,
+			&i.UomQuantity,
+			&i.UomFactor,
+			&i.ReversalOfID,
 			&i.ProductName,
 			&i.Sku,
 			&i.WarehouseName,
@@ -457,6 +741,7 @@ func (q *Queries) ListStockMovementsWithFilter(ctx context.Context, arg *ListSto
 			&i.ProcessedByFirstName,
 			&i.ProcessedByLastName,
 			&i.SupplierName,
+			&i.Uom,
 		); err != nil {
 			return nil, err
 		}
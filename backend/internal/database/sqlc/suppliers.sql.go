@@ -42,9 +42,9 @@ func (q *Queries) CountSuppliersWithFilter(ctx context.Context, arg *CountSuppli
 }
 
 const CreateSupplier = `-- name: CreateSupplier :one
-INSERT INTO suppliers (name, contact_person, email, phone, address, city, state, country, postal_code, is_active)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at
+INSERT INTO suppliers (name, contact_person, email, phone, address, city, state, country, postal_code, is_active, lead_time_days)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at, lead_time_days
 `
 
 type CreateSupplierParams struct {
@@ -58,6 +58,7 @@ type CreateSupplierParams struct {
 	Country       *string `json:"country"`
 	PostalCode    *string `json:"postal_code"`
 	IsActive      *bool   `json:"is_active"`
+	LeadTimeDays  int32   `json:"lead_time_days"`
 }
 
 func (q *Queries) CreateSupplier(ctx context.Context, arg *CreateSupplierParams) (*Supplier, error) {
@@ -72,6 +73,7 @@ func (q *Queries) CreateSupplier(ctx context.Context, arg *CreateSupplierParams)
 		arg.Country,
 		arg.PostalCode,
 		arg.IsActive,
+		arg.LeadTimeDays,
 	)
 	var i Supplier
 	err := row.Scan(
@@ -88,6 +90,7 @@ func (q *Queries) CreateSupplier(ctx context.Context, arg *CreateSupplierParams)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LeadTimeDays,
 	)
 	return &i, err
 }
@@ -104,7 +107,7 @@ func (q *Queries) DeleteSupplier(ctx context.Context, id pgtype.UUID) error {
 }
 
 const GetSupplier = `-- name: GetSupplier :one
-SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at FROM suppliers
+SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at, lead_time_days FROM suppliers
 WHERE id = $1
 `
 
@@ -125,12 +128,13 @@ func (q *Queries) GetSupplier(ctx context.Context, id pgtype.UUID) (*Supplier, e
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LeadTimeDays,
 	)
 	return &i, err
 }
 
 const GetSupplierByName = `-- name: GetSupplierByName :one
-SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at FROM suppliers
+SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at, lead_time_days FROM suppliers
 WHERE name = $1
 `
 
@@ -151,12 +155,13 @@ func (q *Queries) GetSupplierByName(ctx context.Context, name string) (*Supplier
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LeadTimeDays,
 	)
 	return &i, err
 }
 
 const ListSuppliers = `-- name: ListSuppliers :many
-SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at FROM suppliers
+SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at, lead_time_days FROM suppliers
 WHERE is_active = true
 ORDER BY name
 `
@@ -184,6 +189,7 @@ func (q *Queries) ListSuppliers(ctx context.Context) ([]*Supplier, error) {
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LeadTimeDays,
 		); err != nil {
 			return nil, err
 		}
@@ -196,7 +202,7 @@ func (q *Queries) ListSuppliers(ctx context.Context) ([]*Supplier, error) {
 }
 
 const ListSuppliersWithFilter = `-- name: ListSuppliersWithFilter :many
-SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at FROM suppliers
+SELECT id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at, lead_time_days FROM suppliers
 WHERE ($1::text IS NULL OR name ILIKE '%' || $1 || '%')
   AND ($2::text IS NULL OR contact_person ILIKE '%' || $2 || '%')
   AND ($3::text IS NULL OR email ILIKE '%' || $3 || '%')
@@ -255,6 +261,7 @@ func (q *Queries) ListSuppliersWithFilter(ctx context.Context, arg *ListSupplier
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.LeadTimeDays,
 		); err != nil {
 			return nil, err
 		}
@@ -269,9 +276,9 @@ func (q *Queries) ListSuppliersWithFilter(ctx context.Context, arg *ListSupplier
 const UpdateSupplier = `-- name: UpdateSupplier :one
 UPDATE suppliers
 SET name = $2, contact_person = $3, email = $4, phone = $5, address = $6, 
-    city = $7, state = $8, country = $9, postal_code = $10, is_active = $11, updated_at = NOW()
+    city = $7, state = $8, country = $9, postal_code = $10, is_active = $11, lead_time_days = $12, updated_at = NOW()
 WHERE id = $1
-RETURNING id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at
+RETURNING id, name, contact_person, email, phone, address, city, state, country, postal_code, is_active, created_at, updated_at, lead_time_days
 `
 
 type UpdateSupplierParams struct {
@@ -286,6 +293,7 @@ type UpdateSupplierParams struct {
 	Country       *string     `json:"country"`
 	PostalCode    *string     `json:"postal_code"`
 	IsActive      *bool       `json:"is_active"`
+	LeadTimeDays  int32       `json:"lead_time_days"`
 }
 
 func (q *Queries) UpdateSupplier(ctx context.Context, arg *UpdateSupplierParams) (*Supplier, error) {
@@ -301,6 +309,7 @@ func (q *Queries) UpdateSupplier(ctx context.Context, arg *UpdateSupplierParams)
 		arg.Country,
 		arg.PostalCode,
 		arg.IsActive,
+		arg.LeadTimeDays,
 	)
 	var i Supplier
 	err := row.Scan(
@@ -317,6 +326,7 @@ func (q *Queries) UpdateSupplier(ctx context.Context, arg *UpdateSupplierParams)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LeadTimeDays,
 	)
 	return &i, err
 }
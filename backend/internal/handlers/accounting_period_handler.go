@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AccountingPeriodHandler struct {
+	periodService *services.AccountingPeriodService
+}
+
+func NewAccountingPeriodHandler(periodService *services.AccountingPeriodService) *AccountingPeriodHandler {
+	return &AccountingPeriodHandler{
+		periodService: periodService,
+	}
+}
+
+// CreateAccountingPeriod defines the open period for a calendar month
+func (h *AccountingPeriodHandler) CreateAccountingPeriod(c *gin.Context) {
+	var req models.CreateAccountingPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	period, err := h.periodService.CreateAccountingPeriod(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, period)
+}
+
+func (h *AccountingPeriodHandler) GetAccountingPeriod(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid accounting period ID"})
+		return
+	}
+
+	period, err := h.periodService.GetAccountingPeriod(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Accounting period not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// ListAccountingPeriods lists periods newest first, filtered by ?status
+func (h *AccountingPeriodHandler) ListAccountingPeriods(c *gin.Context) {
+	var status *string
+	if statusStr := c.Query("status"); statusStr != "" {
+		status = &statusStr
+	}
+
+	periods, err := h.periodService.ListAccountingPeriods(c.Request.Context(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch accounting periods"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accounting_periods": periods,
+		"total":              len(periods),
+	})
+}
+
+// CloseAccountingPeriod closes an ended period and records its month-end balances
+func (h *AccountingPeriodHandler) CloseAccountingPeriod(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid accounting period ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	period, err := h.periodService.CloseAccountingPeriod(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// ReopenAccountingPeriod lets movements be dated inside a closed period again
+func (h *AccountingPeriodHandler) ReopenAccountingPeriod(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid accounting period ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	period, err := h.periodService.ReopenAccountingPeriod(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, period)
+}
+
+// GetAccountingPeriodBalances returns a closed period's month-end quantity
+// and value per product and warehouse
+func (h *AccountingPeriodHandler) GetAccountingPeriodBalances(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid accounting period ID"})
+		return
+	}
+
+	var productID, warehouseID *uuid.UUID
+	if productIDStr := c.Query("product_id"); productIDStr != "" {
+		id, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		productID = &id
+	}
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		id, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		warehouseID = &id
+	}
+
+	balances, err := h.periodService.GetAccountingPeriodBalances(c.Request.Context(), id, productID, warehouseID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AdjustmentReasonCodeHandler struct {
+	reasonCodeService *services.AdjustmentReasonCodeService
+}
+
+func NewAdjustmentReasonCodeHandler(reasonCodeService *services.AdjustmentReasonCodeService) *AdjustmentReasonCodeHandler {
+	return &AdjustmentReasonCodeHandler{
+		reasonCodeService: reasonCodeService,
+	}
+}
+
+func (h *AdjustmentReasonCodeHandler) CreateAdjustmentReasonCode(c *gin.Context) {
+	var req models.CreateAdjustmentReasonCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reasonCode, err := h.reasonCodeService.CreateAdjustmentReasonCode(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reasonCode)
+}
+
+func (h *AdjustmentReasonCodeHandler) GetAdjustmentReasonCode(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reason code ID"})
+		return
+	}
+
+	reasonCode, err := h.reasonCodeService.GetAdjustmentReasonCode(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reason code not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reasonCode)
+}
+
+func (h *AdjustmentReasonCodeHandler) ListAdjustmentReasonCodes(c *gin.Context) {
+	includeInactive := c.Query("include_inactive") == "true"
+
+	reasonCodes, err := h.reasonCodeService.ListAdjustmentReasonCodes(c.Request.Context(), includeInactive)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reason codes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reason_codes": reasonCodes,
+		"total":        len(reasonCodes),
+	})
+}
+
+func (h *AdjustmentReasonCodeHandler) UpdateAdjustmentReasonCode(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reason code ID"})
+		return
+	}
+
+	var req models.UpdateAdjustmentReasonCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reasonCode, err := h.reasonCodeService.UpdateAdjustmentReasonCode(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reasonCode)
+}
+
+func (h *AdjustmentReasonCodeHandler) DeleteAdjustmentReasonCode(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reason code ID"})
+		return
+	}
+
+	if err := h.reasonCodeService.DeleteAdjustmentReasonCode(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete reason code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reason code deactivated successfully"})
+}
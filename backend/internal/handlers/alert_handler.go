@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AlertHandler struct {
+	alertService *services.AlertService
+}
+
+func NewAlertHandler(alertService *services.AlertService) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+	}
+}
+
+// ListStockAlerts lists stock alerts filtered by status, type, product and warehouse
+func (h *AlertHandler) ListStockAlerts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+	alertType := c.Query("alert_type")
+	productIDStr := c.Query("product_id")
+	warehouseIDStr := c.Query("warehouse_id")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.StockAlertFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if alertType != "" {
+		filter.AlertType = &alertType
+	}
+	if productIDStr != "" {
+		if productID, err := uuid.Parse(productIDStr); err == nil {
+			filter.ProductID = &productID
+		}
+	}
+	if warehouseIDStr != "" {
+		if warehouseID, err := uuid.Parse(warehouseIDStr); err == nil {
+			filter.WarehouseID = &warehouseID
+		}
+	}
+
+	response, err := h.alertService.ListStockAlerts(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AlertHandler) GetStockAlert(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid alert ID"})
+		return
+	}
+
+	alert, err := h.alertService.GetStockAlert(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Stock alert not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+// AcknowledgeStockAlert marks an open alert as being dealt with
+func (h *AlertHandler) AcknowledgeStockAlert(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid alert ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	alert, err := h.alertService.AcknowledgeStockAlert(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+// EvaluateStockAlerts checks every stock level now instead of waiting for
+// the next scheduled evaluation
+func (h *AlertHandler) EvaluateStockAlerts(c *gin.Context) {
+	if err := h.alertService.EvaluateStockLevels(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Stock levels evaluated successfully"})
+}
+
+// ListNotifications returns the caller's in-app inbox
+func (h *AlertHandler) ListNotifications(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	unreadOnly, _ := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	response, err := h.alertService.ListNotifications(c.Request.Context(), userID.(uuid.UUID), models.UserNotificationFilter{
+		UnreadOnly: unreadOnly,
+		Page:       page,
+		Limit:      limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AlertHandler) MarkNotificationRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	notification, err := h.alertService.MarkNotificationRead(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+func (h *AlertHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	if err := h.alertService.MarkAllNotificationsRead(c.Request.Context(), userID.(uuid.UUID)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notifications marked as read"})
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AssemblyHandler struct {
+	assemblyService *services.AssemblyService
+}
+
+func NewAssemblyHandler(assemblyService *services.AssemblyService) *AssemblyHandler {
+	return &AssemblyHandler{
+		assemblyService: assemblyService,
+	}
+}
+
+// GetBOM returns a kit's components and rolled-up cost
+func (h *AssemblyHandler) GetBOM(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	bom, err := h.assemblyService.GetBOM(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bom)
+}
+
+// SetBOM replaces a kit's components
+func (h *AssemblyHandler) SetBOM(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var req models.SetBOMRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bom, err := h.assemblyService.SetBOM(c.Request.Context(), productID, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bom)
+}
+
+// GetKitAvailability returns how many kits can be built per warehouse from
+// component stock
+func (h *AssemblyHandler) GetKitAvailability(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var warehouseID *uuid.UUID
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		id, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		warehouseID = &id
+	}
+
+	availability, err := h.assemblyService.GetKitAvailability(c.Request.Context(), productID, warehouseID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"warehouses": availability})
+}
+
+// CreateAssemblyOrder drafts an assembly or disassembly order
+func (h *AssemblyHandler) CreateAssemblyOrder(c *gin.Context) {
+	var req models.CreateAssemblyOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	order, err := h.assemblyService.CreateAssemblyOrder(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+func (h *AssemblyHandler) GetAssemblyOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assembly order ID"})
+		return
+	}
+
+	order, err := h.assemblyService.GetAssemblyOrder(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Assembly order not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ListAssemblyOrders lists assembly orders filtered by status, type, product and warehouse
+func (h *AssemblyHandler) ListAssemblyOrders(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+	orderType := c.Query("order_type")
+	productIDStr := c.Query("product_id")
+	warehouseIDStr := c.Query("warehouse_id")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.AssemblyOrderFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if orderType != "" {
+		filter.OrderType = &orderType
+	}
+	if productIDStr != "" {
+		if productID, err := uuid.Parse(productIDStr); err == nil {
+			filter.ProductID = &productID
+		}
+	}
+	if warehouseIDStr != "" {
+		if warehouseID, err := uuid.Parse(warehouseIDStr); err == nil {
+			filter.WarehouseID = &warehouseID
+		}
+	}
+
+	response, err := h.assemblyService.ListAssemblyOrders(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CompleteAssemblyOrder posts a draft order's movements
+func (h *AssemblyHandler) CompleteAssemblyOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assembly order ID"})
+		return
+	}
+
+	// Serial numbers are only needed for serialized products, so an empty
+	// body is accepted
+	var req models.CompleteAssemblyOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	order, err := h.assemblyService.CompleteAssemblyOrder(c.Request.Context(), id, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *AssemblyHandler) CancelAssemblyOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assembly order ID"})
+		return
+	}
+
+	order, err := h.assemblyService.CancelAssemblyOrder(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CostingHandler struct {
+	costingService *services.CostingService
+}
+
+func NewCostingHandler(costingService *services.CostingService) *CostingHandler {
+	return &CostingHandler{
+		costingService: costingService,
+	}
+}
+
+// GetCostingSettings returns the company-wide costing method
+func (h *CostingHandler) GetCostingSettings(c *gin.Context) {
+	settings, err := h.costingService.GetCostingSettings(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateCostingSettings changes the costing method and recosts all products
+func (h *CostingHandler) UpdateCostingSettings(c *gin.Context) {
+	var req models.UpdateCostingMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	settings, err := h.costingService.UpdateCostingMethod(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetProductCost returns a product's current unit cost, on-hand value and
+// open cost layers
+func (h *CostingHandler) GetProductCost(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("product_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	cost, err := h.costingService.GetProductCost(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No costed movements for product"})
+		return
+	}
+
+	c.JSON(http.StatusOK, cost)
+}
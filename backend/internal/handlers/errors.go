@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"errors"
+	"inventory-system/internal/services"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// statusForError maps service errors to an HTTP status for document workflows
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidStatusTransition), errors.Is(err, services.ErrPeriodClosed):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInsufficientRole):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
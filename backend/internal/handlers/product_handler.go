@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"inventory-system/internal/models"
 	"inventory-system/internal/services"
@@ -12,8 +13,18 @@ import (
 )
 
 
+// productService is the part of services.ProductService the handler uses
+type productService interface {
+	CreateProduct(ctx context.Context, req models.CreateProductRequest) (*models.Product, error)
+	GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	ListProductsWithFilter(ctx context.Context, filter models.ProductFilter) ([]models.Product, int64, error)
+	ListProductsWithStock(ctx context.Context, filter models.ProductFilter) ([]models.ProductWithStock, int64, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, req models.UpdateProductRequest) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+}
+
 type ProductHandler struct {
-	productService *services.ProductService
+	productService productService
 }
 
 func NewProductHandler(productService *services.ProductService) *ProductHandler {
@@ -12,8 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"edar/internal/models"
-	"edar/internal/services"
+	"inventory-system/internal/models"
 )
 
 // MockProductService is a mock implementation of ProductService
@@ -23,7 +22,8 @@ type MockProductService struct {
 
 func (m *MockProductService) CreateProduct(ctx context.Context, req models.CreateProductRequest) (*models.Product, error) {
 	args := m.Called(ctx, req)
-	return args.Get(0).(*models.Product), args.Error(1)
+	product, _ := args.Get(0).(*models.Product)
+	return product, args.Error(1)
 }
 
 func (m *MockProductService) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
@@ -46,6 +46,11 @@ func (m *MockProductService) ListProductsWithFilter(ctx context.Context, filter
 	return args.Get(0).([]models.Product), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockProductService) ListProductsWithStock(ctx context.Context, filter models.ProductFilter) ([]models.ProductWithStock, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.ProductWithStock), args.Get(1).(int64), args.Error(2)
+}
+
 func TestProductHandler_ListProducts(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -324,16 +329,16 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 			requestBody: models.CreateProductRequest{
 				Name:        "Test Product",
 				SKU:         "TEST-001",
-				Description: "Test description",
+				Description: stringPtr("Test description"),
 				UnitPrice:   10.50,
-				CategoryID:  uuid.New(),
-				SupplierID:  uuid.New(),
+				CategoryID:  uuidPtr(uuid.New()),
+				SupplierID:  uuidPtr(uuid.New()),
 			},
 			mockProduct: &models.Product{
 				ID:          uuid.New(),
 				Name:        "Test Product",
 				SKU:         "TEST-001",
-				Description: "Test description",
+				Description: stringPtr("Test description"),
 				UnitPrice:   10.50,
 				IsActive:    true,
 			},
@@ -357,9 +362,7 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 			// Create mock service
 			mockService := new(MockProductService)
 			
-			if tt.expectedStatus == http.StatusCreated {
-				mockService.On("CreateProduct", mock.Anything, tt.requestBody).Return(tt.mockProduct, tt.mockError)
-			}
+			mockService.On("CreateProduct", mock.Anything, tt.requestBody).Return(tt.mockProduct, tt.mockError)
 
 			// Create handler
 			handler := &ProductHandler{
@@ -396,8 +399,10 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 	}
 }
 
+func stringPtr(s string) *string {
+	return &s
+}
 
-
-
-
-
+func uuidPtr(u uuid.UUID) *uuid.UUID {
+	return &u
+}
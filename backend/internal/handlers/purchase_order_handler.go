@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type PurchaseOrderHandler struct {
@@ -27,9 +28,16 @@ func (h *PurchaseOrderHandler) CreatePurchaseOrder(c *gin.Context) {
 		return
 	}
 
+	// Default the creator to the authenticated user
+	if req.CreatedBy == "" {
+		if userID, exists := c.Get("user_id"); exists {
+			req.CreatedBy = userID.(uuid.UUID).String()
+		}
+	}
+
 	purchaseOrder, err := h.purchaseOrderService.CreatePurchaseOrder(req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create purchase order"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -93,12 +101,89 @@ func (h *PurchaseOrderHandler) UpdatePurchaseOrder(c *gin.Context) {
 		return
 	}
 
-	purchaseOrder, err := h.purchaseOrderService.UpdatePurchaseOrder(id, req)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	purchaseOrder, err := h.purchaseOrderService.UpdatePurchaseOrder(id, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrder)
+}
+
+// ApprovePurchaseOrder approves a pending purchase order
+func (h *PurchaseOrderHandler) ApprovePurchaseOrder(c *gin.Context) {
+	h.decidePurchaseOrder(c, h.purchaseOrderService.ApprovePurchaseOrder)
+}
+
+// RejectPurchaseOrder rejects a pending purchase order
+func (h *PurchaseOrderHandler) RejectPurchaseOrder(c *gin.Context) {
+	h.decidePurchaseOrder(c, h.purchaseOrderService.RejectPurchaseOrder)
+}
+
+func (h *PurchaseOrderHandler) decidePurchaseOrder(c *gin.Context, decide func(string, models.PurchaseOrderDecisionRequest, uuid.UUID, string) (*models.PurchaseOrder, error)) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Purchase order ID is required"})
+		return
+	}
+
+	// The comment is optional, so an empty body is accepted
+	var req models.PurchaseOrderDecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+	role, _ := c.Get("user_role")
+	roleStr, _ := role.(string)
+
+	purchaseOrder, err := decide(id, req, userID.(uuid.UUID), roleStr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update purchase order"})
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, purchaseOrder)
 }
 
+// ReceivePurchaseOrder books goods received against an approved purchase order
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Purchase order ID is required"})
+		return
+	}
+
+	var req models.ReceivePurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	purchaseOrder, err := h.purchaseOrderService.ReceivePurchaseOrder(id, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrder)
+}
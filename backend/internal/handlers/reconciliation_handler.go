@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService *services.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService *services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// GetStockReconciliation reports the stock balances that disagree with the
+// movement ledger
+func (h *ReconciliationHandler) GetStockReconciliation(c *gin.Context) {
+	filter, ok := reconciliationFilter(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reconciliationService.ReconcileStockLevels(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// RepairStockReconciliation repairs the mismatched balances matching the
+// product_id and warehouse_id query filters. The stored balances are set to
+// the ledger unless confirm_stored_balance=true confirms them as the true
+// count, in which case ledger corrections are posted instead.
+func (h *ReconciliationHandler) RepairStockReconciliation(c *gin.Context) {
+	filter, ok := reconciliationFilter(c)
+	if !ok {
+		return
+	}
+
+	storedIsCounted := false
+	if confirm := c.Query("confirm_stored_balance"); confirm != "" {
+		var err error
+		if storedIsCounted, err = strconv.ParseBool(confirm); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid confirm_stored_balance value"})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	report, err := h.reconciliationService.RepairStockLevels(c.Request.Context(), filter, storedIsCounted, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// reconciliationFilter reads the product_id and warehouse_id query filters,
+// writing a 400 response when either is malformed
+func reconciliationFilter(c *gin.Context) (models.StockReconciliationFilter, bool) {
+	var filter models.StockReconciliationFilter
+	if productIDStr := c.Query("product_id"); productIDStr != "" {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return filter, false
+		}
+		filter.ProductID = &productID
+	}
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		warehouseID, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return filter, false
+		}
+		filter.WarehouseID = &warehouseID
+	}
+	return filter, true
+}
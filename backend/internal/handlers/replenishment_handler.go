@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReplenishmentHandler struct {
+	replenishmentService *services.ReplenishmentService
+}
+
+func NewReplenishmentHandler(replenishmentService *services.ReplenishmentService) *ReplenishmentHandler {
+	return &ReplenishmentHandler{
+		replenishmentService: replenishmentService,
+	}
+}
+
+// SaveReorderRule creates or replaces a product's reorder rule for a warehouse
+func (h *ReplenishmentHandler) SaveReorderRule(c *gin.Context) {
+	var req models.SaveReorderRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.replenishmentService.SaveReorderRule(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *ReplenishmentHandler) GetReorderRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reorder rule ID"})
+		return
+	}
+
+	rule, err := h.replenishmentService.GetReorderRule(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reorder rule not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// ListReorderRules lists reorder rules filtered by product and warehouse
+func (h *ReplenishmentHandler) ListReorderRules(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	productIDStr := c.Query("product_id")
+	warehouseIDStr := c.Query("warehouse_id")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.ReorderRuleFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if productIDStr != "" {
+		if productID, err := uuid.Parse(productIDStr); err == nil {
+			filter.ProductID = &productID
+		}
+	}
+	if warehouseIDStr != "" {
+		if warehouseID, err := uuid.Parse(warehouseIDStr); err == nil {
+			filter.WarehouseID = &warehouseID
+		}
+	}
+
+	response, err := h.replenishmentService.ListReorderRules(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ReplenishmentHandler) UpdateReorderRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reorder rule ID"})
+		return
+	}
+
+	var req models.UpdateReorderRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.replenishmentService.UpdateReorderRule(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *ReplenishmentHandler) DeleteReorderRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reorder rule ID"})
+		return
+	}
+
+	if err := h.replenishmentService.DeleteReorderRule(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete reorder rule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reorder rule deleted successfully"})
+}
+
+// GetReorderSuggestions lists the purchase orders the reorder rules call for
+func (h *ReplenishmentHandler) GetReorderSuggestions(c *gin.Context) {
+	var filter models.ReorderSuggestionFilter
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		if warehouseID, err := uuid.Parse(warehouseIDStr); err == nil {
+			filter.WarehouseID = &warehouseID
+		}
+	}
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		if supplierID, err := uuid.Parse(supplierIDStr); err == nil {
+			filter.SupplierID = &supplierID
+		}
+	}
+
+	suggestions, err := h.replenishmentService.GetReorderSuggestions(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// ConvertReorderSuggestions raises purchase orders from reorder suggestions
+func (h *ReplenishmentHandler) ConvertReorderSuggestions(c *gin.Context) {
+	var req models.ConvertReorderSuggestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	orders, err := h.replenishmentService.ConvertReorderSuggestions(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, orders)
+}
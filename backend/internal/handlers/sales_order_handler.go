@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SalesOrderHandler struct {
+	salesOrderService *services.SalesOrderService
+}
+
+func NewSalesOrderHandler(salesOrderService *services.SalesOrderService) *SalesOrderHandler {
+	return &SalesOrderHandler{
+		salesOrderService: salesOrderService,
+	}
+}
+
+// CreateSalesOrder creates a pending sales order with its lines
+func (h *SalesOrderHandler) CreateSalesOrder(c *gin.Context) {
+	var req models.CreateSalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	order, err := h.salesOrderService.CreateSalesOrder(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// GetSalesOrder retrieves a sales order with its lines
+func (h *SalesOrderHandler) GetSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	order, err := h.salesOrderService.GetSalesOrder(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Sales order not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ListSalesOrders lists sales orders filtered by status, customer and order date
+func (h *SalesOrderHandler) ListSalesOrders(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+	customerName := c.Query("customer_name")
+	dateFromStr := c.Query("date_from")
+	dateToStr := c.Query("date_to")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.SalesOrderFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if customerName != "" {
+		filter.CustomerName = &customerName
+	}
+	if dateFromStr != "" {
+		if dateFrom, err := time.Parse("2006-01-02", dateFromStr); err == nil {
+			filter.DateFrom = &dateFrom
+		}
+	}
+	if dateToStr != "" {
+		if dateTo, err := time.Parse("2006-01-02", dateToStr); err == nil {
+			filter.DateTo = &dateTo
+		}
+	}
+
+	response, err := h.salesOrderService.ListSalesOrders(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateSalesOrder edits a pending sales order
+func (h *SalesOrderHandler) UpdateSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	var req models.UpdateSalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.salesOrderService.UpdateSalesOrder(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// DeleteSalesOrder deletes a pending or cancelled sales order
+func (h *SalesOrderHandler) DeleteSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	if err := h.salesOrderService.DeleteSalesOrder(c.Request.Context(), id); err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sales order deleted successfully"})
+}
+
+// ConfirmSalesOrder reserves stock for a pending sales order
+func (h *SalesOrderHandler) ConfirmSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	order, err := h.salesOrderService.ConfirmSalesOrder(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ShipSalesOrder posts the outgoing stock for a confirmed sales order
+func (h *SalesOrderHandler) ShipSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	// Serial numbers are only needed for serialized products, so an empty
+	// body is accepted
+	var req models.ShipSalesOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	order, err := h.salesOrderService.ShipSalesOrder(c.Request.Context(), id, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// DeliverSalesOrder marks a shipped sales order as delivered
+func (h *SalesOrderHandler) DeliverSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	order, err := h.salesOrderService.DeliverSalesOrder(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelSalesOrder cancels a sales order that has not shipped
+func (h *SalesOrderHandler) CancelSalesOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sales order ID"})
+		return
+	}
+
+	order, err := h.salesOrderService.CancelSalesOrder(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
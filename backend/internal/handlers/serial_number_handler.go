@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SerialNumberHandler struct {
+	serialNumberService *services.SerialNumberService
+}
+
+func NewSerialNumberHandler(serialNumberService *services.SerialNumberService) *SerialNumberHandler {
+	return &SerialNumberHandler{
+		serialNumberService: serialNumberService,
+	}
+}
+
+// ListSerialNumbers lists serial numbers filtered by product, warehouse,
+// status and serial number
+func (h *SerialNumberHandler) ListSerialNumbers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	productIDStr := c.Query("product_id")
+	warehouseIDStr := c.Query("warehouse_id")
+	status := c.Query("status")
+	serialNumber := c.Query("serial_number")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.SerialNumberFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if productIDStr != "" {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		filter.ProductID = &productID
+	}
+	if warehouseIDStr != "" {
+		warehouseID, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		filter.WarehouseID = &warehouseID
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if serialNumber != "" {
+		filter.SerialNumber = &serialNumber
+	}
+
+	response, err := h.serialNumberService.ListSerialNumbers(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// LookupSerialNumber returns a unit's current warehouse and movement history.
+// ?product_id is needed when the serial number exists for several products.
+func (h *SerialNumberHandler) LookupSerialNumber(c *gin.Context) {
+	var productID *uuid.UUID
+	if productIDStr := c.Query("product_id"); productIDStr != "" {
+		id, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		productID = &id
+	}
+
+	serialNumber, err := h.serialNumberService.LookupSerialNumber(c.Request.Context(), c.Param("serial_number"), productID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, serialNumber)
+}
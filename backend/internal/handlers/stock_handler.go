@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
 	"inventory-system/internal/models"
 	"inventory-system/internal/services"
@@ -35,8 +38,19 @@ func (h *StockHandler) CreateStockMovement(c *gin.Context) {
 		return
 	}
 
+	// Client retries carrying the same key replay the original response
+	req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+
 	userIDUUID := userID.(uuid.UUID)
 	stockMovement, err := h.stockService.CreateStockMovement(c.Request.Context(), req, &userIDUUID)
+	if errors.Is(err, services.ErrIdempotencyKeyReused) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrPeriodClosed) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -45,6 +59,60 @@ func (h *StockHandler) CreateStockMovement(c *gin.Context) {
 	c.JSON(http.StatusCreated, stockMovement)
 }
 
+// ReverseStockMovement posts the equal and opposite of a movement
+func (h *StockHandler) ReverseStockMovement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stock movement ID"})
+		return
+	}
+
+	h.reverseStockMovements(c, func(req models.ReverseStockMovementRequest, userID *uuid.UUID) ([]models.StockMovement, error) {
+		return h.stockService.ReverseStockMovement(c.Request.Context(), id, req, userID)
+	})
+}
+
+// ReverseStockMovementsByReference reverses every movement sharing a
+// reference ID, such as a whole bulk receipt, together
+func (h *StockHandler) ReverseStockMovementsByReference(c *gin.Context) {
+	referenceID, err := uuid.Parse(c.Param("reference_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reference ID"})
+		return
+	}
+
+	h.reverseStockMovements(c, func(req models.ReverseStockMovementRequest, userID *uuid.UUID) ([]models.StockMovement, error) {
+		return h.stockService.ReverseStockMovementsByReference(c.Request.Context(), referenceID, req, userID)
+	})
+}
+
+// reverseStockMovements reads the optional reversal reason and the acting
+// user, runs the reversal and writes the reversing movements
+func (h *StockHandler) reverseStockMovements(c *gin.Context, reverse func(models.ReverseStockMovementRequest, *uuid.UUID) ([]models.StockMovement, error)) {
+	var req models.ReverseStockMovementRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	userIDUUID := userID.(uuid.UUID)
+	stockMovements, err := reverse(req, &userIDUUID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"stock_movements": stockMovements})
+}
+
 func (h *StockHandler) GetStockLevel(c *gin.Context) {
 	productIDStr := c.Param("product_id")
 	warehouseIDStr := c.Param("warehouse_id")
@@ -123,6 +191,66 @@ func (h *StockHandler) ListStockLevels(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ListStockLevelsAsOf lists the balances held as of ?date (YYYY-MM-DD for
+// the end of that day, or RFC 3339), filtered like ListStockLevels
+func (h *StockHandler) ListStockLevelsAsOf(c *gin.Context) {
+	dateStr := c.Query("date")
+	if dateStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date is required"})
+		return
+	}
+	asOf, err := parseAsOf(dateStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD or RFC 3339"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	productIDStr := c.Query("product_id")
+	warehouseIDStr := c.Query("warehouse_id")
+	productName := c.Query("product_name")
+	productSKU := c.Query("product_sku")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.StockLevelAsOfFilter{
+		AsOf:  asOf,
+		Page:  page,
+		Limit: limit,
+	}
+	if productIDStr != "" {
+		if productID, err := uuid.Parse(productIDStr); err == nil {
+			filter.ProductID = &productID
+		}
+	}
+	if warehouseIDStr != "" {
+		if warehouseID, err := uuid.Parse(warehouseIDStr); err == nil {
+			filter.WarehouseID = &warehouseID
+		}
+	}
+	if productName != "" {
+		filter.ProductName = &productName
+	}
+	if productSKU != "" {
+		filter.ProductSKU = &productSKU
+	}
+
+	response, err := h.stockService.ListStockLevelsAsOf(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 func (h *StockHandler) ListStockMovements(c *gin.Context) {
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -130,6 +258,7 @@ func (h *StockHandler) ListStockMovements(c *gin.Context) {
 	productIDStr := c.Query("product_id")
 	warehouseIDStr := c.Query("warehouse_id")
 	movementType := c.Query("movement_type")
+	reasonCode := c.Query("reason_code")
 	dateFromStr := c.Query("date_from")
 	dateToStr := c.Query("date_to")
 
@@ -175,6 +304,9 @@ func (h *StockHandler) ListStockMovements(c *gin.Context) {
 	if movementType == "" {
 		filter.MovementType = nil
 	}
+	if reasonCode != "" {
+		filter.ReasonCode = &reasonCode
+	}
 
 	response, err := h.stockService.ListStockMovements(c.Request.Context(), filter)
 	if err != nil {
@@ -195,6 +327,86 @@ func (h *StockHandler) GetSOHReport(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": report})
 }
 
+// GetStockValuationReport values stock per product, warehouse and category
+// as of ?as_of (YYYY-MM-DD for the end of that day, or RFC 3339; default
+// now). ?format=csv downloads the report as a spreadsheet.
+func (h *StockHandler) GetStockValuationReport(c *gin.Context) {
+	filter := models.StockValuationFilter{AsOf: time.Now()}
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		asOf, err := parseAsOf(asOfStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of date. Use YYYY-MM-DD or RFC 3339"})
+			return
+		}
+		filter.AsOf = asOf
+	}
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		id, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		filter.WarehouseID = &id
+	}
+	if categoryIDStr := c.Query("category_id"); categoryIDStr != "" {
+		id, err := uuid.Parse(categoryIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return
+		}
+		filter.CategoryID = &id
+	}
+
+	report, err := h.stockService.GetStockValuationReport(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeStockValuationCSV(c, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// writeStockValuationCSV streams the valuation lines followed by the
+// category subtotals and the grand total
+func writeStockValuationCSV(c *gin.Context, report *models.StockValuationReport) {
+	filename := fmt.Sprintf("stock-valuation-%s.csv", report.AsOf.Format("2006-01-02"))
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Status(http.StatusOK)
+
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	category := func(name *string) string {
+		if name == nil {
+			return "Uncategorised"
+		}
+		return *name
+	}
+	subtotalRow := func(label string, t models.StockValuationSubtotal) []string {
+		return []string{label, "", "", "", strconv.Itoa(t.Quantity), money(t.Value),
+			strconv.Itoa(t.InTransitQuantity), money(t.InTransitValue), money(t.TotalValue)}
+	}
+
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"Category", "SKU", "Product", "Warehouse", "Quantity", "Value",
+		"In Transit Quantity", "In Transit Value", "Total Value"})
+	for _, line := range report.Lines {
+		w.Write([]string{category(line.CategoryName), line.ProductSKU, line.ProductName, line.WarehouseName,
+			strconv.Itoa(line.Quantity), money(line.Value),
+			strconv.Itoa(line.InTransitQuantity), money(line.InTransitValue), money(line.TotalValue)})
+	}
+	w.Write(nil)
+	for _, subtotal := range report.Categories {
+		w.Write(subtotalRow(category(subtotal.CategoryName)+" total", subtotal))
+	}
+	w.Write(subtotalRow("Grand total", report.Totals))
+	w.Flush()
+}
+
 func (h *StockHandler) CreateBulkStockMovement(c *gin.Context) {
 	var req models.BulkStockMovementRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -220,12 +432,18 @@ func (h *StockHandler) CreateBulkStockMovement(c *gin.Context) {
 		req.ProcessedBy = userUUID
 	}
 
-	// Set processed_date to current time if not provided
-	if req.ProcessedDate.IsZero() {
-		req.ProcessedDate = time.Now()
-	}
+	// Client retries carrying the same key replay the original response
+	req.IdempotencyKey = c.GetHeader("Idempotency-Key")
 
 	stockMovements, err := h.stockService.CreateBulkStockMovement(c.Request.Context(), req, &userUUID)
+	if errors.Is(err, services.ErrIdempotencyKeyReused) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrInvalidStatusTransition) || errors.Is(err, services.ErrPeriodClosed) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -234,6 +452,53 @@ func (h *StockHandler) CreateBulkStockMovement(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"stock_movements": stockMovements})
 }
 
+func (h *StockHandler) CreateStockTransfer(c *gin.Context) {
+	var req models.StockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	userIDUUID := userID.(uuid.UUID)
+	transfer, err := h.stockService.TransferStock(c.Request.Context(), req, &userIDUUID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// MoveBinStock moves stock between two bins of a warehouse
+func (h *StockHandler) MoveBinStock(c *gin.Context) {
+	var req models.BinMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	userIDUUID := userID.(uuid.UUID)
+	movement, err := h.stockService.MoveBinStock(c.Request.Context(), req, &userIDUUID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, movement)
+}
+
 func (h *StockHandler) GetProductsBySupplier(c *gin.Context) {
 	supplierIDStr := c.Param("supplier_id")
 	supplierID, err := uuid.Parse(supplierIDStr)
@@ -250,3 +515,12 @@ func (h *StockHandler) GetProductsBySupplier(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"products": products})
 }
+
+// parseAsOf reads a point in time given as YYYY-MM-DD, meaning the end of
+// that day, or as RFC 3339
+func parseAsOf(value string) (time.Time, error) {
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StockLotHandler struct {
+	stockLotService *services.StockLotService
+}
+
+func NewStockLotHandler(stockLotService *services.StockLotService) *StockLotHandler {
+	return &StockLotHandler{
+		stockLotService: stockLotService,
+	}
+}
+
+// ListStockLots lists lots filtered by product, lot number and the warehouse
+// holding them
+func (h *StockLotHandler) ListStockLots(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	productIDStr := c.Query("product_id")
+	warehouseIDStr := c.Query("warehouse_id")
+	lotNumber := c.Query("lot_number")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.StockLotFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if productIDStr != "" {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		filter.ProductID = &productID
+	}
+	if warehouseIDStr != "" {
+		warehouseID, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		filter.WarehouseID = &warehouseID
+	}
+	if lotNumber != "" {
+		filter.LotNumber = &lotNumber
+	}
+
+	response, err := h.stockLotService.ListStockLots(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetStockLot retrieves a lot with its balance per warehouse
+func (h *StockLotHandler) GetStockLot(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lot ID"})
+		return
+	}
+
+	lot, err := h.stockLotService.GetStockLot(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lot not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, lot)
+}
+
+// ListStockLotMovements traces every movement of a lot
+func (h *StockLotHandler) ListStockLotMovements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lot ID"})
+		return
+	}
+
+	movements, err := h.stockLotService.ListStockLotMovements(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, movements)
+}
+
+// GetExpiringStockReport lists lot balances expiring within ?days (default
+// 30) per warehouse, with their quantity and value
+func (h *StockLotHandler) GetExpiringStockReport(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a non-negative number"})
+		return
+	}
+
+	var warehouseID *uuid.UUID
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		id, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		warehouseID = &id
+	}
+
+	report, err := h.stockLotService.GetExpiringStockReport(c.Request.Context(), days, warehouseID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StockReservationHandler struct {
+	reservationService *services.StockReservationService
+}
+
+func NewStockReservationHandler(reservationService *services.StockReservationService) *StockReservationHandler {
+	return &StockReservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// CreateStockReservation places a hold on available stock
+func (h *StockReservationHandler) CreateStockReservation(c *gin.Context) {
+	var req models.CreateStockReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	userIDUUID := userID.(uuid.UUID)
+	reservation, err := h.reservationService.CreateStockReservation(c.Request.Context(), req, &userIDUUID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// GetStockReservation retrieves a reservation by ID
+func (h *StockReservationHandler) GetStockReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	reservation, err := h.reservationService.GetStockReservation(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Stock reservation not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// ListStockReservations lists reservations filtered by product, warehouse, status or owner
+func (h *StockReservationHandler) ListStockReservations(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+	ownerType := c.Query("owner_type")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.StockReservationFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if ownerType != "" {
+		filter.OwnerType = &ownerType
+	}
+	if productID, err := uuid.Parse(c.Query("product_id")); err == nil {
+		filter.ProductID = &productID
+	}
+	if warehouseID, err := uuid.Parse(c.Query("warehouse_id")); err == nil {
+		filter.WarehouseID = &warehouseID
+	}
+	if ownerID, err := uuid.Parse(c.Query("owner_id")); err == nil {
+		filter.OwnerID = &ownerID
+	}
+
+	response, err := h.reservationService.ListStockReservations(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ExtendStockReservation changes the expiry of an active reservation
+func (h *StockReservationHandler) ExtendStockReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	var req models.ExtendStockReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := h.reservationService.ExtendStockReservation(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// ReleaseStockReservation releases all or part of a reservation
+func (h *StockReservationHandler) ReleaseStockReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	var req models.ReleaseStockReservationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	reservation, err := h.reservationService.ReleaseStockReservation(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// ConsumeStockReservation ships reserved stock out of the warehouse
+func (h *StockReservationHandler) ConsumeStockReservation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reservation ID"})
+		return
+	}
+
+	var req models.ConsumeStockReservationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	userIDUUID := userID.(uuid.UUID)
+	reservation, err := h.reservationService.ConsumeStockReservation(c.Request.Context(), id, req, &userIDUUID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StockTransferHandler struct {
+	stockTransferService *services.StockTransferService
+}
+
+func NewStockTransferHandler(stockTransferService *services.StockTransferService) *StockTransferHandler {
+	return &StockTransferHandler{
+		stockTransferService: stockTransferService,
+	}
+}
+
+// CreateStockTransfer creates a draft transfer document
+func (h *StockTransferHandler) CreateStockTransfer(c *gin.Context) {
+	var req models.CreateStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	transfer, err := h.stockTransferService.CreateStockTransfer(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// GetStockTransfer retrieves a transfer with its lines
+func (h *StockTransferHandler) GetStockTransfer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	transfer, err := h.stockTransferService.GetStockTransfer(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Stock transfer not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// ListStockTransfers lists transfers filtered by status and warehouses
+func (h *StockTransferHandler) ListStockTransfers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+	fromWarehouseIDStr := c.Query("from_warehouse_id")
+	toWarehouseIDStr := c.Query("to_warehouse_id")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.StockTransferFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if fromWarehouseIDStr != "" {
+		if fromWarehouseID, err := uuid.Parse(fromWarehouseIDStr); err == nil {
+			filter.FromWarehouseID = &fromWarehouseID
+		}
+	}
+	if toWarehouseIDStr != "" {
+		if toWarehouseID, err := uuid.Parse(toWarehouseIDStr); err == nil {
+			filter.ToWarehouseID = &toWarehouseID
+		}
+	}
+
+	response, err := h.stockTransferService.ListStockTransfers(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateStockTransfer edits a draft transfer
+func (h *StockTransferHandler) UpdateStockTransfer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	var req models.UpdateStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	transfer, err := h.stockTransferService.UpdateStockTransfer(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// DispatchStockTransfer ships a draft transfer from the source warehouse
+func (h *StockTransferHandler) DispatchStockTransfer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	transfer, err := h.stockTransferService.DispatchStockTransfer(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// ReceiveStockTransfer books a full or partial receipt at the destination
+func (h *StockTransferHandler) ReceiveStockTransfer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	var req models.ReceiveStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	transfer, err := h.stockTransferService.ReceiveStockTransfer(c.Request.Context(), id, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
+
+// CancelStockTransfer cancels a draft transfer
+func (h *StockTransferHandler) CancelStockTransfer(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transfer ID"})
+		return
+	}
+
+	transfer, err := h.stockTransferService.CancelStockTransfer(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
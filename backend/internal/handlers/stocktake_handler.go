@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StocktakeHandler struct {
+	stocktakeService *services.StocktakeService
+}
+
+func NewStocktakeHandler(stocktakeService *services.StocktakeService) *StocktakeHandler {
+	return &StocktakeHandler{
+		stocktakeService: stocktakeService,
+	}
+}
+
+// CreateStocktake opens a count session and freezes expected quantities
+func (h *StocktakeHandler) CreateStocktake(c *gin.Context) {
+	var req models.CreateStocktakeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.CreateStocktake(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, stocktake)
+}
+
+// GetStocktake retrieves a stocktake with its counts and variances
+func (h *StocktakeHandler) GetStocktake(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.GetStocktake(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Stocktake not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
+
+// ListStocktakes lists stocktakes filtered by status and warehouse
+func (h *StocktakeHandler) ListStocktakes(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	status := c.Query("status")
+	warehouseIDStr := c.Query("warehouse_id")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.StocktakeFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+	if warehouseIDStr != "" {
+		if warehouseID, err := uuid.Parse(warehouseIDStr); err == nil {
+			filter.WarehouseID = &warehouseID
+		}
+	}
+
+	response, err := h.stocktakeService.ListStocktakes(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SubmitStocktakeCounts records the caller's counts
+func (h *StocktakeHandler) SubmitStocktakeCounts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+
+	var req models.SubmitStocktakeCountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.SubmitStocktakeCounts(c.Request.Context(), id, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
+
+// SubmitStocktakeForReview closes counting and works out the variances
+func (h *StocktakeHandler) SubmitStocktakeForReview(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.SubmitStocktakeForReview(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
+
+// ReopenStocktake sends a stocktake back to counting for a recount
+func (h *StocktakeHandler) ReopenStocktake(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.ReopenStocktake(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
+
+// ResolveStocktakeItem settles the count of a line under review
+func (h *StocktakeHandler) ResolveStocktakeItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+	itemID, err := uuid.Parse(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	var req models.ResolveStocktakeItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.ResolveStocktakeItem(c.Request.Context(), id, itemID, req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
+
+// ApproveStocktake posts the count corrections
+func (h *StocktakeHandler) ApproveStocktake(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.ApproveStocktake(c.Request.Context(), id, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
+
+// CancelStocktake abandons a stocktake
+func (h *StocktakeHandler) CancelStocktake(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid stocktake ID"})
+		return
+	}
+
+	stocktake, err := h.stocktakeService.CancelStocktake(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stocktake)
+}
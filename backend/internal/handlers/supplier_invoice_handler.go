@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SupplierInvoiceHandler struct {
+	supplierInvoiceService *services.SupplierInvoiceService
+}
+
+func NewSupplierInvoiceHandler(supplierInvoiceService *services.SupplierInvoiceService) *SupplierInvoiceHandler {
+	return &SupplierInvoiceHandler{
+		supplierInvoiceService: supplierInvoiceService,
+	}
+}
+
+// CreateSupplierInvoice records a supplier invoice against a purchase order and
+// matches it against the order and its receipts
+func (h *SupplierInvoiceHandler) CreateSupplierInvoice(c *gin.Context) {
+	var req models.CreateSupplierInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	invoice, err := h.supplierInvoiceService.CreateSupplierInvoice(c.Request.Context(), req, userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invoice)
+}
+
+// GetSupplierInvoice retrieves a supplier invoice with its matched lines
+func (h *SupplierInvoiceHandler) GetSupplierInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier invoice ID"})
+		return
+	}
+
+	invoice, err := h.supplierInvoiceService.GetSupplierInvoice(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Supplier invoice not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// ListSupplierInvoices lists supplier invoices filtered by purchase order and
+// match status
+func (h *SupplierInvoiceHandler) ListSupplierInvoices(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	purchaseOrderIDStr := c.Query("purchase_order_id")
+	status := c.Query("status")
+
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := models.SupplierInvoiceFilter{
+		Page:  page,
+		Limit: limit,
+	}
+	if purchaseOrderIDStr != "" {
+		purchaseOrderID, err := uuid.Parse(purchaseOrderIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid purchase order ID"})
+			return
+		}
+		filter.PurchaseOrderID = &purchaseOrderID
+	}
+	if status != "" {
+		filter.Status = &status
+	}
+
+	response, err := h.supplierInvoiceService.ListSupplierInvoices(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MatchSupplierInvoice re-runs the three-way match for a supplier invoice
+func (h *SupplierInvoiceHandler) MatchSupplierInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier invoice ID"})
+		return
+	}
+
+	invoice, err := h.supplierInvoiceService.MatchSupplierInvoice(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
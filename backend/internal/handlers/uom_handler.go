@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UOMHandler struct {
+	uomService *services.UOMService
+}
+
+func NewUOMHandler(uomService *services.UOMService) *UOMHandler {
+	return &UOMHandler{
+		uomService: uomService,
+	}
+}
+
+func (h *UOMHandler) CreateUnitOfMeasure(c *gin.Context) {
+	var req models.CreateUnitOfMeasureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uom, err := h.uomService.CreateUnitOfMeasure(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, uom)
+}
+
+func (h *UOMHandler) GetUnitOfMeasure(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit of measure ID"})
+		return
+	}
+
+	uom, err := h.uomService.GetUnitOfMeasure(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unit of measure not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, uom)
+}
+
+func (h *UOMHandler) ListUnitsOfMeasure(c *gin.Context) {
+	uoms, err := h.uomService.ListUnitsOfMeasure(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch units of measure"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"uoms":  uoms,
+		"total": len(uoms),
+	})
+}
+
+func (h *UOMHandler) UpdateUnitOfMeasure(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit of measure ID"})
+		return
+	}
+
+	var req models.UpdateUnitOfMeasureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uom, err := h.uomService.UpdateUnitOfMeasure(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, uom)
+}
+
+// GetProductUOMs lists the units a product can be bought, sold and moved in
+func (h *UOMHandler) GetProductUOMs(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	uoms, err := h.uomService.GetProductUOMs(c.Request.Context(), productID)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"uoms": uoms})
+}
+
+// SetProductUOMs replaces a product's units and their conversion factors
+func (h *UOMHandler) SetProductUOMs(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var req models.SetProductUOMsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uoms, err := h.uomService.SetProductUOMs(c.Request.Context(), productID, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"uoms": uoms})
+}
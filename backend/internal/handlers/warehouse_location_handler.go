@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"inventory-system/internal/models"
+	"inventory-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WarehouseLocationHandler struct {
+	locationService *services.WarehouseLocationService
+}
+
+func NewWarehouseLocationHandler(locationService *services.WarehouseLocationService) *WarehouseLocationHandler {
+	return &WarehouseLocationHandler{
+		locationService: locationService,
+	}
+}
+
+// ListWarehouseLocations lists the zones, aisles and bins of a warehouse
+func (h *WarehouseLocationHandler) ListWarehouseLocations(c *gin.Context) {
+	warehouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+		return
+	}
+	includeInactive := c.Query("include_inactive") == "true"
+
+	locations, err := h.locationService.ListWarehouseLocations(c.Request.Context(), warehouseID, includeInactive)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, locations)
+}
+
+// CreateWarehouseLocation adds a zone, aisle or bin to a warehouse
+func (h *WarehouseLocationHandler) CreateWarehouseLocation(c *gin.Context) {
+	warehouseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+		return
+	}
+
+	var req models.CreateWarehouseLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	location, err := h.locationService.CreateWarehouseLocation(c.Request.Context(), warehouseID, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, location)
+}
+
+// GetWarehouseLocation retrieves a location and, for bins, the stock held in it
+func (h *WarehouseLocationHandler) GetWarehouseLocation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location ID"})
+		return
+	}
+
+	location, err := h.locationService.GetWarehouseLocation(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Location not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+// UpdateWarehouseLocation renames or (de)activates a location
+func (h *WarehouseLocationHandler) UpdateWarehouseLocation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location ID"})
+		return
+	}
+
+	var req models.UpdateWarehouseLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	location, err := h.locationService.UpdateWarehouseLocation(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(statusForError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+// ListBinStockLevels lists bin balances filtered by warehouse, bin and product
+func (h *WarehouseLocationHandler) ListBinStockLevels(c *gin.Context) {
+	var filter models.BinStockLevelFilter
+	if warehouseIDStr := c.Query("warehouse_id"); warehouseIDStr != "" {
+		warehouseID, err := uuid.Parse(warehouseIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid warehouse ID"})
+			return
+		}
+		filter.WarehouseID = &warehouseID
+	}
+	if locationIDStr := c.Query("location_id"); locationIDStr != "" {
+		locationID, err := uuid.Parse(locationIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location ID"})
+			return
+		}
+		filter.LocationID = &locationID
+	}
+	if productIDStr := c.Query("product_id"); productIDStr != "" {
+		productID, err := uuid.Parse(productIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		filter.ProductID = &productID
+	}
+
+	levels, err := h.locationService.ListBinStockLevels(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, levels)
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Accounting period statuses. No movement may be processed inside a closed
+// period, or before it, until an admin reopens it.
+const (
+	AccountingPeriodStatusOpen   = "open"
+	AccountingPeriodStatusClosed = "closed"
+)
+
+// AccountingPeriod is a calendar month covering [StartsAt, EndsAt) in UTC
+type AccountingPeriod struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     time.Time  `json:"ends_at"`
+	Status     string     `json:"status"`
+	ClosedBy   *uuid.UUID `json:"closed_by"`
+	ClosedAt   *time.Time `json:"closed_at"`
+	ReopenedBy *uuid.UUID `json:"reopened_by"`
+	ReopenedAt *time.Time `json:"reopened_at"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type CreateAccountingPeriodRequest struct {
+	Year  int `json:"year" validate:"required"`
+	Month int `json:"month" validate:"required,min=1,max=12"`
+}
+
+// MonthPeriod returns the name ("2006-01") and bounds of the accounting
+// period for a calendar month
+func MonthPeriod(year, month int) (string, time.Time, time.Time, error) {
+	if year < 1 || year > 9999 {
+		return "", time.Time{}, time.Time{}, errors.New("year must be between 1 and 9999")
+	}
+	if month < 1 || month > 12 {
+		return "", time.Time{}, time.Time{}, errors.New("month must be between 1 and 12")
+	}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("%04d-%02d", year, month), start, start.AddDate(0, 1, 0), nil
+}
+
+// AccountingPeriodBalance is the quantity and value a warehouse held of a
+// product at the end of a period, recorded when the period was closed
+type AccountingPeriodBalance struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    int       `json:"quantity"`
+	TotalValue  float64   `json:"total_value"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+// AccountingPeriodBalances is a closed period's month-end snapshot. Open
+// periods have no balances.
+type AccountingPeriodBalances struct {
+	Period     AccountingPeriod          `json:"period"`
+	Balances   []AccountingPeriodBalance `json:"balances"`
+	TotalValue float64                   `json:"total_value"`
+}
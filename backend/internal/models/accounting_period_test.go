@@ -0,0 +1,25 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthPeriod(t *testing.T) {
+	name, start, end, err := MonthPeriod(2026, 12)
+	if err != nil {
+		t.Fatalf("MonthPeriod() error = %v", err)
+	}
+	if name != "2026-12" {
+		t.Errorf("name = %q, want 2026-12", name)
+	}
+	if !start.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("bounds = %v, %v, want 2026-12-01 to 2027-01-01", start, end)
+	}
+
+	for _, tt := range []struct{ year, month int }{{2026, 0}, {2026, 13}, {0, 1}} {
+		if _, _, _, err := MonthPeriod(tt.year, tt.month); err == nil {
+			t.Errorf("MonthPeriod(%d, %d) error = nil, want error", tt.year, tt.month)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdjustmentReasonCode struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Code        string    `json:"code" db:"code"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description" db:"description"`
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateAdjustmentReasonCodeRequest struct {
+	Code        string  `json:"code" validate:"required,max=50"`
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description *string `json:"description"`
+}
+
+type UpdateAdjustmentReasonCodeRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	Description *string `json:"description"`
+	IsActive    *bool   `json:"is_active"`
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockAlertTypeLowStock        = "low_stock"
+	StockAlertTypeStockOut        = "stock_out"
+	StockAlertTypeOverMax         = "over_max"
+	StockAlertTypeNegativeAttempt = "negative_attempt"
+)
+
+const (
+	StockAlertStatusOpen         = "open"
+	StockAlertStatusAcknowledged = "acknowledged"
+	StockAlertStatusResolved     = "resolved"
+)
+
+// StockAlertCondition is an alert a stock balance currently calls for and
+// the level it crossed
+type StockAlertCondition struct {
+	AlertType string
+	Threshold int
+}
+
+// EvaluateStockAlerts returns the alerts a balance calls for. Stock-outs and
+// low stock only apply where a minimum level is set, which is how a
+// warehouse marks the products it is meant to carry; over max applies where
+// a maximum above zero is set. A stock-out replaces low stock.
+func EvaluateStockAlerts(quantity, available, minLevel int, maxLevel *int) []StockAlertCondition {
+	var conditions []StockAlertCondition
+	if minLevel > 0 {
+		switch {
+		case available <= 0:
+			conditions = append(conditions, StockAlertCondition{AlertType: StockAlertTypeStockOut, Threshold: 0})
+		case available < minLevel:
+			conditions = append(conditions, StockAlertCondition{AlertType: StockAlertTypeLowStock, Threshold: minLevel})
+		}
+	}
+	if maxLevel != nil && *maxLevel > 0 && quantity > *maxLevel {
+		conditions = append(conditions, StockAlertCondition{AlertType: StockAlertTypeOverMax, Threshold: *maxLevel})
+	}
+	return conditions
+}
+
+// AcknowledgedAlertStatus returns the status an open alert moves to when it
+// is acknowledged. Refused postings have no condition that could clear, so
+// acknowledging them closes them.
+func AcknowledgedAlertStatus(alertType string) string {
+	if alertType == StockAlertTypeNegativeAttempt {
+		return StockAlertStatusResolved
+	}
+	return StockAlertStatusAcknowledged
+}
+
+// StockAlert is a stock condition raised by the alert evaluator. Quantity is
+// the available stock when the alert was last triggered (on hand for over
+// max alerts).
+type StockAlert struct {
+	ID              uuid.UUID  `json:"id"`
+	AlertType       string     `json:"alert_type"`
+	Status          string     `json:"status"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	WarehouseID     uuid.UUID  `json:"warehouse_id"`
+	Quantity        int        `json:"quantity"`
+	Threshold       *int       `json:"threshold"`
+	Message         string     `json:"message"`
+	Occurrences     int        `json:"occurrences"`
+	LastTriggeredAt time.Time  `json:"last_triggered_at"`
+	AcknowledgedBy  *uuid.UUID `json:"acknowledged_by"`
+	AcknowledgedAt  *time.Time `json:"acknowledged_at"`
+	ResolvedAt      *time.Time `json:"resolved_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+type StockAlertFilter struct {
+	Status      *string    `json:"status"`
+	AlertType   *string    `json:"alert_type"`
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type StockAlertListResponse struct {
+	StockAlerts []StockAlert `json:"stock_alerts"`
+	Total       int64        `json:"total"`
+	Page        int          `json:"page"`
+	Limit       int          `json:"limit"`
+	Pages       int          `json:"pages"`
+}
+
+// UserNotification is an entry in a user's in-app inbox
+type UserNotification struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	StockAlertID *uuid.UUID `json:"stock_alert_id"`
+	Title        string     `json:"title"`
+	Message      string     `json:"message"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type UserNotificationFilter struct {
+	UnreadOnly bool `json:"unread_only"`
+	Page       int  `json:"page" validate:"min=1"`
+	Limit      int  `json:"limit" validate:"min=1,max=100"`
+}
+
+type UserNotificationListResponse struct {
+	Notifications []UserNotification `json:"notifications"`
+	Unread        int64              `json:"unread"`
+	Total         int64              `json:"total"`
+	Page          int                `json:"page"`
+	Limit         int                `json:"limit"`
+	Pages         int                `json:"pages"`
+}
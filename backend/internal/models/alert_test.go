@@ -0,0 +1,35 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateStockAlerts(t *testing.T) {
+	max := 100
+	zero := 0
+	tests := []struct {
+		name                         string
+		quantity, available, minimum int
+		maximum                      *int
+		want                         []StockAlertCondition
+	}{
+		{"above minimum", 20, 20, 10, &max, nil},
+		{"at minimum", 10, 10, 10, nil, nil},
+		{"below minimum", 9, 9, 10, nil, []StockAlertCondition{{StockAlertTypeLowStock, 10}}},
+		{"reserved below minimum", 20, 5, 10, nil, []StockAlertCondition{{StockAlertTypeLowStock, 10}}},
+		{"out of stock", 0, 0, 10, nil, []StockAlertCondition{{StockAlertTypeStockOut, 0}}},
+		{"all reserved", 5, 0, 10, nil, []StockAlertCondition{{StockAlertTypeStockOut, 0}}},
+		{"no minimum", 0, 0, 0, nil, nil},
+		{"over maximum", 150, 150, 10, &max, []StockAlertCondition{{StockAlertTypeOverMax, 100}}},
+		{"zero maximum", 150, 150, 10, &zero, nil},
+		{"reserved out of stock over maximum", 150, 0, 10, &max, []StockAlertCondition{{StockAlertTypeStockOut, 0}, {StockAlertTypeOverMax, 100}}},
+	}
+
+	for _, tt := range tests {
+		got := EvaluateStockAlerts(tt.quantity, tt.available, tt.minimum, tt.maximum)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: EvaluateStockAlerts(%d, %d, %d) = %v, want %v", tt.name, tt.quantity, tt.available, tt.minimum, got, tt.want)
+		}
+	}
+}
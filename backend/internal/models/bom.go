@@ -0,0 +1,192 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Assembly order types. Assembly consumes components and produces the kit;
+// disassembly consumes the kit and returns its components to stock.
+const (
+	AssemblyOrderTypeAssembly    = "assembly"
+	AssemblyOrderTypeDisassembly = "disassembly"
+)
+
+// Assembly order statuses. Drafts post nothing until they are completed.
+const (
+	AssemblyOrderStatusDraft     = "draft"
+	AssemblyOrderStatusCompleted = "completed"
+	AssemblyOrderStatusCancelled = "cancelled"
+)
+
+// AssemblyOrderReferenceType is the reference type of the movements an assembly order posts
+const AssemblyOrderReferenceType = "assembly_order"
+
+// BOMComponent is a product a kit is made of and how many base units of it
+// one kit takes. UnitCost is the component's current unit cost.
+type BOMComponent struct {
+	ID          uuid.UUID `json:"id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	ComponentID uuid.UUID `json:"component_id"`
+	Quantity    int       `json:"quantity"`
+	UnitCost    float64   `json:"unit_cost"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// Joined fields
+	ComponentName *string `json:"component_name,omitempty"`
+	ComponentSKU  *string `json:"component_sku,omitempty"`
+}
+
+// BillOfMaterials is a kit's components with the cost of one kit rolled up
+// from their current unit costs
+type BillOfMaterials struct {
+	ProductID  uuid.UUID      `json:"product_id"`
+	Components []BOMComponent `json:"components"`
+	UnitCost   float64        `json:"unit_cost"`
+}
+
+// SetBOMRequest replaces a kit's components; an empty list makes the product
+// an ordinary one again
+type SetBOMRequest struct {
+	Components []BOMComponentInput `json:"components"`
+}
+
+type BOMComponentInput struct {
+	ComponentID uuid.UUID `json:"component_id" validate:"required"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+}
+
+// ValidateBOM checks a kit's components: each listed once, with a positive
+// quantity, and not the kit itself
+func ValidateBOM(productID uuid.UUID, components []BOMComponentInput) error {
+	seen := make(map[uuid.UUID]bool, len(components))
+	for _, component := range components {
+		if component.ComponentID == uuid.Nil {
+			return errors.New("a component product is required for every entry")
+		}
+		if component.ComponentID == productID {
+			return errors.New("a kit cannot be a component of itself")
+		}
+		if seen[component.ComponentID] {
+			return fmt.Errorf("component %s is listed more than once", component.ComponentID)
+		}
+		seen[component.ComponentID] = true
+		if component.Quantity <= 0 {
+			return fmt.Errorf("quantity for component %s must be greater than zero", component.ComponentID)
+		}
+	}
+	return nil
+}
+
+// KitComponentStock is a component's stock in one warehouse with the quantity
+// one kit takes
+type KitComponentStock struct {
+	ComponentID   uuid.UUID `json:"component_id"`
+	QuantityPer   int       `json:"quantity_per"`
+	Available     int       `json:"available"`
+	Buildable     int       `json:"buildable"`
+	ComponentName *string   `json:"component_name,omitempty"`
+	ComponentSKU  *string   `json:"component_sku,omitempty"`
+}
+
+// KitAvailability is how many kits can be built in a warehouse from the
+// available stock of their components. LimitingComponentID is the component
+// that runs out first.
+type KitAvailability struct {
+	WarehouseID         uuid.UUID           `json:"warehouse_id"`
+	WarehouseName       string              `json:"warehouse_name"`
+	Buildable           int                 `json:"buildable"`
+	LimitingComponentID *uuid.UUID          `json:"limiting_component_id"`
+	Components          []KitComponentStock `json:"components"`
+}
+
+// BuildableKits returns how many kits the components' available stock makes,
+// and the index of the component that limits it, or -1 when there are no
+// components. Each component's Buildable is filled in.
+func BuildableKits(components []KitComponentStock) (int, int) {
+	buildable, limiting := 0, -1
+	for i := range components {
+		c := &components[i]
+		c.Buildable = 0
+		if c.QuantityPer > 0 && c.Available > 0 {
+			c.Buildable = c.Available / c.QuantityPer
+		}
+		if limiting < 0 || c.Buildable < buildable {
+			buildable, limiting = c.Buildable, i
+		}
+	}
+	return buildable, limiting
+}
+
+type AssemblyOrder struct {
+	ID          uuid.UUID           `json:"id"`
+	OrderNumber string              `json:"order_number"`
+	OrderType   string              `json:"order_type"`
+	ProductID   uuid.UUID           `json:"product_id"`
+	WarehouseID uuid.UUID           `json:"warehouse_id"`
+	Quantity    int                 `json:"quantity"`
+	Status      string              `json:"status"`
+	UnitCost    *float64            `json:"unit_cost"`
+	TotalCost   *float64            `json:"total_cost"`
+	Notes       *string             `json:"notes"`
+	CreatedBy   uuid.UUID           `json:"created_by"`
+	CompletedBy *uuid.UUID          `json:"completed_by"`
+	CompletedAt *time.Time          `json:"completed_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Components  []AssemblyOrderLine `json:"components,omitempty"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+// AssemblyOrderLine is a component of an assembly order, copied from the bill
+// of materials when the order was created, with the cost completion consumed
+// or allocated to it
+type AssemblyOrderLine struct {
+	ID          uuid.UUID `json:"id"`
+	ComponentID uuid.UUID `json:"component_id"`
+	QuantityPer int       `json:"quantity_per"`
+	Quantity    int       `json:"quantity"`
+	UnitCost    *float64  `json:"unit_cost"`
+	TotalCost   *float64  `json:"total_cost"`
+	// Joined fields
+	ComponentName *string `json:"component_name,omitempty"`
+	ComponentSKU  *string `json:"component_sku,omitempty"`
+}
+
+type CreateAssemblyOrderRequest struct {
+	// OrderType is assembly or disassembly; it defaults to assembly
+	OrderType   string    `json:"order_type,omitempty"`
+	ProductID   uuid.UUID `json:"product_id" validate:"required"`
+	WarehouseID uuid.UUID `json:"warehouse_id" validate:"required"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+	Notes       *string   `json:"notes"`
+}
+
+// CompleteAssemblyOrderRequest names the units of serialized products the
+// order consumes or produces, keyed by product ID
+type CompleteAssemblyOrderRequest struct {
+	SerialNumbers map[uuid.UUID][]string `json:"serial_numbers,omitempty"`
+}
+
+type AssemblyOrderFilter struct {
+	Status      *string    `json:"status"`
+	OrderType   *string    `json:"order_type"`
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type AssemblyOrderListResponse struct {
+	AssemblyOrders []AssemblyOrder `json:"assembly_orders"`
+	Total          int64           `json:"total"`
+	Page           int             `json:"page"`
+	Limit          int             `json:"limit"`
+	Pages          int             `json:"pages"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateBOM(t *testing.T) {
+	kit, a, b := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name       string
+		components []BOMComponentInput
+		wantErr    bool
+	}{
+		{"none", nil, false},
+		{"two components", []BOMComponentInput{{a, 2}, {b, 1}}, false},
+		{"kit in itself", []BOMComponentInput{{kit, 1}}, true},
+		{"duplicate component", []BOMComponentInput{{a, 1}, {a, 2}}, true},
+		{"zero quantity", []BOMComponentInput{{a, 0}}, true},
+		{"missing component", []BOMComponentInput{{uuid.Nil, 1}}, true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateBOM(kit, tt.components); (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateBOM() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestBuildableKits(t *testing.T) {
+	components := []KitComponentStock{
+		{QuantityPer: 2, Available: 9},
+		{QuantityPer: 1, Available: 3},
+		{QuantityPer: 4, Available: 20},
+	}
+	buildable, limiting := BuildableKits(components)
+	if buildable != 3 || limiting != 1 {
+		t.Errorf("BuildableKits() = %d, %d, want 3, 1", buildable, limiting)
+	}
+	if components[0].Buildable != 4 || components[2].Buildable != 5 {
+		t.Errorf("component buildable = %d, %d, want 4, 5", components[0].Buildable, components[2].Buildable)
+	}
+
+	if buildable, limiting := BuildableKits([]KitComponentStock{{QuantityPer: 1, Available: -2}}); buildable != 0 || limiting != 0 {
+		t.Errorf("BuildableKits() with negative stock = %d, %d, want 0, 0", buildable, limiting)
+	}
+	if buildable, limiting := BuildableKits(nil); buildable != 0 || limiting != -1 {
+		t.Errorf("BuildableKits(nil) = %d, %d, want 0, -1", buildable, limiting)
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CostingSettings holds the company-wide inventory costing method
+type CostingSettings struct {
+	Method    string     `json:"method"`
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type UpdateCostingMethodRequest struct {
+	Method string `json:"method" validate:"required,oneof=fifo average"`
+}
+
+// ProductCost is the current cost position of a product across all warehouses
+type ProductCost struct {
+	ProductID     uuid.UUID   `json:"product_id"`
+	Method        string      `json:"method"`
+	Quantity      int         `json:"quantity"`
+	TotalValue    float64     `json:"total_value"`
+	UnitCost      float64     `json:"unit_cost"`
+	CostedThrough *time.Time  `json:"costed_through"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	Layers        []CostLayer `json:"layers,omitempty"`
+	// Joined fields
+	ProductName string `json:"product_name"`
+	ProductSKU  string `json:"product_sku"`
+}
+
+// CostLayer is the open part of a receipt under FIFO costing. Stock carried
+// over from the end of a closed period is a layer with no stock movement.
+type CostLayer struct {
+	ID                uuid.UUID  `json:"id"`
+	StockMovementID   *uuid.UUID `json:"stock_movement_id"`
+	ReceivedDate      time.Time  `json:"received_date"`
+	Quantity          int        `json:"quantity"`
+	RemainingQuantity int        `json:"remaining_quantity"`
+	UnitCost          float64    `json:"unit_cost"`
+}
@@ -18,6 +18,8 @@ type Product struct {
 	UnitPrice     float64    `json:"unit_price" db:"unit_price"`
 	MinStockLevel *int       `json:"min_stock_level" db:"min_stock_level"`
 	IsActive      bool       `json:"is_active" db:"is_active"`
+	TracksExpiry  bool       `json:"tracks_expiry" db:"tracks_expiry"`
+	Serialized    bool       `json:"serialized" db:"serialized"`
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 }
@@ -30,6 +32,8 @@ type CreateProductRequest struct {
 	SupplierID    *uuid.UUID `json:"supplier_id" validate:"required"`
 	UnitPrice     float64    `json:"unit_price" validate:"required,min=0"`
 	MinStockLevel *int       `json:"min_stock_level" validate:"omitempty,min=0"`
+	TracksExpiry  bool       `json:"tracks_expiry"`
+	Serialized    bool       `json:"serialized"`
 }
 
 type UpdateProductRequest struct {
@@ -40,6 +44,8 @@ type UpdateProductRequest struct {
 	SupplierID    *uuid.UUID `json:"supplier_id" validate:"required"`
 	UnitPrice     float64    `json:"unit_price" validate:"required,min=0"`
 	MinStockLevel *int       `json:"min_stock_level" validate:"omitempty,min=0"`
+	TracksExpiry  bool       `json:"tracks_expiry"`
+	Serialized    bool       `json:"serialized"`
 }
 
 type ProductFilter struct {
@@ -1,23 +1,123 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Purchase order statuses
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusPending           = "pending"
+	PurchaseOrderStatusApproved          = "approved"
+	PurchaseOrderStatusRejected          = "rejected"
+	PurchaseOrderStatusOrdered           = "ordered"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+// purchaseOrderTransitions lists the statuses each status may move to.
+// Received and cancelled orders are closed.
+var purchaseOrderTransitions = map[string][]string{
+	PurchaseOrderStatusDraft:             {PurchaseOrderStatusPending, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPending:           {PurchaseOrderStatusDraft, PurchaseOrderStatusApproved, PurchaseOrderStatusRejected, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusRejected:          {PurchaseOrderStatusDraft, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusApproved:          {PurchaseOrderStatusOrdered, PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusOrdered:           {PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled},
+	PurchaseOrderStatusPartiallyReceived: {PurchaseOrderStatusReceived},
+}
+
+// CanTransitionPurchaseOrder reports whether a purchase order may move from
+// one status to another
+func CanTransitionPurchaseOrder(from, to string) bool {
+	for _, next := range purchaseOrderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPurchaseOrderStatus reports whether status is a known purchase order status
+func IsPurchaseOrderStatus(status string) bool {
+	switch status {
+	case PurchaseOrderStatusDraft, PurchaseOrderStatusPending, PurchaseOrderStatusApproved, PurchaseOrderStatusRejected,
+		PurchaseOrderStatusOrdered, PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, PurchaseOrderStatusCancelled:
+		return true
+	}
+	return false
+}
 
 type PurchaseOrder struct {
-	ID                   string     `json:"id"`
-	PoNumber             string     `json:"po_number"`
-	SupplierName         string     `json:"supplier_name"`
-	SupplierContact      *string    `json:"supplier_contact"`
-	TotalAmount          float64    `json:"total_amount"`
-	Status               string     `json:"status"`
-	OrderDate            time.Time  `json:"order_date"`
-	ExpectedDeliveryDate *time.Time `json:"expected_delivery_date"`
-	ReceivedDate         *time.Time `json:"received_date"`
-	Notes                *string    `json:"notes"`
-	CreatedBy            string     `json:"created_by"`
-	CreatedByFirstName   *string    `json:"created_by_first_name"`
-	CreatedByLastName    *string    `json:"created_by_last_name"`
-	CreatedAt            time.Time  `json:"created_at"`
-	UpdatedAt            time.Time  `json:"updated_at"`
+	ID                   string                  `json:"id"`
+	PoNumber             string                  `json:"po_number"`
+	SupplierName         string                  `json:"supplier_name"`
+	SupplierContact      *string                 `json:"supplier_contact"`
+	TotalAmount          float64                 `json:"total_amount"`
+	Status               string                  `json:"status"`
+	OrderDate            time.Time               `json:"order_date"`
+	ExpectedDeliveryDate *time.Time              `json:"expected_delivery_date"`
+	ReceivedDate         *time.Time              `json:"received_date"`
+	Notes                *string                 `json:"notes"`
+	// WarehouseID is where the order is delivered; receipts default to it
+	WarehouseID          *string                 `json:"warehouse_id"`
+	CreatedBy            string                  `json:"created_by"`
+	CreatedByFirstName   *string                 `json:"created_by_first_name"`
+	CreatedByLastName    *string                 `json:"created_by_last_name"`
+	CreatedAt            time.Time               `json:"created_at"`
+	UpdatedAt            time.Time               `json:"updated_at"`
+	Items                []PurchaseOrderLine     `json:"items,omitempty"`
+	Approvals            []PurchaseOrderApproval `json:"approvals,omitempty"`
+}
+
+// Purchase order approval actions
+const (
+	PurchaseOrderApprovalApproved = "approved"
+	PurchaseOrderApprovalRejected = "rejected"
+)
+
+// PurchaseOrderApproval records who approved or rejected a purchase order and
+// when. RequiredRole is the role the order's total called for, or nil when the
+// total was within the limit that needs no sign-off.
+type PurchaseOrderApproval struct {
+	ID               string    `json:"id"`
+	Action           string    `json:"action"`
+	RequiredRole     *string   `json:"required_role"`
+	TotalAmount      float64   `json:"total_amount"`
+	Comment          *string   `json:"comment"`
+	ActedBy          string    `json:"acted_by"`
+	ActedByFirstName *string   `json:"acted_by_first_name"`
+	ActedByLastName  *string   `json:"acted_by_last_name"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// PurchaseOrderLine quantities and unit price are in base units. Lines
+// ordered in another unit also carry the unit, the quantity and price per
+// that unit, and its factor at the time of ordering.
+type PurchaseOrderLine struct {
+	ID               string   `json:"id"`
+	ProductID        string   `json:"product_id"`
+	Quantity         int      `json:"quantity"`
+	UnitPrice        float64  `json:"unit_price"`
+	TotalPrice       float64  `json:"total_price"`
+	ReceivedQuantity int      `json:"received_quantity"`
+	UOM              *string  `json:"uom,omitempty"`
+	UOMQuantity      *int     `json:"uom_quantity,omitempty"`
+	UOMPrice         *float64 `json:"uom_price,omitempty"`
+	UOMFactor        *int     `json:"uom_factor,omitempty"`
+	// Joined fields
+	ProductName *string `json:"product_name,omitempty"`
+	ProductSKU  *string `json:"product_sku,omitempty"`
+}
+
+type PurchaseOrderItem struct {
+	ProductID string  `json:"product_id"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	// UOM is the product unit Quantity and UnitPrice are given in
+	UOM *string `json:"uom,omitempty"`
 }
 
 type CreatePurchaseOrderRequest struct {
@@ -27,7 +127,11 @@ type CreatePurchaseOrderRequest struct {
 	OrderDate            time.Time  `json:"order_date"`
 	ExpectedDeliveryDate *time.Time `json:"expected_delivery_date"`
 	Notes                *string    `json:"notes"`
+	WarehouseID          *uuid.UUID `json:"warehouse_id,omitempty"`
 	CreatedBy            string     `json:"created_by"`
+	// Status is draft or pending; new orders are pending when omitted
+	Status string              `json:"status,omitempty"`
+	Items  []PurchaseOrderItem `json:"items"`
 }
 
 type UpdatePurchaseOrderRequest struct {
@@ -37,5 +141,40 @@ type UpdatePurchaseOrderRequest struct {
 	ExpectedDeliveryDate *time.Time `json:"expected_delivery_date"`
 	ReceivedDate         *time.Time `json:"received_date"`
 	Notes                *string    `json:"notes"`
+	// Items replaces the order lines when set; only draft and pending orders can be edited
+	Items []PurchaseOrderItem `json:"items,omitempty"`
+}
+
+// PurchaseOrderDecisionRequest approves or rejects a pending purchase order
+type PurchaseOrderDecisionRequest struct {
+	Comment *string `json:"comment"`
 }
 
+type ReceivePurchaseOrderRequest struct {
+	// WarehouseID is where lines are booked unless a line names its own
+	// warehouse; it defaults to the order's delivery warehouse
+	WarehouseID     uuid.UUID                  `json:"warehouse_id"`
+	ReferenceNumber *string                    `json:"reference_number,omitempty"`
+	ProcessedDate   *time.Time                 `json:"processed_date,omitempty"`
+	Lines           []ReceivePurchaseOrderLine `json:"lines" validate:"required,min=1"`
+}
+
+type ReceivePurchaseOrderLine struct {
+	ItemID      uuid.UUID  `json:"item_id" validate:"required"`
+	WarehouseID *uuid.UUID `json:"warehouse_id,omitempty"`
+	Quantity    int        `json:"quantity" validate:"required,min=1"`
+	// CostPrice defaults to the line's unit price
+	CostPrice *float64 `json:"cost_price,omitempty" validate:"omitempty,min=0"`
+	Reason    *string  `json:"reason"`
+	// LotNumber books the line into that lot, creating it on first use
+	LotNumber       *string    `json:"lot_number,omitempty"`
+	ManufactureDate *time.Time `json:"manufacture_date,omitempty"`
+	ExpiryDate      *time.Time `json:"expiry_date,omitempty"`
+	// SerialNumbers captures the units received; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	// LocationID is the bin the line is put into
+	LocationID *uuid.UUID `json:"location_id,omitempty"`
+	// UOM is the product unit Quantity and CostPrice are given in. It
+	// defaults to the unit the line was ordered in.
+	UOM *string `json:"uom,omitempty"`
+}
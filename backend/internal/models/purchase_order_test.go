@@ -0,0 +1,56 @@
+package models
+
+import "testing"
+
+func TestCanTransitionPurchaseOrder(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{PurchaseOrderStatusDraft, PurchaseOrderStatusPending, true},
+		{PurchaseOrderStatusPending, PurchaseOrderStatusApproved, true},
+		{PurchaseOrderStatusApproved, PurchaseOrderStatusPartiallyReceived, true},
+		{PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived, true},
+		{PurchaseOrderStatusApproved, PurchaseOrderStatusCancelled, true},
+		{PurchaseOrderStatusPending, PurchaseOrderStatusRejected, true},
+		{PurchaseOrderStatusRejected, PurchaseOrderStatusDraft, true},
+		{PurchaseOrderStatusApproved, PurchaseOrderStatusOrdered, true},
+		{PurchaseOrderStatusOrdered, PurchaseOrderStatusPartiallyReceived, true},
+		{PurchaseOrderStatusPending, PurchaseOrderStatusOrdered, false},
+		{PurchaseOrderStatusRejected, PurchaseOrderStatusApproved, false},
+		{PurchaseOrderStatusDraft, PurchaseOrderStatusOrdered, false},
+		{PurchaseOrderStatusDraft, PurchaseOrderStatusApproved, false},
+		{PurchaseOrderStatusPending, PurchaseOrderStatusReceived, false},
+		{PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusCancelled, false},
+		{PurchaseOrderStatusReceived, PurchaseOrderStatusPending, false},
+		{PurchaseOrderStatusCancelled, PurchaseOrderStatusPending, false},
+		{"completed", PurchaseOrderStatusReceived, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransitionPurchaseOrder(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionPurchaseOrder(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRoleSatisfies(t *testing.T) {
+	tests := []struct {
+		role, required string
+		want           bool
+	}{
+		{UserRoleAdmin, UserRoleAdmin, true},
+		{UserRoleAdmin, UserRoleManager, true},
+		{UserRoleManager, UserRoleManager, true},
+		{UserRoleManager, UserRoleAdmin, false},
+		{UserRoleStaff, UserRoleManager, false},
+		{UserRoleStaff, UserRoleStaff, true},
+		{"", UserRoleStaff, false},
+	}
+
+	for _, tt := range tests {
+		if got := RoleSatisfies(tt.role, tt.required); got != tt.want {
+			t.Errorf("RoleSatisfies(%q, %q) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "github.com/google/uuid"
+
+// ReconciliationReferenceType is the reference type of the corrections a
+// ledger reconciliation posts
+const ReconciliationReferenceType = "stock_reconciliation"
+
+// ReconciliationReasonCode is the adjustment reason code ledger corrections are posted under
+const ReconciliationReasonCode = "ledger_correction"
+
+// StockDrift is a product/warehouse whose stored balance differs from the
+// balance its movement ledger adds up to. Drift is stored minus ledger: the
+// quantity the stored balance is off by, and the quantity a confirmed ledger
+// correction posts.
+type StockDrift struct {
+	ProductID      uuid.UUID `json:"product_id"`
+	ProductName    string    `json:"product_name"`
+	ProductSKU     string    `json:"product_sku"`
+	WarehouseID    uuid.UUID `json:"warehouse_id"`
+	WarehouseName  string    `json:"warehouse_name"`
+	StoredQuantity int       `json:"stored_quantity"`
+	LedgerQuantity int       `json:"ledger_quantity"`
+	Drift          int       `json:"drift"`
+}
+
+// StockReconciliationFilter limits a reconciliation to a product, a
+// warehouse, or both
+type StockReconciliationFilter struct {
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+}
+
+// StockReconciliationReport lists the balances that disagree with the
+// ledger. After a repair, Realigned holds the stored balances that were set
+// to their ledger balance, Corrections the ledger corrections posted for
+// confirmed stored balances, and Mismatches what is left, which is empty
+// unless stock moved meanwhile.
+type StockReconciliationReport struct {
+	CheckedBalances int64           `json:"checked_balances"`
+	Mismatches      []StockDrift    `json:"mismatches"`
+	Realigned       []StockDrift    `json:"realigned,omitempty"`
+	Corrections     []StockMovement `json:"corrections,omitempty"`
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuggestReorderQuantity returns how much to order for a stock position
+// (available plus on order) under a reorder rule: nothing while the position
+// is above the reorder point, otherwise the smallest multiple of the reorder
+// quantity that lifts it above the point
+func SuggestReorderQuantity(position, reorderPoint, reorderQuantity int) int {
+	if position > reorderPoint || reorderQuantity <= 0 {
+		return 0
+	}
+	return ((reorderPoint-position)/reorderQuantity + 1) * reorderQuantity
+}
+
+// ReorderRule sets when and how much of a product to reorder for a warehouse
+type ReorderRule struct {
+	ID              uuid.UUID `json:"id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	ReorderPoint    int       `json:"reorder_point"`
+	ReorderQuantity int       `json:"reorder_quantity"`
+	IsActive        bool      `json:"is_active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+// SaveReorderRuleRequest creates the rule for a product and warehouse, or
+// replaces it when one exists
+type SaveReorderRuleRequest struct {
+	ProductID       uuid.UUID `json:"product_id" validate:"required"`
+	WarehouseID     uuid.UUID `json:"warehouse_id" validate:"required"`
+	ReorderPoint    int       `json:"reorder_point" validate:"min=0"`
+	ReorderQuantity int       `json:"reorder_quantity" validate:"required,min=1"`
+	IsActive        *bool     `json:"is_active,omitempty"`
+}
+
+type UpdateReorderRuleRequest struct {
+	ReorderPoint    int  `json:"reorder_point" validate:"min=0"`
+	ReorderQuantity int  `json:"reorder_quantity" validate:"required,min=1"`
+	IsActive        bool `json:"is_active"`
+}
+
+type ReorderRuleFilter struct {
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type ReorderRuleListResponse struct {
+	ReorderRules []ReorderRule `json:"reorder_rules"`
+	Total        int64         `json:"total"`
+	Page         int           `json:"page"`
+	Limit        int           `json:"limit"`
+	Pages        int           `json:"pages"`
+}
+
+type ReorderSuggestionFilter struct {
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	SupplierID  *uuid.UUID `json:"supplier_id"`
+}
+
+// ReorderSuggestions are the purchase orders the reorder rules call for, one
+// per supplier and delivery warehouse. Unassigned lists products that need
+// reordering but have no supplier.
+type ReorderSuggestions struct {
+	GeneratedAt time.Time                `json:"generated_at"`
+	Orders      []SuggestedPurchaseOrder `json:"orders"`
+	Unassigned  []ReorderSuggestionLine  `json:"unassigned"`
+}
+
+// SuggestedPurchaseOrder is a purchase order the reorder rules call for.
+// ExpectedDeliveryDate is today plus the supplier's lead time.
+type SuggestedPurchaseOrder struct {
+	SupplierID           uuid.UUID               `json:"supplier_id"`
+	SupplierName         string                  `json:"supplier_name"`
+	WarehouseID          uuid.UUID               `json:"warehouse_id"`
+	WarehouseName        string                  `json:"warehouse_name"`
+	LeadTimeDays         int                     `json:"lead_time_days"`
+	ExpectedDeliveryDate time.Time               `json:"expected_delivery_date"`
+	TotalAmount          float64                 `json:"total_amount"`
+	Lines                []ReorderSuggestionLine `json:"lines"`
+}
+
+// ReorderSuggestionLine is a product at or below its reorder point. Position
+// is available plus on-order stock.
+type ReorderSuggestionLine struct {
+	RuleID            uuid.UUID `json:"rule_id"`
+	ProductID         uuid.UUID `json:"product_id"`
+	ProductName       string    `json:"product_name"`
+	ProductSKU        string    `json:"product_sku"`
+	WarehouseID       uuid.UUID `json:"warehouse_id"`
+	Quantity          int       `json:"quantity"`
+	ReservedQuantity  int       `json:"reserved_quantity"`
+	OnOrderQuantity   int       `json:"on_order_quantity"`
+	Position          int       `json:"position"`
+	ReorderPoint      int       `json:"reorder_point"`
+	ReorderQuantity   int       `json:"reorder_quantity"`
+	SuggestedQuantity int       `json:"suggested_quantity"`
+	UnitPrice         float64   `json:"unit_price"`
+	TotalPrice        float64   `json:"total_price"`
+}
+
+// ConvertReorderSuggestionsRequest turns reviewed suggestions into purchase
+// orders in one transaction. Without orders, every current suggestion that
+// has a supplier is converted as suggested.
+type ConvertReorderSuggestionsRequest struct {
+	Orders []ConvertReorderOrder `json:"orders,omitempty"`
+	// Status is draft or pending; converted orders are drafts when omitted
+	Status string `json:"status,omitempty"`
+}
+
+// ConvertReorderOrder is a reviewed suggested order; quantities and prices
+// may differ from the suggestion
+type ConvertReorderOrder struct {
+	SupplierID           uuid.UUID            `json:"supplier_id" validate:"required"`
+	WarehouseID          uuid.UUID            `json:"warehouse_id" validate:"required"`
+	ExpectedDeliveryDate *time.Time           `json:"expected_delivery_date,omitempty"`
+	Notes                *string              `json:"notes"`
+	Lines                []ConvertReorderLine `json:"lines" validate:"required,min=1"`
+}
+
+type ConvertReorderLine struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	UnitPrice float64   `json:"unit_price" validate:"min=0"`
+}
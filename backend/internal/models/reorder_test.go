@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestSuggestReorderQuantity(t *testing.T) {
+	tests := []struct {
+		position, point, quantity int
+		want                      int
+	}{
+		{10, 5, 20, 0},
+		{6, 5, 20, 0},
+		{5, 5, 20, 20},
+		{0, 0, 10, 10},
+		{0, 25, 10, 30},
+		{-3, 5, 4, 12},
+		{0, 5, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestReorderQuantity(tt.position, tt.point, tt.quantity); got != tt.want {
+			t.Errorf("SuggestReorderQuantity(%d, %d, %d) = %d, want %d", tt.position, tt.point, tt.quantity, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+// ReversalReferenceType is the reference type of the movements a reversal posts
+const ReversalReferenceType = "reversal"
+
+// ReversalReasonCode is the reason code of reversed adjustments. Reversed
+// receipts, issues and bin moves carry no reason code, like the movements
+// they undo.
+const ReversalReasonCode = "reversal"
+
+// PurchaseOrderReferenceType is the reference type of purchase order
+// receipts. Every receipt against an order shares the order's ID as its
+// reference ID.
+const PurchaseOrderReferenceType = "purchase_order"
+
+// documentReferenceTypes are the reference types of movements a document
+// keeps its own quantities for, such as a sales order's shipped quantities.
+// Reversing those movements alone would leave the document out of step, so
+// they are corrected through the document instead. Purchase order receipts
+// are not among them: reversing one takes it off the order's received
+// quantities as well.
+var documentReferenceTypes = map[string]bool{
+	SalesOrderReferenceType:        true,
+	StockTransferReferenceType:     true,
+	StockTransferLossReferenceType: true,
+	AssemblyOrderReferenceType:     true,
+	StocktakeReferenceType:         true,
+	ReconciliationReferenceType:    true,
+}
+
+// IsReversible reports whether a movement with the given reference type can
+// be reversed
+func IsReversible(referenceType *string) bool {
+	return referenceType == nil || !documentReferenceTypes[*referenceType]
+}
+
+// IsReversedByReference reports whether movements with the given reference
+// type can only be reversed together with the rest of their reference, such
+// as the paired out/in legs of an immediate warehouse transfer
+func IsReversedByReference(referenceType *string) bool {
+	return referenceType != nil && *referenceType == WarehouseTransferReferenceType
+}
+
+// ReverseStockMovementRequest gives the reason recorded on the reversing
+// movements
+type ReverseStockMovementRequest struct {
+	Reason *string `json:"reason"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "testing"
+
+func TestIsReversible(t *testing.T) {
+	if !IsReversible(nil) {
+		t.Error("IsReversible(nil) = false, want true")
+	}
+
+	tests := []struct {
+		referenceType string
+		want          bool
+	}{
+		{"stock_in", true},
+		{BinMoveReferenceType, true},
+		{PurchaseOrderReferenceType, true},
+		{WarehouseTransferReferenceType, true},
+		{SalesOrderReferenceType, false},
+		{StockTransferReferenceType, false},
+		{ReconciliationReferenceType, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsReversible(&tt.referenceType); got != tt.want {
+			t.Errorf("IsReversible(%q) = %v, want %v", tt.referenceType, got, tt.want)
+		}
+	}
+}
+
+func TestIsReversedByReference(t *testing.T) {
+	if IsReversedByReference(nil) {
+		t.Error("IsReversedByReference(nil) = true, want false")
+	}
+
+	tests := []struct {
+		referenceType string
+		want          bool
+	}{
+		{WarehouseTransferReferenceType, true},
+		{PurchaseOrderReferenceType, false},
+		{StockTransferReferenceType, false},
+		{BinMoveReferenceType, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsReversedByReference(&tt.referenceType); got != tt.want {
+			t.Errorf("IsReversedByReference(%q) = %v, want %v", tt.referenceType, got, tt.want)
+		}
+	}
+}
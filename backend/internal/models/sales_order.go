@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sales order statuses, as allowed by the sales_orders status CHECK
+const (
+	SalesOrderStatusPending   = "pending"
+	SalesOrderStatusConfirmed = "confirmed"
+	SalesOrderStatusShipped   = "shipped"
+	SalesOrderStatusDelivered = "delivered"
+	SalesOrderStatusCancelled = "cancelled"
+)
+
+// SalesOrderReferenceType is the owner and movement reference type used for
+// reservations and ledger entries raised by a sales order
+const SalesOrderReferenceType = "sales_order"
+
+type SalesOrder struct {
+	ID                   uuid.UUID        `json:"id"`
+	SoNumber             string           `json:"so_number"`
+	CustomerName         string           `json:"customer_name"`
+	CustomerContact      *string          `json:"customer_contact"`
+	TotalAmount          float64          `json:"total_amount"`
+	Status               string           `json:"status"`
+	OrderDate            time.Time        `json:"order_date"`
+	ExpectedDeliveryDate *time.Time       `json:"expected_delivery_date"`
+	ShippedDate          *time.Time       `json:"shipped_date"`
+	DeliveredDate        *time.Time       `json:"delivered_date"`
+	Notes                *string          `json:"notes"`
+	CreatedBy            uuid.UUID        `json:"created_by"`
+	CreatedByFirstName   *string          `json:"created_by_first_name"`
+	CreatedByLastName    *string          `json:"created_by_last_name"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+	Items                []SalesOrderLine `json:"items,omitempty"`
+}
+
+// SalesOrderLine quantities and unit price are in base units. Lines sold in
+// another unit also carry the unit, the quantity and price per that unit, and
+// its factor at the time of sale.
+type SalesOrderLine struct {
+	ID              uuid.UUID `json:"id"`
+	ProductID       uuid.UUID `json:"product_id"`
+	WarehouseID     uuid.UUID `json:"warehouse_id"`
+	Quantity        int       `json:"quantity"`
+	UnitPrice       float64   `json:"unit_price"`
+	TotalPrice      float64   `json:"total_price"`
+	ShippedQuantity int       `json:"shipped_quantity"`
+	UOM             *string   `json:"uom,omitempty"`
+	UOMQuantity     *int      `json:"uom_quantity,omitempty"`
+	UOMPrice        *float64  `json:"uom_price,omitempty"`
+	UOMFactor       *int      `json:"uom_factor,omitempty"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+type SalesOrderItem struct {
+	ProductID   uuid.UUID `json:"product_id" validate:"required"`
+	WarehouseID uuid.UUID `json:"warehouse_id" validate:"required"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+	UnitPrice   float64   `json:"unit_price" validate:"min=0"`
+	// UOM is the product unit Quantity and UnitPrice are given in
+	UOM *string `json:"uom,omitempty"`
+}
+
+type CreateSalesOrderRequest struct {
+	SoNumber             *string          `json:"so_number,omitempty"`
+	CustomerName         string           `json:"customer_name" validate:"required"`
+	CustomerContact      *string          `json:"customer_contact"`
+	OrderDate            *time.Time       `json:"order_date,omitempty"`
+	ExpectedDeliveryDate *time.Time       `json:"expected_delivery_date"`
+	Notes                *string          `json:"notes"`
+	Items                []SalesOrderItem `json:"items" validate:"required,min=1"`
+}
+
+type UpdateSalesOrderRequest struct {
+	CustomerName         string           `json:"customer_name" validate:"required"`
+	CustomerContact      *string          `json:"customer_contact"`
+	ExpectedDeliveryDate *time.Time       `json:"expected_delivery_date"`
+	Notes                *string          `json:"notes"`
+	Items                []SalesOrderItem `json:"items" validate:"required,min=1"`
+}
+
+// ShipSalesOrderRequest names the serial numbers shipped on lines of
+// serialized products. Other lines need no entry.
+type ShipSalesOrderRequest struct {
+	Lines []ShipSalesOrderLine `json:"lines"`
+}
+
+type ShipSalesOrderLine struct {
+	ItemID        uuid.UUID `json:"item_id" validate:"required"`
+	SerialNumbers []string  `json:"serial_numbers"`
+}
+
+type SalesOrderFilter struct {
+	Status       *string    `json:"status"`
+	CustomerName *string    `json:"customer_name"`
+	DateFrom     *time.Time `json:"date_from"`
+	DateTo       *time.Time `json:"date_to"`
+	Page         int        `json:"page" validate:"min=1"`
+	Limit        int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type SalesOrderListResponse struct {
+	SalesOrders []SalesOrder `json:"sales_orders"`
+	Total       int64        `json:"total"`
+	Page        int          `json:"page"`
+	Limit       int          `json:"limit"`
+	Pages       int          `json:"pages"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Serial number statuses. A unit is in a warehouse only while in stock.
+const (
+	SerialNumberStatusInStock   = "in_stock"
+	SerialNumberStatusInTransit = "in_transit"
+	SerialNumberStatusIssued    = "issued"
+)
+
+// SerialNumber is an individual unit of a serialized product
+type SerialNumber struct {
+	ID           uuid.UUID              `json:"id"`
+	ProductID    uuid.UUID              `json:"product_id"`
+	SerialNumber string                 `json:"serial_number"`
+	Status       string                 `json:"status"`
+	WarehouseID  *uuid.UUID             `json:"warehouse_id"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	Movements    []SerialNumberMovement `json:"movements,omitempty"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+// SerialNumberMovement is a ledger entry that moved the unit
+type SerialNumberMovement struct {
+	StockMovementID uuid.UUID  `json:"stock_movement_id"`
+	MovementType    string     `json:"movement_type"`
+	WarehouseID     uuid.UUID  `json:"warehouse_id"`
+	WarehouseName   string     `json:"warehouse_name"`
+	ReferenceType   *string    `json:"reference_type"`
+	ReferenceID     *uuid.UUID `json:"reference_id"`
+	ReferenceNumber *string    `json:"reference_number"`
+	ProcessedDate   *time.Time `json:"processed_date"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type SerialNumberFilter struct {
+	ProductID    *uuid.UUID `json:"product_id"`
+	WarehouseID  *uuid.UUID `json:"warehouse_id"`
+	Status       *string    `json:"status"`
+	SerialNumber *string    `json:"serial_number"`
+	Page         int        `json:"page" validate:"min=1"`
+	Limit        int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type SerialNumberListResponse struct {
+	SerialNumbers []SerialNumber `json:"serial_numbers"`
+	Total         int64          `json:"total"`
+	Page          int            `json:"page"`
+	Limit         int            `json:"limit"`
+	Pages         int            `json:"pages"`
+}
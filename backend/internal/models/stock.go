@@ -22,6 +22,8 @@ type StockLevel struct {
 	ProductName   *string `json:"product_name,omitempty" db:"product_name"`
 	ProductSKU    *string `json:"product_sku,omitempty" db:"sku"`
 	WarehouseName *string `json:"warehouse_name,omitempty" db:"warehouse_name"`
+	// Bins holding the stock; the rest of Quantity has not been put away
+	Bins []BinStockLevel `json:"bins,omitempty"`
 }
 
 type StockMovement struct {
@@ -32,13 +34,30 @@ type StockMovement struct {
 	Quantity      int        `json:"quantity" db:"quantity"`
 	CostPrice     *float64   `json:"cost_price,omitempty" db:"cost_price"`
 	TotalAmount   *float64   `json:"total_amount,omitempty" db:"total_amount"`
+	// UnitCost and TotalCost are what the movement was valued at by the
+	// costing method; for issues this is the cost of goods issued
+	UnitCost      *float64   `json:"unit_cost,omitempty" db:"unit_cost"`
+	TotalCost     *float64   `json:"total_cost,omitempty" db:"total_cost"`
 	ReferenceType *string    `json:"reference_type" db:"reference_type"`
 	ReferenceID   *uuid.UUID `json:"reference_id" db:"reference_id"`
 	ReferenceNumber *string  `json:"reference_number,omitempty" db:"reference_number"`
 	Reason        *string    `json:"reason" db:"reason"`
+	ReasonCode    *string    `json:"reason_code,omitempty" db:"reason_code"`
 	UserID        *uuid.UUID `json:"user_id" db:"user_id"`
 	ProcessedBy   *uuid.UUID `json:"processed_by" db:"processed_by"`
 	ProcessedDate *time.Time `json:"processed_date" db:"processed_date"`
+	// FromLocationID and ToLocationID are the bins asked for; bin-to-bin
+	// moves are "transfer" movements carrying both
+	FromLocationID *uuid.UUID `json:"from_location_id,omitempty" db:"from_location_id"`
+	ToLocationID   *uuid.UUID `json:"to_location_id,omitempty" db:"to_location_id"`
+	// UOM is the unit the quantity was entered in, with the quantity in that
+	// unit and its factor at the time; Quantity is always in base units
+	UOMID       *uuid.UUID `json:"uom_id,omitempty" db:"uom_id"`
+	UOM         *string    `json:"uom,omitempty" db:"uom"`
+	UOMQuantity *int       `json:"uom_quantity,omitempty" db:"uom_quantity"`
+	UOMFactor   *int       `json:"uom_factor,omitempty" db:"uom_factor"`
+	// ReversalOfID is the movement this one reverses
+	ReversalOfID *uuid.UUID `json:"reversal_of_id,omitempty" db:"reversal_of_id"`
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	// Joined fields
 	ProductName   *string `json:"product_name,omitempty" db:"product_name"`
@@ -49,25 +68,60 @@ type StockMovement struct {
 	ProcessedByFirstName *string `json:"processed_by_first_name,omitempty" db:"processed_by_first_name"`
 	ProcessedByLastName  *string `json:"processed_by_last_name,omitempty" db:"processed_by_last_name"`
 	SupplierName  *string `json:"supplier_name,omitempty" db:"supplier_name"`
+	// Lots the movement took from or put into
+	Lots []StockMovementLot `json:"lots,omitempty"`
+	// SerialNumbers of the units moved, for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	// Locations are the bin balances the movement changed
+	Locations []StockMovementLocation `json:"locations,omitempty"`
 }
 
 type CreateStockMovementRequest struct {
 	ProductID     uuid.UUID  `json:"product_id" validate:"required"`
 	WarehouseID   uuid.UUID  `json:"warehouse_id" validate:"required"`
 	MovementType  string     `json:"movement_type" validate:"required,oneof=in out transfer adjustment"`
-	Quantity      int        `json:"quantity" validate:"required"`
+	// Quantity is signed for adjustments; negative values write stock off
+	Quantity      int        `json:"quantity"`
+	// CountedQuantity sets an adjustment's target on-hand instead of a delta
+	CountedQuantity *int     `json:"counted_quantity,omitempty" validate:"omitempty,min=0"`
 	CostPrice     *float64   `json:"cost_price,omitempty" validate:"omitempty,min=0"`
 	ReferenceType *string    `json:"reference_type"`
 	ReferenceID   *uuid.UUID `json:"reference_id"`
 	Reason        *string    `json:"reason"`
+	ReasonCode    *string    `json:"reason_code,omitempty"`
+	// ProcessedDate back-dates the movement; costing is replayed from it
+	ProcessedDate *time.Time `json:"processed_date,omitempty"`
+	// LotID picks the lot an issue or adjustment is taken from, overriding
+	// the automatic (FEFO for products that track expiry) allocation
+	LotID         *uuid.UUID `json:"lot_id,omitempty"`
+	// LotNumber books a receipt into that lot, creating it on first use
+	LotNumber       *string    `json:"lot_number,omitempty"`
+	ManufactureDate *time.Time `json:"manufacture_date,omitempty"`
+	ExpiryDate      *time.Time `json:"expiry_date,omitempty"`
+	// SerialNumbers names the units moved; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	// FromLocationID picks the bin an issue is taken from; issues without one
+	// use stock not yet put away first, then the bins in code order.
+	// ToLocationID is the bin a receipt is put into.
+	FromLocationID *uuid.UUID `json:"from_location_id,omitempty"`
+	ToLocationID   *uuid.UUID `json:"to_location_id,omitempty"`
+	// UOM is the product unit Quantity, CountedQuantity and CostPrice are
+	// given in; they are in base units when it is omitted
+	UOM *string `json:"uom,omitempty"`
+	// IdempotencyKey is taken from the Idempotency-Key header
+	IdempotencyKey string    `json:"-"`
 }
 
 type BulkStockMovementRequest struct {
 	SupplierID      uuid.UUID                    `json:"supplier_id" validate:"required"`
+	// PurchaseOrderID books the items as a receipt against that order's lines
+	PurchaseOrderID *uuid.UUID                   `json:"purchase_order_id,omitempty"`
 	ReferenceNumber *string                      `json:"reference_number,omitempty"`
 	ProcessedBy     uuid.UUID                    `json:"processed_by,omitempty"`
 	ProcessedDate   time.Time                    `json:"processed_date,omitempty"`
 	Items           []BulkStockMovementItem      `json:"items" validate:"required,min=1"`
+	// IdempotencyKey is taken from the Idempotency-Key header
+	IdempotencyKey  string                       `json:"-"`
 }
 
 type BulkStockMovementItem struct {
@@ -76,6 +130,38 @@ type BulkStockMovementItem struct {
 	Quantity    int       `json:"quantity" validate:"required,min=1"`
 	CostPrice   *float64  `json:"cost_price,omitempty" validate:"omitempty,min=0"`
 	Reason      *string   `json:"reason"`
+	// LotNumber books the item into that lot, creating it on first use
+	LotNumber       *string    `json:"lot_number,omitempty"`
+	ManufactureDate *time.Time `json:"manufacture_date,omitempty"`
+	ExpiryDate      *time.Time `json:"expiry_date,omitempty"`
+	// SerialNumbers captures the units received; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	// LocationID is the bin the item is put into
+	LocationID *uuid.UUID `json:"location_id,omitempty"`
+	// UOM is the product unit Quantity and CostPrice are given in
+	UOM *string `json:"uom,omitempty"`
+}
+
+type StockTransferRequest struct {
+	FromWarehouseID uuid.UUID           `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   uuid.UUID           `json:"to_warehouse_id" validate:"required"`
+	ReferenceNumber *string             `json:"reference_number,omitempty"`
+	Reason          *string             `json:"reason"`
+	ProcessedDate   *time.Time          `json:"processed_date,omitempty"`
+	Items           []StockTransferItem `json:"items" validate:"required,min=1"`
+}
+
+type StockTransferItem struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required,min=1"`
+	// SerialNumbers names the units moved; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
+
+type StockTransferResponse struct {
+	TransferID      uuid.UUID       `json:"transfer_id"`
+	ReferenceNumber string          `json:"reference_number"`
+	StockMovements  []StockMovement `json:"stock_movements"`
 }
 
 type StockLevelFilter struct {
@@ -87,10 +173,35 @@ type StockLevelFilter struct {
 	Limit       int        `json:"limit" validate:"min=1,max=100"`
 }
 
+// StockLevelAsOfFilter selects the balances of the point-in-time stock
+// query. The filters match those of the stock level list.
+type StockLevelAsOfFilter struct {
+	AsOf        time.Time  `json:"as_of"`
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	ProductName *string    `json:"product_name"`
+	ProductSKU  *string    `json:"product_sku"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+// StockLevelAsOf is the quantity a warehouse held of a product at a point
+// in time, rebuilt from the movement ledger
+type StockLevelAsOf struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Quantity    int       `json:"quantity"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
 type StockMovementFilter struct {
 	ProductID     *uuid.UUID `json:"product_id"`
 	WarehouseID   *uuid.UUID `json:"warehouse_id"`
 	MovementType  *string    `json:"movement_type"`
+	ReasonCode    *string    `json:"reason_code"`
 	DateFrom      *time.Time `json:"date_from"`
 	DateTo        *time.Time `json:"date_to"`
 	Page          int        `json:"page" validate:"min=1"`
@@ -105,6 +216,15 @@ type StockLevelListResponse struct {
 	Pages       int          `json:"pages"`
 }
 
+type StockLevelAsOfListResponse struct {
+	AsOf        time.Time        `json:"as_of"`
+	StockLevels []StockLevelAsOf `json:"stock_levels"`
+	Total       int64            `json:"total"`
+	Page        int              `json:"page"`
+	Limit       int              `json:"limit"`
+	Pages       int              `json:"pages"`
+}
+
 type StockMovementListResponse struct {
 	StockMovements []StockMovement `json:"stock_movements"`
 	Total          int64           `json:"total"`
@@ -122,11 +242,56 @@ type SOHReport struct {
 	Quantity      int       `json:"quantity"`
 	ReservedQty   int       `json:"reserved_quantity"`
 	AvailableQty  int       `json:"available_quantity"`
+	InTransitQty  int       `json:"in_transit_quantity"`
 	MinLevel      int       `json:"min_stock_level"`
 	MaxLevel      *int      `json:"max_stock_level"`
 	LastUpdated   time.Time `json:"last_updated"`
 }
 
+// StockValuationFilter selects the stock valued by the valuation report
+type StockValuationFilter struct {
+	AsOf        time.Time  `json:"as_of"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	CategoryID  *uuid.UUID `json:"category_id"`
+}
+
+// StockValuationReport is the quantity and value of stock as of a point in
+// time, rebuilt from the movement ledger
+type StockValuationReport struct {
+	AsOf       time.Time                `json:"as_of"`
+	Lines      []StockValuationLine     `json:"lines"`
+	Categories []StockValuationSubtotal `json:"categories"`
+	Totals     StockValuationSubtotal   `json:"totals"`
+}
+
+// StockValuationLine values one product in one warehouse. Stock dispatched
+// on a transfer but not yet received is valued against the destination.
+type StockValuationLine struct {
+	ProductID         uuid.UUID  `json:"product_id"`
+	ProductName       string     `json:"product_name"`
+	ProductSKU        string     `json:"product_sku"`
+	CategoryID        *uuid.UUID `json:"category_id"`
+	CategoryName      *string    `json:"category_name"`
+	WarehouseID       uuid.UUID  `json:"warehouse_id"`
+	WarehouseName     string     `json:"warehouse_name"`
+	Quantity          int        `json:"quantity"`
+	Value             float64    `json:"value"`
+	InTransitQuantity int        `json:"in_transit_quantity"`
+	InTransitValue    float64    `json:"in_transit_value"`
+	TotalValue        float64    `json:"total_value"`
+}
+
+// StockValuationSubtotal sums valuation lines, per category or overall
+type StockValuationSubtotal struct {
+	CategoryID        *uuid.UUID `json:"category_id,omitempty"`
+	CategoryName      *string    `json:"category_name,omitempty"`
+	Quantity          int        `json:"quantity"`
+	Value             float64    `json:"value"`
+	InTransitQuantity int        `json:"in_transit_quantity"`
+	InTransitValue    float64    `json:"in_transit_value"`
+	TotalValue        float64    `json:"total_value"`
+}
+
 type StockInTransaction struct {
 	ReferenceID           *uuid.UUID `json:"reference_id" db:"reference_id"`
 	ProcessedDate         *time.Time `json:"processed_date" db:"processed_date"`
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockLot is a batch of a product with its total balance across warehouses
+type StockLot struct {
+	ID              uuid.UUID       `json:"id"`
+	ProductID       uuid.UUID       `json:"product_id"`
+	LotNumber       string          `json:"lot_number"`
+	ManufactureDate *time.Time      `json:"manufacture_date"`
+	ExpiryDate      *time.Time      `json:"expiry_date"`
+	Quantity        int             `json:"quantity"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Levels          []StockLotLevel `json:"levels,omitempty"`
+	// Joined fields
+	ProductName *string `json:"product_name,omitempty"`
+	ProductSKU  *string `json:"product_sku,omitempty"`
+}
+
+// StockLotLevel is the balance of a lot in one warehouse
+type StockLotLevel struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Quantity      int       `json:"quantity"`
+}
+
+// StockMovementLot is the quantity of a movement taken from or put into a lot
+type StockMovementLot struct {
+	LotID      uuid.UUID  `json:"lot_id"`
+	LotNumber  string     `json:"lot_number"`
+	ExpiryDate *time.Time `json:"expiry_date"`
+	Quantity   int        `json:"quantity"`
+}
+
+// StockLotMovement is a ledger entry that moved stock of a lot, used to trace
+// where a batch went
+type StockLotMovement struct {
+	StockMovementID uuid.UUID  `json:"stock_movement_id"`
+	MovementType    string     `json:"movement_type"`
+	WarehouseID     uuid.UUID  `json:"warehouse_id"`
+	WarehouseName   string     `json:"warehouse_name"`
+	Quantity        int        `json:"quantity"`
+	ReferenceType   *string    `json:"reference_type"`
+	ReferenceID     *uuid.UUID `json:"reference_id"`
+	ReferenceNumber *string    `json:"reference_number"`
+	ProcessedDate   *time.Time `json:"processed_date"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type StockLotFilter struct {
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	LotNumber   *string    `json:"lot_number"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type StockLotListResponse struct {
+	StockLots []StockLot `json:"stock_lots"`
+	Total     int64      `json:"total"`
+	Page      int        `json:"page"`
+	Limit     int        `json:"limit"`
+	Pages     int        `json:"pages"`
+}
+
+// ExpiringStockReport is a lot balance in a warehouse that is close to or past
+// its expiry date. DaysToExpiry is negative once the lot has expired.
+type ExpiringStockReport struct {
+	LotID         uuid.UUID `json:"lot_id"`
+	LotNumber     string    `json:"lot_number"`
+	ExpiryDate    time.Time `json:"expiry_date"`
+	DaysToExpiry  int       `json:"days_to_expiry"`
+	ProductID     uuid.UUID `json:"product_id"`
+	ProductName   string    `json:"product_name"`
+	ProductSKU    string    `json:"product_sku"`
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Quantity      int       `json:"quantity"`
+	UnitPrice     float64   `json:"unit_price"`
+	Value         float64   `json:"value"`
+}
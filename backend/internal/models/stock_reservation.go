@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock reservation statuses
+const (
+	StockReservationStatusActive   = "active"
+	StockReservationStatusReleased = "released"
+	StockReservationStatusConsumed = "consumed"
+	StockReservationStatusExpired  = "expired"
+)
+
+// reservationOwnerTypes are the documents that place holds of their own.
+// Their holds are shipped through the document, which posts the movements
+// under its own reference and keeps the shipped quantities.
+var reservationOwnerTypes = map[string]bool{
+	SalesOrderReferenceType: true,
+}
+
+// IsReservationOwnerType reports whether reservations can be held for an
+// owner of the given type
+func IsReservationOwnerType(ownerType string) bool {
+	return reservationOwnerTypes[ownerType]
+}
+
+type StockReservation struct {
+	ID               uuid.UUID  `json:"id"`
+	ProductID        uuid.UUID  `json:"product_id"`
+	WarehouseID      uuid.UUID  `json:"warehouse_id"`
+	Quantity         int        `json:"quantity"`
+	ConsumedQuantity int        `json:"consumed_quantity"`
+	Status           string     `json:"status"`
+	OwnerType        *string    `json:"owner_type"`
+	OwnerID          *uuid.UUID `json:"owner_id"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	Notes            *string    `json:"notes"`
+	CreatedBy        *uuid.UUID `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	// Joined fields
+	ProductName   *string `json:"product_name,omitempty"`
+	ProductSKU    *string `json:"product_sku,omitempty"`
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+}
+
+type CreateStockReservationRequest struct {
+	ProductID   uuid.UUID  `json:"product_id" validate:"required"`
+	WarehouseID uuid.UUID  `json:"warehouse_id" validate:"required"`
+	Quantity    int        `json:"quantity" validate:"required,min=1"`
+	OwnerType   *string    `json:"owner_type"`
+	OwnerID     *uuid.UUID `json:"owner_id"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Notes       *string    `json:"notes"`
+}
+
+type ExtendStockReservationRequest struct {
+	// ExpiresAt replaces the current expiry; null removes it
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ReleaseStockReservationRequest struct {
+	// Quantity releases part of the hold; omitted releases all of it
+	Quantity *int `json:"quantity,omitempty" validate:"omitempty,min=1"`
+}
+
+type ConsumeStockReservationRequest struct {
+	// Quantity ships part of the hold; omitted consumes all of it
+	Quantity        *int    `json:"quantity,omitempty" validate:"omitempty,min=1"`
+	ReferenceNumber *string `json:"reference_number,omitempty"`
+	Reason          *string `json:"reason"`
+	// SerialNumbers names the units shipped; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
+
+type StockReservationFilter struct {
+	ProductID   *uuid.UUID `json:"product_id"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	Status      *string    `json:"status"`
+	OwnerType   *string    `json:"owner_type"`
+	OwnerID     *uuid.UUID `json:"owner_id"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type StockReservationListResponse struct {
+	StockReservations []StockReservation `json:"stock_reservations"`
+	Total             int64              `json:"total"`
+	Page              int                `json:"page"`
+	Limit             int                `json:"limit"`
+	Pages             int                `json:"pages"`
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock transfer document statuses
+const (
+	StockTransferStatusDraft             = "draft"
+	StockTransferStatusDispatched        = "dispatched"
+	StockTransferStatusPartiallyReceived = "partially_received"
+	StockTransferStatusReceived          = "received"
+	StockTransferStatusCancelled         = "cancelled"
+)
+
+// Reference types of movements between warehouses. Transfer documents post
+// their dispatch and receipt under StockTransferReferenceType; immediate
+// transfers post their paired out/in legs under WarehouseTransferReferenceType.
+// Quantities a transfer document is closed short of are written off under
+// StockTransferLossReferenceType.
+const (
+	StockTransferReferenceType     = "transfer"
+	WarehouseTransferReferenceType = "warehouse_transfer"
+	StockTransferLossReferenceType = "transfer_loss"
+)
+
+// TransitLossReasonCode is the adjustment reason code the shortfall of a
+// transfer closed short is written off under
+const TransitLossReasonCode = "transit_loss"
+
+// IsTransferReference reports whether a movement with the given reference
+// type carries stock from one warehouse to another
+func IsTransferReference(referenceType *string) bool {
+	if referenceType == nil {
+		return false
+	}
+	return *referenceType == StockTransferReferenceType || *referenceType == WarehouseTransferReferenceType
+}
+
+type StockTransfer struct {
+	ID                uuid.UUID           `json:"id"`
+	TransferNumber    string              `json:"transfer_number"`
+	FromWarehouseID   uuid.UUID           `json:"from_warehouse_id"`
+	ToWarehouseID     uuid.UUID           `json:"to_warehouse_id"`
+	Status            string              `json:"status"`
+	Notes             *string             `json:"notes"`
+	CreatedBy         uuid.UUID           `json:"created_by"`
+	DispatchedBy      *uuid.UUID          `json:"dispatched_by"`
+	DispatchedAt      *time.Time          `json:"dispatched_at"`
+	ReceivedBy        *uuid.UUID          `json:"received_by"`
+	ReceivedAt        *time.Time          `json:"received_at"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+	Items             []StockTransferLine `json:"items,omitempty"`
+	// Joined fields
+	FromWarehouseName *string `json:"from_warehouse_name,omitempty"`
+	ToWarehouseName   *string `json:"to_warehouse_name,omitempty"`
+}
+
+type StockTransferLine struct {
+	ID                  uuid.UUID `json:"id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	Quantity            int       `json:"quantity"`
+	ReceivedQuantity    int       `json:"received_quantity"`
+	InTransitQuantity   int       `json:"in_transit_quantity"`
+	DiscrepancyQuantity int       `json:"discrepancy_quantity"`
+	DiscrepancyReason   *string   `json:"discrepancy_reason"`
+	SerialNumbers       []string  `json:"serial_numbers,omitempty"`
+	// Joined fields
+	ProductName *string `json:"product_name,omitempty"`
+	ProductSKU  *string `json:"product_sku,omitempty"`
+}
+
+type CreateStockTransferRequest struct {
+	TransferNumber  *string             `json:"transfer_number,omitempty"`
+	FromWarehouseID uuid.UUID           `json:"from_warehouse_id" validate:"required"`
+	ToWarehouseID   uuid.UUID           `json:"to_warehouse_id" validate:"required"`
+	Notes           *string             `json:"notes"`
+	Items           []StockTransferItem `json:"items" validate:"required,min=1"`
+}
+
+type UpdateStockTransferRequest struct {
+	Notes *string             `json:"notes"`
+	Items []StockTransferItem `json:"items" validate:"required,min=1"`
+}
+
+type ReceiveStockTransferRequest struct {
+	Lines []ReceiveStockTransferLine `json:"lines"`
+	// Complete closes the transfer and records any outstanding quantity as a discrepancy
+	Complete      bool       `json:"complete"`
+	ProcessedDate *time.Time `json:"processed_date,omitempty"`
+}
+
+type ReceiveStockTransferLine struct {
+	ItemID            uuid.UUID `json:"item_id" validate:"required"`
+	Quantity          int       `json:"quantity" validate:"min=0"`
+	DiscrepancyReason *string   `json:"discrepancy_reason"`
+	// SerialNumbers names the units received; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
+
+type StockTransferFilter struct {
+	Status          *string    `json:"status"`
+	FromWarehouseID *uuid.UUID `json:"from_warehouse_id"`
+	ToWarehouseID   *uuid.UUID `json:"to_warehouse_id"`
+	Page            int        `json:"page" validate:"min=1"`
+	Limit           int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type StockTransferListResponse struct {
+	StockTransfers []StockTransfer `json:"stock_transfers"`
+	Total          int64           `json:"total"`
+	Page           int             `json:"page"`
+	Limit          int             `json:"limit"`
+	Pages          int             `json:"pages"`
+}
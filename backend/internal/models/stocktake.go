@@ -0,0 +1,135 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stocktake statuses. Counts are taken while counting; review closes counting
+// so variances can be checked before approval posts the corrections.
+const (
+	StocktakeStatusCounting  = "counting"
+	StocktakeStatusReview    = "review"
+	StocktakeStatusApproved  = "approved"
+	StocktakeStatusCancelled = "cancelled"
+)
+
+// StocktakeReferenceType is the reference type of the adjustments a stocktake posts
+const StocktakeReferenceType = "stocktake"
+
+// StocktakeReasonCode is the adjustment reason code stocktake corrections are posted under
+const StocktakeReasonCode = "count_correction"
+
+// stocktakeTransitions lists the statuses each status may move to. Approved
+// and cancelled stocktakes are closed.
+var stocktakeTransitions = map[string][]string{
+	StocktakeStatusCounting: {StocktakeStatusReview, StocktakeStatusCancelled},
+	StocktakeStatusReview:   {StocktakeStatusCounting, StocktakeStatusApproved, StocktakeStatusCancelled},
+}
+
+// CanTransitionStocktake reports whether a stocktake may move from one status
+// to another
+func CanTransitionStocktake(from, to string) bool {
+	for _, next := range stocktakeTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type Stocktake struct {
+	ID              uuid.UUID       `json:"id"`
+	StocktakeNumber string          `json:"stocktake_number"`
+	WarehouseID     uuid.UUID       `json:"warehouse_id"`
+	CategoryID      *uuid.UUID      `json:"category_id"`
+	Status          string          `json:"status"`
+	Notes           *string         `json:"notes"`
+	CreatedBy       uuid.UUID       `json:"created_by"`
+	SubmittedBy     *uuid.UUID      `json:"submitted_by"`
+	SubmittedAt     *time.Time      `json:"submitted_at"`
+	ApprovedBy      *uuid.UUID      `json:"approved_by"`
+	ApprovedAt      *time.Time      `json:"approved_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Items           []StocktakeLine `json:"items,omitempty"`
+	// Joined fields
+	WarehouseName *string `json:"warehouse_name,omitempty"`
+	CategoryName  *string `json:"category_name,omitempty"`
+}
+
+// StocktakeLine is a product in a stocktake with its frozen expected
+// quantity, the counts taken and the resulting variance. Conflict is set when
+// counters disagree and the count has not been resolved.
+type StocktakeLine struct {
+	ID               uuid.UUID        `json:"id"`
+	ProductID        uuid.UUID        `json:"product_id"`
+	ExpectedQuantity int              `json:"expected_quantity"`
+	ExpectedSerials  []string         `json:"expected_serials,omitempty"`
+	CountedQuantity  *int             `json:"counted_quantity"`
+	CountedSerials   []string         `json:"counted_serials,omitempty"`
+	ResolvedBy       *uuid.UUID       `json:"resolved_by,omitempty"`
+	VarianceQuantity *int             `json:"variance_quantity"`
+	VarianceValue    *float64         `json:"variance_value"`
+	Conflict         bool             `json:"conflict"`
+	AdjustedQuantity *int             `json:"adjusted_quantity,omitempty"`
+	Counts           []StocktakeCount `json:"counts"`
+	// Joined fields
+	ProductName *string `json:"product_name,omitempty"`
+	ProductSKU  *string `json:"product_sku,omitempty"`
+}
+
+// StocktakeCount is one counter's count of a product
+type StocktakeCount struct {
+	ID            uuid.UUID `json:"id"`
+	CountedBy     uuid.UUID `json:"counted_by"`
+	Quantity      int       `json:"quantity"`
+	SerialNumbers []string  `json:"serial_numbers,omitempty"`
+	Notes         *string   `json:"notes"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	// Joined fields
+	CounterFirstName *string `json:"counter_first_name,omitempty"`
+	CounterLastName  *string `json:"counter_last_name,omitempty"`
+}
+
+type CreateStocktakeRequest struct {
+	StocktakeNumber *string    `json:"stocktake_number,omitempty"`
+	WarehouseID     uuid.UUID  `json:"warehouse_id" validate:"required"`
+	CategoryID      *uuid.UUID `json:"category_id,omitempty"`
+	Notes           *string    `json:"notes"`
+}
+
+type SubmitStocktakeCountsRequest struct {
+	Lines []StocktakeCountLine `json:"lines" validate:"required,min=1"`
+}
+
+type StocktakeCountLine struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"min=0"`
+	// SerialNumbers names the units counted; required for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	Notes         *string  `json:"notes"`
+}
+
+// ResolveStocktakeItemRequest settles the count of a line the counters
+// disagreed on
+type ResolveStocktakeItemRequest struct {
+	CountedQuantity int      `json:"counted_quantity" validate:"min=0"`
+	SerialNumbers   []string `json:"serial_numbers,omitempty"`
+}
+
+type StocktakeFilter struct {
+	Status      *string    `json:"status"`
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	Page        int        `json:"page" validate:"min=1"`
+	Limit       int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type StocktakeListResponse struct {
+	Stocktakes []Stocktake `json:"stocktakes"`
+	Total      int64       `json:"total"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	Pages      int         `json:"pages"`
+}
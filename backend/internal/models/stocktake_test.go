@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestCanTransitionStocktake(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StocktakeStatusCounting, StocktakeStatusReview, true},
+		{StocktakeStatusCounting, StocktakeStatusCancelled, true},
+		{StocktakeStatusReview, StocktakeStatusCounting, true},
+		{StocktakeStatusReview, StocktakeStatusApproved, true},
+		{StocktakeStatusReview, StocktakeStatusCancelled, true},
+		{StocktakeStatusCounting, StocktakeStatusApproved, false},
+		{StocktakeStatusApproved, StocktakeStatusCounting, false},
+		{StocktakeStatusApproved, StocktakeStatusCancelled, false},
+		{StocktakeStatusCancelled, StocktakeStatusCounting, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransitionStocktake(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionStocktake(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
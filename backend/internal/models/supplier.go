@@ -18,6 +18,8 @@ type Supplier struct {
 	Country      string    `json:"country" db:"country"`
 	PostalCode   string    `json:"postal_code" db:"postal_code"`
 	IsActive     bool      `json:"is_active" db:"is_active"`
+	// LeadTimeDays is how long the supplier takes to deliver an order
+	LeadTimeDays int       `json:"lead_time_days" db:"lead_time_days"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -32,6 +34,7 @@ type CreateSupplierRequest struct {
 	State        string `json:"state" validate:"max=100"`
 	Country      string `json:"country" validate:"max=100"`
 	PostalCode   string `json:"postal_code" validate:"max=20"`
+	LeadTimeDays int    `json:"lead_time_days" validate:"min=0"`
 }
 
 type UpdateSupplierRequest struct {
@@ -44,6 +47,7 @@ type UpdateSupplierRequest struct {
 	State        string `json:"state" validate:"max=100"`
 	Country      string `json:"country" validate:"max=100"`
 	PostalCode   string `json:"postal_code" validate:"max=20"`
+	LeadTimeDays int    `json:"lead_time_days" validate:"min=0"`
 }
 
 type SupplierFilter struct {
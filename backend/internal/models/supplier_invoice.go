@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supplier invoice match statuses, used both per line and for the invoice as a
+// whole. An invoice takes the worst status of its lines.
+const (
+	SupplierInvoiceStatusMatched  = "matched"
+	SupplierInvoiceStatusVariance = "variance"
+	SupplierInvoiceStatusBlocked  = "blocked"
+)
+
+type SupplierInvoice struct {
+	ID              uuid.UUID             `json:"id"`
+	InvoiceNumber   string                `json:"invoice_number"`
+	PurchaseOrderID uuid.UUID             `json:"purchase_order_id"`
+	SupplierID      *uuid.UUID            `json:"supplier_id"`
+	InvoiceDate     time.Time             `json:"invoice_date"`
+	DueDate         *time.Time            `json:"due_date"`
+	TotalAmount     float64               `json:"total_amount"`
+	Status          string                `json:"status"`
+	Notes           *string               `json:"notes"`
+	CreatedBy       uuid.UUID             `json:"created_by"`
+	MatchedAt       *time.Time            `json:"matched_at"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	Items           []SupplierInvoiceLine `json:"items,omitempty"`
+	// Joined fields
+	PoNumber     *string `json:"po_number,omitempty"`
+	SupplierName *string `json:"supplier_name,omitempty"`
+}
+
+// SupplierInvoiceLine is an invoice line together with the PO and receipt
+// figures it was matched against
+type SupplierInvoiceLine struct {
+	ID                         uuid.UUID `json:"id"`
+	PurchaseOrderItemID        uuid.UUID `json:"purchase_order_item_id"`
+	ProductID                  uuid.UUID `json:"product_id"`
+	Quantity                   int       `json:"quantity"`
+	UnitPrice                  float64   `json:"unit_price"`
+	TotalPrice                 float64   `json:"total_price"`
+	OrderedQuantity            int       `json:"ordered_quantity"`
+	ReceivedQuantity           int       `json:"received_quantity"`
+	PreviouslyInvoicedQuantity int       `json:"previously_invoiced_quantity"`
+	PoUnitPrice                float64   `json:"po_unit_price"`
+	QuantityVariance           int       `json:"quantity_variance"`
+	PriceVariance              float64   `json:"price_variance"`
+	MatchStatus                string    `json:"match_status"`
+	MatchNotes                 *string   `json:"match_notes"`
+	// Joined fields
+	ProductName *string `json:"product_name,omitempty"`
+	ProductSKU  *string `json:"product_sku,omitempty"`
+}
+
+type SupplierInvoiceItem struct {
+	PurchaseOrderItemID uuid.UUID `json:"purchase_order_item_id" validate:"required"`
+	Quantity            int       `json:"quantity" validate:"required,min=1"`
+	UnitPrice           float64   `json:"unit_price" validate:"min=0"`
+}
+
+type CreateSupplierInvoiceRequest struct {
+	InvoiceNumber   string                `json:"invoice_number" validate:"required"`
+	PurchaseOrderID uuid.UUID             `json:"purchase_order_id" validate:"required"`
+	SupplierID      *uuid.UUID            `json:"supplier_id"`
+	InvoiceDate     *time.Time            `json:"invoice_date,omitempty"`
+	DueDate         *time.Time            `json:"due_date"`
+	Notes           *string               `json:"notes"`
+	Items           []SupplierInvoiceItem `json:"items" validate:"required,min=1"`
+}
+
+type SupplierInvoiceFilter struct {
+	PurchaseOrderID *uuid.UUID `json:"purchase_order_id"`
+	Status          *string    `json:"status"`
+	Page            int        `json:"page" validate:"min=1"`
+	Limit           int        `json:"limit" validate:"min=1,max=100"`
+}
+
+type SupplierInvoiceListResponse struct {
+	SupplierInvoices []SupplierInvoice `json:"supplier_invoices"`
+	Total            int64             `json:"total"`
+	Page             int               `json:"page"`
+	Limit            int               `json:"limit"`
+	Pages            int               `json:"pages"`
+}
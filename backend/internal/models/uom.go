@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UnitOfMeasure is a unit products can be bought, sold and moved in, such as
+// EA or CASE
+type UnitOfMeasure struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateUnitOfMeasureRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+	Name string `json:"name" validate:"required"`
+}
+
+type UpdateUnitOfMeasureRequest struct {
+	Name     string `json:"name" validate:"required"`
+	IsActive bool   `json:"is_active"`
+}
+
+// ProductUOM is a unit a product can be handled in. Factor is the number of
+// base units, the unit stock levels are kept in, that one of it holds.
+type ProductUOM struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	UOMID     uuid.UUID `json:"uom_id"`
+	UOM       string    `json:"uom"`
+	UOMName   string    `json:"uom_name"`
+	Factor    int       `json:"factor"`
+	IsBase    bool      `json:"is_base"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetProductUOMsRequest replaces the units a product can be handled in
+type SetProductUOMsRequest struct {
+	UOMs []ProductUOMInput `json:"uoms"`
+}
+
+type ProductUOMInput struct {
+	UOM    string `json:"uom" validate:"required"`
+	Factor int    `json:"factor" validate:"required,min=1"`
+	IsBase bool   `json:"is_base"`
+}
+
+// ValidateProductUOMs checks a product's units: each listed once with a
+// positive factor, and at most one base unit, whose factor is 1
+func ValidateProductUOMs(uoms []ProductUOMInput) error {
+	seen := make(map[string]bool, len(uoms))
+	base := false
+	for _, uom := range uoms {
+		if uom.UOM == "" {
+			return errors.New("a unit of measure is required for every entry")
+		}
+		if seen[uom.UOM] {
+			return fmt.Errorf("unit %s is listed more than once", uom.UOM)
+		}
+		seen[uom.UOM] = true
+		if uom.Factor <= 0 {
+			return fmt.Errorf("factor for %s must be greater than zero", uom.UOM)
+		}
+		if uom.IsBase {
+			if base {
+				return errors.New("a product can only have one base unit")
+			}
+			if uom.Factor != 1 {
+				return fmt.Errorf("base unit %s must have a factor of 1", uom.UOM)
+			}
+			base = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestValidateProductUOMs(t *testing.T) {
+	tests := []struct {
+		name    string
+		uoms    []ProductUOMInput
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"base and case", []ProductUOMInput{{"EA", 1, true}, {"CASE", 24, false}}, false},
+		{"no base", []ProductUOMInput{{"CASE", 24, false}}, false},
+		{"duplicate unit", []ProductUOMInput{{"EA", 1, true}, {"EA", 1, false}}, true},
+		{"zero factor", []ProductUOMInput{{"CASE", 0, false}}, true},
+		{"two bases", []ProductUOMInput{{"EA", 1, true}, {"PC", 1, true}}, true},
+		{"base factor not one", []ProductUOMInput{{"PACK", 6, true}}, true},
+		{"missing code", []ProductUOMInput{{"", 1, false}}, true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateProductUOMs(tt.uoms); (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateProductUOMs() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
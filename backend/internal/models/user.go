@@ -6,6 +6,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// User roles, as allowed by the users role CHECK. Admins can do everything a
+// manager can, and managers everything staff can.
+const (
+	UserRoleAdmin   = "admin"
+	UserRoleManager = "manager"
+	UserRoleStaff   = "staff"
+)
+
+var userRoleRanks = map[string]int{
+	UserRoleStaff:   1,
+	UserRoleManager: 2,
+	UserRoleAdmin:   3,
+}
+
+// RoleSatisfies reports whether a user with role may act where required is
+// the minimum role
+func RoleSatisfies(role, required string) bool {
+	rank, ok := userRoleRanks[role]
+	return ok && rank >= userRoleRanks[required]
+}
+
 type User struct {
 	ID           uuid.UUID `json:"id" db:"id"`
 	Email        string    `json:"email" db:"email"`
@@ -47,9 +68,3 @@ type LoginResponse struct {
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
-
-
-
-
-
-
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Location types. Zones group aisles and aisles group bins; stock is only
+// held in bins.
+const (
+	LocationTypeZone  = "zone"
+	LocationTypeAisle = "aisle"
+	LocationTypeBin   = "bin"
+)
+
+// BinMoveReferenceType is the reference type of bin-to-bin moves
+const BinMoveReferenceType = "bin_move"
+
+// locationParents lists the location types each type may be placed under; an
+// empty string means it may sit directly in the warehouse
+var locationParents = map[string][]string{
+	LocationTypeZone:  {""},
+	LocationTypeAisle: {LocationTypeZone},
+	LocationTypeBin:   {"", LocationTypeZone, LocationTypeAisle},
+}
+
+// CanNestLocation reports whether a location of type child may be placed
+// under a location of type parent. Pass an empty parent for a top-level
+// location.
+func CanNestLocation(parent, child string) bool {
+	for _, allowed := range locationParents[child] {
+		if allowed == parent {
+			return true
+		}
+	}
+	return false
+}
+
+// WarehouseLocation is a zone, aisle or bin inside a warehouse. Quantity is
+// the total stock held in a bin.
+type WarehouseLocation struct {
+	ID           uuid.UUID       `json:"id"`
+	WarehouseID  uuid.UUID       `json:"warehouse_id"`
+	ParentID     *uuid.UUID      `json:"parent_id"`
+	LocationType string          `json:"location_type"`
+	Code         string          `json:"code"`
+	Name         *string         `json:"name"`
+	IsActive     bool            `json:"is_active"`
+	Quantity     int             `json:"quantity"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	Stock        []BinStockLevel `json:"stock,omitempty"`
+}
+
+type CreateWarehouseLocationRequest struct {
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	LocationType string     `json:"location_type" validate:"required,oneof=zone aisle bin"`
+	Code         string     `json:"code" validate:"required"`
+	Name         *string    `json:"name"`
+}
+
+type UpdateWarehouseLocationRequest struct {
+	Name     *string `json:"name"`
+	IsActive bool    `json:"is_active"`
+}
+
+// BinStockLevel is the balance of a product in one bin
+type BinStockLevel struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	WarehouseID  uuid.UUID `json:"warehouse_id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	Quantity     int       `json:"quantity"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// Joined fields
+	ProductName *string `json:"product_name,omitempty"`
+	ProductSKU  *string `json:"product_sku,omitempty"`
+}
+
+type BinStockLevelFilter struct {
+	WarehouseID *uuid.UUID `json:"warehouse_id"`
+	LocationID  *uuid.UUID `json:"location_id"`
+	ProductID   *uuid.UUID `json:"product_id"`
+}
+
+// StockMovementLocation is the change a movement made to a bin balance;
+// negative quantities were taken out of the bin
+type StockMovementLocation struct {
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	Quantity     int       `json:"quantity"`
+}
+
+// BinMoveRequest moves stock between two bins of the same warehouse. The
+// warehouse total is unchanged.
+type BinMoveRequest struct {
+	ProductID      uuid.UUID `json:"product_id" validate:"required"`
+	FromLocationID uuid.UUID `json:"from_location_id" validate:"required"`
+	ToLocationID   uuid.UUID `json:"to_location_id" validate:"required"`
+	Quantity       int       `json:"quantity" validate:"required,min=1"`
+	Reason         *string   `json:"reason"`
+}
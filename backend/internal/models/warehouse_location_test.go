@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestCanNestLocation(t *testing.T) {
+	tests := []struct {
+		parent, child string
+		want          bool
+	}{
+		{"", LocationTypeZone, true},
+		{"", LocationTypeBin, true},
+		{LocationTypeZone, LocationTypeAisle, true},
+		{LocationTypeZone, LocationTypeBin, true},
+		{LocationTypeAisle, LocationTypeBin, true},
+		{"", LocationTypeAisle, false},
+		{LocationTypeZone, LocationTypeZone, false},
+		{LocationTypeAisle, LocationTypeAisle, false},
+		{LocationTypeBin, LocationTypeBin, false},
+	}
+
+	for _, tt := range tests {
+		if got := CanNestLocation(tt.parent, tt.child); got != tt.want {
+			t.Errorf("CanNestLocation(%q, %q) = %v, want %v", tt.parent, tt.child, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"inventory-system/internal/config"
+	"inventory-system/internal/models"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// EmailNotifier mails alerts to a fixed list of recipients over SMTP
+type EmailNotifier struct {
+	addr       string
+	auth       smtp.Auth
+	from       string
+	recipients []string
+}
+
+func NewEmailNotifier(cfg config.AlertConfig) *EmailNotifier {
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return &EmailNotifier{
+		addr:       net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		auth:       auth,
+		from:       cfg.SMTPFrom,
+		recipients: cfg.EmailRecipients,
+	}
+}
+
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, alert models.StockAlert) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.addr, n.auth, n.from, n.recipients, emailMessage(n.from, n.recipients, alert))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// emailMessage renders an alert as a plain text RFC 5322 message
+func emailMessage(from string, recipients []string, alert models.StockAlert) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", Title(alert))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "%s\r\n\r\n", alert.Message)
+	fmt.Fprintf(&b, "Alert: %s\r\n", alert.ID)
+	fmt.Fprintf(&b, "Raised: %s\r\n", alert.CreatedAt.Format(time.RFC1123Z))
+	return b.Bytes()
+}
@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+)
+
+// InboxNotifier puts alerts in the in-app inbox of every active admin and
+// manager
+type InboxNotifier struct {
+	db *database.DB
+}
+
+func NewInboxNotifier(db *database.DB) *InboxNotifier {
+	return &InboxNotifier{db: db}
+}
+
+func (n *InboxNotifier) Name() string {
+	return "inbox"
+}
+
+func (n *InboxNotifier) Notify(ctx context.Context, alert models.StockAlert) error {
+	return n.db.CreateAlertNotifications(ctx, &sqlc.CreateAlertNotificationsParams{
+		StockAlertID: utils.UUIDToPgxUUID(alert.ID),
+		Title:        Title(alert),
+		Message:      alert.Message,
+	})
+}
@@ -0,0 +1,39 @@
+// Package notify delivers stock alerts to the people who need to act on them
+package notify
+
+import (
+	"context"
+	"fmt"
+	"inventory-system/internal/models"
+)
+
+// Notifier delivers newly raised stock alerts over one channel
+type Notifier interface {
+	// Name identifies the channel in logs
+	Name() string
+	Notify(ctx context.Context, alert models.StockAlert) error
+}
+
+// Title returns a one-line summary of an alert for subjects and inbox titles
+func Title(alert models.StockAlert) string {
+	product := alert.ProductID.String()
+	if alert.ProductName != nil {
+		product = *alert.ProductName
+	}
+	warehouse := alert.WarehouseID.String()
+	if alert.WarehouseName != nil {
+		warehouse = *alert.WarehouseName
+	}
+
+	switch alert.AlertType {
+	case models.StockAlertTypeLowStock:
+		return fmt.Sprintf("Low stock: %s in %s", product, warehouse)
+	case models.StockAlertTypeStockOut:
+		return fmt.Sprintf("Out of stock: %s in %s", product, warehouse)
+	case models.StockAlertTypeOverMax:
+		return fmt.Sprintf("Over maximum: %s in %s", product, warehouse)
+	case models.StockAlertTypeNegativeAttempt:
+		return fmt.Sprintf("Posting refused: %s in %s", product, warehouse)
+	}
+	return fmt.Sprintf("Stock alert: %s in %s", product, warehouse)
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"inventory-system/internal/models"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func testAlert() models.StockAlert {
+	product, warehouse := "Widget", "Main"
+	return models.StockAlert{
+		ID:            uuid.New(),
+		AlertType:     models.StockAlertTypeLowStock,
+		Status:        models.StockAlertStatusOpen,
+		Quantity:      3,
+		Message:       "Widget (W-1) is low in Main: 3 available, minimum 10",
+		ProductName:   &product,
+		WarehouseName: &warehouse,
+	}
+}
+
+func TestWebhookNotifierSignsPayload(t *testing.T) {
+	alert := testAlert()
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if got, want := r.Header.Get(SignatureHeader), "sha256="+hex.EncodeToString(mac.Sum(nil)); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("payload is not JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL, "secret").Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if payload.Alert.ID != alert.ID || payload.Title != "Low stock: Widget in Main" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestWebhookNotifierRejectsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookNotifier(server.URL, "").Notify(context.Background(), testAlert()); err == nil {
+		t.Fatal("Notify() error = nil, want error for 500 response")
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	message := string(emailMessage("stock@example.com", []string{"a@example.com", "b@example.com"}, testAlert()))
+
+	for _, want := range []string{
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: Low stock: Widget in Main\r\n",
+		"\r\n\r\nWidget (W-1) is low in Main: 3 available, minimum 10\r\n",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message missing %q:\n%s", want, message)
+		}
+	}
+}
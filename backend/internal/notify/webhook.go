@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"inventory-system/internal/models"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body when the
+// webhook has a secret
+const SignatureHeader = "X-Inventory-Signature"
+
+// WebhookNotifier posts alerts as JSON to a URL
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// webhookPayload is the body posted for each alert
+type webhookPayload struct {
+	Event string            `json:"event"`
+	Title string            `json:"title"`
+	Alert models.StockAlert `json:"alert"`
+}
+
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert models.StockAlert) error {
+	body, err := json.Marshal(webhookPayload{
+		Event: "stock_alert.raised",
+		Title: Title(alert),
+		Alert: alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/costing"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AccountingPeriodService manages the monthly periods finance closes. Closing
+// a period records the quantity and value of stock at its end, and from then
+// on postStockMovement refuses movements dated inside or before it until it
+// is reopened.
+type AccountingPeriodService struct {
+	db *database.DB
+}
+
+func NewAccountingPeriodService(db *database.DB) *AccountingPeriodService {
+	return &AccountingPeriodService{db: db}
+}
+
+func (s *AccountingPeriodService) CreateAccountingPeriod(ctx context.Context, req models.CreateAccountingPeriodRequest, userID uuid.UUID) (*models.AccountingPeriod, error) {
+	name, startsAt, endsAt, err := models.MonthPeriod(req.Year, req.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := s.db.CreateAccountingPeriod(ctx, &sqlc.CreateAccountingPeriodParams{
+		Name:      name,
+		StartsAt:  utils.TimeToPgxTimestamptz(startsAt),
+		EndsAt:    utils.TimeToPgxTimestamptz(endsAt),
+		CreatedBy: utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create accounting period %s: %w", name, err)
+	}
+
+	result := toAccountingPeriodModel(period)
+	return &result, nil
+}
+
+func (s *AccountingPeriodService) GetAccountingPeriod(ctx context.Context, id uuid.UUID) (*models.AccountingPeriod, error) {
+	period, err := s.db.GetAccountingPeriod(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toAccountingPeriodModel(period)
+	return &result, nil
+}
+
+// ListAccountingPeriods lists periods newest first, optionally only those in a status
+func (s *AccountingPeriodService) ListAccountingPeriods(ctx context.Context, status *string) ([]models.AccountingPeriod, error) {
+	periods, err := s.db.ListAccountingPeriods(ctx, utils.OptionalStringToString(status))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.AccountingPeriod, len(periods))
+	for i, period := range periods {
+		result[i] = toAccountingPeriodModel(period)
+	}
+	return result, nil
+}
+
+// CloseAccountingPeriod closes a period that has ended and records its
+// month-end balances. Periods close in order, so every earlier period must be
+// closed already. Postings dated inside the period hold a share lock on it,
+// so the close waits for them and the balances include them.
+func (s *AccountingPeriodService) CloseAccountingPeriod(ctx context.Context, id, userID uuid.UUID) (*models.AccountingPeriod, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	periods, i, err := lockAccountingPeriods(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	period := periods[i]
+	if period.Status != models.AccountingPeriodStatusOpen {
+		return nil, fmt.Errorf("%w: the accounting period is already %s", ErrInvalidStatusTransition, period.Status)
+	}
+	if period.EndsAt.Time.After(time.Now()) {
+		return nil, errors.New("an accounting period cannot be closed before it ends")
+	}
+	if err := checkPeriodSequence(periods, i, models.AccountingPeriodStatusClosed); err != nil {
+		return nil, err
+	}
+
+	if err := q.DeleteAccountingPeriodBalances(ctx, period.ID); err != nil {
+		return nil, err
+	}
+	if err := q.CreateAccountingPeriodBalances(ctx, &sqlc.CreateAccountingPeriodBalancesParams{
+		PeriodID: period.ID,
+		Column2:  period.EndsAt,
+	}); err != nil {
+		return nil, err
+	}
+	closed, err := q.CloseAccountingPeriod(ctx, &sqlc.CloseAccountingPeriodParams{
+		ID:       period.ID,
+		ClosedBy: utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result := toAccountingPeriodModel(closed)
+	return &result, nil
+}
+
+// ReopenAccountingPeriod reopens a closed period so movements can be dated
+// inside it again, as long as no later period is still closed. Its month-end
+// balances are dropped; closing it again records them afresh.
+func (s *AccountingPeriodService) ReopenAccountingPeriod(ctx context.Context, id, userID uuid.UUID) (*models.AccountingPeriod, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	periods, i, err := lockAccountingPeriods(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	period := periods[i]
+	if period.Status != models.AccountingPeriodStatusClosed {
+		return nil, fmt.Errorf("%w: the accounting period is already %s", ErrInvalidStatusTransition, period.Status)
+	}
+	if err := checkPeriodSequence(periods, i, models.AccountingPeriodStatusOpen); err != nil {
+		return nil, err
+	}
+
+	if err := q.DeleteAccountingPeriodBalances(ctx, period.ID); err != nil {
+		return nil, err
+	}
+	reopened, err := q.ReopenAccountingPeriod(ctx, &sqlc.ReopenAccountingPeriodParams{
+		ID:         period.ID,
+		ReopenedBy: utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result := toAccountingPeriodModel(reopened)
+	return &result, nil
+}
+
+// GetAccountingPeriodBalances returns the balances recorded when the period
+// was closed, optionally for one product or warehouse
+func (s *AccountingPeriodService) GetAccountingPeriodBalances(ctx context.Context, id uuid.UUID, productID, warehouseID *uuid.UUID) (*models.AccountingPeriodBalances, error) {
+	period, err := s.db.GetAccountingPeriod(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.ListAccountingPeriodBalances(ctx, &sqlc.ListAccountingPeriodBalancesParams{
+		PeriodID: period.ID,
+		Column2:  utils.OptionalUUIDToPgxUUID(productID),
+		Column3:  utils.OptionalUUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.AccountingPeriodBalances{
+		Period:   toAccountingPeriodModel(period),
+		Balances: make([]models.AccountingPeriodBalance, len(rows)),
+	}
+	for i, row := range rows {
+		result.Balances[i] = models.AccountingPeriodBalance{
+			ProductID:     utils.PgxUUIDToUUID(row.ProductID),
+			WarehouseID:   utils.PgxUUIDToUUID(row.WarehouseID),
+			Quantity:      int(row.Quantity),
+			TotalValue:    utils.PgxNumericToFloat64(row.TotalValue),
+			ProductName:   &row.ProductName,
+			ProductSKU:    &row.Sku,
+			WarehouseName: &row.WarehouseName,
+		}
+		result.TotalValue += result.Balances[i].TotalValue
+	}
+	result.TotalValue = costing.Round(result.TotalValue)
+	return result, nil
+}
+
+// lockAccountingPeriods locks every period in start order, the order
+// postings share-lock them in, and returns them with the index of the period
+// with the given ID
+func lockAccountingPeriods(ctx context.Context, q *sqlc.Queries, id uuid.UUID) ([]*sqlc.AccountingPeriod, int, error) {
+	periods, err := q.ListAccountingPeriodsForUpdate(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i, period := range periods {
+		if utils.PgxUUIDToUUID(period.ID) == id {
+			return periods, i, nil
+		}
+	}
+	return nil, 0, pgx.ErrNoRows
+}
+
+// checkPeriodSequence keeps closed periods contiguous from the oldest one
+// when the period at index i of periods, in start order, moves to status: a
+// period cannot close while an earlier one is open, nor reopen while a later
+// one is closed.
+func checkPeriodSequence(periods []*sqlc.AccountingPeriod, i int, status string) error {
+	if status == models.AccountingPeriodStatusClosed {
+		for _, earlier := range periods[:i] {
+			if earlier.Status == models.AccountingPeriodStatusOpen {
+				return fmt.Errorf("%w: the earlier accounting period %s is still open", ErrInvalidStatusTransition, earlier.Name)
+			}
+		}
+		return nil
+	}
+	for _, later := range periods[i+1:] {
+		if later.Status == models.AccountingPeriodStatusClosed {
+			return fmt.Errorf("%w: the later accounting period %s is still closed", ErrInvalidStatusTransition, later.Name)
+		}
+	}
+	return nil
+}
+
+func toAccountingPeriodModel(p *sqlc.AccountingPeriod) models.AccountingPeriod {
+	return models.AccountingPeriod{
+		ID:         utils.PgxUUIDToUUID(p.ID),
+		Name:       p.Name,
+		StartsAt:   utils.PgxTimestamptzToTime(p.StartsAt),
+		EndsAt:     utils.PgxTimestamptzToTime(p.EndsAt),
+		Status:     p.Status,
+		ClosedBy:   utils.OptionalPgxUUIDToUUID(p.ClosedBy),
+		ClosedAt:   utils.OptionalPgxTimestamptzToTimePtr(p.ClosedAt),
+		ReopenedBy: utils.OptionalPgxUUIDToUUID(p.ReopenedBy),
+		ReopenedAt: utils.OptionalPgxTimestamptzToTimePtr(p.ReopenedAt),
+		CreatedBy:  utils.PgxUUIDToUUID(p.CreatedBy),
+		CreatedAt:  utils.PgxTimestamptzToTime(p.CreatedAt),
+		UpdatedAt:  utils.PgxTimestamptzToTime(p.UpdatedAt),
+	}
+}
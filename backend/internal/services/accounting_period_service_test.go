@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"inventory-system/internal/config"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+)
+
+func TestCheckPeriodSequence(t *testing.T) {
+	open, closed := models.AccountingPeriodStatusOpen, models.AccountingPeriodStatusClosed
+	periods := []*sqlc.AccountingPeriod{
+		{Name: "2024-01", Status: closed},
+		{Name: "2024-02", Status: closed},
+		{Name: "2024-03", Status: open},
+		{Name: "2024-04", Status: open},
+	}
+
+	tests := []struct {
+		name    string
+		index   int
+		status  string
+		allowed bool
+	}{
+		{"close the first open period", 2, closed, true},
+		{"close past an open period", 3, closed, false},
+		{"reopen the last closed period", 1, open, true},
+		{"reopen before a closed period", 0, open, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPeriodSequence(periods, tt.index, tt.status)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrInvalidStatusTransition))
+			}
+		})
+	}
+}
+
+func TestAccountingPeriodClose(t *testing.T) {
+	db := newTestDB(t)
+	f := newTestFixtures(t, db)
+	ctx := context.Background()
+	service := NewAccountingPeriodService(db)
+	stock := NewStockService(db, config.ReceivingConfig{})
+	userID := f.user("admin")
+
+	january, err := service.CreateAccountingPeriod(ctx, models.CreateAccountingPeriodRequest{Year: 2024, Month: 1}, userID)
+	require.NoError(t, err)
+	february, err := service.CreateAccountingPeriod(ctx, models.CreateAccountingPeriodRequest{Year: 2024, Month: 2}, userID)
+	require.NoError(t, err)
+
+	_, err = service.CloseAccountingPeriod(ctx, february.ID, userID)
+	assert.True(t, errors.Is(err, ErrInvalidStatusTransition), "closing past an open period")
+
+	_, err = service.CloseAccountingPeriod(ctx, january.ID, userID)
+	require.NoError(t, err)
+	_, err = service.CloseAccountingPeriod(ctx, february.ID, userID)
+	require.NoError(t, err)
+
+	_, err = service.ReopenAccountingPeriod(ctx, january.ID, userID)
+	assert.True(t, errors.Is(err, ErrInvalidStatusTransition), "reopening before a closed period")
+
+	warehouse := f.warehouse()
+	product := f.product(false)
+	cost := 5.0
+	post := func(processedDate time.Time) error {
+		_, err := stock.CreateStockMovement(ctx, models.CreateStockMovementRequest{
+			ProductID:     product,
+			WarehouseID:   warehouse,
+			MovementType:  "in",
+			Quantity:      1,
+			CostPrice:     &cost,
+			ProcessedDate: &processedDate,
+		}, nil)
+		return err
+	}
+
+	// Period ends are exclusive, so the end of February is the start of March
+	assert.True(t, errors.Is(post(february.EndsAt.Add(-time.Second)), ErrPeriodClosed))
+	assert.NoError(t, post(february.EndsAt))
+
+	reopened, err := service.ReopenAccountingPeriod(ctx, february.ID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AccountingPeriodStatusOpen, reopened.Status)
+	assert.True(t, errors.Is(post(january.EndsAt.Add(-time.Second)), ErrPeriodClosed))
+	assert.NoError(t, post(february.EndsAt.Add(-time.Second)))
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// reasonCodePattern keeps codes usable as stable identifiers in filters and reports
+var reasonCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type AdjustmentReasonCodeService struct {
+	db *database.DB
+}
+
+func NewAdjustmentReasonCodeService(db *database.DB) *AdjustmentReasonCodeService {
+	return &AdjustmentReasonCodeService{db: db}
+}
+
+func (s *AdjustmentReasonCodeService) CreateAdjustmentReasonCode(ctx context.Context, req models.CreateAdjustmentReasonCodeRequest) (*models.AdjustmentReasonCode, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if !reasonCodePattern.MatchString(code) || len(code) > 50 {
+		return nil, errors.New("code must be lowercase letters, digits and underscores")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+
+	existing, err := s.db.GetAdjustmentReasonCodeByCode(ctx, code)
+	if err == nil && existing != nil {
+		return nil, errors.New("reason code already exists")
+	}
+
+	reasonCode, err := s.db.CreateAdjustmentReasonCode(ctx, &sqlc.CreateAdjustmentReasonCodeParams{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		IsActive:    &[]bool{true}[0],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toAdjustmentReasonCodeModel(reasonCode)
+	return &result, nil
+}
+
+func (s *AdjustmentReasonCodeService) GetAdjustmentReasonCode(ctx context.Context, id uuid.UUID) (*models.AdjustmentReasonCode, error) {
+	reasonCode, err := s.db.GetAdjustmentReasonCode(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toAdjustmentReasonCodeModel(reasonCode)
+	return &result, nil
+}
+
+func (s *AdjustmentReasonCodeService) ListAdjustmentReasonCodes(ctx context.Context, includeInactive bool) ([]models.AdjustmentReasonCode, error) {
+	reasonCodes, err := s.db.ListAdjustmentReasonCodes(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.AdjustmentReasonCode, len(reasonCodes))
+	for i, reasonCode := range reasonCodes {
+		result[i] = toAdjustmentReasonCodeModel(reasonCode)
+	}
+
+	return result, nil
+}
+
+// UpdateAdjustmentReasonCode changes the display fields of a reason code. The
+// code itself is immutable because it is stored on posted movements.
+func (s *AdjustmentReasonCodeService) UpdateAdjustmentReasonCode(ctx context.Context, id uuid.UUID, req models.UpdateAdjustmentReasonCodeRequest) (*models.AdjustmentReasonCode, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	reasonCode, err := s.db.UpdateAdjustmentReasonCode(ctx, &sqlc.UpdateAdjustmentReasonCodeParams{
+		ID:          utils.UUIDToPgxUUID(id),
+		Name:        req.Name,
+		Description: req.Description,
+		IsActive:    &isActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toAdjustmentReasonCodeModel(reasonCode)
+	return &result, nil
+}
+
+// DeleteAdjustmentReasonCode deactivates a reason code; history keeps referring to it
+func (s *AdjustmentReasonCodeService) DeleteAdjustmentReasonCode(ctx context.Context, id uuid.UUID) error {
+	return s.db.DeleteAdjustmentReasonCode(ctx, utils.UUIDToPgxUUID(id))
+}
+
+func toAdjustmentReasonCodeModel(r *sqlc.AdjustmentReasonCode) models.AdjustmentReasonCode {
+	return models.AdjustmentReasonCode{
+		ID:          utils.PgxUUIDToUUID(r.ID),
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		IsActive:    r.IsActive != nil && *r.IsActive,
+		CreatedAt:   utils.PgxTimestamptzToTime(r.CreatedAt),
+		UpdatedAt:   utils.PgxTimestamptzToTime(r.UpdatedAt),
+	}
+}
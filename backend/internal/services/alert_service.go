@@ -0,0 +1,515 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/config"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/notify"
+	"inventory-system/internal/utils"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// stockLevelChannel is the Postgres channel the stock_levels trigger
+// announces committed balance changes on
+const stockLevelChannel = "stock_level_changes"
+
+// rejectedPosting is an outgoing posting refused for insufficient stock
+type rejectedPosting struct {
+	ProductID   uuid.UUID
+	WarehouseID uuid.UUID
+	Requested   int32
+	Available   int32
+}
+
+// rejectedPostings carries refused postings from applyStockDelta to the
+// running alert evaluator. The refusing transaction rolls back, so the
+// attempt can only be recorded from outside it. Reports are dropped when
+// the buffer is full rather than holding up the caller.
+var rejectedPostings = make(chan rejectedPosting, 256)
+
+func reportRejectedPosting(productID, warehouseID uuid.UUID, requested, available int32) {
+	select {
+	case rejectedPostings <- rejectedPosting{productID, warehouseID, requested, available}:
+	default:
+	}
+}
+
+// AlertService raises stock alerts, delivers them through its notifiers and
+// serves the alert list and the in-app inbox
+type AlertService struct {
+	db        *database.DB
+	config    config.AlertConfig
+	notifiers []notify.Notifier
+}
+
+func NewAlertService(db *database.DB, cfg config.AlertConfig, notifiers ...notify.Notifier) *AlertService {
+	return &AlertService{
+		db:        db,
+		config:    cfg,
+		notifiers: notifiers,
+	}
+}
+
+// RunEvaluator evaluates each balance as its postings commit, every balance
+// each interval, and records refused postings, until ctx is done. All
+// evaluation happens on this goroutine.
+func (s *AlertService) RunEvaluator(ctx context.Context, interval time.Duration) {
+	changes := make(chan stockKey, 256)
+	go s.listenStockLevelChanges(ctx, changes)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if err := s.EvaluateStockLevels(ctx); err != nil {
+		log.Printf("Failed to evaluate stock alerts: %v", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case key := <-changes:
+			if err := s.evaluateStockLevel(ctx, key); err != nil {
+				log.Printf("Failed to evaluate stock alerts for product %s in warehouse %s: %v", key.ProductID, key.WarehouseID, err)
+			}
+		case rejected := <-rejectedPostings:
+			if err := s.recordRejectedPosting(ctx, rejected); err != nil {
+				log.Printf("Failed to record refused posting for product %s in warehouse %s: %v", rejected.ProductID, rejected.WarehouseID, err)
+			}
+		case <-ticker.C:
+			if err := s.EvaluateStockLevels(ctx); err != nil {
+				log.Printf("Failed to evaluate stock alerts: %v", err)
+			}
+		}
+	}
+}
+
+// listenStockLevelChanges feeds the balances announced on stockLevelChannel
+// into changes, reconnecting when the connection drops. Changes missed while
+// disconnected are picked up by the next full evaluation.
+func (s *AlertService) listenStockLevelChanges(ctx context.Context, changes chan<- stockKey) {
+	for ctx.Err() == nil {
+		if err := s.listen(ctx, changes); err != nil && ctx.Err() == nil {
+			log.Printf("Stock level listener stopped, reconnecting: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}
+}
+
+func (s *AlertService) listen(ctx context.Context, changes chan<- stockKey) error {
+	pooled, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection keeps listening, so it is taken out of the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+stockLevelChannel); err != nil {
+		return err
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		key, err := parseStockLevelChange(notification.Payload)
+		if err != nil {
+			log.Printf("Ignoring stock level change %q: %v", notification.Payload, err)
+			continue
+		}
+		select {
+		case changes <- key:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// parseStockLevelChange reads a "<product_id>:<warehouse_id>" payload
+func parseStockLevelChange(payload string) (stockKey, error) {
+	productID, warehouseID, ok := strings.Cut(payload, ":")
+	if !ok {
+		return stockKey{}, errors.New("payload is not product:warehouse")
+	}
+	product, err := uuid.Parse(productID)
+	if err != nil {
+		return stockKey{}, err
+	}
+	warehouse, err := uuid.Parse(warehouseID)
+	if err != nil {
+		return stockKey{}, err
+	}
+	return stockKey{ProductID: product, WarehouseID: warehouse}, nil
+}
+
+// EvaluateStockLevels checks every balance of an active product against its
+// levels, raising new alerts and resolving those whose condition has cleared
+func (s *AlertService) EvaluateStockLevels(ctx context.Context) error {
+	levels, err := s.db.ListStockAlertLevels(ctx, &sqlc.ListStockAlertLevelsParams{})
+	if err != nil {
+		return err
+	}
+	for _, level := range levels {
+		if err := s.evaluateLevel(ctx, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AlertService) evaluateStockLevel(ctx context.Context, key stockKey) error {
+	levels, err := s.db.ListStockAlertLevels(ctx, &sqlc.ListStockAlertLevelsParams{
+		Column1: utils.UUIDToPgxUUID(key.ProductID),
+		Column2: utils.UUIDToPgxUUID(key.WarehouseID),
+	})
+	if err != nil {
+		return err
+	}
+	for _, level := range levels {
+		if err := s.evaluateLevel(ctx, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluateLevel raises the alerts a balance calls for that are not already
+// unresolved, then resolves the unresolved ones it no longer calls for.
+// Acknowledged alerts stay unresolved while their condition lasts, so
+// acknowledging does not cause the alert to be raised again.
+func (s *AlertService) evaluateLevel(ctx context.Context, level *sqlc.ListStockAlertLevelsRow) error {
+	available := level.Quantity - level.ReservedQuantity
+	conditions := models.EvaluateStockAlerts(int(level.Quantity), int(available), int(level.MinStockLevel), utils.OptionalInt32PtrToInt(level.MaxStockLevel))
+
+	unresolved, err := s.db.ListUnresolvedStockAlertTypes(ctx, &sqlc.ListUnresolvedStockAlertTypesParams{
+		ProductID:   level.ProductID,
+		WarehouseID: level.WarehouseID,
+	})
+	if err != nil {
+		return err
+	}
+	active := make(map[string]bool, len(unresolved))
+	for _, alertType := range unresolved {
+		active[alertType] = true
+	}
+
+	current := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		current = append(current, condition.AlertType)
+		if active[condition.AlertType] {
+			continue
+		}
+
+		quantity := available
+		var message string
+		switch condition.AlertType {
+		case models.StockAlertTypeStockOut:
+			message = fmt.Sprintf("%s (%s) is out of stock in %s: %d available, minimum %d", level.ProductName, level.Sku, level.WarehouseName, available, level.MinStockLevel)
+		case models.StockAlertTypeLowStock:
+			message = fmt.Sprintf("%s (%s) is low in %s: %d available, minimum %d", level.ProductName, level.Sku, level.WarehouseName, available, condition.Threshold)
+		case models.StockAlertTypeOverMax:
+			quantity = level.Quantity
+			message = fmt.Sprintf("%s (%s) is over its maximum in %s: %d on hand, maximum %d", level.ProductName, level.Sku, level.WarehouseName, level.Quantity, condition.Threshold)
+		}
+
+		threshold := int32(condition.Threshold)
+		alert, err := s.db.CreateStockAlert(ctx, &sqlc.CreateStockAlertParams{
+			AlertType:   condition.AlertType,
+			ProductID:   level.ProductID,
+			WarehouseID: level.WarehouseID,
+			Quantity:    quantity,
+			Threshold:   &threshold,
+			Message:     message,
+			Column7:     int32(s.config.RealertWindow),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Already unresolved, or resolved within the re-alert window
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		s.deliver(ctx, alert, level.ProductName, level.Sku, level.WarehouseName)
+	}
+
+	return s.db.ResolveClearedStockAlerts(ctx, &sqlc.ResolveClearedStockAlertsParams{
+		ProductID:   level.ProductID,
+		WarehouseID: level.WarehouseID,
+		Column3:     current,
+	})
+}
+
+// recordRejectedPosting raises a negative attempt alert, or counts another
+// occurrence on the one that is still unresolved
+func (s *AlertService) recordRejectedPosting(ctx context.Context, rejected rejectedPosting) error {
+	product, err := s.db.GetProduct(ctx, utils.UUIDToPgxUUID(rejected.ProductID))
+	if err != nil {
+		return err
+	}
+	warehouse, err := s.db.GetWarehouse(ctx, utils.UUIDToPgxUUID(rejected.WarehouseID))
+	if err != nil {
+		return err
+	}
+
+	alert, err := s.db.CreateStockAlert(ctx, &sqlc.CreateStockAlertParams{
+		AlertType:   models.StockAlertTypeNegativeAttempt,
+		ProductID:   product.ID,
+		WarehouseID: warehouse.ID,
+		Quantity:    rejected.Available,
+		Message:     fmt.Sprintf("Posting of %d %s (%s) out of %s was refused: %d available", rejected.Requested, product.Name, product.Sku, warehouse.Name, rejected.Available),
+		Column7:     int32(s.config.RealertWindow),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.db.RepeatStockAlert(ctx, &sqlc.RepeatStockAlertParams{
+			AlertType:   models.StockAlertTypeNegativeAttempt,
+			ProductID:   product.ID,
+			WarehouseID: warehouse.ID,
+			Quantity:    rejected.Available,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	s.deliver(ctx, alert, product.Name, product.Sku, warehouse.Name)
+	return nil
+}
+
+// deliver hands a new alert to every notifier in the background so a slow
+// channel cannot hold up evaluation. Failures are logged; the alert itself
+// is already recorded.
+func (s *AlertService) deliver(ctx context.Context, alert *sqlc.StockAlert, productName, sku, warehouseName string) {
+	result := toStockAlertModel(alert)
+	result.ProductName = &productName
+	result.ProductSKU = &sku
+	result.WarehouseName = &warehouseName
+
+	for _, notifier := range s.notifiers {
+		go func(notifier notify.Notifier) {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+			defer cancel()
+			if err := notifier.Notify(ctx, result); err != nil {
+				log.Printf("Failed to deliver stock alert %s by %s: %v", result.ID, notifier.Name(), err)
+			}
+		}(notifier)
+	}
+}
+
+// GetStockAlert returns an alert with its product and warehouse names
+func (s *AlertService) GetStockAlert(ctx context.Context, id uuid.UUID) (*models.StockAlert, error) {
+	row, err := s.db.GetStockAlert(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toStockAlertModel(&sqlc.StockAlert{
+		ID:              row.ID,
+		AlertType:       row.AlertType,
+		Status:          row.Status,
+		ProductID:       row.ProductID,
+		WarehouseID:     row.WarehouseID,
+		Quantity:        row.Quantity,
+		Threshold:       row.Threshold,
+		Message:         row.Message,
+		Occurrences:     row.Occurrences,
+		LastTriggeredAt: row.LastTriggeredAt,
+		AcknowledgedBy:  row.AcknowledgedBy,
+		AcknowledgedAt:  row.AcknowledgedAt,
+		ResolvedAt:      row.ResolvedAt,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	})
+	result.ProductName = &row.ProductName
+	result.ProductSKU = &row.Sku
+	result.WarehouseName = &row.WarehouseName
+	return &result, nil
+}
+
+// ListStockAlerts lists alerts, most recently triggered first
+func (s *AlertService) ListStockAlerts(ctx context.Context, filter models.StockAlertFilter) (*models.StockAlertListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListStockAlertsWithFilter(ctx, &sqlc.ListStockAlertsWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalStringToString(filter.AlertType),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column4: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountStockAlertsWithFilter(ctx, &sqlc.CountStockAlertsWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalStringToString(filter.AlertType),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column4: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockAlert, len(rows))
+	for i, row := range rows {
+		result[i] = toStockAlertModel(&sqlc.StockAlert{
+			ID:              row.ID,
+			AlertType:       row.AlertType,
+			Status:          row.Status,
+			ProductID:       row.ProductID,
+			WarehouseID:     row.WarehouseID,
+			Quantity:        row.Quantity,
+			Threshold:       row.Threshold,
+			Message:         row.Message,
+			Occurrences:     row.Occurrences,
+			LastTriggeredAt: row.LastTriggeredAt,
+			AcknowledgedBy:  row.AcknowledgedBy,
+			AcknowledgedAt:  row.AcknowledgedAt,
+			ResolvedAt:      row.ResolvedAt,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+		})
+		result[i].ProductName = &row.ProductName
+		result[i].ProductSKU = &row.Sku
+		result[i].WarehouseName = &row.WarehouseName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.StockAlertListResponse{
+		StockAlerts: result,
+		Total:       total,
+		Page:        filter.Page,
+		Limit:       filter.Limit,
+		Pages:       pages,
+	}, nil
+}
+
+// AcknowledgeStockAlert records that someone is dealing with an open alert
+func (s *AlertService) AcknowledgeStockAlert(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.StockAlert, error) {
+	alert, err := s.GetStockAlert(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Status != models.StockAlertStatusOpen {
+		return nil, fmt.Errorf("%w: alert is %s", ErrInvalidStatusTransition, alert.Status)
+	}
+
+	if _, err := s.db.AcknowledgeStockAlert(ctx, &sqlc.AcknowledgeStockAlertParams{
+		ID:             utils.UUIDToPgxUUID(id),
+		Status:         models.AcknowledgedAlertStatus(alert.AlertType),
+		AcknowledgedBy: utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: alert is no longer open", ErrInvalidStatusTransition)
+		}
+		return nil, err
+	}
+
+	return s.GetStockAlert(ctx, id)
+}
+
+// ListNotifications returns a page of the user's inbox, newest first
+func (s *AlertService) ListNotifications(ctx context.Context, userID uuid.UUID, filter models.UserNotificationFilter) (*models.UserNotificationListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListUserNotifications(ctx, &sqlc.ListUserNotificationsParams{
+		UserID:  utils.UUIDToPgxUUID(userID),
+		Column2: filter.UnreadOnly,
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountUserNotifications(ctx, &sqlc.CountUserNotificationsParams{
+		UserID:  utils.UUIDToPgxUUID(userID),
+		Column2: filter.UnreadOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.db.CountUnreadUserNotifications(ctx, utils.UUIDToPgxUUID(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.UserNotification, len(rows))
+	for i, row := range rows {
+		result[i] = toUserNotificationModel(row)
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.UserNotificationListResponse{
+		Notifications: result,
+		Unread:        unread,
+		Total:         total,
+		Page:          filter.Page,
+		Limit:         filter.Limit,
+		Pages:         pages,
+	}, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications read
+func (s *AlertService) MarkNotificationRead(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.UserNotification, error) {
+	row, err := s.db.MarkUserNotificationRead(ctx, &sqlc.MarkUserNotificationReadParams{
+		ID:     utils.UUIDToPgxUUID(id),
+		UserID: utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toUserNotificationModel(row)
+	return &result, nil
+}
+
+func (s *AlertService) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	return s.db.MarkAllUserNotificationsRead(ctx, utils.UUIDToPgxUUID(userID))
+}
+
+func toStockAlertModel(a *sqlc.StockAlert) models.StockAlert {
+	return models.StockAlert{
+		ID:              utils.PgxUUIDToUUID(a.ID),
+		AlertType:       a.AlertType,
+		Status:          a.Status,
+		ProductID:       utils.PgxUUIDToUUID(a.ProductID),
+		WarehouseID:     utils.PgxUUIDToUUID(a.WarehouseID),
+		Quantity:        int(a.Quantity),
+		Threshold:       utils.OptionalInt32PtrToInt(a.Threshold),
+		Message:         a.Message,
+		Occurrences:     int(a.Occurrences),
+		LastTriggeredAt: utils.PgxTimestamptzToTime(a.LastTriggeredAt),
+		AcknowledgedBy:  utils.OptionalPgxUUIDToUUID(a.AcknowledgedBy),
+		AcknowledgedAt:  utils.OptionalPgxTimestamptzToTimePtr(a.AcknowledgedAt),
+		ResolvedAt:      utils.OptionalPgxTimestamptzToTimePtr(a.ResolvedAt),
+		CreatedAt:       utils.PgxTimestamptzToTime(a.CreatedAt),
+		UpdatedAt:       utils.PgxTimestamptzToTime(a.UpdatedAt),
+	}
+}
+
+func toUserNotificationModel(n *sqlc.UserNotification) models.UserNotification {
+	return models.UserNotification{
+		ID:           utils.PgxUUIDToUUID(n.ID),
+		UserID:       utils.PgxUUIDToUUID(n.UserID),
+		StockAlertID: utils.OptionalPgxUUIDToUUID(n.StockAlertID),
+		Title:        n.Title,
+		Message:      n.Message,
+		ReadAt:       utils.OptionalPgxTimestamptzToTimePtr(n.ReadAt),
+		CreatedAt:    utils.PgxTimestamptzToTime(n.CreatedAt),
+	}
+}
@@ -0,0 +1,538 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/costing"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AssemblyService manages kits: products whose bill of materials lists the
+// component products they are made of. Assembly orders consume components
+// and produce kits at the cost of the components issued; disassembly orders
+// do the reverse, spreading the kit's issue cost over the components in
+// proportion to their current unit costs.
+type AssemblyService struct {
+	db *database.DB
+}
+
+func NewAssemblyService(db *database.DB) *AssemblyService {
+	return &AssemblyService{db: db}
+}
+
+// GetBOM returns a kit's components with the cost of one kit rolled up from
+// their current unit costs
+func (s *AssemblyService) GetBOM(ctx context.Context, productID uuid.UUID) (*models.BillOfMaterials, error) {
+	if _, err := s.db.GetProduct(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+	return getBOM(ctx, s.db.Queries, productID)
+}
+
+// SetBOM replaces a kit's components. A product cannot end up among its own
+// components through another kit.
+func (s *AssemblyService) SetBOM(ctx context.Context, productID uuid.UUID, req models.SetBOMRequest) (*models.BillOfMaterials, error) {
+	if err := models.ValidateBOM(productID, req.Components); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	if _, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+	if err := q.DeleteBOMComponents(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+
+	for _, component := range req.Components {
+		product, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(component.ComponentID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("component product %s not found", component.ComponentID)
+			}
+			return nil, err
+		}
+
+		descendants, err := q.ListBOMDescendants(ctx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, descendant := range descendants {
+			if utils.PgxUUIDToUUID(descendant) == productID {
+				return nil, fmt.Errorf("%s (%s) is made from this product and cannot be one of its components", product.Name, product.Sku)
+			}
+		}
+
+		if _, err := q.CreateBOMComponent(ctx, &sqlc.CreateBOMComponentParams{
+			ProductID:   utils.UUIDToPgxUUID(productID),
+			ComponentID: product.ID,
+			Quantity:    int32(component.Quantity),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return getBOM(ctx, s.db.Queries, productID)
+}
+
+// GetKitAvailability returns how many kits each active warehouse, or just the
+// given one, can build from the available stock of their components. Only
+// components on hand count; a component that is itself a kit counts its own
+// stock, not what could be built of it.
+func (s *AssemblyService) GetKitAvailability(ctx context.Context, productID uuid.UUID, warehouseID *uuid.UUID) ([]models.KitAvailability, error) {
+	if _, err := s.db.GetProduct(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.ListKitComponentStock(ctx, &sqlc.ListKitComponentStockParams{
+		ProductID: utils.UUIDToPgxUUID(productID),
+		Column2:   utils.OptionalUUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.KitAvailability{}
+	for _, row := range rows {
+		id := utils.PgxUUIDToUUID(row.WarehouseID)
+		if len(result) == 0 || result[len(result)-1].WarehouseID != id {
+			result = append(result, models.KitAvailability{
+				WarehouseID:   id,
+				WarehouseName: row.WarehouseName,
+			})
+		}
+		availability := &result[len(result)-1]
+		availability.Components = append(availability.Components, models.KitComponentStock{
+			ComponentID:   utils.PgxUUIDToUUID(row.ComponentID),
+			QuantityPer:   int(row.QuantityPer),
+			Available:     int(row.OnHand - row.Reserved),
+			ComponentName: &row.ComponentName,
+			ComponentSKU:  &row.ComponentSku,
+		})
+	}
+
+	for i := range result {
+		buildable, limiting := models.BuildableKits(result[i].Components)
+		result[i].Buildable = buildable
+		if limiting >= 0 {
+			result[i].LimitingComponentID = &result[i].Components[limiting].ComponentID
+		}
+	}
+	return result, nil
+}
+
+// CreateAssemblyOrder drafts an assembly or disassembly order, copying the
+// kit's current bill of materials onto it
+func (s *AssemblyService) CreateAssemblyOrder(ctx context.Context, req models.CreateAssemblyOrderRequest, userID uuid.UUID) (*models.AssemblyOrder, error) {
+	if req.OrderType == "" {
+		req.OrderType = models.AssemblyOrderTypeAssembly
+	}
+	if req.OrderType != models.AssemblyOrderTypeAssembly && req.OrderType != models.AssemblyOrderTypeDisassembly {
+		return nil, errors.New("order type must be assembly or disassembly")
+	}
+	if req.ProductID == uuid.Nil || req.WarehouseID == uuid.Nil {
+		return nil, errors.New("product and warehouse are required")
+	}
+	if req.Quantity <= 0 {
+		return nil, errors.New("quantity must be greater than zero")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	if _, err := q.GetWarehouse(ctx, utils.UUIDToPgxUUID(req.WarehouseID)); err != nil {
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	components, err := q.ListBOMComponents(ctx, utils.UUIDToPgxUUID(req.ProductID))
+	if err != nil {
+		return nil, err
+	}
+	if len(components) == 0 {
+		return nil, errors.New("product has no bill of materials")
+	}
+
+	order, err := q.CreateAssemblyOrder(ctx, &sqlc.CreateAssemblyOrderParams{
+		OrderNumber: fmt.Sprintf("ASM-%d", time.Now().UnixMilli()),
+		OrderType:   req.OrderType,
+		ProductID:   utils.UUIDToPgxUUID(req.ProductID),
+		WarehouseID: utils.UUIDToPgxUUID(req.WarehouseID),
+		Quantity:    int32(req.Quantity),
+		Notes:       req.Notes,
+		CreatedBy:   utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, component := range components {
+		if _, err := q.CreateAssemblyOrderComponent(ctx, &sqlc.CreateAssemblyOrderComponentParams{
+			AssemblyOrderID: order.ID,
+			ComponentID:     component.ComponentID,
+			QuantityPer:     component.Quantity,
+			Quantity:        component.Quantity * int32(req.Quantity),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetAssemblyOrder(ctx, utils.PgxUUIDToUUID(order.ID))
+}
+
+func (s *AssemblyService) GetAssemblyOrder(ctx context.Context, id uuid.UUID) (*models.AssemblyOrder, error) {
+	row, err := s.db.GetAssemblyOrder(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := s.db.ListAssemblyOrderComponents(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toAssemblyOrderModel(&sqlc.AssemblyOrder{
+		ID:          row.ID,
+		OrderNumber: row.OrderNumber,
+		OrderType:   row.OrderType,
+		ProductID:   row.ProductID,
+		WarehouseID: row.WarehouseID,
+		Quantity:    row.Quantity,
+		Status:      row.Status,
+		UnitCost:    row.UnitCost,
+		TotalCost:   row.TotalCost,
+		Notes:       row.Notes,
+		CreatedBy:   row.CreatedBy,
+		CompletedBy: row.CompletedBy,
+		CompletedAt: row.CompletedAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	})
+	result.ProductName = &row.ProductName
+	result.ProductSKU = &row.Sku
+	result.WarehouseName = &row.WarehouseName
+	result.Components = make([]models.AssemblyOrderLine, len(components))
+	for i, component := range components {
+		result.Components[i] = models.AssemblyOrderLine{
+			ID:            utils.PgxUUIDToUUID(component.ID),
+			ComponentID:   utils.PgxUUIDToUUID(component.ComponentID),
+			QuantityPer:   int(component.QuantityPer),
+			Quantity:      int(component.Quantity),
+			UnitCost:      utils.OptionalPgxNumericToFloat64Ptr(component.UnitCost),
+			TotalCost:     utils.OptionalPgxNumericToFloat64Ptr(component.TotalCost),
+			ComponentName: &component.ComponentName,
+			ComponentSKU:  &component.ComponentSku,
+		}
+	}
+
+	return &result, nil
+}
+
+func (s *AssemblyService) ListAssemblyOrders(ctx context.Context, filter models.AssemblyOrderFilter) (*models.AssemblyOrderListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListAssemblyOrdersWithFilter(ctx, &sqlc.ListAssemblyOrdersWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalStringToString(filter.OrderType),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column4: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountAssemblyOrdersWithFilter(ctx, &sqlc.CountAssemblyOrdersWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalStringToString(filter.OrderType),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column4: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.AssemblyOrder, len(rows))
+	for i, row := range rows {
+		result[i] = toAssemblyOrderModel(&sqlc.AssemblyOrder{
+			ID:          row.ID,
+			OrderNumber: row.OrderNumber,
+			OrderType:   row.OrderType,
+			ProductID:   row.ProductID,
+			WarehouseID: row.WarehouseID,
+			Quantity:    row.Quantity,
+			Status:      row.Status,
+			UnitCost:    row.UnitCost,
+			TotalCost:   row.TotalCost,
+			Notes:       row.Notes,
+			CreatedBy:   row.CreatedBy,
+			CompletedBy: row.CompletedBy,
+			CompletedAt: row.CompletedAt,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		result[i].ProductName = &row.ProductName
+		result[i].ProductSKU = &row.Sku
+		result[i].WarehouseName = &row.WarehouseName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.AssemblyOrderListResponse{
+		AssemblyOrders: result,
+		Total:          total,
+		Page:           filter.Page,
+		Limit:          filter.Limit,
+		Pages:          pages,
+	}, nil
+}
+
+// CompleteAssemblyOrder posts a draft order in one transaction: every
+// component and the kit move, or none do. Assembly issues the components
+// first and receives the kits at the cost they were issued at; disassembly
+// issues the kits and receives the components at their share of that cost.
+func (s *AssemblyService) CompleteAssemblyOrder(ctx context.Context, id uuid.UUID, req models.CompleteAssemblyOrderRequest, userID uuid.UUID) (*models.AssemblyOrder, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := lockDraftAssemblyOrder(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	components, err := q.ListAssemblyOrderComponents(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	productID := utils.PgxUUIDToUUID(order.ProductID)
+	warehouseID := utils.PgxUUIDToUUID(order.WarehouseID)
+	keys := []stockKey{{ProductID: productID, WarehouseID: warehouseID}}
+	for _, component := range components {
+		keys = append(keys, stockKey{ProductID: utils.PgxUUIDToUUID(component.ComponentID), WarehouseID: warehouseID})
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	orderID := utils.PgxUUIDToUUID(order.ID)
+	referenceType := models.AssemblyOrderReferenceType
+	posting := stockPosting{
+		WarehouseID:     warehouseID,
+		ReferenceType:   &referenceType,
+		ReferenceID:     &orderID,
+		ReferenceNumber: &order.OrderNumber,
+		UserID:          &userID,
+		ProcessedDate:   time.Now(),
+	}
+	post := func(productID uuid.UUID, movementType string, quantity int, costPrice *float64, name, sku string) (*sqlc.StockMovement, error) {
+		p := posting
+		p.ProductID = productID
+		p.MovementType = movementType
+		p.Quantity = quantity
+		p.CostPrice = costPrice
+		p.Serials = req.SerialNumbers[productID]
+		movement, err := postStockMovement(ctx, q, p)
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, fmt.Errorf("%w for %s (%s)", ErrInsufficientStock, name, sku)
+		}
+		return movement, err
+	}
+
+	product, err := q.GetProduct(ctx, order.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	componentCosts := make([]float64, len(components))
+	var totalCost float64
+	if order.OrderType == models.AssemblyOrderTypeAssembly {
+		for i, component := range components {
+			movement, err := post(utils.PgxUUIDToUUID(component.ComponentID), "out", int(component.Quantity), nil, component.ComponentName, component.ComponentSku)
+			if err != nil {
+				return nil, err
+			}
+			componentCosts[i] = utils.PgxNumericToFloat64(movement.TotalCost)
+			totalCost += componentCosts[i]
+		}
+		unitCost := totalCost / float64(order.Quantity)
+		if _, err := post(productID, "in", int(order.Quantity), &unitCost, product.Name, product.Sku); err != nil {
+			return nil, err
+		}
+	} else {
+		movement, err := post(productID, "out", int(order.Quantity), nil, product.Name, product.Sku)
+		if err != nil {
+			return nil, err
+		}
+		totalCost = utils.PgxNumericToFloat64(movement.TotalCost)
+
+		weights := make([]float64, len(components))
+		for i, component := range components {
+			unitCost, err := currentUnitCost(ctx, q, component.ComponentID)
+			if err != nil {
+				return nil, err
+			}
+			weights[i] = unitCost * float64(component.Quantity)
+		}
+		componentCosts = costing.Allocate(totalCost, weights)
+		for i, component := range components {
+			unitCost := componentCosts[i] / float64(component.Quantity)
+			if _, err := post(utils.PgxUUIDToUUID(component.ComponentID), "in", int(component.Quantity), &unitCost, component.ComponentName, component.ComponentSku); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i, component := range components {
+		if err := q.UpdateAssemblyOrderComponentCost(ctx, &sqlc.UpdateAssemblyOrderComponentCostParams{
+			ID:        component.ID,
+			UnitCost:  utils.CostToPgxNumeric(costing.Round(componentCosts[i] / float64(component.Quantity))),
+			TotalCost: utils.CostToPgxNumeric(costing.Round(componentCosts[i])),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := q.MarkAssemblyOrderCompleted(ctx, &sqlc.MarkAssemblyOrderCompletedParams{
+		ID:          order.ID,
+		UnitCost:    utils.CostToPgxNumeric(costing.Round(totalCost / float64(order.Quantity))),
+		TotalCost:   utils.CostToPgxNumeric(costing.Round(totalCost)),
+		CompletedBy: utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetAssemblyOrder(ctx, id)
+}
+
+// CancelAssemblyOrder cancels a draft order; completed orders have moved stock
+func (s *AssemblyService) CancelAssemblyOrder(ctx context.Context, id uuid.UUID) (*models.AssemblyOrder, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := lockDraftAssemblyOrder(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := q.UpdateAssemblyOrderStatus(ctx, &sqlc.UpdateAssemblyOrderStatusParams{
+		ID:     order.ID,
+		Status: models.AssemblyOrderStatusCancelled,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetAssemblyOrder(ctx, id)
+}
+
+// lockDraftAssemblyOrder locks an assembly order and checks it is still a draft
+func lockDraftAssemblyOrder(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (*sqlc.AssemblyOrder, error) {
+	order, err := q.GetAssemblyOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.AssemblyOrderStatusDraft {
+		return nil, fmt.Errorf("%w: the assembly order is already %s", ErrInvalidStatusTransition, order.Status)
+	}
+	return order, nil
+}
+
+// currentUnitCost returns a product's unit cost from its cost position, or
+// zero when it has never been costed
+func currentUnitCost(ctx context.Context, q *sqlc.Queries, productID pgtype.UUID) (float64, error) {
+	cost, err := q.GetProductCost(ctx, productID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return utils.PgxNumericToFloat64(cost.UnitCost), nil
+}
+
+func getBOM(ctx context.Context, q *sqlc.Queries, productID uuid.UUID) (*models.BillOfMaterials, error) {
+	rows, err := q.ListBOMComponents(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BillOfMaterials{
+		ProductID:  productID,
+		Components: make([]models.BOMComponent, len(rows)),
+	}
+	for i, row := range rows {
+		result.Components[i] = models.BOMComponent{
+			ID:            utils.PgxUUIDToUUID(row.ID),
+			ProductID:     utils.PgxUUIDToUUID(row.ProductID),
+			ComponentID:   utils.PgxUUIDToUUID(row.ComponentID),
+			Quantity:      int(row.Quantity),
+			UnitCost:      utils.PgxNumericToFloat64(row.UnitCost),
+			CreatedAt:     utils.PgxTimestamptzToTime(row.CreatedAt),
+			UpdatedAt:     utils.PgxTimestamptzToTime(row.UpdatedAt),
+			ComponentName: &row.ComponentName,
+			ComponentSKU:  &row.ComponentSku,
+		}
+		result.UnitCost += float64(row.Quantity) * result.Components[i].UnitCost
+	}
+	result.UnitCost = costing.Round(result.UnitCost)
+	return result, nil
+}
+
+func toAssemblyOrderModel(o *sqlc.AssemblyOrder) models.AssemblyOrder {
+	return models.AssemblyOrder{
+		ID:          utils.PgxUUIDToUUID(o.ID),
+		OrderNumber: o.OrderNumber,
+		OrderType:   o.OrderType,
+		ProductID:   utils.PgxUUIDToUUID(o.ProductID),
+		WarehouseID: utils.PgxUUIDToUUID(o.WarehouseID),
+		Quantity:    int(o.Quantity),
+		Status:      o.Status,
+		UnitCost:    utils.OptionalPgxNumericToFloat64Ptr(o.UnitCost),
+		TotalCost:   utils.OptionalPgxNumericToFloat64Ptr(o.TotalCost),
+		Notes:       o.Notes,
+		CreatedBy:   utils.PgxUUIDToUUID(o.CreatedBy),
+		CompletedBy: utils.OptionalPgxUUIDToUUID(o.CompletedBy),
+		CompletedAt: utils.OptionalPgxTimestamptzToTimePtr(o.CompletedAt),
+		CreatedAt:   utils.PgxTimestamptzToTime(o.CreatedAt),
+		UpdatedAt:   utils.PgxTimestamptzToTime(o.UpdatedAt),
+	}
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/costing"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CostingService struct {
+	db *database.DB
+}
+
+func NewCostingService(db *database.DB) *CostingService {
+	return &CostingService{db: db}
+}
+
+// GetCostingSettings returns the company-wide costing method
+func (s *CostingService) GetCostingSettings(ctx context.Context) (*models.CostingSettings, error) {
+	settings, err := s.db.GetCostingSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := toCostingSettingsModel(settings)
+	return &result, nil
+}
+
+// UpdateCostingMethod switches the company to another costing method and
+// recosts every product's movements under it from the end of the latest
+// closed accounting period; movements in closed periods keep their costs
+func (s *CostingService) UpdateCostingMethod(ctx context.Context, req models.UpdateCostingMethodRequest, userID uuid.UUID) (*models.CostingSettings, error) {
+	if !costing.ValidMethod(req.Method) {
+		return nil, fmt.Errorf("unknown costing method %q", req.Method)
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	// The settings row stays locked until commit, so postings queue behind the
+	// recost instead of costing under the old method
+	settings, err := q.UpdateCostingMethod(ctx, &sqlc.UpdateCostingMethodParams{
+		Method:    req.Method,
+		UpdatedBy: utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs, err := q.ListCostedProductIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, productID := range productIDs {
+		if _, _, err := lockProductCost(ctx, q, utils.PgxUUIDToUUID(productID)); err != nil {
+			return nil, err
+		}
+		if _, err := recostProduct(ctx, q, utils.PgxUUIDToUUID(productID), req.Method); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result := toCostingSettingsModel(settings)
+	return &result, nil
+}
+
+// GetProductCost returns a product's current unit cost and on-hand value,
+// with its open cost layers under FIFO
+func (s *CostingService) GetProductCost(ctx context.Context, productID uuid.UUID) (*models.ProductCost, error) {
+	row, err := s.db.GetProductCost(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := s.db.ListCostLayers(ctx, row.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ProductCost{
+		ProductID:     utils.PgxUUIDToUUID(row.ProductID),
+		Method:        row.Method,
+		Quantity:      int(row.Quantity),
+		TotalValue:    utils.PgxNumericToFloat64(row.TotalValue),
+		UnitCost:      utils.PgxNumericToFloat64(row.UnitCost),
+		CostedThrough: utils.OptionalPgxTimestamptzToTimePtr(row.CostedThrough),
+		UpdatedAt:     utils.PgxTimestamptzToTime(row.UpdatedAt),
+		Layers:        make([]models.CostLayer, len(layers)),
+		ProductName:   row.ProductName,
+		ProductSKU:    row.Sku,
+	}
+	for i, layer := range layers {
+		result.Layers[i] = models.CostLayer{
+			ID:                utils.PgxUUIDToUUID(layer.ID),
+			StockMovementID:   utils.OptionalPgxUUIDToUUID(layer.StockMovementID),
+			ReceivedDate:      utils.PgxTimestamptzToTime(layer.ReceivedDate),
+			Quantity:          int(layer.Quantity),
+			RemainingQuantity: int(layer.RemainingQuantity),
+			UnitCost:          utils.PgxNumericToFloat64(layer.UnitCost),
+		}
+	}
+	return result, nil
+}
+
+// costStockMovement values a freshly written movement and stamps its cost on
+// it. A movement processed before the latest one already costed for the
+// product, or a change of costing method, rebuilds the product's position
+// with recostProduct so every later issue is revalued.
+func costStockMovement(ctx context.Context, q *sqlc.Queries, m *sqlc.StockMovement) error {
+	productID := utils.PgxUUIDToUUID(m.ProductID)
+	current, method, err := lockProductCost(ctx, q, productID)
+	if err != nil {
+		return err
+	}
+
+	processedAt := movementProcessedAt(m)
+	if current.Method != method || (current.CostedThrough.Valid && processedAt.Before(current.CostedThrough.Time)) {
+		movements, err := recostProduct(ctx, q, productID, method)
+		if err != nil {
+			return err
+		}
+		for _, movement := range movements {
+			if movement.ID == m.ID {
+				m.UnitCost, m.TotalCost = movement.UnitCost, movement.TotalCost
+			}
+		}
+		return nil
+	}
+
+	position, err := loadPosition(ctx, q, current)
+	if err != nil {
+		return err
+	}
+	if err := valueMovement(ctx, q, position, m); err != nil {
+		return err
+	}
+	return savePosition(ctx, q, productID, position, processedAt)
+}
+
+// recostProduct replays the movements of a product in processed order,
+// restamping their costs and rebuilding its position and layers. Movements
+// before the end of the latest closed accounting period keep the costs the
+// period was closed with: the replay opens with the product's balance
+// recorded at that end and only restamps the movements since. The caller
+// must hold the product cost lock.
+func recostProduct(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, method string) ([]*sqlc.StockMovement, error) {
+	position := costing.NewPosition(method)
+	var costedThrough time.Time
+	from := pgtype.Timestamptz{}
+
+	// The period stays share-locked so it cannot be reopened, and its
+	// balances dropped, during the recost
+	period, err := q.GetLatestClosedAccountingPeriod(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		opening, err := q.GetAccountingPeriodProductBalance(ctx, &sqlc.GetAccountingPeriodProductBalanceParams{
+			PeriodID:  period.ID,
+			ProductID: utils.UUIDToPgxUUID(productID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		position.Open(period.EndsAt.Time, int(opening.Quantity), utils.PgxNumericToFloat64(opening.TotalValue))
+		costedThrough = period.EndsAt.Time
+		from = period.EndsAt
+	}
+
+	movements, err := q.ListCostingMovements(ctx, &sqlc.ListCostingMovementsParams{
+		ProductID: utils.UUIDToPgxUUID(productID),
+		Column2:   from,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range movements {
+		if err := valueMovement(ctx, q, position, m); err != nil {
+			return nil, err
+		}
+		if processedAt := movementProcessedAt(m); processedAt.After(costedThrough) {
+			costedThrough = processedAt
+		}
+	}
+
+	if err := savePosition(ctx, q, productID, position, costedThrough); err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// valueMovement applies one movement to the position and stamps the unit and
+// total cost it was valued at. Issues take their cost from the position;
+// receipts are valued at their cost price, at the cost the stock left the
+// source warehouse for transfers, or at the current unit cost otherwise.
+func valueMovement(ctx context.Context, q *sqlc.Queries, position *costing.Position, m *sqlc.StockMovement) error {
+	quantity := int(m.Quantity)
+	if m.MovementType == "out" {
+		quantity = -quantity
+	} else if m.MovementType != "in" && m.MovementType != "adjustment" {
+		quantity = 0
+	}
+
+	unitCost := position.UnitCost
+	switch {
+	case quantity < 0:
+		unitCost = position.Issue(-quantity) / float64(-quantity)
+	case m.CostPrice.Valid:
+		unitCost = utils.PgxNumericToFloat64(m.CostPrice)
+	case models.IsTransferReference(m.ReferenceType) && m.ReferenceID.Valid:
+		issued, err := q.GetTransferIssueCost(ctx, &sqlc.GetTransferIssueCostParams{
+			ReferenceType: m.ReferenceType,
+			ReferenceID:   m.ReferenceID,
+			ProductID:     m.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+		if issued.Quantity > 0 {
+			unitCost = utils.PgxNumericToFloat64(issued.TotalCost) / float64(issued.Quantity)
+		}
+	}
+	if quantity > 0 {
+		position.Receive(utils.PgxUUIDToUUID(m.ID), movementProcessedAt(m), quantity, unitCost)
+	}
+
+	m.UnitCost = utils.CostToPgxNumeric(costing.Round(unitCost))
+	m.TotalCost = utils.CostToPgxNumeric(costing.Round(unitCost * float64(m.Quantity)))
+	return q.UpdateStockMovementCost(ctx, &sqlc.UpdateStockMovementCostParams{
+		ID:        m.ID,
+		UnitCost:  m.UnitCost,
+		TotalCost: m.TotalCost,
+	})
+}
+
+// lockProductCost returns the product's cost position locked FOR UPDATE,
+// creating it on first use, together with the company costing method. The
+// settings row is share-locked first so a change of method waits for
+// in-flight postings and they in turn wait for its recost.
+func lockProductCost(ctx context.Context, q *sqlc.Queries, productID uuid.UUID) (*sqlc.ProductCost, string, error) {
+	settings, err := q.GetCostingSettingsForShare(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := q.EnsureProductCost(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, "", err
+	}
+	current, err := q.GetProductCostForUpdate(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return nil, "", err
+	}
+	return current, settings.Method, nil
+}
+
+// loadPosition rebuilds the in-memory position from its stored totals and
+// open layers
+func loadPosition(ctx context.Context, q *sqlc.Queries, current *sqlc.ProductCost) (*costing.Position, error) {
+	position := &costing.Position{
+		Method:   current.Method,
+		Quantity: int(current.Quantity),
+		Value:    utils.PgxNumericToFloat64(current.TotalValue),
+		UnitCost: utils.PgxNumericToFloat64(current.UnitCost),
+	}
+	if current.Method != costing.MethodFIFO {
+		return position, nil
+	}
+
+	layers, err := q.ListCostLayers(ctx, current.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	for _, layer := range layers {
+		position.Layers = append(position.Layers, costing.Layer{
+			MovementID: utils.PgxUUIDToUUID(layer.StockMovementID),
+			ReceivedAt: utils.PgxTimestamptzToTime(layer.ReceivedDate),
+			Quantity:   int(layer.Quantity),
+			Remaining:  int(layer.RemainingQuantity),
+			UnitCost:   utils.PgxNumericToFloat64(layer.UnitCost),
+		})
+	}
+	return position, nil
+}
+
+// savePosition writes the position and replaces the product's open layers
+func savePosition(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, position *costing.Position, costedThrough time.Time) error {
+	if err := q.DeleteCostLayers(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return err
+	}
+	for _, layer := range position.Layers {
+		movementID := pgtype.UUID{}
+		if layer.MovementID != uuid.Nil {
+			movementID = utils.UUIDToPgxUUID(layer.MovementID)
+		}
+		if err := q.CreateCostLayer(ctx, &sqlc.CreateCostLayerParams{
+			ProductID:         utils.UUIDToPgxUUID(productID),
+			StockMovementID:   movementID,
+			ReceivedDate:      utils.TimeToPgxTimestamptz(layer.ReceivedAt),
+			Quantity:          int32(layer.Quantity),
+			RemainingQuantity: int32(layer.Remaining),
+			UnitCost:          utils.CostToPgxNumeric(layer.UnitCost),
+		}); err != nil {
+			return err
+		}
+	}
+
+	through := pgtype.Timestamptz{}
+	if !costedThrough.IsZero() {
+		through = utils.TimeToPgxTimestamptz(costedThrough)
+	}
+	_, err := q.UpdateProductCost(ctx, &sqlc.UpdateProductCostParams{
+		ProductID:     utils.UUIDToPgxUUID(productID),
+		Method:        position.Method,
+		Quantity:      int32(position.Quantity),
+		TotalValue:    utils.CostToPgxNumeric(position.Value),
+		UnitCost:      utils.CostToPgxNumeric(position.UnitCost),
+		CostedThrough: through,
+	})
+	return err
+}
+
+// movementProcessedAt is the date a movement is costed at: when it was
+// processed, falling back to when it was recorded
+func movementProcessedAt(m *sqlc.StockMovement) time.Time {
+	if m.ProcessedDate.Valid {
+		return m.ProcessedDate.Time
+	}
+	return m.CreatedAt.Time
+}
+
+func toCostingSettingsModel(settings *sqlc.CostingSetting) models.CostingSettings {
+	return models.CostingSettings{
+		Method:    settings.Method,
+		UpdatedBy: utils.OptionalPgxUUIDToUUID(settings.UpdatedBy),
+		UpdatedAt: utils.PgxTimestamptzToTime(settings.UpdatedAt),
+	}
+}
@@ -0,0 +1,17 @@
+package services
+
+import "errors"
+
+var (
+	// ErrInsufficientStock is returned when a posting would take a balance below zero
+	ErrInsufficientStock = errors.New("insufficient stock")
+
+	// ErrInvalidStatusTransition is returned when a document cannot move to the requested status
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// ErrInsufficientRole is returned when the acting user's role is too low for the action
+	ErrInsufficientRole = errors.New("insufficient role")
+
+	// ErrPeriodClosed is returned when a movement is dated inside or before a closed accounting period
+	ErrPeriodClosed = errors.New("accounting period is closed")
+)
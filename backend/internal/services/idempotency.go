@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Endpoints that accept an Idempotency-Key
+const (
+	idempotencyStockMovement     = "stock-movements"
+	idempotencyBulkStockMovement = "stock-movements/bulk"
+)
+
+// ErrIdempotencyKeyReused is returned when a key is replayed with a different request body
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// claimIdempotencyKey records key for the user and endpoint inside the
+// caller's transaction. When the key has been used before, replayed is true
+// and the stored response is decoded into out; the caller should return it
+// without posting anything. A concurrent request with the same key blocks on
+// the unique index until the first transaction finishes.
+func claimIdempotencyKey(ctx context.Context, q *sqlc.Queries, userID *uuid.UUID, endpoint, key string, req any, out any) (claim pgtype.UUID, replayed bool, err error) {
+	if key == "" || userID == nil {
+		return pgtype.UUID{}, false, nil
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return pgtype.UUID{}, false, err
+	}
+	sum := sha256.Sum256(body)
+	requestHash := hex.EncodeToString(sum[:])
+
+	claim, err = q.ClaimIdempotencyKey(ctx, &sqlc.ClaimIdempotencyKeyParams{
+		IdempotencyKey: key,
+		UserID:         utils.UUIDToPgxUUID(*userID),
+		Endpoint:       endpoint,
+		RequestHash:    requestHash,
+	})
+	if err == nil {
+		return claim, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return pgtype.UUID{}, false, err
+	}
+
+	existing, err := q.GetIdempotencyKey(ctx, &sqlc.GetIdempotencyKeyParams{
+		UserID:         utils.UUIDToPgxUUID(*userID),
+		Endpoint:       endpoint,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return pgtype.UUID{}, false, err
+	}
+	if existing.RequestHash != requestHash {
+		return pgtype.UUID{}, false, ErrIdempotencyKeyReused
+	}
+	if err := json.Unmarshal(existing.ResponseBody, out); err != nil {
+		return pgtype.UUID{}, false, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	return pgtype.UUID{}, true, nil
+}
+
+// completeIdempotencyKey stores the response for a claimed key so retries can replay it
+func completeIdempotencyKey(ctx context.Context, q *sqlc.Queries, claim pgtype.UUID, response any) error {
+	if !claim.Valid {
+		return nil
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return q.CompleteIdempotencyKey(ctx, &sqlc.CompleteIdempotencyKeyParams{
+		ID:           claim,
+		ResponseBody: body,
+	})
+}
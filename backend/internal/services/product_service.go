@@ -35,6 +35,8 @@ func (s *ProductService) CreateProduct(ctx context.Context, req models.CreatePro
 		SupplierID:    utils.OptionalUUIDToPgxUUID(req.SupplierID),
 		UnitPrice:     utils.Float64ToPgxNumeric(req.UnitPrice),
 		MinStockLevel: utils.OptionalIntToInt32(req.MinStockLevel),
+		TracksExpiry:  req.TracksExpiry,
+		Serialized:    req.Serialized,
 	})
 	if err != nil {
 		return nil, err
@@ -50,6 +52,8 @@ func (s *ProductService) CreateProduct(ctx context.Context, req models.CreatePro
 		UnitPrice:     utils.PgxNumericToFloat64(product.UnitPrice),
 		MinStockLevel: utils.Int32ToIntPtr(product.MinStockLevel),
 		IsActive:      *product.IsActive,
+		TracksExpiry:  product.TracksExpiry,
+		Serialized:    product.Serialized,
 		CreatedAt:     utils.PgxTimestamptzToTime(product.CreatedAt),
 		UpdatedAt:     utils.PgxTimestamptzToTime(product.UpdatedAt),
 	}, nil
@@ -71,6 +75,8 @@ func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (*models.
 		UnitPrice:     utils.PgxNumericToFloat64(product.UnitPrice),
 		MinStockLevel: utils.Int32ToIntPtr(product.MinStockLevel),
 		IsActive:      *product.IsActive,
+		TracksExpiry:  product.TracksExpiry,
+		Serialized:    product.Serialized,
 		CreatedAt:   utils.PgxTimestamptzToTime(product.CreatedAt),
 		UpdatedAt:   utils.PgxTimestamptzToTime(product.UpdatedAt),
 	}, nil
@@ -97,6 +103,8 @@ func (s *ProductService) ListProducts(ctx context.Context) ([]models.Product, er
 			UnitPrice:     utils.PgxNumericToFloat64(product.UnitPrice),
 			MinStockLevel: utils.Int32ToIntPtr(product.MinStockLevel),
 			IsActive:      *product.IsActive,
+			TracksExpiry:  product.TracksExpiry,
+			Serialized:    product.Serialized,
 			CreatedAt:     utils.PgxTimestamptzToTime(product.CreatedAt),
 			UpdatedAt:     utils.PgxTimestamptzToTime(product.UpdatedAt),
 			Category:      product.CategoryName,
@@ -157,6 +165,8 @@ func (s *ProductService) ListProductsWithFilter(ctx context.Context, filter mode
 			UnitPrice:     utils.PgxNumericToFloat64(product.UnitPrice),
 			MinStockLevel: utils.Int32ToIntPtr(product.MinStockLevel),
 			IsActive:      *product.IsActive,
+			TracksExpiry:  product.TracksExpiry,
+			Serialized:    product.Serialized,
 			CreatedAt:     utils.PgxTimestamptzToTime(product.CreatedAt),
 			UpdatedAt:     utils.PgxTimestamptzToTime(product.UpdatedAt),
 			Category:      product.CategoryName,
@@ -218,6 +228,8 @@ func (s *ProductService) ListProductsWithStock(ctx context.Context, filter model
 				UnitPrice:     utils.PgxNumericToFloat64(product.UnitPrice),
 				MinStockLevel: utils.Int32ToIntPtr(product.MinStockLevel),
 				IsActive:      *product.IsActive,
+				TracksExpiry:  product.TracksExpiry,
+				Serialized:    product.Serialized,
 				CreatedAt:     utils.PgxTimestamptzToTime(product.CreatedAt),
 				UpdatedAt:     utils.PgxTimestamptzToTime(product.UpdatedAt),
 				Category:      product.CategoryName,
@@ -242,6 +254,8 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req mo
 		SupplierID:    utils.OptionalUUIDToPgxUUID(req.SupplierID),
 		UnitPrice:     utils.Float64ToPgxNumeric(req.UnitPrice),
 		MinStockLevel: utils.OptionalIntToInt32(req.MinStockLevel),
+		TracksExpiry:  req.TracksExpiry,
+		Serialized:    req.Serialized,
 	})
 	if err != nil {
 		return nil, err
@@ -257,6 +271,8 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, req mo
 		UnitPrice:     utils.PgxNumericToFloat64(product.UnitPrice),
 		MinStockLevel: utils.Int32ToIntPtr(product.MinStockLevel),
 		IsActive:      *product.IsActive,
+		TracksExpiry:  product.TracksExpiry,
+		Serialized:    product.Serialized,
 		CreatedAt:   utils.PgxTimestamptzToTime(product.CreatedAt),
 		UpdatedAt:   utils.PgxTimestamptzToTime(product.UpdatedAt),
 	}, nil
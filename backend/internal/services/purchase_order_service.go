@@ -2,100 +2,193 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/config"
 	"inventory-system/internal/database"
 	sqlc "inventory-system/internal/database/sqlc"
 	"inventory-system/internal/models"
 	"inventory-system/internal/utils"
 	"time"
+
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type PurchaseOrderService struct {
-	db *database.DB
+	db        *database.DB
+	receiving config.ReceivingConfig
+	approvals config.ApprovalConfig
 }
 
-func NewPurchaseOrderService(db *database.DB) *PurchaseOrderService {
+func NewPurchaseOrderService(db *database.DB, receiving config.ReceivingConfig, approvals config.ApprovalConfig) *PurchaseOrderService {
 	return &PurchaseOrderService{
-		db: db,
+		db:        db,
+		receiving: receiving,
+		approvals: approvals,
 	}
 }
 
 func (s *PurchaseOrderService) CreatePurchaseOrder(req models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
 	ctx := context.Background()
-	orderDate := utils.TimeToPgxDate(req.OrderDate)
-	var expectedDeliveryDate *time.Time
-	if req.ExpectedDeliveryDate != nil {
-		expectedDeliveryDate = req.ExpectedDeliveryDate
+	createdBy, err := uuid.Parse(req.CreatedBy)
+	if err != nil {
+		return nil, errors.New("invalid created_by user ID")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	po, err := createPurchaseOrder(ctx, q, s.approvals, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(utils.PgxUUIDToUUID(po.ID).String())
+}
+
+// createPurchaseOrder writes a new draft or pending order with its lines and
+// submits pending orders for approval
+func createPurchaseOrder(ctx context.Context, q *sqlc.Queries, policy config.ApprovalConfig, req models.CreatePurchaseOrderRequest, createdBy uuid.UUID) (*sqlc.PurchaseOrder, error) {
+	status := req.Status
+	if status == "" {
+		status = models.PurchaseOrderStatusPending
+	}
+	if status != models.PurchaseOrderStatusDraft && status != models.PurchaseOrderStatusPending {
+		return nil, errors.New("new purchase orders must be draft or pending")
+	}
+	if err := validatePurchaseOrderItems(req.Items); err != nil {
+		return nil, err
 	}
 
-	po, err := s.db.CreatePurchaseOrder(ctx, &sqlc.CreatePurchaseOrderParams{
-		PoNumber:             req.PoNumber,
+	poNumber := req.PoNumber
+	if poNumber == "" {
+		poNumber = fmt.Sprintf("PO-%d", time.Now().UnixMilli())
+	}
+	orderDate := req.OrderDate
+	if orderDate.IsZero() {
+		orderDate = time.Now()
+	}
+
+	po, err := q.CreatePurchaseOrder(ctx, &sqlc.CreatePurchaseOrderParams{
+		PoNumber:             poNumber,
 		SupplierName:         req.SupplierName,
 		SupplierContact:      req.SupplierContact,
-		OrderDate:            orderDate,
-		ExpectedDeliveryDate: utils.TimeToPgxDatePtr(expectedDeliveryDate),
+		OrderDate:            utils.TimeToPgxDate(orderDate),
+		ExpectedDeliveryDate: utils.TimeToPgxDatePtr(req.ExpectedDeliveryDate),
 		Notes:                req.Notes,
-		CreatedBy:            utils.UUIDToPgxUUID(uuid.MustParse(req.CreatedBy)),
+		CreatedBy:            utils.UUIDToPgxUUID(createdBy),
+		Status:               status,
+		WarehouseID:          utils.OptionalUUIDToPgxUUID(req.WarehouseID),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Always set status to "completed"
-	_, err = s.db.UpdatePurchaseOrder(ctx, &sqlc.UpdatePurchaseOrderParams{
-		ID:                   po.ID,
-		SupplierName:         po.SupplierName,
-		SupplierContact:      po.SupplierContact,
-		Status:               "completed", // Always set to completed
-		ExpectedDeliveryDate: po.ExpectedDeliveryDate,
-		ReceivedDate:         po.ReceivedDate,
-		Notes:                po.Notes,
-	})
-	if err != nil {
+	if err := replacePurchaseOrderItems(ctx, q, po.ID, req.Items); err != nil {
 		return nil, err
 	}
 
-	return &models.PurchaseOrder{
-		ID:                   utils.PgxUUIDToUUID(po.ID).String(),
-		PoNumber:             po.PoNumber,
-		SupplierName:         po.SupplierName,
-		SupplierContact:      po.SupplierContact,
-		TotalAmount:          utils.PgxNumericToFloat64(po.TotalAmount),
-		Status:               "completed", // Always return completed
-		OrderDate:            utils.PgxDateToTime(po.OrderDate),
-		ExpectedDeliveryDate: utils.PgxDateToTimePtr(po.ExpectedDeliveryDate),
-		ReceivedDate:         utils.PgxDateToTimePtr(po.ReceivedDate),
-		Notes:                po.Notes,
-		CreatedBy:            utils.PgxUUIDToUUID(po.CreatedBy).String(),
-		CreatedAt:            utils.PgxTimestamptzToTime(po.CreatedAt),
-		UpdatedAt:            utils.PgxTimestamptzToTime(po.UpdatedAt),
-	}, nil
+	if status == models.PurchaseOrderStatusPending {
+		if po, err = q.GetPurchaseOrderForUpdate(ctx, po.ID); err != nil {
+			return nil, err
+		}
+		if err := submitPurchaseOrder(ctx, q, policy, po, createdBy); err != nil {
+			return nil, err
+		}
+	}
+	return po, nil
 }
 
 func (s *PurchaseOrderService) GetPurchaseOrder(id string) (*models.PurchaseOrder, error) {
 	ctx := context.Background()
-	po, err := s.db.GetPurchaseOrder(ctx, utils.UUIDToPgxUUID(uuid.MustParse(id)))
+	poID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid purchase order ID")
+	}
+
+	po, err := s.db.GetPurchaseOrder(ctx, utils.UUIDToPgxUUID(poID))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.ListPurchaseOrderItems(ctx, po.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.db.ListPurchaseOrderApprovals(ctx, po.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &models.PurchaseOrder{
+	result := &models.PurchaseOrder{
 		ID:                   utils.PgxUUIDToUUID(po.ID).String(),
 		PoNumber:             po.PoNumber,
 		SupplierName:         po.SupplierName,
 		SupplierContact:      po.SupplierContact,
 		TotalAmount:          utils.PgxNumericToFloat64(po.TotalAmount),
-		Status:               "completed", // Always return completed
+		Status:               po.Status,
 		OrderDate:            utils.PgxDateToTime(po.OrderDate),
 		ExpectedDeliveryDate: utils.PgxDateToTimePtr(po.ExpectedDeliveryDate),
 		ReceivedDate:         utils.PgxDateToTimePtr(po.ReceivedDate),
 		Notes:                po.Notes,
+		WarehouseID:          optionalUUIDString(po.WarehouseID),
 		CreatedBy:            utils.PgxUUIDToUUID(po.CreatedBy).String(),
 		CreatedByFirstName:   &po.FirstName,
 		CreatedByLastName:    &po.LastName,
 		CreatedAt:            utils.PgxTimestamptzToTime(po.CreatedAt),
 		UpdatedAt:            utils.PgxTimestamptzToTime(po.UpdatedAt),
-	}, nil
+		Items:                make([]models.PurchaseOrderLine, len(items)),
+	}
+
+	for i, item := range items {
+		var received int
+		if item.ReceivedQuantity != nil {
+			received = int(*item.ReceivedQuantity)
+		}
+		result.Items[i] = models.PurchaseOrderLine{
+			ID:               utils.PgxUUIDToUUID(item.ID).String(),
+			ProductID:        utils.PgxUUIDToUUID(item.ProductID).String(),
+			Quantity:         int(item.Quantity),
+			UnitPrice:        utils.PgxNumericToFloat64(item.UnitPrice),
+			TotalPrice:       utils.PgxNumericToFloat64(item.TotalPrice),
+			ReceivedQuantity: received,
+			UOM:              item.Uom,
+			UOMQuantity:      utils.OptionalInt32PtrToInt(item.UomQuantity),
+			UOMPrice:         uomPrice(utils.PgxNumericToFloat64(item.TotalPrice), item.UomQuantity),
+			UOMFactor:        utils.OptionalInt32PtrToInt(item.UomFactor),
+			ProductName:      &item.ProductName,
+			ProductSKU:       &item.Sku,
+		}
+	}
+
+	if len(approvals) > 0 {
+		result.Approvals = make([]models.PurchaseOrderApproval, len(approvals))
+	}
+	for i, approval := range approvals {
+		result.Approvals[i] = models.PurchaseOrderApproval{
+			ID:               utils.PgxUUIDToUUID(approval.ID).String(),
+			Action:           approval.Action,
+			RequiredRole:     approval.RequiredRole,
+			TotalAmount:      utils.PgxNumericToFloat64(approval.TotalAmount),
+			Comment:          approval.Comment,
+			ActedBy:          utils.PgxUUIDToUUID(approval.ActedBy).String(),
+			ActedByFirstName: &approval.FirstName,
+			ActedByLastName:  &approval.LastName,
+			CreatedAt:        utils.PgxTimestamptzToTime(approval.CreatedAt),
+		}
+	}
+
+	return result, nil
 }
 
 func (s *PurchaseOrderService) ListPurchaseOrders(limit, offset int32) ([]models.PurchaseOrder, error) {
@@ -116,11 +209,12 @@ func (s *PurchaseOrderService) ListPurchaseOrders(limit, offset int32) ([]models
 			SupplierName:         po.SupplierName,
 			SupplierContact:      po.SupplierContact,
 			TotalAmount:          utils.PgxNumericToFloat64(po.TotalAmount),
-			Status:               "completed", // Always return completed
+			Status:               po.Status,
 			OrderDate:            utils.PgxDateToTime(po.OrderDate),
 			ExpectedDeliveryDate: utils.PgxDateToTimePtr(po.ExpectedDeliveryDate),
 			ReceivedDate:         utils.PgxDateToTimePtr(po.ReceivedDate),
 			Notes:                po.Notes,
+			WarehouseID:          optionalUUIDString(po.WarehouseID),
 			CreatedBy:            utils.PgxUUIDToUUID(po.CreatedBy).String(),
 			CreatedByFirstName:   &po.FirstName,
 			CreatedByLastName:    &po.LastName,
@@ -132,34 +226,551 @@ func (s *PurchaseOrderService) ListPurchaseOrders(limit, offset int32) ([]models
 	return result, nil
 }
 
-func (s *PurchaseOrderService) UpdatePurchaseOrder(id string, req models.UpdatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+// UpdatePurchaseOrder edits the order header, replaces its lines while it is
+// still a draft or pending, and moves it to req.Status when that transition is
+// allowed. Approval and rejection go through ApprovePurchaseOrder and
+// RejectPurchaseOrder; submitting an order whose total needs no sign-off
+// approves it straight away.
+func (s *PurchaseOrderService) UpdatePurchaseOrder(id string, req models.UpdatePurchaseOrderRequest, userID uuid.UUID) (*models.PurchaseOrder, error) {
 	ctx := context.Background()
-	po, err := s.db.UpdatePurchaseOrder(ctx, &sqlc.UpdatePurchaseOrderParams{
-		ID:                   utils.UUIDToPgxUUID(uuid.MustParse(id)),
-		SupplierName:         req.SupplierName,
+	poID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid purchase order ID")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	po, err := q.GetPurchaseOrderForUpdate(ctx, utils.UUIDToPgxUUID(poID))
+	if err != nil {
+		return nil, err
+	}
+	if po.Status == models.PurchaseOrderStatusReceived || po.Status == models.PurchaseOrderStatusCancelled {
+		return nil, fmt.Errorf("%w: purchase order is %s", ErrInvalidStatusTransition, po.Status)
+	}
+
+	if req.Items != nil {
+		if po.Status != models.PurchaseOrderStatusDraft && po.Status != models.PurchaseOrderStatusPending {
+			return nil, fmt.Errorf("%w: lines of a %s purchase order cannot be changed", ErrInvalidStatusTransition, po.Status)
+		}
+		if err := validatePurchaseOrderItems(req.Items); err != nil {
+			return nil, err
+		}
+		if err := q.DeletePurchaseOrderItems(ctx, po.ID); err != nil {
+			return nil, err
+		}
+		if err := replacePurchaseOrderItems(ctx, q, po.ID, req.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	status := po.Status
+	if req.Status != "" && req.Status != po.Status {
+		if !models.IsPurchaseOrderStatus(req.Status) {
+			return nil, fmt.Errorf("unknown purchase order status %q", req.Status)
+		}
+		if req.Status == models.PurchaseOrderStatusApproved || req.Status == models.PurchaseOrderStatusRejected {
+			return nil, fmt.Errorf("%w: purchase orders are approved and rejected through the approval endpoints", ErrInvalidStatusTransition)
+		}
+		if !isApprovedPurchaseOrderStatus(po.Status) && (req.Status == models.PurchaseOrderStatusOrdered ||
+			req.Status == models.PurchaseOrderStatusPartiallyReceived || req.Status == models.PurchaseOrderStatusReceived) {
+			return nil, fmt.Errorf("%w: a %s purchase order must be approved before it is ordered or received", ErrInvalidStatusTransition, po.Status)
+		}
+		if !models.CanTransitionPurchaseOrder(po.Status, req.Status) {
+			return nil, fmt.Errorf("%w: cannot move a %s purchase order to %s", ErrInvalidStatusTransition, po.Status, req.Status)
+		}
+		if req.Status == models.PurchaseOrderStatusPartiallyReceived || req.Status == models.PurchaseOrderStatusReceived {
+			return nil, fmt.Errorf("%w: purchase orders are marked received by receiving goods against them", ErrInvalidStatusTransition)
+		}
+		status = req.Status
+	}
+
+	supplierName := po.SupplierName
+	if req.SupplierName != "" {
+		supplierName = req.SupplierName
+	}
+	receivedDate := utils.TimeToPgxDatePtr(req.ReceivedDate)
+	if status == models.PurchaseOrderStatusReceived && !receivedDate.Valid {
+		receivedDate = utils.TimeToPgxDate(time.Now())
+	}
+
+	updated, err := q.UpdatePurchaseOrder(ctx, &sqlc.UpdatePurchaseOrderParams{
+		ID:                   po.ID,
+		SupplierName:         supplierName,
 		SupplierContact:      req.SupplierContact,
-		Status:               "completed", // Always set to completed
+		Status:               status,
 		ExpectedDeliveryDate: utils.TimeToPgxDatePtr(req.ExpectedDeliveryDate),
-		ReceivedDate:         utils.TimeToPgxDatePtr(req.ReceivedDate),
+		ReceivedDate:         receivedDate,
 		Notes:                req.Notes,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &models.PurchaseOrder{
-		ID:                   utils.PgxUUIDToUUID(po.ID).String(),
-		PoNumber:             po.PoNumber,
+	// Submitting the order, or changing the lines of a submitted order, checks
+	// whether its total still needs sign-off
+	if status == models.PurchaseOrderStatusPending && (po.Status != status || req.Items != nil) {
+		if err := submitPurchaseOrder(ctx, q, s.approvals, updated, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(id)
+}
+
+// ApprovePurchaseOrder approves a pending purchase order. The acting user's
+// role must be at least the role the order total calls for under the approval
+// policy.
+func (s *PurchaseOrderService) ApprovePurchaseOrder(id string, req models.PurchaseOrderDecisionRequest, userID uuid.UUID, role string) (*models.PurchaseOrder, error) {
+	return s.decidePurchaseOrder(id, models.PurchaseOrderApprovalApproved, req, userID, role)
+}
+
+// RejectPurchaseOrder rejects a pending purchase order. Rejected orders can be
+// reworked as a draft and submitted again, or cancelled.
+func (s *PurchaseOrderService) RejectPurchaseOrder(id string, req models.PurchaseOrderDecisionRequest, userID uuid.UUID, role string) (*models.PurchaseOrder, error) {
+	return s.decidePurchaseOrder(id, models.PurchaseOrderApprovalRejected, req, userID, role)
+}
+
+func (s *PurchaseOrderService) decidePurchaseOrder(id, action string, req models.PurchaseOrderDecisionRequest, userID uuid.UUID, role string) (*models.PurchaseOrder, error) {
+	ctx := context.Background()
+	poID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid purchase order ID")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	po, err := q.GetPurchaseOrderForUpdate(ctx, utils.UUIDToPgxUUID(poID))
+	if err != nil {
+		return nil, err
+	}
+	if po.Status != models.PurchaseOrderStatusPending {
+		return nil, fmt.Errorf("%w: only pending purchase orders can be %s, this one is %s", ErrInvalidStatusTransition, action, po.Status)
+	}
+
+	total := utils.PgxNumericToFloat64(po.TotalAmount)
+	required := requiredApprovalRole(s.approvals, total)
+	if required != "" && !models.RoleSatisfies(role, required) {
+		return nil, fmt.Errorf("%w: a purchase order of %.2f must be %s by a %s or above", ErrInsufficientRole, total, action, required)
+	}
+
+	status := models.PurchaseOrderStatusApproved
+	if action == models.PurchaseOrderApprovalRejected {
+		status = models.PurchaseOrderStatusRejected
+	}
+	if err := recordPurchaseOrderDecision(ctx, q, po, action, required, req.Comment, userID); err != nil {
+		return nil, err
+	}
+	if _, err := q.UpdatePurchaseOrderStatus(ctx, &sqlc.UpdatePurchaseOrderStatusParams{
+		ID:     po.ID,
+		Status: status,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(id)
+}
+
+// ReceivePurchaseOrder books goods received against the lines of an approved
+// purchase order. Each line posts an "in" movement and adds to the line's
+// received quantity; the order becomes partially received or received
+// depending on what is still outstanding.
+func (s *PurchaseOrderService) ReceivePurchaseOrder(id string, req models.ReceivePurchaseOrderRequest, userID uuid.UUID) (*models.PurchaseOrder, error) {
+	ctx := context.Background()
+	poID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid purchase order ID")
+	}
+	if len(req.Lines) == 0 {
+		return nil, errors.New("at least one line is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	po, items, err := lockReceivablePurchaseOrder(ctx, q, poID)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make(map[uuid.UUID]uuid.UUID, len(items))
+	lineUOMs := make(map[uuid.UUID]*productUOM, len(items))
+	for _, item := range items {
+		id := utils.PgxUUIDToUUID(item.ID)
+		productIDs[id] = utils.PgxUUIDToUUID(item.ProductID)
+		lineUOMs[id] = uomFromColumns(item.UomID, item.Uom, item.UomFactor)
+	}
+
+	defaultWarehouseID := req.WarehouseID
+	if defaultWarehouseID == uuid.Nil && po.WarehouseID.Valid {
+		defaultWarehouseID = utils.PgxUUIDToUUID(po.WarehouseID)
+	}
+	receipts := make([]purchaseOrderReceipt, len(req.Lines))
+	for i, line := range req.Lines {
+		warehouseID := defaultWarehouseID
+		if line.WarehouseID != nil {
+			warehouseID = *line.WarehouseID
+		}
+		var lotID *uuid.UUID
+		// Quantities are in the unit the line was ordered in unless the
+		// receipt names another
+		uom := lineUOMs[line.ItemID]
+		if productID, ok := productIDs[line.ItemID]; ok {
+			if lotID, err = resolveReceiptLot(ctx, q, productID, line.LotNumber, line.ManufactureDate, line.ExpiryDate); err != nil {
+				return nil, err
+			}
+			if line.UOM != nil && *line.UOM != "" {
+				if uom, err = resolveProductUOM(ctx, q, productID, line.UOM); err != nil {
+					return nil, err
+				}
+			}
+		}
+		receipts[i] = purchaseOrderReceipt{
+			ItemID:      line.ItemID,
+			WarehouseID: warehouseID,
+			Quantity:    uom.toBase(line.Quantity),
+			CostPrice:   uom.priceToBase(line.CostPrice),
+			Reason:      line.Reason,
+			LotID:       lotID,
+			Serials:     line.SerialNumbers,
+			LocationID:  line.LocationID,
+			UOM:         uom,
+		}
+	}
+
+	referenceNumber := &po.PoNumber
+	if req.ReferenceNumber != nil && *req.ReferenceNumber != "" {
+		referenceNumber = req.ReferenceNumber
+	}
+	posting := stockPosting{
+		ReferenceNumber: referenceNumber,
+		UserID:          &userID,
+	}
+	if req.ProcessedDate != nil {
+		posting.ProcessedDate = *req.ProcessedDate
+	}
+
+	if _, err := receivePurchaseOrderLines(ctx, q, s.receiving, po, items, receipts, posting); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(id)
+}
+
+// requiredApprovalRole returns the minimum role that must approve a purchase
+// order with the given total, or "" when the total needs no sign-off
+func requiredApprovalRole(policy config.ApprovalConfig, total float64) string {
+	switch {
+	case total > float64(policy.AdminThreshold):
+		return models.UserRoleAdmin
+	case total > float64(policy.ManagerThreshold):
+		return models.UserRoleManager
+	default:
+		return ""
+	}
+}
+
+// submitPurchaseOrder approves a pending order on submission when its total
+// is within the limit that needs no sign-off. Larger orders stay pending until
+// a manager or admin decides on them.
+func submitPurchaseOrder(ctx context.Context, q *sqlc.Queries, policy config.ApprovalConfig, po *sqlc.PurchaseOrder, userID uuid.UUID) error {
+	if requiredApprovalRole(policy, utils.PgxNumericToFloat64(po.TotalAmount)) != "" {
+		return nil
+	}
+
+	comment := "approved automatically: total is within the approval limit"
+	if err := recordPurchaseOrderDecision(ctx, q, po, models.PurchaseOrderApprovalApproved, "", &comment, userID); err != nil {
+		return err
+	}
+	_, err := q.UpdatePurchaseOrderStatus(ctx, &sqlc.UpdatePurchaseOrderStatusParams{
+		ID:     po.ID,
+		Status: models.PurchaseOrderStatusApproved,
+	})
+	return err
+}
+
+// recordPurchaseOrderDecision writes who approved or rejected the order, the
+// role its total required and the total at the time
+func recordPurchaseOrderDecision(ctx context.Context, q *sqlc.Queries, po *sqlc.PurchaseOrder, action, requiredRole string, comment *string, userID uuid.UUID) error {
+	var role *string
+	if requiredRole != "" {
+		role = &requiredRole
+	}
+	_, err := q.CreatePurchaseOrderApproval(ctx, &sqlc.CreatePurchaseOrderApprovalParams{
+		PurchaseOrderID: po.ID,
+		Action:          action,
+		RequiredRole:    role,
+		TotalAmount:     po.TotalAmount,
+		Comment:         comment,
+		ActedBy:         utils.UUIDToPgxUUID(userID),
+	})
+	return err
+}
+
+// isApprovedPurchaseOrderStatus reports whether an order in status has been
+// approved, i.e. may be ordered from the supplier and received against
+func isApprovedPurchaseOrderStatus(status string) bool {
+	switch status {
+	case models.PurchaseOrderStatusApproved, models.PurchaseOrderStatusOrdered,
+		models.PurchaseOrderStatusPartiallyReceived, models.PurchaseOrderStatusReceived:
+		return true
+	}
+	return false
+}
+
+func validatePurchaseOrderItems(items []models.PurchaseOrderItem) error {
+	for _, item := range items {
+		if _, err := uuid.Parse(item.ProductID); err != nil {
+			return errors.New("a valid product is required for every item")
+		}
+		if item.Quantity <= 0 {
+			return errors.New("item quantity must be greater than zero")
+		}
+		if item.UnitPrice < 0 {
+			return errors.New("unit price cannot be negative")
+		}
+	}
+	return nil
+}
+
+// replacePurchaseOrderItems writes the order lines and recomputes the order
+// total from them. Existing lines must already have been deleted. Lines
+// ordered in another unit are stored in base units, with the line total
+// taken from the quantity and price as entered.
+func replacePurchaseOrderItems(ctx context.Context, q *sqlc.Queries, poID pgtype.UUID, items []models.PurchaseOrderItem) error {
+	for _, item := range items {
+		productID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			return errors.New("a valid product is required for every item")
+		}
+		uom, err := resolveProductUOM(ctx, q, productID, item.UOM)
+		if err != nil {
+			return err
+		}
+		quantity := uom.toBase(item.Quantity)
+		uomID, uomQuantity, uomFactor := uom.columns(quantity)
+		if _, err := q.CreatePurchaseOrderItem(ctx, &sqlc.CreatePurchaseOrderItemParams{
+			PurchaseOrderID: poID,
+			ProductID:       utils.UUIDToPgxUUID(productID),
+			Quantity:        int32(quantity),
+			UnitPrice:       utils.CostToPgxNumeric(*uom.priceToBase(&item.UnitPrice)),
+			TotalPrice:      utils.Float64ToPgxNumeric(float64(item.Quantity) * item.UnitPrice),
+			UomID:           uomID,
+			UomQuantity:     uomQuantity,
+			UomFactor:       uomFactor,
+		}); err != nil {
+			return err
+		}
+	}
+
+	total, err := q.GetPurchaseOrderItemsTotal(ctx, poID)
+	if err != nil {
+		return err
+	}
+	_, err = q.UpdatePurchaseOrderTotal(ctx, &sqlc.UpdatePurchaseOrderTotalParams{
+		ID:          poID,
+		TotalAmount: total,
+	})
+	return err
+}
+
+// optionalUUIDString returns the ID in the string form purchase order models
+// use, or nil when it is not set
+func optionalUUIDString(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	str := utils.PgxUUIDToUUID(id).String()
+	return &str
+}
+
+// purchaseOrderReceipt is a quantity received against one purchase order
+// line. Quantity and CostPrice are in base units; UOM is the unit they were
+// given in.
+type purchaseOrderReceipt struct {
+	ItemID      uuid.UUID
+	WarehouseID uuid.UUID
+	Quantity    int
+	CostPrice   *float64
+	Reason      *string
+	LotID       *uuid.UUID
+	Serials     []string
+	LocationID  *uuid.UUID
+	UOM         *productUOM
+}
+
+// lockReceivablePurchaseOrder reads the order FOR UPDATE and checks that goods
+// can be received against it
+func lockReceivablePurchaseOrder(ctx context.Context, q *sqlc.Queries, id uuid.UUID) (*sqlc.PurchaseOrder, []*sqlc.ListPurchaseOrderItemsRow, error) {
+	po, err := q.GetPurchaseOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isApprovedPurchaseOrderStatus(po.Status) || po.Status == models.PurchaseOrderStatusReceived {
+		return nil, nil, fmt.Errorf("%w: cannot receive against a %s purchase order", ErrInvalidStatusTransition, po.Status)
+	}
+
+	items, err := q.ListPurchaseOrderItems(ctx, po.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil, errors.New("purchase order has no lines to receive against")
+	}
+	return po, items, nil
+}
+
+// receivePurchaseOrderLines posts an "in" movement for every receipt, adds it
+// to the line's received quantity and moves the order to partially received or
+// received. Lines may not be received beyond the over-receipt tolerance, and a
+// line within the under-receipt tolerance of its ordered quantity counts as
+// fully received. posting supplies the reference number, user and date shared
+// by all movements.
+func receivePurchaseOrderLines(ctx context.Context, q *sqlc.Queries, tolerance config.ReceivingConfig, po *sqlc.PurchaseOrder, items []*sqlc.ListPurchaseOrderItemsRow, receipts []purchaseOrderReceipt, posting stockPosting) ([]*sqlc.StockMovement, error) {
+	itemsByID := make(map[uuid.UUID]*sqlc.ListPurchaseOrderItemsRow, len(items))
+	received := make(map[uuid.UUID]int32, len(items))
+	for _, item := range items {
+		id := utils.PgxUUIDToUUID(item.ID)
+		itemsByID[id] = item
+		if item.ReceivedQuantity != nil {
+			received[id] = *item.ReceivedQuantity
+		}
+	}
+
+	keys := make([]stockKey, 0, len(receipts))
+	for _, receipt := range receipts {
+		item, ok := itemsByID[receipt.ItemID]
+		if !ok {
+			return nil, fmt.Errorf("item %s does not belong to this purchase order", receipt.ItemID)
+		}
+		if receipt.WarehouseID == uuid.Nil {
+			return nil, errors.New("warehouse is required for every received line")
+		}
+		if receipt.Quantity <= 0 {
+			return nil, errors.New("received quantity must be greater than zero")
+		}
+
+		received[receipt.ItemID] += int32(receipt.Quantity)
+		limit := item.Quantity + item.Quantity*int32(tolerance.OverReceiptTolerance)/100
+		if received[receipt.ItemID] > limit {
+			return nil, fmt.Errorf("%s (%s): receiving %d of %d ordered exceeds the over-receipt tolerance", item.ProductName, item.Sku, received[receipt.ItemID], item.Quantity)
+		}
+		keys = append(keys, stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: receipt.WarehouseID})
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	referenceType := models.PurchaseOrderReferenceType
+	referenceID := utils.PgxUUIDToUUID(po.ID)
+	movements := make([]*sqlc.StockMovement, 0, len(receipts))
+	for _, receipt := range receipts {
+		item := itemsByID[receipt.ItemID]
+		costPrice := receipt.CostPrice
+		if costPrice == nil {
+			unitPrice := utils.PgxNumericToFloat64(item.UnitPrice)
+			costPrice = &unitPrice
+		}
+
+		p := posting
+		p.ProductID = utils.PgxUUIDToUUID(item.ProductID)
+		p.WarehouseID = receipt.WarehouseID
+		p.MovementType = "in"
+		p.Quantity = receipt.Quantity
+		p.CostPrice = costPrice
+		p.ReferenceType = &referenceType
+		p.ReferenceID = &referenceID
+		p.Reason = receipt.Reason
+		p.Lots = singleLot(receipt.LotID, receipt.Quantity)
+		p.Serials = receipt.Serials
+		p.ToLocationID = receipt.LocationID
+		p.UOM = receipt.UOM
+
+		movement, err := postStockMovement(ctx, q, p)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+
+	if err := updatePurchaseOrderReceipts(ctx, q, tolerance, po, items, received); err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// updatePurchaseOrderReceipts writes the lines' new received quantities and
+// sets the order's status from them: received once every line is within the
+// under-receipt tolerance of its ordered quantity, partially received while
+// anything has been received, and back to ordered when reversals have taken
+// every receipt off it
+func updatePurchaseOrderReceipts(ctx context.Context, q *sqlc.Queries, tolerance config.ReceivingConfig, po *sqlc.PurchaseOrder, items []*sqlc.ListPurchaseOrderItemsRow, received map[uuid.UUID]int32) error {
+	complete := true
+	anyReceived := false
+	for _, item := range items {
+		id := utils.PgxUUIDToUUID(item.ID)
+		quantity := received[id]
+		if item.ReceivedQuantity == nil || *item.ReceivedQuantity != quantity {
+			if _, err := q.UpdatePurchaseOrderItemReceivedQuantity(ctx, &sqlc.UpdatePurchaseOrderItemReceivedQuantityParams{
+				ID:               item.ID,
+				ReceivedQuantity: &quantity,
+			}); err != nil {
+				return err
+			}
+		}
+		if quantity < item.Quantity-item.Quantity*int32(tolerance.UnderReceiptTolerance)/100 {
+			complete = false
+		}
+		if quantity > 0 {
+			anyReceived = true
+		}
+	}
+
+	status := models.PurchaseOrderStatusOrdered
+	receivedDate := pgtype.Date{}
+	switch {
+	case complete:
+		status = models.PurchaseOrderStatusReceived
+		receivedDate = po.ReceivedDate
+		if !receivedDate.Valid {
+			receivedDate = utils.TimeToPgxDate(time.Now())
+		}
+	case anyReceived:
+		status = models.PurchaseOrderStatusPartiallyReceived
+	}
+	_, err := q.UpdatePurchaseOrder(ctx, &sqlc.UpdatePurchaseOrderParams{
+		ID:                   po.ID,
 		SupplierName:         po.SupplierName,
 		SupplierContact:      po.SupplierContact,
-		TotalAmount:          utils.PgxNumericToFloat64(po.TotalAmount),
-		Status:               "completed", // Always return completed
-		OrderDate:            utils.PgxDateToTime(po.OrderDate),
-		ExpectedDeliveryDate: utils.PgxDateToTimePtr(po.ExpectedDeliveryDate),
-		ReceivedDate:         utils.PgxDateToTimePtr(po.ReceivedDate),
+		Status:               status,
+		ExpectedDeliveryDate: po.ExpectedDeliveryDate,
+		ReceivedDate:         receivedDate,
 		Notes:                po.Notes,
-		CreatedBy:            utils.PgxUUIDToUUID(po.CreatedBy).String(),
-		CreatedAt:            utils.PgxTimestamptzToTime(po.CreatedAt),
-		UpdatedAt:            utils.PgxTimestamptzToTime(po.UpdatedAt),
-	}, nil
+	})
+	return err
 }
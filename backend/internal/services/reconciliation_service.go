@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconciliationService checks stock_levels against the movement ledger.
+// Every balance should equal the sum of its in, out and adjustment
+// movements; rows written around the ledger, or movements purged from it,
+// make the two drift apart. The ledger is the source of truth: a repair sets
+// the stored balance back to the ledger balance. Only when an operator
+// confirms that the stored balance is the stock physically there is a
+// correction posted to the ledger instead.
+type ReconciliationService struct {
+	db *database.DB
+}
+
+func NewReconciliationService(db *database.DB) *ReconciliationService {
+	return &ReconciliationService{db: db}
+}
+
+// ReconcileStockLevels recomputes every balance in the filter from the
+// ledger and reports those that differ from stock_levels
+func (s *ReconciliationService) ReconcileStockLevels(ctx context.Context, filter models.StockReconciliationFilter) (*models.StockReconciliationReport, error) {
+	rows, err := s.db.ListStockLedgerDrift(ctx, &sqlc.ListStockLedgerDriftParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checked, err := s.db.CountReconciledBalances(ctx, &sqlc.CountReconciledBalancesParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.StockReconciliationReport{
+		CheckedBalances: checked,
+		Mismatches:      make([]models.StockDrift, len(rows)),
+	}
+	for i, row := range rows {
+		report.Mismatches[i] = models.StockDrift{
+			ProductID:      utils.PgxUUIDToUUID(row.ProductID),
+			ProductName:    row.ProductName,
+			ProductSKU:     row.Sku,
+			WarehouseID:    utils.PgxUUIDToUUID(row.WarehouseID),
+			WarehouseName:  row.WarehouseName,
+			StoredQuantity: int(row.StoredQuantity),
+			LedgerQuantity: int(row.LedgerQuantity),
+			Drift:          int(row.StoredQuantity - row.LedgerQuantity),
+		}
+	}
+	return report, nil
+}
+
+// RepairStockLevels repairs every mismatched balance in the filter and
+// returns a fresh report. By default each stored balance is set to its
+// ledger balance. With storedIsCounted the operator confirms that the stored
+// balances are the true count, and a ledger correction is posted for each
+// instead. Each balance is repaired in its own transaction under its stock
+// level lock, with the drift recomputed there, so postings made since the
+// report was taken are accounted for.
+func (s *ReconciliationService) RepairStockLevels(ctx context.Context, filter models.StockReconciliationFilter, storedIsCounted bool, userID uuid.UUID) (*models.StockReconciliationReport, error) {
+	before, err := s.ReconcileStockLevels(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	realigned := []models.StockDrift{}
+	corrections := []models.StockMovement{}
+	for _, drift := range before.Mismatches {
+		if !storedIsCounted {
+			repaired, err := s.realignStockLevel(ctx, drift)
+			if err != nil {
+				return nil, fmt.Errorf("failed to realign %s (%s) in %s: %w", drift.ProductName, drift.ProductSKU, drift.WarehouseName, err)
+			}
+			if repaired != nil {
+				realigned = append(realigned, *repaired)
+			}
+			continue
+		}
+
+		movement, err := s.correctStockLedger(ctx, drift.ProductID, drift.WarehouseID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to correct %s (%s) in %s: %w", drift.ProductName, drift.ProductSKU, drift.WarehouseName, err)
+		}
+		if movement != nil {
+			corrections = append(corrections, *movement)
+		}
+	}
+
+	report, err := s.ReconcileStockLevels(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	report.Realigned = realigned
+	report.Corrections = corrections
+	return report, nil
+}
+
+// realignStockLevel sets a stored balance to the balance its ledger adds up
+// to. Lot, bin and value balances follow the ledger already and are left as
+// they are. It returns nil when the balance agrees with the ledger.
+func (s *ReconciliationService) realignStockLevel(ctx context.Context, drift models.StockDrift) (*models.StockDrift, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	level, ledger, err := lockStockLedgerBalance(ctx, q, drift.ProductID, drift.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if level.Quantity == ledger {
+		return nil, nil
+	}
+	if ledger < 0 {
+		return nil, fmt.Errorf("the ledger balance of %d cannot be stored; confirm the stored balance of %d to post a correction", ledger, level.Quantity)
+	}
+	if ledger < level.ReservedQuantity {
+		return nil, fmt.Errorf("the ledger balance of %d is below the %d units reserved; release the reservations or confirm the stored balance", ledger, level.ReservedQuantity)
+	}
+
+	if _, err := q.UpdateStockQuantity(ctx, &sqlc.UpdateStockQuantityParams{
+		ProductID:   level.ProductID,
+		WarehouseID: level.WarehouseID,
+		Quantity:    ledger,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	drift.StoredQuantity = int(level.Quantity)
+	drift.LedgerQuantity = int(ledger)
+	drift.Drift = int(level.Quantity - ledger)
+	return &drift, nil
+}
+
+// correctStockLedger posts an adjustment for the difference between a
+// stored balance and its ledger, like any other adjustment, so the lot, bin,
+// serial and value balances follow it. Posting moves the stored balance by
+// the same difference, so a decrease is posted from the ledger balance and
+// an increase is posted on top of the stored balance and then set back;
+// either way the stored balance ends where it was. It returns nil when the
+// balance agrees with the ledger.
+func (s *ReconciliationService) correctStockLedger(ctx context.Context, productID, warehouseID, userID uuid.UUID) (*models.StockMovement, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	level, ledger, err := lockStockLedgerBalance(ctx, q, productID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	drift := level.Quantity - ledger
+	if drift == 0 {
+		return nil, nil
+	}
+	if _, _, err := lockProductCost(ctx, q, productID); err != nil {
+		return nil, err
+	}
+
+	if drift < 0 {
+		if _, err := q.UpdateStockQuantity(ctx, &sqlc.UpdateStockQuantityParams{
+			ProductID:   level.ProductID,
+			WarehouseID: level.WarehouseID,
+			Quantity:    ledger,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	referenceType := models.ReconciliationReferenceType
+	reasonCode := models.ReconciliationReasonCode
+	reason := fmt.Sprintf("Ledger correction: stored balance %d, ledger balance %d", level.Quantity, ledger)
+	movement, err := postStockMovement(ctx, q, stockPosting{
+		ProductID:     productID,
+		WarehouseID:   warehouseID,
+		MovementType:  "adjustment",
+		Quantity:      int(drift),
+		ReferenceType: &referenceType,
+		Reason:        &reason,
+		ReasonCode:    &reasonCode,
+		UserID:        &userID,
+		ProcessedDate: time.Now(),
+		Counted:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if drift > 0 {
+		if _, err := q.UpdateStockQuantity(ctx, &sqlc.UpdateStockQuantityParams{
+			ProductID:   level.ProductID,
+			WarehouseID: level.WarehouseID,
+			Quantity:    level.Quantity,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := stockMovementWithTracking(ctx, q, movement)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// lockStockLedgerBalance locks a stored balance and returns it together with
+// the balance its ledger adds up to
+func lockStockLedgerBalance(ctx context.Context, q *sqlc.Queries, productID, warehouseID uuid.UUID) (*sqlc.StockLevel, int32, error) {
+	level, err := lockStockLevel(ctx, q, productID, warehouseID)
+	if err != nil {
+		return nil, 0, err
+	}
+	ledger, err := q.GetStockLedgerBalance(ctx, &sqlc.GetStockLedgerBalanceParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return level, ledger, nil
+}
+
+// RunReconciliation reports ledger drift every interval until ctx is done.
+// It only logs what it finds; balances are repaired on request.
+func (s *ReconciliationService) RunReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.ReconcileStockLevels(ctx, models.StockReconciliationFilter{})
+			if err != nil {
+				log.Printf("Failed to reconcile stock levels: %v", err)
+				continue
+			}
+			for _, drift := range report.Mismatches {
+				log.Printf("Stock ledger drift for %s (%s) in %s: stored %d, ledger %d", drift.ProductName, drift.ProductSKU, drift.WarehouseName, drift.StoredQuantity, drift.LedgerQuantity)
+			}
+			if len(report.Mismatches) > 0 {
+				log.Printf("%d of %d stock balances disagree with the movement ledger", len(report.Mismatches), report.CheckedBalances)
+			}
+		}
+	}
+}
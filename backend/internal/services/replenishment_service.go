@@ -0,0 +1,390 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReplenishmentService maintains reorder rules and turns the rules that have
+// been reached into suggested purchase orders, grouped by supplier and
+// delivery warehouse, which can be converted into real orders
+type ReplenishmentService struct {
+	db             *database.DB
+	purchaseOrders *PurchaseOrderService
+}
+
+func NewReplenishmentService(db *database.DB, purchaseOrders *PurchaseOrderService) *ReplenishmentService {
+	return &ReplenishmentService{
+		db:             db,
+		purchaseOrders: purchaseOrders,
+	}
+}
+
+// SaveReorderRule creates or replaces the reorder rule of a product in a
+// warehouse
+func (s *ReplenishmentService) SaveReorderRule(ctx context.Context, req models.SaveReorderRuleRequest) (*models.ReorderRule, error) {
+	if err := validateReorderRule(req.ReorderPoint, req.ReorderQuantity); err != nil {
+		return nil, err
+	}
+	if _, err := s.db.GetProduct(ctx, utils.UUIDToPgxUUID(req.ProductID)); err != nil {
+		return nil, fmt.Errorf("product %s not found", req.ProductID)
+	}
+	if _, err := s.db.GetWarehouse(ctx, utils.UUIDToPgxUUID(req.WarehouseID)); err != nil {
+		return nil, fmt.Errorf("warehouse %s not found", req.WarehouseID)
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	rule, err := s.db.UpsertReorderRule(ctx, &sqlc.UpsertReorderRuleParams{
+		ProductID:       utils.UUIDToPgxUUID(req.ProductID),
+		WarehouseID:     utils.UUIDToPgxUUID(req.WarehouseID),
+		ReorderPoint:    int32(req.ReorderPoint),
+		ReorderQuantity: int32(req.ReorderQuantity),
+		IsActive:        isActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetReorderRule(ctx, utils.PgxUUIDToUUID(rule.ID))
+}
+
+// GetReorderRule returns a reorder rule with its product and warehouse names
+func (s *ReplenishmentService) GetReorderRule(ctx context.Context, id uuid.UUID) (*models.ReorderRule, error) {
+	rule, err := s.db.GetReorderRule(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toReorderRuleModel(&sqlc.ReorderRule{
+		ID:              rule.ID,
+		ProductID:       rule.ProductID,
+		WarehouseID:     rule.WarehouseID,
+		ReorderPoint:    rule.ReorderPoint,
+		ReorderQuantity: rule.ReorderQuantity,
+		IsActive:        rule.IsActive,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	})
+	result.ProductName = &rule.ProductName
+	result.ProductSKU = &rule.Sku
+	result.WarehouseName = &rule.WarehouseName
+	return &result, nil
+}
+
+// UpdateReorderRule changes a rule's reorder point, quantity or active flag
+func (s *ReplenishmentService) UpdateReorderRule(ctx context.Context, id uuid.UUID, req models.UpdateReorderRuleRequest) (*models.ReorderRule, error) {
+	if err := validateReorderRule(req.ReorderPoint, req.ReorderQuantity); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.UpdateReorderRule(ctx, &sqlc.UpdateReorderRuleParams{
+		ID:              utils.UUIDToPgxUUID(id),
+		ReorderPoint:    int32(req.ReorderPoint),
+		ReorderQuantity: int32(req.ReorderQuantity),
+		IsActive:        req.IsActive,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetReorderRule(ctx, id)
+}
+
+func (s *ReplenishmentService) DeleteReorderRule(ctx context.Context, id uuid.UUID) error {
+	return s.db.DeleteReorderRule(ctx, utils.UUIDToPgxUUID(id))
+}
+
+// ListReorderRules lists reorder rules filtered by product and warehouse
+func (s *ReplenishmentService) ListReorderRules(ctx context.Context, filter models.ReorderRuleFilter) (*models.ReorderRuleListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rules, err := s.db.ListReorderRulesWithFilter(ctx, &sqlc.ListReorderRulesWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountReorderRulesWithFilter(ctx, &sqlc.CountReorderRulesWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ReorderRule, len(rules))
+	for i, rule := range rules {
+		result[i] = toReorderRuleModel(&sqlc.ReorderRule{
+			ID:              rule.ID,
+			ProductID:       rule.ProductID,
+			WarehouseID:     rule.WarehouseID,
+			ReorderPoint:    rule.ReorderPoint,
+			ReorderQuantity: rule.ReorderQuantity,
+			IsActive:        rule.IsActive,
+			CreatedAt:       rule.CreatedAt,
+			UpdatedAt:       rule.UpdatedAt,
+		})
+		result[i].ProductName = &rule.ProductName
+		result[i].ProductSKU = &rule.Sku
+		result[i].WarehouseName = &rule.WarehouseName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.ReorderRuleListResponse{
+		ReorderRules: result,
+		Total:        total,
+		Page:         filter.Page,
+		Limit:        filter.Limit,
+		Pages:        pages,
+	}, nil
+}
+
+// GetReorderSuggestions runs the reorder rules against current stock
+func (s *ReplenishmentService) GetReorderSuggestions(ctx context.Context, filter models.ReorderSuggestionFilter) (*models.ReorderSuggestions, error) {
+	return reorderSuggestions(ctx, s.db.Queries, filter, time.Now())
+}
+
+// ConvertReorderSuggestions raises a purchase order for each reviewed
+// suggested order, or for every current suggestion when none are given. All
+// orders are created in one transaction; pending orders go through the usual
+// approval rules.
+func (s *ReplenishmentService) ConvertReorderSuggestions(ctx context.Context, req models.ConvertReorderSuggestionsRequest, userID uuid.UUID) ([]models.PurchaseOrder, error) {
+	status := req.Status
+	if status == "" {
+		status = models.PurchaseOrderStatusDraft
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	orders := req.Orders
+	if len(orders) == 0 {
+		// Queue concurrent conversions so the second one sees the orders the
+		// first raised as on order
+		if err := q.LockReorderRules(ctx); err != nil {
+			return nil, err
+		}
+		suggestions, err := reorderSuggestions(ctx, q, models.ReorderSuggestionFilter{}, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		for _, suggested := range suggestions.Orders {
+			expected := suggested.ExpectedDeliveryDate
+			order := models.ConvertReorderOrder{
+				SupplierID:           suggested.SupplierID,
+				WarehouseID:          suggested.WarehouseID,
+				ExpectedDeliveryDate: &expected,
+			}
+			for _, line := range suggested.Lines {
+				order.Lines = append(order.Lines, models.ConvertReorderLine{
+					ProductID: line.ProductID,
+					Quantity:  line.SuggestedQuantity,
+					UnitPrice: line.UnitPrice,
+				})
+			}
+			orders = append(orders, order)
+		}
+		if len(orders) == 0 {
+			return nil, errors.New("no products are at or below their reorder point")
+		}
+	}
+
+	batch := time.Now().UnixMilli()
+	poIDs := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		poReq, err := reorderPurchaseOrderRequest(ctx, q, order, status, userID)
+		if err != nil {
+			return nil, err
+		}
+		poReq.PoNumber = fmt.Sprintf("PO-%d-%d", batch, i+1)
+
+		po, err := createPurchaseOrder(ctx, q, s.purchaseOrders.approvals, poReq, userID)
+		if err != nil {
+			return nil, err
+		}
+		poIDs[i] = utils.PgxUUIDToUUID(po.ID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result := make([]models.PurchaseOrder, len(poIDs))
+	for i, id := range poIDs {
+		po, err := s.purchaseOrders.GetPurchaseOrder(id.String())
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *po
+	}
+	return result, nil
+}
+
+// reorderPurchaseOrderRequest checks a reviewed suggested order and builds
+// the purchase order for it. Every product must be supplied by the order's
+// supplier.
+func reorderPurchaseOrderRequest(ctx context.Context, q *sqlc.Queries, order models.ConvertReorderOrder, status string, userID uuid.UUID) (models.CreatePurchaseOrderRequest, error) {
+	var req models.CreatePurchaseOrderRequest
+	if len(order.Lines) == 0 {
+		return req, errors.New("every order needs at least one line")
+	}
+	supplier, err := q.GetSupplier(ctx, utils.UUIDToPgxUUID(order.SupplierID))
+	if err != nil {
+		return req, fmt.Errorf("supplier %s not found", order.SupplierID)
+	}
+	if _, err := q.GetWarehouse(ctx, utils.UUIDToPgxUUID(order.WarehouseID)); err != nil {
+		return req, fmt.Errorf("warehouse %s not found", order.WarehouseID)
+	}
+
+	items := make([]models.PurchaseOrderItem, len(order.Lines))
+	for i, line := range order.Lines {
+		product, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(line.ProductID))
+		if err != nil {
+			return req, fmt.Errorf("product %s not found", line.ProductID)
+		}
+		if utils.PgxUUIDToUUID(product.SupplierID) != order.SupplierID {
+			return req, fmt.Errorf("%s (%s) is not supplied by %s", product.Name, product.Sku, supplier.Name)
+		}
+		items[i] = models.PurchaseOrderItem{
+			ProductID: line.ProductID.String(),
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+		}
+	}
+
+	expected := order.ExpectedDeliveryDate
+	if expected == nil {
+		date := time.Now().AddDate(0, 0, int(supplier.LeadTimeDays))
+		expected = &date
+	}
+	notes := order.Notes
+	if notes == nil {
+		text := "Raised from reorder suggestions"
+		notes = &text
+	}
+	warehouseID := order.WarehouseID
+
+	return models.CreatePurchaseOrderRequest{
+		SupplierName:         supplier.Name,
+		SupplierContact:      supplier.ContactPerson,
+		ExpectedDeliveryDate: expected,
+		Notes:                notes,
+		WarehouseID:          &warehouseID,
+		CreatedBy:            userID.String(),
+		Status:               status,
+		Items:                items,
+	}, nil
+}
+
+// reorderSuggestions checks every active rule against the stock position of
+// its product and warehouse: available stock plus the open quantity of
+// purchase orders delivering there, drafts included so suggestions already
+// converted are not suggested again. Lines are priced at the last price paid,
+// else the current unit cost.
+func reorderSuggestions(ctx context.Context, q *sqlc.Queries, filter models.ReorderSuggestionFilter, now time.Time) (*models.ReorderSuggestions, error) {
+	candidates, err := q.ListReorderCandidates(ctx, &sqlc.ListReorderCandidatesParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.SupplierID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ReorderSuggestions{
+		GeneratedAt: now,
+		Orders:      []models.SuggestedPurchaseOrder{},
+		Unassigned:  []models.ReorderSuggestionLine{},
+	}
+	type orderKey struct{ supplierID, warehouseID uuid.UUID }
+	index := make(map[orderKey]int)
+	for _, c := range candidates {
+		position := int(c.Quantity-c.ReservedQuantity) + int(c.OnOrderQuantity)
+		suggested := models.SuggestReorderQuantity(position, int(c.ReorderPoint), int(c.ReorderQuantity))
+		if suggested == 0 {
+			continue
+		}
+
+		unitPrice := utils.PgxNumericToFloat64(c.UnitPrice)
+		line := models.ReorderSuggestionLine{
+			RuleID:            utils.PgxUUIDToUUID(c.RuleID),
+			ProductID:         utils.PgxUUIDToUUID(c.ProductID),
+			ProductName:       c.ProductName,
+			ProductSKU:        c.Sku,
+			WarehouseID:       utils.PgxUUIDToUUID(c.WarehouseID),
+			Quantity:          int(c.Quantity),
+			ReservedQuantity:  int(c.ReservedQuantity),
+			OnOrderQuantity:   int(c.OnOrderQuantity),
+			Position:          position,
+			ReorderPoint:      int(c.ReorderPoint),
+			ReorderQuantity:   int(c.ReorderQuantity),
+			SuggestedQuantity: suggested,
+			UnitPrice:         unitPrice,
+			TotalPrice:        unitPrice * float64(suggested),
+		}
+		if !c.SupplierID.Valid || c.SupplierName == nil {
+			result.Unassigned = append(result.Unassigned, line)
+			continue
+		}
+
+		key := orderKey{utils.PgxUUIDToUUID(c.SupplierID), line.WarehouseID}
+		i, ok := index[key]
+		if !ok {
+			i = len(result.Orders)
+			index[key] = i
+			result.Orders = append(result.Orders, models.SuggestedPurchaseOrder{
+				SupplierID:           key.supplierID,
+				SupplierName:         *c.SupplierName,
+				WarehouseID:          key.warehouseID,
+				WarehouseName:        c.WarehouseName,
+				LeadTimeDays:         int(c.LeadTimeDays),
+				ExpectedDeliveryDate: now.AddDate(0, 0, int(c.LeadTimeDays)),
+			})
+		}
+		order := &result.Orders[i]
+		order.Lines = append(order.Lines, line)
+		order.TotalAmount += line.TotalPrice
+	}
+	return result, nil
+}
+
+func validateReorderRule(reorderPoint, reorderQuantity int) error {
+	if reorderPoint < 0 {
+		return errors.New("reorder point cannot be negative")
+	}
+	if reorderQuantity <= 0 {
+		return errors.New("reorder quantity must be greater than zero")
+	}
+	return nil
+}
+
+func toReorderRuleModel(r *sqlc.ReorderRule) models.ReorderRule {
+	return models.ReorderRule{
+		ID:              utils.PgxUUIDToUUID(r.ID),
+		ProductID:       utils.PgxUUIDToUUID(r.ProductID),
+		WarehouseID:     utils.PgxUUIDToUUID(r.WarehouseID),
+		ReorderPoint:    int(r.ReorderPoint),
+		ReorderQuantity: int(r.ReorderQuantity),
+		IsActive:        r.IsActive,
+		CreatedAt:       utils.PgxTimestamptzToTime(r.CreatedAt),
+		UpdatedAt:       utils.PgxTimestamptzToTime(r.UpdatedAt),
+	}
+}
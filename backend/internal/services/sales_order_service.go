@@ -0,0 +1,662 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SalesOrderService manages sales orders through the pending → confirmed →
+// shipped → delivered lifecycle. Confirming an order reserves its lines,
+// shipping consumes those reservations with "out" movements, and cancelling a
+// confirmed order releases them again.
+type SalesOrderService struct {
+	db *database.DB
+}
+
+func NewSalesOrderService(db *database.DB) *SalesOrderService {
+	return &SalesOrderService{db: db}
+}
+
+func (s *SalesOrderService) CreateSalesOrder(ctx context.Context, req models.CreateSalesOrderRequest, userID uuid.UUID) (*models.SalesOrder, error) {
+	if strings.TrimSpace(req.CustomerName) == "" {
+		return nil, errors.New("customer name is required")
+	}
+	if err := validateSalesOrderItems(req.Items); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	soNumber := fmt.Sprintf("SO-%d", time.Now().UnixMilli())
+	if req.SoNumber != nil && *req.SoNumber != "" {
+		soNumber = *req.SoNumber
+	}
+	orderDate := time.Now()
+	if req.OrderDate != nil {
+		orderDate = *req.OrderDate
+	}
+
+	order, err := q.CreateSalesOrder(ctx, &sqlc.CreateSalesOrderParams{
+		SoNumber:             soNumber,
+		CustomerName:         req.CustomerName,
+		CustomerContact:      req.CustomerContact,
+		OrderDate:            utils.TimeToPgxDate(orderDate),
+		ExpectedDeliveryDate: utils.TimeToPgxDatePtr(req.ExpectedDeliveryDate),
+		Notes:                req.Notes,
+		CreatedBy:            utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := replaceSalesOrderItems(ctx, q, order.ID, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrder(ctx, utils.PgxUUIDToUUID(order.ID))
+}
+
+func (s *SalesOrderService) GetSalesOrder(ctx context.Context, id uuid.UUID) (*models.SalesOrder, error) {
+	order, err := s.db.GetSalesOrder(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.ListSalesOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toSalesOrderModel(&sqlc.SalesOrder{
+		ID:                   order.ID,
+		SoNumber:             order.SoNumber,
+		CustomerName:         order.CustomerName,
+		CustomerContact:      order.CustomerContact,
+		TotalAmount:          order.TotalAmount,
+		Status:               order.Status,
+		OrderDate:            order.OrderDate,
+		ExpectedDeliveryDate: order.ExpectedDeliveryDate,
+		ShippedDate:          order.ShippedDate,
+		DeliveredDate:        order.DeliveredDate,
+		Notes:                order.Notes,
+		CreatedBy:            order.CreatedBy,
+		CreatedAt:            order.CreatedAt,
+		UpdatedAt:            order.UpdatedAt,
+	})
+	result.CreatedByFirstName = &order.FirstName
+	result.CreatedByLastName = &order.LastName
+
+	result.Items = make([]models.SalesOrderLine, len(items))
+	for i, item := range items {
+		var shipped int
+		if item.ShippedQuantity != nil {
+			shipped = int(*item.ShippedQuantity)
+		}
+		result.Items[i] = models.SalesOrderLine{
+			ID:              utils.PgxUUIDToUUID(item.ID),
+			ProductID:       utils.PgxUUIDToUUID(item.ProductID),
+			WarehouseID:     utils.PgxUUIDToUUID(item.WarehouseID),
+			Quantity:        int(item.Quantity),
+			UnitPrice:       utils.PgxNumericToFloat64(item.UnitPrice),
+			TotalPrice:      utils.PgxNumericToFloat64(item.TotalPrice),
+			ShippedQuantity: shipped,
+			UOM:             item.Uom,
+			UOMQuantity:     utils.OptionalInt32PtrToInt(item.UomQuantity),
+			UOMPrice:        uomPrice(utils.PgxNumericToFloat64(item.TotalPrice), item.UomQuantity),
+			UOMFactor:       utils.OptionalInt32PtrToInt(item.UomFactor),
+			ProductName:     &item.ProductName,
+			ProductSKU:      &item.Sku,
+			WarehouseName:   &item.WarehouseName,
+		}
+	}
+
+	return &result, nil
+}
+
+func (s *SalesOrderService) ListSalesOrders(ctx context.Context, filter models.SalesOrderFilter) (*models.SalesOrderListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	orders, err := s.db.ListSalesOrdersWithFilter(ctx, &sqlc.ListSalesOrdersWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalStringToString(filter.CustomerName),
+		Column3: utils.TimeToPgxDatePtr(filter.DateFrom),
+		Column4: utils.TimeToPgxDatePtr(filter.DateTo),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountSalesOrdersWithFilter(ctx, &sqlc.CountSalesOrdersWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalStringToString(filter.CustomerName),
+		Column3: utils.TimeToPgxDatePtr(filter.DateFrom),
+		Column4: utils.TimeToPgxDatePtr(filter.DateTo),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.SalesOrder, len(orders))
+	for i, order := range orders {
+		result[i] = toSalesOrderModel(&sqlc.SalesOrder{
+			ID:                   order.ID,
+			SoNumber:             order.SoNumber,
+			CustomerName:         order.CustomerName,
+			CustomerContact:      order.CustomerContact,
+			TotalAmount:          order.TotalAmount,
+			Status:               order.Status,
+			OrderDate:            order.OrderDate,
+			ExpectedDeliveryDate: order.ExpectedDeliveryDate,
+			ShippedDate:          order.ShippedDate,
+			DeliveredDate:        order.DeliveredDate,
+			Notes:                order.Notes,
+			CreatedBy:            order.CreatedBy,
+			CreatedAt:            order.CreatedAt,
+			UpdatedAt:            order.UpdatedAt,
+		})
+		result[i].CreatedByFirstName = &order.FirstName
+		result[i].CreatedByLastName = &order.LastName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.SalesOrderListResponse{
+		SalesOrders: result,
+		Total:       total,
+		Page:        filter.Page,
+		Limit:       filter.Limit,
+		Pages:       pages,
+	}, nil
+}
+
+// UpdateSalesOrder replaces the header details and lines of a pending order
+func (s *SalesOrderService) UpdateSalesOrder(ctx context.Context, id uuid.UUID, req models.UpdateSalesOrderRequest) (*models.SalesOrder, error) {
+	if strings.TrimSpace(req.CustomerName) == "" {
+		return nil, errors.New("customer name is required")
+	}
+	if err := validateSalesOrderItems(req.Items); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := q.GetSalesOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SalesOrderStatusPending {
+		return nil, fmt.Errorf("%w: only pending sales orders can be edited", ErrInvalidStatusTransition)
+	}
+
+	order.CustomerName = req.CustomerName
+	order.CustomerContact = req.CustomerContact
+	order.ExpectedDeliveryDate = utils.TimeToPgxDatePtr(req.ExpectedDeliveryDate)
+	order.Notes = req.Notes
+	if _, err := updateSalesOrder(ctx, q, order); err != nil {
+		return nil, err
+	}
+
+	if err := q.DeleteSalesOrderItems(ctx, order.ID); err != nil {
+		return nil, err
+	}
+	if err := replaceSalesOrderItems(ctx, q, order.ID, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrder(ctx, id)
+}
+
+// DeleteSalesOrder removes a pending or cancelled order and its lines. Orders
+// that have shipped have ledger history and cannot be deleted.
+func (s *SalesOrderService) DeleteSalesOrder(ctx context.Context, id uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := q.GetSalesOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return err
+	}
+	if order.Status != models.SalesOrderStatusPending && order.Status != models.SalesOrderStatusCancelled {
+		return fmt.Errorf("%w: cannot delete a %s sales order", ErrInvalidStatusTransition, order.Status)
+	}
+
+	if err := q.DeleteSalesOrder(ctx, order.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ConfirmSalesOrder reserves stock for every line of a pending order. The
+// whole order is confirmed or nothing is reserved.
+func (s *SalesOrderService) ConfirmSalesOrder(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.SalesOrder, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := q.GetSalesOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SalesOrderStatusPending {
+		return nil, fmt.Errorf("%w: cannot confirm a %s sales order", ErrInvalidStatusTransition, order.Status)
+	}
+
+	items, err := q.ListSalesOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.New("sales order has no items")
+	}
+
+	keys := make([]stockKey, len(items))
+	for i, item := range items {
+		keys[i] = stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: utils.PgxUUIDToUUID(item.WarehouseID)}
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	ownerType := models.SalesOrderReferenceType
+	for _, item := range items {
+		_, err := reserveStock(ctx, q, models.CreateStockReservationRequest{
+			ProductID:   utils.PgxUUIDToUUID(item.ProductID),
+			WarehouseID: utils.PgxUUIDToUUID(item.WarehouseID),
+			Quantity:    int(item.Quantity),
+			OwnerType:   &ownerType,
+			OwnerID:     &id,
+			Notes:       &order.SoNumber,
+		}, &userID)
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, fmt.Errorf("%w for %s (%s) in %s", ErrInsufficientStock, item.ProductName, item.Sku, item.WarehouseName)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order.Status = models.SalesOrderStatusConfirmed
+	if _, err := updateSalesOrder(ctx, q, order); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrder(ctx, id)
+}
+
+// ShipSalesOrder posts "out" movements for every line of a confirmed order.
+// Quantities are taken from the order's reservations first; anything no longer
+// held (for example a reservation released by hand or expired) must come from
+// available stock. Quantities already issued from the order's reservations
+// count as shipped.
+func (s *SalesOrderService) ShipSalesOrder(ctx context.Context, id uuid.UUID, req models.ShipSalesOrderRequest, userID uuid.UUID) (*models.SalesOrder, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := q.GetSalesOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SalesOrderStatusConfirmed {
+		return nil, fmt.Errorf("%w: cannot ship a %s sales order", ErrInvalidStatusTransition, order.Status)
+	}
+
+	items, err := q.ListSalesOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerType := models.SalesOrderReferenceType
+	reservations, err := q.ListActiveStockReservationsByOwner(ctx, &sqlc.ListActiveStockReservationsByOwnerParams{
+		OwnerType: &ownerType,
+		OwnerID:   order.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]stockKey, len(items))
+	outstanding := make(map[stockKey]int32, len(items))
+	for i, item := range items {
+		keys[i] = stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: utils.PgxUUIDToUUID(item.WarehouseID)}
+		var shipped int32
+		if item.ShippedQuantity != nil {
+			shipped = *item.ShippedQuantity
+		}
+		outstanding[keys[i]] += item.Quantity - shipped
+	}
+	// Whatever was already issued from the order's holds has left the
+	// warehouse and only the rest is still to ship
+	consumed, err := q.ListConsumedStockReservationQuantitiesByOwner(ctx, &sqlc.ListConsumedStockReservationQuantitiesByOwnerParams{
+		OwnerType: &ownerType,
+		OwnerID:   order.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range consumed {
+		key := stockKey{ProductID: utils.PgxUUIDToUUID(row.ProductID), WarehouseID: utils.PgxUUIDToUUID(row.WarehouseID)}
+		outstanding[key] = max(outstanding[key]-row.ConsumedQuantity, 0)
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	// Serial numbers are pooled per product and warehouse and handed out to
+	// the postings in the order given
+	serials := make(map[stockKey][]string)
+	for _, line := range req.Lines {
+		var key *stockKey
+		for i, item := range items {
+			if utils.PgxUUIDToUUID(item.ID) == line.ItemID {
+				key = &keys[i]
+				break
+			}
+		}
+		if key == nil {
+			return nil, fmt.Errorf("item %s does not belong to this sales order", line.ItemID)
+		}
+		serials[*key] = append(serials[*key], line.SerialNumbers...)
+	}
+	takeSerials := func(key stockKey, quantity int32) []string {
+		n := min(int(quantity), len(serials[key]))
+		taken := serials[key][:n]
+		serials[key] = serials[key][n:]
+		return taken
+	}
+
+	now := time.Now()
+	for _, reservation := range reservations {
+		// An expired hold no longer covers the order; release it as the
+		// sweeper would and ship from available stock instead
+		if reservationExpired(reservation, now) {
+			if _, err := releaseReservation(ctx, q, reservation, reservation.Quantity, models.StockReservationStatusExpired); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		key := stockKey{ProductID: utils.PgxUUIDToUUID(reservation.ProductID), WarehouseID: utils.PgxUUIDToUUID(reservation.WarehouseID)}
+		quantity := min(reservation.Quantity, outstanding[key])
+		if quantity > 0 {
+			reservation, _, err = consumeReservation(ctx, q, reservation, quantity, takeSerials(key, quantity), &order.SoNumber, nil, &userID)
+			if err != nil {
+				return nil, err
+			}
+			outstanding[key] -= quantity
+		}
+		// Holds beyond what the order still needs are handed back
+		if reservation.Status == models.StockReservationStatusActive {
+			if _, err := releaseReservation(ctx, q, reservation, reservation.Quantity, models.StockReservationStatusReleased); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	referenceType := models.SalesOrderReferenceType
+	for _, item := range items {
+		key := stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: utils.PgxUUIDToUUID(item.WarehouseID)}
+		if outstanding[key] > 0 {
+			_, err := postStockMovement(ctx, q, stockPosting{
+				ProductID:       key.ProductID,
+				WarehouseID:     key.WarehouseID,
+				MovementType:    "out",
+				Quantity:        int(outstanding[key]),
+				ReferenceType:   &referenceType,
+				ReferenceID:     &id,
+				ReferenceNumber: &order.SoNumber,
+				UserID:          &userID,
+				Serials:         takeSerials(key, outstanding[key]),
+				UOM:             uomFromColumns(item.UomID, item.Uom, item.UomFactor),
+			})
+			if errors.Is(err, ErrInsufficientStock) {
+				return nil, fmt.Errorf("%w for %s (%s) in %s", ErrInsufficientStock, item.ProductName, item.Sku, item.WarehouseName)
+			}
+			if err != nil {
+				return nil, err
+			}
+			outstanding[key] = 0
+		}
+
+		if _, err := q.UpdateSalesOrderItemShippedQuantity(ctx, &sqlc.UpdateSalesOrderItemShippedQuantityParams{
+			ID:              item.ID,
+			ShippedQuantity: &item.Quantity,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, item := range items {
+		if left := serials[keys[i]]; len(left) > 0 {
+			return nil, fmt.Errorf("serial number %s was given but not shipped for %s (%s)", left[0], item.ProductName, item.Sku)
+		}
+	}
+
+	order.Status = models.SalesOrderStatusShipped
+	order.ShippedDate = utils.TimeToPgxDate(time.Now())
+	if _, err := updateSalesOrder(ctx, q, order); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrder(ctx, id)
+}
+
+// DeliverSalesOrder records that a shipped order reached the customer
+func (s *SalesOrderService) DeliverSalesOrder(ctx context.Context, id uuid.UUID) (*models.SalesOrder, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := q.GetSalesOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SalesOrderStatusShipped {
+		return nil, fmt.Errorf("%w: cannot deliver a %s sales order", ErrInvalidStatusTransition, order.Status)
+	}
+
+	order.Status = models.SalesOrderStatusDelivered
+	order.DeliveredDate = utils.TimeToPgxDate(time.Now())
+	if _, err := updateSalesOrder(ctx, q, order); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrder(ctx, id)
+}
+
+// CancelSalesOrder cancels an order that has not shipped and releases any
+// stock it still has reserved
+func (s *SalesOrderService) CancelSalesOrder(ctx context.Context, id uuid.UUID) (*models.SalesOrder, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	order, err := q.GetSalesOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SalesOrderStatusPending && order.Status != models.SalesOrderStatusConfirmed {
+		return nil, fmt.Errorf("%w: cannot cancel a %s sales order", ErrInvalidStatusTransition, order.Status)
+	}
+
+	ownerType := models.SalesOrderReferenceType
+	reservations, err := q.ListActiveStockReservationsByOwner(ctx, &sqlc.ListActiveStockReservationsByOwnerParams{
+		OwnerType: &ownerType,
+		OwnerID:   order.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]stockKey, len(reservations))
+	for i, reservation := range reservations {
+		keys[i] = stockKey{ProductID: utils.PgxUUIDToUUID(reservation.ProductID), WarehouseID: utils.PgxUUIDToUUID(reservation.WarehouseID)}
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	for _, reservation := range reservations {
+		if _, err := releaseReservation(ctx, q, reservation, reservation.Quantity, models.StockReservationStatusReleased); err != nil {
+			return nil, err
+		}
+	}
+
+	order.Status = models.SalesOrderStatusCancelled
+	if _, err := updateSalesOrder(ctx, q, order); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrder(ctx, id)
+}
+
+func validateSalesOrderItems(items []models.SalesOrderItem) error {
+	if len(items) == 0 {
+		return errors.New("at least one item is required")
+	}
+	for _, item := range items {
+		if item.ProductID == uuid.Nil || item.WarehouseID == uuid.Nil {
+			return errors.New("product and warehouse are required for every item")
+		}
+		if item.Quantity <= 0 {
+			return errors.New("item quantity must be greater than zero")
+		}
+		if item.UnitPrice < 0 {
+			return errors.New("unit price cannot be negative")
+		}
+	}
+	return nil
+}
+
+// replaceSalesOrderItems writes the order lines and recomputes the order total
+// from them. Existing lines must already have been deleted. Lines sold in
+// another unit are stored in base units, with the line total taken from the
+// quantity and price as entered.
+func replaceSalesOrderItems(ctx context.Context, q *sqlc.Queries, orderID pgtype.UUID, items []models.SalesOrderItem) error {
+	for _, item := range items {
+		uom, err := resolveProductUOM(ctx, q, item.ProductID, item.UOM)
+		if err != nil {
+			return err
+		}
+		quantity := uom.toBase(item.Quantity)
+		uomID, uomQuantity, uomFactor := uom.columns(quantity)
+		if _, err := q.CreateSalesOrderItem(ctx, &sqlc.CreateSalesOrderItemParams{
+			SalesOrderID: orderID,
+			ProductID:    utils.UUIDToPgxUUID(item.ProductID),
+			WarehouseID:  utils.UUIDToPgxUUID(item.WarehouseID),
+			Quantity:     int32(quantity),
+			UnitPrice:    utils.CostToPgxNumeric(*uom.priceToBase(&item.UnitPrice)),
+			TotalPrice:   utils.Float64ToPgxNumeric(float64(item.Quantity) * item.UnitPrice),
+			UomID:        uomID,
+			UomQuantity:  uomQuantity,
+			UomFactor:    uomFactor,
+		}); err != nil {
+			return err
+		}
+	}
+
+	total, err := q.GetSalesOrderItemsTotal(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	_, err = q.UpdateSalesOrderTotal(ctx, &sqlc.UpdateSalesOrderTotalParams{
+		ID:          orderID,
+		TotalAmount: total,
+	})
+	return err
+}
+
+// updateSalesOrder writes back the editable header fields and status of order
+func updateSalesOrder(ctx context.Context, q *sqlc.Queries, order *sqlc.SalesOrder) (*sqlc.SalesOrder, error) {
+	return q.UpdateSalesOrder(ctx, &sqlc.UpdateSalesOrderParams{
+		ID:                   order.ID,
+		CustomerName:         order.CustomerName,
+		CustomerContact:      order.CustomerContact,
+		Status:               order.Status,
+		ExpectedDeliveryDate: order.ExpectedDeliveryDate,
+		ShippedDate:          order.ShippedDate,
+		DeliveredDate:        order.DeliveredDate,
+		Notes:                order.Notes,
+	})
+}
+
+func toSalesOrderModel(o *sqlc.SalesOrder) models.SalesOrder {
+	return models.SalesOrder{
+		ID:                   utils.PgxUUIDToUUID(o.ID),
+		SoNumber:             o.SoNumber,
+		CustomerName:         o.CustomerName,
+		CustomerContact:      o.CustomerContact,
+		TotalAmount:          utils.PgxNumericToFloat64(o.TotalAmount),
+		Status:               o.Status,
+		OrderDate:            utils.PgxDateToTime(o.OrderDate),
+		ExpectedDeliveryDate: utils.PgxDateToTimePtr(o.ExpectedDeliveryDate),
+		ShippedDate:          utils.PgxDateToTimePtr(o.ShippedDate),
+		DeliveredDate:        utils.PgxDateToTimePtr(o.DeliveredDate),
+		Notes:                o.Notes,
+		CreatedBy:            utils.PgxUUIDToUUID(o.CreatedBy),
+		CreatedAt:            utils.PgxTimestamptzToTime(o.CreatedAt),
+		UpdatedAt:            utils.PgxTimestamptzToTime(o.UpdatedAt),
+	}
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SerialNumberService exposes the serial number registry. Serial numbers are
+// registered and moved by postStockMovement.
+type SerialNumberService struct {
+	db *database.DB
+}
+
+func NewSerialNumberService(db *database.DB) *SerialNumberService {
+	return &SerialNumberService{db: db}
+}
+
+// LookupSerialNumber returns a unit with its current warehouse and every
+// movement that moved it. productID is needed only when the same serial
+// number is registered for more than one product.
+func (s *SerialNumberService) LookupSerialNumber(ctx context.Context, serialNumber string, productID *uuid.UUID) (*models.SerialNumber, error) {
+	rows, err := s.db.ListSerialNumbersBySerial(ctx, &sqlc.ListSerialNumbersBySerialParams{
+		SerialNumber: strings.TrimSpace(serialNumber),
+		Column2:      utils.OptionalUUIDToPgxUUID(productID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	if len(rows) > 1 {
+		return nil, fmt.Errorf("serial number %s is registered for %d products; give a product_id", serialNumber, len(rows))
+	}
+	row := rows[0]
+
+	movements, err := s.db.ListSerialNumberMovements(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toSerialNumberModel(&sqlc.SerialNumber{
+		ID:           row.ID,
+		ProductID:    row.ProductID,
+		SerialNumber: row.SerialNumber,
+		Status:       row.Status,
+		WarehouseID:  row.WarehouseID,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	})
+	result.ProductName = &row.ProductName
+	result.ProductSKU = &row.Sku
+	result.WarehouseName = row.WarehouseName
+
+	result.Movements = make([]models.SerialNumberMovement, len(movements))
+	for i, m := range movements {
+		result.Movements[i] = models.SerialNumberMovement{
+			StockMovementID: utils.PgxUUIDToUUID(m.ID),
+			MovementType:    m.MovementType,
+			WarehouseID:     utils.PgxUUIDToUUID(m.WarehouseID),
+			WarehouseName:   m.WarehouseName,
+			ReferenceType:   m.ReferenceType,
+			ReferenceID:     utils.OptionalPgxUUIDToUUID(m.ReferenceID),
+			ReferenceNumber: m.ReferenceNumber,
+			ProcessedDate:   utils.OptionalPgxTimestamptzToTimePtr(m.ProcessedDate),
+			CreatedAt:       utils.PgxTimestamptzToTime(m.CreatedAt),
+		}
+	}
+
+	return &result, nil
+}
+
+func (s *SerialNumberService) ListSerialNumbers(ctx context.Context, filter models.SerialNumberFilter) (*models.SerialNumberListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListSerialNumbersWithFilter(ctx, &sqlc.ListSerialNumbersWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Column3: utils.OptionalStringToString(filter.Status),
+		Column4: utils.OptionalStringToString(filter.SerialNumber),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountSerialNumbersWithFilter(ctx, &sqlc.CountSerialNumbersWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Column3: utils.OptionalStringToString(filter.Status),
+		Column4: utils.OptionalStringToString(filter.SerialNumber),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.SerialNumber, len(rows))
+	for i, row := range rows {
+		result[i] = toSerialNumberModel(&sqlc.SerialNumber{
+			ID:           row.ID,
+			ProductID:    row.ProductID,
+			SerialNumber: row.SerialNumber,
+			Status:       row.Status,
+			WarehouseID:  row.WarehouseID,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		})
+		result[i].ProductName = &row.ProductName
+		result[i].ProductSKU = &row.Sku
+		result[i].WarehouseName = row.WarehouseName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.SerialNumberListResponse{
+		SerialNumbers: result,
+		Total:         total,
+		Page:          filter.Page,
+		Limit:         filter.Limit,
+		Pages:         pages,
+	}, nil
+}
+
+// checkSerials validates the serial numbers given for quantity units of a
+// product and returns them trimmed. Serialized products need exactly one
+// distinct serial number per unit; other products take none.
+func checkSerials(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, quantity int, serials []string) ([]string, bool, error) {
+	product, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return nil, false, err
+	}
+	if !product.Serialized {
+		if len(serials) > 0 {
+			return nil, false, fmt.Errorf("%s (%s) is not serialized", product.Name, product.Sku)
+		}
+		return nil, false, nil
+	}
+	if len(serials) != quantity {
+		return nil, true, fmt.Errorf("%s (%s) is serialized; %d serial numbers are required, got %d", product.Name, product.Sku, quantity, len(serials))
+	}
+
+	result := make([]string, len(serials))
+	seen := make(map[string]bool, len(serials))
+	for i, serial := range serials {
+		serial = strings.TrimSpace(serial)
+		if serial == "" {
+			return nil, true, errors.New("serial numbers cannot be blank")
+		}
+		if seen[serial] {
+			return nil, true, fmt.Errorf("serial number %s is given more than once", serial)
+		}
+		seen[serial] = true
+		result[i] = serial
+	}
+	return result, true, nil
+}
+
+// postMovementSerials registers the units a posting moves. Receipts register
+// new serial numbers or bring back ones that were issued or are in transit on
+// the same document; issues need the units in stock in the warehouse. Units
+// leaving on a transfer are in transit until the destination receives them.
+func postMovementSerials(ctx context.Context, q *sqlc.Queries, p stockPosting, movementID pgtype.UUID) error {
+	delta := p.delta()
+	quantity := int(delta)
+	if quantity < 0 {
+		quantity = -quantity
+	}
+	serials, serialized, err := checkSerials(ctx, q, p.ProductID, quantity, p.Serials)
+	if err != nil || !serialized {
+		return err
+	}
+
+	warehouseID := utils.UUIDToPgxUUID(p.WarehouseID)
+	for _, serial := range serials {
+		sn, err := q.GetSerialNumberForUpdate(ctx, &sqlc.GetSerialNumberForUpdateParams{
+			ProductID:    utils.UUIDToPgxUUID(p.ProductID),
+			SerialNumber: serial,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		found := err == nil
+
+		var serialID pgtype.UUID
+		switch {
+		case delta > 0 && !found:
+			created, err := q.CreateSerialNumber(ctx, &sqlc.CreateSerialNumberParams{
+				ProductID:      utils.UUIDToPgxUUID(p.ProductID),
+				SerialNumber:   serial,
+				Status:         models.SerialNumberStatusInStock,
+				WarehouseID:    warehouseID,
+				LastMovementID: movementID,
+			})
+			if err != nil {
+				return err
+			}
+			serialID = created.ID
+		case delta > 0:
+			if sn.Status == models.SerialNumberStatusInStock {
+				return fmt.Errorf("serial number %s is already in stock", serial)
+			}
+			if sn.Status == models.SerialNumberStatusInTransit && !sameReference(sn.LastReferenceType, sn.LastReferenceID, p.ReferenceType, p.ReferenceID) {
+				return fmt.Errorf("serial number %s is in transit on another document", serial)
+			}
+			if err := moveSerial(ctx, q, sn.ID, models.SerialNumberStatusInStock, warehouseID, movementID); err != nil {
+				return err
+			}
+			serialID = sn.ID
+		default:
+			if !found || sn.Status != models.SerialNumberStatusInStock || sn.WarehouseID != warehouseID {
+				return fmt.Errorf("serial number %s is not in stock in this warehouse", serial)
+			}
+			status := models.SerialNumberStatusIssued
+			if p.MovementType == "out" && models.IsTransferReference(p.ReferenceType) {
+				status = models.SerialNumberStatusInTransit
+			}
+			if err := moveSerial(ctx, q, sn.ID, status, pgtype.UUID{}, movementID); err != nil {
+				return err
+			}
+			serialID = sn.ID
+		}
+
+		if _, err := q.CreateStockMovementSerial(ctx, &sqlc.CreateStockMovementSerialParams{
+			StockMovementID: movementID,
+			SerialNumberID:  serialID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func moveSerial(ctx context.Context, q *sqlc.Queries, id pgtype.UUID, status string, warehouseID, movementID pgtype.UUID) error {
+	_, err := q.UpdateSerialNumberLocation(ctx, &sqlc.UpdateSerialNumberLocationParams{
+		ID:             id,
+		Status:         status,
+		WarehouseID:    warehouseID,
+		LastMovementID: movementID,
+	})
+	return err
+}
+
+// sameReference reports whether a unit's last movement was raised by the
+// document a posting references
+func sameReference(lastType *string, lastID pgtype.UUID, referenceType *string, referenceID *uuid.UUID) bool {
+	if lastType == nil || referenceType == nil || !lastID.Valid || referenceID == nil {
+		return false
+	}
+	return *lastType == *referenceType && utils.PgxUUIDToUUID(lastID) == *referenceID
+}
+
+func toSerialNumberModel(sn *sqlc.SerialNumber) models.SerialNumber {
+	return models.SerialNumber{
+		ID:           utils.PgxUUIDToUUID(sn.ID),
+		ProductID:    utils.PgxUUIDToUUID(sn.ProductID),
+		SerialNumber: sn.SerialNumber,
+		Status:       sn.Status,
+		WarehouseID:  utils.OptionalPgxUUIDToUUID(sn.WarehouseID),
+		CreatedAt:    utils.PgxTimestamptzToTime(sn.CreatedAt),
+		UpdatedAt:    utils.PgxTimestamptzToTime(sn.UpdatedAt),
+	}
+}
@@ -0,0 +1,542 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// StockLotService exposes lots and their balances, and traces where the stock
+// of a lot went. Lot balances are maintained by postStockMovement.
+type StockLotService struct {
+	db *database.DB
+}
+
+func NewStockLotService(db *database.DB) *StockLotService {
+	return &StockLotService{db: db}
+}
+
+// GetStockLot returns a lot with its balance per warehouse
+func (s *StockLotService) GetStockLot(ctx context.Context, id uuid.UUID) (*models.StockLot, error) {
+	lot, err := s.db.GetStockLot(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := s.db.ListStockLotLevels(ctx, lot.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toStockLotModel(&sqlc.StockLot{
+		ID:              lot.ID,
+		ProductID:       lot.ProductID,
+		LotNumber:       lot.LotNumber,
+		ManufactureDate: lot.ManufactureDate,
+		ExpiryDate:      lot.ExpiryDate,
+		CreatedAt:       lot.CreatedAt,
+		UpdatedAt:       lot.UpdatedAt,
+	})
+	result.ProductName = &lot.ProductName
+	result.ProductSKU = &lot.Sku
+
+	result.Levels = make([]models.StockLotLevel, len(levels))
+	for i, level := range levels {
+		result.Levels[i] = models.StockLotLevel{
+			WarehouseID:   utils.PgxUUIDToUUID(level.WarehouseID),
+			WarehouseName: level.WarehouseName,
+			Quantity:      int(level.Quantity),
+		}
+		result.Quantity += int(level.Quantity)
+	}
+
+	return &result, nil
+}
+
+func (s *StockLotService) ListStockLots(ctx context.Context, filter models.StockLotFilter) (*models.StockLotListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	lots, err := s.db.ListStockLotsWithFilter(ctx, &sqlc.ListStockLotsWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalStringToString(filter.LotNumber),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountStockLotsWithFilter(ctx, &sqlc.CountStockLotsWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalStringToString(filter.LotNumber),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockLot, len(lots))
+	for i, lot := range lots {
+		result[i] = toStockLotModel(&sqlc.StockLot{
+			ID:              lot.ID,
+			ProductID:       lot.ProductID,
+			LotNumber:       lot.LotNumber,
+			ManufactureDate: lot.ManufactureDate,
+			ExpiryDate:      lot.ExpiryDate,
+			CreatedAt:       lot.CreatedAt,
+			UpdatedAt:       lot.UpdatedAt,
+		})
+		result[i].Quantity = int(lot.Quantity)
+		result[i].ProductName = &lot.ProductName
+		result[i].ProductSKU = &lot.Sku
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.StockLotListResponse{
+		StockLots: result,
+		Total:     total,
+		Page:      filter.Page,
+		Limit:     filter.Limit,
+		Pages:     pages,
+	}, nil
+}
+
+// ListStockLotMovements returns every movement that took stock from or put
+// stock into the lot, oldest first, so a batch can be traced for a recall
+func (s *StockLotService) ListStockLotMovements(ctx context.Context, id uuid.UUID) ([]models.StockLotMovement, error) {
+	if _, err := s.db.GetStockLot(ctx, utils.UUIDToPgxUUID(id)); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.ListStockLotMovements(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockLotMovement, len(rows))
+	for i, row := range rows {
+		result[i] = models.StockLotMovement{
+			StockMovementID: utils.PgxUUIDToUUID(row.ID),
+			MovementType:    row.MovementType,
+			WarehouseID:     utils.PgxUUIDToUUID(row.WarehouseID),
+			WarehouseName:   row.WarehouseName,
+			Quantity:        int(row.Quantity),
+			ReferenceType:   row.ReferenceType,
+			ReferenceID:     utils.OptionalPgxUUIDToUUID(row.ReferenceID),
+			ReferenceNumber: row.ReferenceNumber,
+			ProcessedDate:   utils.OptionalPgxTimestamptzToTimePtr(row.ProcessedDate),
+			CreatedAt:       utils.PgxTimestamptzToTime(row.CreatedAt),
+		}
+	}
+	return result, nil
+}
+
+// GetExpiringStockReport lists the lot balances that expire within the given
+// number of days, including lots already past their expiry date, valued at
+// the product's unit price
+func (s *StockLotService) GetExpiringStockReport(ctx context.Context, days int, warehouseID *uuid.UUID) ([]models.ExpiringStockReport, error) {
+	rows, err := s.db.ListExpiringStockLots(ctx, &sqlc.ListExpiringStockLotsParams{
+		Column1: int32(days),
+		Column2: utils.OptionalUUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ExpiringStockReport, len(rows))
+	for i, row := range rows {
+		unitPrice := utils.PgxNumericToFloat64(row.UnitPrice)
+		result[i] = models.ExpiringStockReport{
+			LotID:         utils.PgxUUIDToUUID(row.LotID),
+			LotNumber:     row.LotNumber,
+			ExpiryDate:    row.ExpiryDate.Time,
+			DaysToExpiry:  int(row.DaysToExpiry),
+			ProductID:     utils.PgxUUIDToUUID(row.ProductID),
+			ProductName:   row.ProductName,
+			ProductSKU:    row.Sku,
+			WarehouseID:   utils.PgxUUIDToUUID(row.WarehouseID),
+			WarehouseName: row.WarehouseName,
+			Quantity:      int(row.Quantity),
+			UnitPrice:     unitPrice,
+			Value:         float64(row.Quantity) * unitPrice,
+		}
+	}
+
+	return result, nil
+}
+
+// lotAllocation is the part of a posting taken from or put into one lot
+type lotAllocation struct {
+	LotID    uuid.UUID
+	Quantity int
+}
+
+// singleLot returns the allocation of a whole posting to one lot, or nil when
+// no lot is given
+func singleLot(lotID *uuid.UUID, quantity int) []lotAllocation {
+	if lotID == nil {
+		return nil
+	}
+	if quantity < 0 {
+		quantity = -quantity
+	}
+	return []lotAllocation{{LotID: *lotID, Quantity: quantity}}
+}
+
+// resolveLot returns the lot a receipt of the product is booked into,
+// creating it on first use, or nil when no lot number is given. Dates given
+// for an existing lot must agree with those already recorded; dates the lot
+// lacks are filled in.
+func resolveLot(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, lotNumber *string, manufactureDate, expiryDate *time.Time) (*uuid.UUID, error) {
+	if lotNumber == nil || strings.TrimSpace(*lotNumber) == "" {
+		if manufactureDate != nil || expiryDate != nil {
+			return nil, errors.New("a lot number is required when manufacture or expiry dates are given")
+		}
+		return nil, nil
+	}
+	number := strings.TrimSpace(*lotNumber)
+	if manufactureDate != nil && expiryDate != nil && expiryDate.Before(*manufactureDate) {
+		return nil, fmt.Errorf("lot %s expires before it was manufactured", number)
+	}
+
+	lot, err := q.GetStockLotByNumber(ctx, &sqlc.GetStockLotByNumberParams{
+		ProductID: utils.UUIDToPgxUUID(productID),
+		LotNumber: number,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		lot, err = q.CreateStockLot(ctx, &sqlc.CreateStockLotParams{
+			ProductID:       utils.UUIDToPgxUUID(productID),
+			LotNumber:       number,
+			ManufactureDate: utils.TimeToPgxDatePtr(manufactureDate),
+			ExpiryDate:      utils.TimeToPgxDatePtr(expiryDate),
+		})
+		if err != nil {
+			return nil, err
+		}
+		id := utils.PgxUUIDToUUID(lot.ID)
+		return &id, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manufacture, mfgChanged, err := mergeLotDate(number, "manufacture", lot.ManufactureDate, manufactureDate)
+	if err != nil {
+		return nil, err
+	}
+	expiry, expChanged, err := mergeLotDate(number, "expiry", lot.ExpiryDate, expiryDate)
+	if err != nil {
+		return nil, err
+	}
+	if mfgChanged || expChanged {
+		if _, err := q.UpdateStockLotDates(ctx, &sqlc.UpdateStockLotDatesParams{
+			ID:              lot.ID,
+			ManufactureDate: manufacture,
+			ExpiryDate:      expiry,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	id := utils.PgxUUIDToUUID(lot.ID)
+	return &id, nil
+}
+
+// resolveReceiptLot resolves the lot a receipt is booked into and checks it
+// carries an expiry date when the product tracks expiry
+func resolveReceiptLot(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, lotNumber *string, manufactureDate, expiryDate *time.Time) (*uuid.UUID, error) {
+	lotID, err := resolveLot(ctx, q, productID, lotNumber, manufactureDate, expiryDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireExpiryLot(ctx, q, productID, lotID); err != nil {
+		return nil, err
+	}
+	return lotID, nil
+}
+
+// requireExpiryLot checks that a receipt of a product that tracks expiry is
+// booked into a lot with an expiry date
+func requireExpiryLot(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, lotID *uuid.UUID) error {
+	product, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return err
+	}
+	if !product.TracksExpiry {
+		return nil
+	}
+	if lotID == nil {
+		return fmt.Errorf("%s (%s) tracks expiry; a lot number and expiry date are required on receipt", product.Name, product.Sku)
+	}
+
+	lot, err := q.GetStockLot(ctx, utils.UUIDToPgxUUID(*lotID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("lot %s not found", *lotID)
+	}
+	if err != nil {
+		return err
+	}
+	if !lot.ExpiryDate.Valid {
+		return fmt.Errorf("%s (%s) tracks expiry; lot %s needs an expiry date", product.Name, product.Sku, lot.LotNumber)
+	}
+	return nil
+}
+
+// mergeLotDate checks a date given on receipt against the one recorded on the
+// lot and reports whether the lot needs updating
+func mergeLotDate(lotNumber, name string, recorded pgtype.Date, given *time.Time) (pgtype.Date, bool, error) {
+	if given == nil {
+		return recorded, false, nil
+	}
+	if !recorded.Valid {
+		return utils.TimeToPgxDate(*given), true, nil
+	}
+	if recorded.Time.Format("2006-01-02") != given.Format("2006-01-02") {
+		return recorded, false, fmt.Errorf("lot %s already has %s date %s", lotNumber, name, recorded.Time.Format("2006-01-02"))
+	}
+	return recorded, false, nil
+}
+
+// postMovementLots applies a posting to lot balances and records which lots
+// the movement used. Explicit lots may cover part of the quantity. The rest of
+// a receipt is unlotted stock; the rest of an issue comes from unlotted stock
+// first and then from the lots, oldest first. onHand is the stock level
+// quantity before the posting.
+func postMovementLots(ctx context.Context, q *sqlc.Queries, p stockPosting, movementID pgtype.UUID, onHand int32) error {
+	delta := p.delta()
+	remaining := delta
+	if remaining < 0 {
+		remaining = -remaining
+	}
+
+	allocations := make([]lotAllocation, 0, len(p.Lots))
+	for _, a := range p.Lots {
+		if a.Quantity <= 0 {
+			return errors.New("lot quantities must be greater than zero")
+		}
+		if int32(a.Quantity) > remaining {
+			return errors.New("lot quantities exceed the movement quantity")
+		}
+		remaining -= int32(a.Quantity)
+		allocations = append(allocations, a)
+	}
+	if delta < 0 && remaining > 0 {
+		taken := -delta - remaining
+		auto, err := allocateLots(ctx, q, p.ProductID, p.WarehouseID, remaining, onHand-taken, allocations)
+		if err != nil {
+			return err
+		}
+		allocations = append(allocations, auto...)
+	}
+
+	for _, a := range allocations {
+		change := int32(a.Quantity)
+		if delta < 0 {
+			change = -change
+		}
+		if err := applyLotDelta(ctx, q, p.ProductID, a.LotID, p.WarehouseID, change); err != nil {
+			return err
+		}
+		if _, err := q.CreateStockMovementLot(ctx, &sqlc.CreateStockMovementLotParams{
+			StockMovementID: movementID,
+			LotID:           utils.UUIDToPgxUUID(a.LotID),
+			Quantity:        int32(a.Quantity),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocateLots picks the lots the unassigned part of an issue is taken from.
+// Unlotted stock is used first; the rest comes from the lots in the order they
+// were created, or earliest expiry first (FEFO) for products that track
+// expiry. Picking a lot explicitly overrides this order. onHand and the lot
+// balances exclude the explicit allocations, which are applied only
+// afterwards.
+func allocateLots(ctx context.Context, q *sqlc.Queries, productID, warehouseID uuid.UUID, quantity, onHand int32, explicit []lotAllocation) ([]lotAllocation, error) {
+	product, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return nil, err
+	}
+
+	lotted, err := q.GetLottedStockQuantity(ctx, &sqlc.GetLottedStockQuantityParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[uuid.UUID]int32, len(explicit))
+	for _, a := range explicit {
+		taken[a.LotID] += int32(a.Quantity)
+		lotted -= int32(a.Quantity)
+	}
+	need := quantity - max(onHand-lotted, 0)
+	if need <= 0 {
+		return nil, nil
+	}
+
+	levels, err := q.ListStockLotLevelsForAllocation(ctx, &sqlc.ListStockLotLevelsForAllocationParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+		Column3:     product.TracksExpiry,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []lotAllocation
+	for _, level := range levels {
+		if need == 0 {
+			break
+		}
+		lotID := utils.PgxUUIDToUUID(level.LotID)
+		take := min(need, level.Quantity-taken[lotID])
+		if take <= 0 {
+			continue
+		}
+		allocations = append(allocations, lotAllocation{LotID: lotID, Quantity: int(take)})
+		need -= take
+	}
+	if need > 0 {
+		return nil, ErrInsufficientStock
+	}
+	return allocations, nil
+}
+
+// applyLotDelta adds delta to a lot's balance in a warehouse under a row lock.
+// The caller must already hold the product's stock level lock.
+func applyLotDelta(ctx context.Context, q *sqlc.Queries, productID, lotID, warehouseID uuid.UUID, delta int32) error {
+	lot, err := q.GetStockLot(ctx, utils.UUIDToPgxUUID(lotID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("lot %s not found", lotID)
+	}
+	if err != nil {
+		return err
+	}
+	if utils.PgxUUIDToUUID(lot.ProductID) != productID {
+		return fmt.Errorf("lot %s is not a lot of %s (%s)", lot.LotNumber, lot.ProductName, lot.Sku)
+	}
+
+	if err := q.EnsureStockLotLevel(ctx, &sqlc.EnsureStockLotLevelParams{
+		LotID:       lot.ID,
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	}); err != nil {
+		return err
+	}
+	level, err := q.GetStockLotLevelForUpdate(ctx, &sqlc.GetStockLotLevelForUpdateParams{
+		LotID:       lot.ID,
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return err
+	}
+	if level.Quantity+delta < 0 {
+		return fmt.Errorf("%w in lot %s", ErrInsufficientStock, lot.LotNumber)
+	}
+
+	_, err = q.UpdateStockLotLevelQuantity(ctx, &sqlc.UpdateStockLotLevelQuantityParams{
+		LotID:       lot.ID,
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+		Quantity:    level.Quantity + delta,
+	})
+	return err
+}
+
+// carriedLots returns the lots still in transit for a document that moved
+// stock out under referenceType/referenceID, e.g. a dispatched transfer, so
+// the receipt can put the same lots back. Anything beyond the lots in transit
+// is received unlotted.
+func carriedLots(ctx context.Context, q *sqlc.Queries, referenceType string, referenceID, productID uuid.UUID, quantity int) ([]lotAllocation, error) {
+	rows, err := q.ListUnreceivedReferenceLots(ctx, &sqlc.ListUnreceivedReferenceLotsParams{
+		ReferenceType: &referenceType,
+		ReferenceID:   utils.UUIDToPgxUUID(referenceID),
+		ProductID:     utils.UUIDToPgxUUID(productID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []lotAllocation
+	remaining := quantity
+	for _, row := range rows {
+		if remaining == 0 {
+			break
+		}
+		take := min(remaining, int(row.Quantity))
+		allocations = append(allocations, lotAllocation{LotID: utils.PgxUUIDToUUID(row.LotID), Quantity: take})
+		remaining -= take
+	}
+	return allocations, nil
+}
+
+// stockMovementWithTracking converts a ledger row to its API model including
+// the lots and serial numbers it moved
+func stockMovementWithTracking(ctx context.Context, q *sqlc.Queries, m *sqlc.StockMovement) (models.StockMovement, error) {
+	result := toStockMovementModel(m)
+
+	lots, err := q.ListStockMovementLots(ctx, m.ID)
+	if err != nil {
+		return result, err
+	}
+	if len(lots) > 0 {
+		result.Lots = make([]models.StockMovementLot, len(lots))
+	}
+	for i, lot := range lots {
+		result.Lots[i] = models.StockMovementLot{
+			LotID:      utils.PgxUUIDToUUID(lot.LotID),
+			LotNumber:  lot.LotNumber,
+			ExpiryDate: utils.PgxDateToTimePtr(lot.ExpiryDate),
+			Quantity:   int(lot.Quantity),
+		}
+	}
+
+	serials, err := q.ListStockMovementSerials(ctx, m.ID)
+	if err != nil {
+		return result, err
+	}
+	if len(serials) > 0 {
+		result.SerialNumbers = serials
+	}
+
+	locations, err := q.ListStockMovementLocations(ctx, m.ID)
+	if err != nil {
+		return result, err
+	}
+	if len(locations) > 0 {
+		result.Locations = make([]models.StockMovementLocation, len(locations))
+	}
+	for i, location := range locations {
+		result.Locations[i] = models.StockMovementLocation{
+			LocationID:   utils.PgxUUIDToUUID(location.LocationID),
+			LocationCode: location.Code,
+			Quantity:     int(location.Quantity),
+		}
+	}
+	return result, nil
+}
+
+func toStockLotModel(l *sqlc.StockLot) models.StockLot {
+	return models.StockLot{
+		ID:              utils.PgxUUIDToUUID(l.ID),
+		ProductID:       utils.PgxUUIDToUUID(l.ProductID),
+		LotNumber:       l.LotNumber,
+		ManufactureDate: utils.PgxDateToTimePtr(l.ManufactureDate),
+		ExpiryDate:      utils.PgxDateToTimePtr(l.ExpiryDate),
+		CreatedAt:       utils.PgxTimestamptzToTime(l.CreatedAt),
+		UpdatedAt:       utils.PgxTimestamptzToTime(l.UpdatedAt),
+	}
+}
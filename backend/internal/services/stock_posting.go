@@ -0,0 +1,315 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// stockPosting describes a single ledger entry and the balance change it causes
+type stockPosting struct {
+	ProductID       uuid.UUID
+	WarehouseID     uuid.UUID
+	MovementType    string
+	Quantity        int
+	CostPrice       *float64
+	ReferenceType   *string
+	ReferenceID     *uuid.UUID
+	ReferenceNumber *string
+	Reason          *string
+	ReasonCode      *string
+	UserID          *uuid.UUID
+	ProcessedBy     *uuid.UUID
+	ProcessedDate   time.Time
+	// Lots the posting goes into or comes from. Receipts without lots are
+	// unlotted stock; issues without lots are allocated by postMovementLots.
+	Lots []lotAllocation
+	// Serials names the units of a serialized product the posting moves
+	Serials []string
+	// FromLocationID and ToLocationID are the bins the posting takes from and
+	// puts into. Bin moves ("transfer") need both; see postMovementLocations.
+	FromLocationID *uuid.UUID
+	ToLocationID   *uuid.UUID
+	// UOM is the unit the quantity was entered in. Quantity and CostPrice
+	// are already converted to base units.
+	UOM *productUOM
+	// Locations are exact bin changes that replace the bin selection by
+	// FromLocationID and ToLocationID; reversals use them to undo the
+	// original's bin changes
+	Locations []binAllocation
+	// ReversalOf is the movement this posting reverses
+	ReversalOf *uuid.UUID
+	// Counted marks corrections to a physical count or to the ledger. Units
+	// they take out are already gone, reserved or not, so they are limited by
+	// on-hand rather than available stock.
+	Counted bool
+}
+
+// delta returns the signed change the posting makes to on-hand quantity.
+// Adjustment quantities are already signed.
+func (p stockPosting) delta() int32 {
+	switch p.MovementType {
+	case "in", "adjustment":
+		return int32(p.Quantity)
+	case "out":
+		return -int32(p.Quantity)
+	}
+	return 0
+}
+
+// postStockMovement writes the ledger entry and applies it to stock_levels,
+// the lot and bin balances and the serial number registry, then costs it.
+// Entries dated inside or before a closed accounting period are refused, and
+// stock snapshots at or after a back-dated entry are dropped. q must be bound
+// to the caller's transaction so all writes commit together.
+func postStockMovement(ctx context.Context, q *sqlc.Queries, p stockPosting) (*sqlc.StockMovement, error) {
+	processedDate := p.ProcessedDate
+	if processedDate.IsZero() {
+		processedDate = time.Now()
+	}
+	if err := ensurePeriodOpen(ctx, q, processedDate); err != nil {
+		return nil, err
+	}
+
+	level, err := applyStockDelta(ctx, q, p.ProductID, p.WarehouseID, p.delta(), p.Counted)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalAmount *float64
+	if p.CostPrice != nil {
+		amount := float64(p.Quantity) * *p.CostPrice
+		totalAmount = &amount
+	}
+
+	processedBy := p.ProcessedBy
+	if processedBy == nil {
+		processedBy = p.UserID
+	}
+	uomID, uomQuantity, uomFactor := p.UOM.columns(p.Quantity)
+	movement, err := q.CreateStockMovement(ctx, &sqlc.CreateStockMovementParams{
+		ProductID:       utils.UUIDToPgxUUID(p.ProductID),
+		WarehouseID:     utils.UUIDToPgxUUID(p.WarehouseID),
+		MovementType:    p.MovementType,
+		Quantity:        int32(p.Quantity),
+		CostPrice:       utils.OptionalCostToPgxNumeric(p.CostPrice),
+		TotalAmount:     utils.OptionalFloat64ToPgxNumeric(totalAmount),
+		ReferenceType:   p.ReferenceType,
+		ReferenceID:     utils.OptionalUUIDToPgxUUID(p.ReferenceID),
+		ReferenceNumber: p.ReferenceNumber,
+		Reason:          p.Reason,
+		UserID:          utils.OptionalUUIDToPgxUUID(p.UserID),
+		ProcessedBy:     utils.OptionalUUIDToPgxUUID(processedBy),
+		ProcessedDate:   utils.TimeToPgxTimestamptz(processedDate),
+		ReasonCode:      p.ReasonCode,
+		FromLocationID:  utils.OptionalUUIDToPgxUUID(p.FromLocationID),
+		ToLocationID:    utils.OptionalUUIDToPgxUUID(p.ToLocationID),
+		UomID:           uomID,
+		UomQuantity:     uomQuantity,
+		UomFactor:       uomFactor,
+		ReversalOfID:    utils.OptionalUUIDToPgxUUID(p.ReversalOf),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := q.DeleteStockSnapshotsFrom(ctx, &sqlc.DeleteStockSnapshotsFromParams{
+		ProductID:   movement.ProductID,
+		WarehouseID: movement.WarehouseID,
+		SnapshotAt:  movement.ProcessedDate,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := postMovementLots(ctx, q, p, movement.ID, level.Quantity-p.delta()); err != nil {
+		return nil, err
+	}
+	if err := postMovementLocations(ctx, q, p, movement.ID, level.Quantity-p.delta()); err != nil {
+		return nil, err
+	}
+	if err := postMovementSerials(ctx, q, p, movement.ID); err != nil {
+		return nil, err
+	}
+	if err := costStockMovement(ctx, q, movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// ensurePeriodOpen refuses a posting dated before the end of the latest
+// closed accounting period, whether or not a period covers the date itself,
+// since it would change the balances recorded when that period was closed.
+// Period ends are exclusive: they are the first instant of the next period.
+// The periods ending after the processed date stay share-locked until the
+// caller's transaction ends, so none of them can be closed under the posting.
+func ensurePeriodOpen(ctx context.Context, q *sqlc.Queries, processedDate time.Time) error {
+	periods, err := q.ListAccountingPeriodsEndingFrom(ctx, utils.TimeToPgxTimestamptz(processedDate))
+	if err != nil {
+		return err
+	}
+	for i := len(periods) - 1; i >= 0; i-- {
+		if periods[i].Status == models.AccountingPeriodStatusClosed {
+			return fmt.Errorf("%w: the processed date is before the end of %s", ErrPeriodClosed, periods[i].Name)
+		}
+	}
+	return nil
+}
+
+// applyStockDelta adds delta to the product/warehouse balance under a row
+// lock, creating the stock level on first use. Outgoing quantities are limited
+// to what issuableQuantity allows; refusals are reported to the alert
+// evaluator.
+func applyStockDelta(ctx context.Context, q *sqlc.Queries, productID, warehouseID uuid.UUID, delta int32, counted bool) (*sqlc.StockLevel, error) {
+	current, err := lockStockLevel(ctx, q, productID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuable := issuableQuantity(current, counted); delta < 0 && issuable+delta < 0 {
+		reportRejectedPosting(productID, warehouseID, -delta, issuable)
+		return nil, ErrInsufficientStock
+	}
+	if delta == 0 {
+		return current, nil
+	}
+
+	return q.UpdateStockQuantity(ctx, &sqlc.UpdateStockQuantityParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+		Quantity:    current.Quantity + delta,
+	})
+}
+
+// issuableQuantity is how much a posting can take out of a stock level.
+// Postings are limited to available stock so reserved units cannot be taken
+// by other postings; count corrections can write off everything on hand.
+func issuableQuantity(level *sqlc.StockLevel, counted bool) int32 {
+	if counted {
+		return level.Quantity
+	}
+	return level.Quantity - level.ReservedQuantity
+}
+
+// applyReservedDelta adds delta to the reserved quantity under a row lock.
+// New holds can only be taken against available stock.
+func applyReservedDelta(ctx context.Context, q *sqlc.Queries, productID, warehouseID uuid.UUID, delta int32) (*sqlc.StockLevel, error) {
+	current, err := lockStockLevel(ctx, q, productID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delta > 0 && current.Quantity-current.ReservedQuantity < delta {
+		return nil, ErrInsufficientStock
+	}
+	reserved := current.ReservedQuantity + delta
+	if reserved < 0 {
+		return nil, errors.New("cannot release more than is reserved")
+	}
+
+	return q.UpdateReservedQuantity(ctx, &sqlc.UpdateReservedQuantityParams{
+		ProductID:        utils.UUIDToPgxUUID(productID),
+		WarehouseID:      utils.UUIDToPgxUUID(warehouseID),
+		ReservedQuantity: reserved,
+	})
+}
+
+// lockStockLevel returns the balance row locked FOR UPDATE until the caller's
+// transaction ends. A zero row is inserted first if none exists so concurrent
+// first receipts queue on the same row instead of racing to create it.
+//
+// The snapshot lock is share-locked before the row, and also held until the
+// transaction ends, so a stock snapshot waits for postings in flight and
+// cannot miss a movement whose transaction deletes stale snapshots before it
+// commits. Taking it first means no transaction holding a stock level waits
+// for a snapshot.
+func lockStockLevel(ctx context.Context, q *sqlc.Queries, productID, warehouseID uuid.UUID) (*sqlc.StockLevel, error) {
+	if err := q.LockStockSnapshotsShared(ctx); err != nil {
+		return nil, err
+	}
+	if err := q.EnsureStockLevel(ctx, &sqlc.EnsureStockLevelParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	}); err != nil {
+		return nil, err
+	}
+
+	return q.GetStockLevelForUpdate(ctx, &sqlc.GetStockLevelForUpdateParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	})
+}
+
+// stockKey identifies a single stock_levels row
+type stockKey struct {
+	ProductID   uuid.UUID
+	WarehouseID uuid.UUID
+}
+
+// lockStockLevels locks every balance a multi-line posting will touch, and
+// then the cost positions of their products, in a fixed order, so two
+// postings over the same rows cannot deadlock
+func lockStockLevels(ctx context.Context, q *sqlc.Queries, keys []stockKey) error {
+	sorted := make([]stockKey, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := bytes.Compare(sorted[i].ProductID[:], sorted[j].ProductID[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(sorted[i].WarehouseID[:], sorted[j].WarehouseID[:]) < 0
+	})
+
+	for i, key := range sorted {
+		if i > 0 && key == sorted[i-1] {
+			continue
+		}
+		if _, err := lockStockLevel(ctx, q, key.ProductID, key.WarehouseID); err != nil {
+			return err
+		}
+	}
+	for i, key := range sorted {
+		if i > 0 && key.ProductID == sorted[i-1].ProductID {
+			continue
+		}
+		if _, _, err := lockProductCost(ctx, q, key.ProductID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toStockMovementModel converts a freshly written ledger row to its API model
+func toStockMovementModel(m *sqlc.StockMovement) models.StockMovement {
+	return models.StockMovement{
+		ID:              utils.PgxUUIDToUUID(m.ID),
+		ProductID:       utils.PgxUUIDToUUID(m.ProductID),
+		WarehouseID:     utils.PgxUUIDToUUID(m.WarehouseID),
+		MovementType:    m.MovementType,
+		Quantity:        int(m.Quantity),
+		CostPrice:       utils.OptionalPgxNumericToFloat64Ptr(m.CostPrice),
+		TotalAmount:     utils.OptionalPgxNumericToFloat64Ptr(m.TotalAmount),
+		UnitCost:        utils.OptionalPgxNumericToFloat64Ptr(m.UnitCost),
+		TotalCost:       utils.OptionalPgxNumericToFloat64Ptr(m.TotalCost),
+		ReferenceType:   m.ReferenceType,
+		ReferenceID:     utils.OptionalPgxUUIDToUUID(m.ReferenceID),
+		ReferenceNumber: m.ReferenceNumber,
+		Reason:          m.Reason,
+		ReasonCode:      m.ReasonCode,
+		UserID:          utils.OptionalPgxUUIDToUUID(m.UserID),
+		ProcessedBy:     utils.OptionalPgxUUIDToUUID(m.ProcessedBy),
+		ProcessedDate:   utils.OptionalPgxTimestamptzToTimePtr(m.ProcessedDate),
+		FromLocationID:  utils.OptionalPgxUUIDToUUID(m.FromLocationID),
+		ToLocationID:    utils.OptionalPgxUUIDToUUID(m.ToLocationID),
+		UOMID:           utils.OptionalPgxUUIDToUUID(m.UomID),
+		UOMQuantity:     utils.OptionalInt32PtrToInt(m.UomQuantity),
+		UOMFactor:       utils.OptionalInt32PtrToInt(m.UomFactor),
+		ReversalOfID:    utils.OptionalPgxUUIDToUUID(m.ReversalOfID),
+		CreatedAt:       utils.PgxTimestamptzToTime(m.CreatedAt),
+	}
+}
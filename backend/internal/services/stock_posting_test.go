@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"inventory-system/internal/config"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+)
+
+func TestIssuableQuantity(t *testing.T) {
+	level := &sqlc.StockLevel{Quantity: 10, ReservedQuantity: 8}
+
+	assert.Equal(t, int32(2), issuableQuantity(level, false))
+	assert.Equal(t, int32(10), issuableQuantity(level, true))
+}
+
+func TestPostStockMovement(t *testing.T) {
+	db := newTestDB(t)
+	f := newTestFixtures(t, db)
+	ctx := context.Background()
+	service := NewStockService(db, config.ReceivingConfig{})
+	reservations := NewStockReservationService(db)
+
+	warehouse := f.warehouse()
+	product := f.product(false)
+	f.receive(product, warehouse, 10)
+	_, err := reservations.CreateStockReservation(ctx, models.CreateStockReservationRequest{
+		ProductID:   product,
+		WarehouseID: warehouse,
+		Quantity:    8,
+	}, nil)
+	require.NoError(t, err)
+
+	t.Run("issues only available stock", func(t *testing.T) {
+		_, err := service.CreateStockMovement(ctx, models.CreateStockMovementRequest{
+			ProductID:    product,
+			WarehouseID:  warehouse,
+			MovementType: "out",
+			Quantity:     3,
+		}, nil)
+		assert.True(t, errors.Is(err, ErrInsufficientStock))
+
+		reasonCode := "damage"
+		_, err = service.CreateStockMovement(ctx, models.CreateStockMovementRequest{
+			ProductID:    product,
+			WarehouseID:  warehouse,
+			MovementType: "adjustment",
+			Quantity:     -3,
+			ReasonCode:   &reasonCode,
+		}, nil)
+		assert.True(t, errors.Is(err, ErrInsufficientStock))
+	})
+
+	t.Run("corrects a count below the reserved quantity", func(t *testing.T) {
+		counted := 1
+		reasonCode := models.StocktakeReasonCode
+		movement, err := service.CreateStockMovement(ctx, models.CreateStockMovementRequest{
+			ProductID:       product,
+			WarehouseID:     warehouse,
+			MovementType:    "adjustment",
+			CountedQuantity: &counted,
+			ReasonCode:      &reasonCode,
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, -9, movement.Quantity)
+
+		onHand, reserved := f.stockLevel(product, warehouse)
+		assert.Equal(t, 1, onHand)
+		assert.Equal(t, 8, reserved)
+	})
+}
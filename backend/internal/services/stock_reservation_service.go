@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// expiredReservationBatch bounds how many holds one sweep releases per transaction
+const expiredReservationBatch = 100
+
+// StockReservationService holds stock for a product/warehouse against
+// stock_levels.reserved_quantity. Held units stay on hand but are excluded
+// from available quantity until they are released, expire or are consumed by
+// an "out" movement.
+type StockReservationService struct {
+	db *database.DB
+}
+
+func NewStockReservationService(db *database.DB) *StockReservationService {
+	return &StockReservationService{db: db}
+}
+
+func (s *StockReservationService) CreateStockReservation(ctx context.Context, req models.CreateStockReservationRequest, userID *uuid.UUID) (*models.StockReservation, error) {
+	if req.ProductID == uuid.Nil || req.WarehouseID == uuid.Nil {
+		return nil, errors.New("product and warehouse are required")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	reservation, err := reserveStock(ctx, q, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockReservation(ctx, utils.PgxUUIDToUUID(reservation.ID))
+}
+
+func (s *StockReservationService) GetStockReservation(ctx context.Context, id uuid.UUID) (*models.StockReservation, error) {
+	row, err := s.db.GetStockReservation(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := toStockReservationModel(&sqlc.StockReservation{
+		ID:               row.ID,
+		ProductID:        row.ProductID,
+		WarehouseID:      row.WarehouseID,
+		Quantity:         row.Quantity,
+		ConsumedQuantity: row.ConsumedQuantity,
+		Status:           row.Status,
+		OwnerType:        row.OwnerType,
+		OwnerID:          row.OwnerID,
+		ExpiresAt:        row.ExpiresAt,
+		Notes:            row.Notes,
+		CreatedBy:        row.CreatedBy,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	})
+	reservation.ProductName = &row.ProductName
+	reservation.ProductSKU = &row.Sku
+	reservation.WarehouseName = &row.WarehouseName
+
+	return &reservation, nil
+}
+
+func (s *StockReservationService) ListStockReservations(ctx context.Context, filter models.StockReservationFilter) (*models.StockReservationListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListStockReservationsWithFilter(ctx, &sqlc.ListStockReservationsWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Column3: utils.OptionalStringToString(filter.Status),
+		Column4: utils.OptionalStringToString(filter.OwnerType),
+		Column5: utils.OptionalUUIDToPgxUUID(filter.OwnerID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountStockReservationsWithFilter(ctx, &sqlc.CountStockReservationsWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Column3: utils.OptionalStringToString(filter.Status),
+		Column4: utils.OptionalStringToString(filter.OwnerType),
+		Column5: utils.OptionalUUIDToPgxUUID(filter.OwnerID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockReservation, len(rows))
+	for i, row := range rows {
+		result[i] = toStockReservationModel(&sqlc.StockReservation{
+			ID:               row.ID,
+			ProductID:        row.ProductID,
+			WarehouseID:      row.WarehouseID,
+			Quantity:         row.Quantity,
+			ConsumedQuantity: row.ConsumedQuantity,
+			Status:           row.Status,
+			OwnerType:        row.OwnerType,
+			OwnerID:          row.OwnerID,
+			ExpiresAt:        row.ExpiresAt,
+			Notes:            row.Notes,
+			CreatedBy:        row.CreatedBy,
+			CreatedAt:        row.CreatedAt,
+			UpdatedAt:        row.UpdatedAt,
+		})
+		result[i].ProductName = &row.ProductName
+		result[i].ProductSKU = &row.Sku
+		result[i].WarehouseName = &row.WarehouseName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.StockReservationListResponse{
+		StockReservations: result,
+		Total:             total,
+		Page:              filter.Page,
+		Limit:             filter.Limit,
+		Pages:             pages,
+	}, nil
+}
+
+// ExtendStockReservation moves or clears the expiry of an active reservation
+func (s *StockReservationService) ExtendStockReservation(ctx context.Context, id uuid.UUID, req models.ExtendStockReservationRequest) (*models.StockReservation, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	reservation, err := q.GetStockReservationForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != models.StockReservationStatusActive {
+		return nil, fmt.Errorf("%w: reservation is %s", ErrInvalidStatusTransition, reservation.Status)
+	}
+
+	if _, err := q.UpdateStockReservationExpiry(ctx, &sqlc.UpdateStockReservationExpiryParams{
+		ID:        reservation.ID,
+		ExpiresAt: utils.OptionalTimeToPgxTimestamptz(req.ExpiresAt),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockReservation(ctx, id)
+}
+
+// ReleaseStockReservation gives back all or part of a hold
+func (s *StockReservationService) ReleaseStockReservation(ctx context.Context, id uuid.UUID, req models.ReleaseStockReservationRequest) (*models.StockReservation, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	reservation, err := q.GetStockReservationForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := reservation.Quantity
+	if req.Quantity != nil {
+		quantity = int32(*req.Quantity)
+	}
+	if _, err := releaseReservation(ctx, q, reservation, quantity, models.StockReservationStatusReleased); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockReservation(ctx, id)
+}
+
+// ConsumeStockReservation ships all or part of a hold with an "out" movement.
+// Holds placed by a document are shipped through that document instead.
+func (s *StockReservationService) ConsumeStockReservation(ctx context.Context, id uuid.UUID, req models.ConsumeStockReservationRequest, userID *uuid.UUID) (*models.StockReservation, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	reservation, err := q.GetStockReservationForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	// Shipping an owned hold here would leave the document's shipped
+	// quantities behind and the document would ship the units again
+	if reservation.OwnerType != nil {
+		return nil, fmt.Errorf("%w: reservation is held for %s %s and ships through it", ErrInvalidStatusTransition, *reservation.OwnerType, utils.PgxUUIDToUUID(reservation.OwnerID))
+	}
+
+	quantity := reservation.Quantity
+	if req.Quantity != nil {
+		quantity = int32(*req.Quantity)
+	}
+	if _, _, err := consumeReservation(ctx, q, reservation, quantity, req.SerialNumbers, req.ReferenceNumber, req.Reason, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockReservation(ctx, id)
+}
+
+// ReleaseExpiredReservations releases active holds whose expiry has passed and
+// returns how many were released. Rows locked by other transactions are
+// skipped and picked up by a later sweep.
+func (s *StockReservationService) ReleaseExpiredReservations(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		n, err := s.releaseExpiredBatch(ctx)
+		released += n
+		if err != nil || n < expiredReservationBatch {
+			return released, err
+		}
+	}
+}
+
+func (s *StockReservationService) releaseExpiredBatch(ctx context.Context) (int, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	expired, err := q.ListExpiredStockReservationsForUpdate(ctx, expiredReservationBatch)
+	if err != nil {
+		return 0, err
+	}
+	for _, reservation := range expired {
+		if _, err := releaseReservation(ctx, q, reservation, reservation.Quantity, models.StockReservationStatusExpired); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(expired), nil
+}
+
+// RunExpirySweeper releases expired reservations every interval until ctx is done
+func (s *StockReservationService) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := s.ReleaseExpiredReservations(ctx)
+			if err != nil {
+				log.Printf("Failed to release expired stock reservations: %v", err)
+			}
+			if released > 0 {
+				log.Printf("Released %d expired stock reservations", released)
+			}
+		}
+	}
+}
+
+// reserveStock places a hold inside the caller's transaction
+func reserveStock(ctx context.Context, q *sqlc.Queries, req models.CreateStockReservationRequest, userID *uuid.UUID) (*sqlc.StockReservation, error) {
+	if req.Quantity <= 0 {
+		return nil, errors.New("reservation quantity must be greater than zero")
+	}
+	if (req.OwnerType == nil) != (req.OwnerID == nil) {
+		return nil, errors.New("owner type and owner ID must be given together")
+	}
+	if req.OwnerType != nil && !models.IsReservationOwnerType(*req.OwnerType) {
+		return nil, fmt.Errorf("unknown reservation owner type %q", *req.OwnerType)
+	}
+
+	if _, err := applyReservedDelta(ctx, q, req.ProductID, req.WarehouseID, int32(req.Quantity)); err != nil {
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, fmt.Errorf("%w: not enough available stock to reserve %d", ErrInsufficientStock, req.Quantity)
+		}
+		return nil, err
+	}
+
+	return q.CreateStockReservation(ctx, &sqlc.CreateStockReservationParams{
+		ProductID:   utils.UUIDToPgxUUID(req.ProductID),
+		WarehouseID: utils.UUIDToPgxUUID(req.WarehouseID),
+		Quantity:    int32(req.Quantity),
+		OwnerType:   req.OwnerType,
+		OwnerID:     utils.OptionalUUIDToPgxUUID(req.OwnerID),
+		ExpiresAt:   utils.OptionalTimeToPgxTimestamptz(req.ExpiresAt),
+		Notes:       req.Notes,
+		CreatedBy:   utils.OptionalUUIDToPgxUUID(userID),
+	})
+}
+
+// releaseReservation returns quantity of an active hold to available stock.
+// The reservation moves to status once nothing is held any more. r must have
+// been read FOR UPDATE in the caller's transaction.
+func releaseReservation(ctx context.Context, q *sqlc.Queries, r *sqlc.StockReservation, quantity int32, status string) (*sqlc.StockReservation, error) {
+	if r.Status != models.StockReservationStatusActive {
+		return nil, fmt.Errorf("%w: reservation is %s", ErrInvalidStatusTransition, r.Status)
+	}
+	if quantity <= 0 || quantity > r.Quantity {
+		return nil, fmt.Errorf("release quantity must be between 1 and %d", r.Quantity)
+	}
+
+	if _, err := applyReservedDelta(ctx, q, utils.PgxUUIDToUUID(r.ProductID), utils.PgxUUIDToUUID(r.WarehouseID), -quantity); err != nil {
+		return nil, err
+	}
+
+	remaining := r.Quantity - quantity
+	newStatus := models.StockReservationStatusActive
+	if remaining == 0 {
+		newStatus = status
+		// A partly consumed hold that is released is still recorded as consumed
+		if r.ConsumedQuantity > 0 && status == models.StockReservationStatusReleased {
+			newStatus = models.StockReservationStatusConsumed
+		}
+	}
+
+	return q.UpdateStockReservationQuantities(ctx, &sqlc.UpdateStockReservationQuantitiesParams{
+		ID:               r.ID,
+		Quantity:         remaining,
+		ConsumedQuantity: r.ConsumedQuantity,
+		Status:           newStatus,
+	})
+}
+
+// consumeReservation releases quantity from the hold and posts the matching
+// "out" movement in the caller's transaction. The movement references the
+// reservation's owner when it has one. serials names the units shipped for
+// serialized products.
+func consumeReservation(ctx context.Context, q *sqlc.Queries, r *sqlc.StockReservation, quantity int32, serials []string, referenceNumber, reason *string, userID *uuid.UUID) (*sqlc.StockReservation, *sqlc.StockMovement, error) {
+	if r.Status != models.StockReservationStatusActive {
+		return nil, nil, fmt.Errorf("%w: reservation is %s", ErrInvalidStatusTransition, r.Status)
+	}
+	if reservationExpired(r, time.Now()) {
+		return nil, nil, fmt.Errorf("%w: reservation expired at %s", ErrInvalidStatusTransition, r.ExpiresAt.Time.Format(time.RFC3339))
+	}
+	if quantity <= 0 || quantity > r.Quantity {
+		return nil, nil, fmt.Errorf("consume quantity must be between 1 and %d", r.Quantity)
+	}
+
+	productID := utils.PgxUUIDToUUID(r.ProductID)
+	warehouseID := utils.PgxUUIDToUUID(r.WarehouseID)
+
+	// Drop the hold first so the outgoing posting sees the units as available
+	if _, err := applyReservedDelta(ctx, q, productID, warehouseID, -quantity); err != nil {
+		return nil, nil, err
+	}
+
+	referenceType := "reservation"
+	referenceID := utils.PgxUUIDToUUID(r.ID)
+	if r.OwnerType != nil && r.OwnerID.Valid {
+		referenceType = *r.OwnerType
+		referenceID = utils.PgxUUIDToUUID(r.OwnerID)
+	}
+
+	movement, err := postStockMovement(ctx, q, stockPosting{
+		ProductID:       productID,
+		WarehouseID:     warehouseID,
+		MovementType:    "out",
+		Quantity:        int(quantity),
+		ReferenceType:   &referenceType,
+		ReferenceID:     &referenceID,
+		ReferenceNumber: referenceNumber,
+		Reason:          reason,
+		UserID:          userID,
+		Serials:         serials,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remaining := r.Quantity - quantity
+	status := models.StockReservationStatusActive
+	if remaining == 0 {
+		status = models.StockReservationStatusConsumed
+	}
+
+	updated, err := q.UpdateStockReservationQuantities(ctx, &sqlc.UpdateStockReservationQuantitiesParams{
+		ID:               r.ID,
+		Quantity:         remaining,
+		ConsumedQuantity: r.ConsumedQuantity + quantity,
+		Status:           status,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, movement, nil
+}
+
+// reservationExpired reports whether a hold has reached its expiry, whether
+// or not the sweeper has released it yet
+func reservationExpired(r *sqlc.StockReservation, now time.Time) bool {
+	return r.ExpiresAt.Valid && !r.ExpiresAt.Time.After(now)
+}
+
+func toStockReservationModel(r *sqlc.StockReservation) models.StockReservation {
+	return models.StockReservation{
+		ID:               utils.PgxUUIDToUUID(r.ID),
+		ProductID:        utils.PgxUUIDToUUID(r.ProductID),
+		WarehouseID:      utils.PgxUUIDToUUID(r.WarehouseID),
+		Quantity:         int(r.Quantity),
+		ConsumedQuantity: int(r.ConsumedQuantity),
+		Status:           r.Status,
+		OwnerType:        r.OwnerType,
+		OwnerID:          utils.OptionalPgxUUIDToUUID(r.OwnerID),
+		ExpiresAt:        utils.OptionalPgxTimestamptzToTimePtr(r.ExpiresAt),
+		Notes:            r.Notes,
+		CreatedBy:        utils.OptionalPgxUUIDToUUID(r.CreatedBy),
+		CreatedAt:        utils.PgxTimestamptzToTime(r.CreatedAt),
+		UpdatedAt:        utils.PgxTimestamptzToTime(r.UpdatedAt),
+	}
+}
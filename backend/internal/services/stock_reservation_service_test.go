@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+)
+
+func TestReservationExpired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		expiresAt pgtype.Timestamptz
+		expected  bool
+	}{
+		{"no expiry", pgtype.Timestamptz{}, false},
+		{"expires later", utils.TimeToPgxTimestamptz(now.Add(time.Minute)), false},
+		{"expires now", utils.TimeToPgxTimestamptz(now), true},
+		{"expired", utils.TimeToPgxTimestamptz(now.Add(-time.Minute)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, reservationExpired(&sqlc.StockReservation{ExpiresAt: tt.expiresAt}, now))
+		})
+	}
+}
+
+func TestConsumeStockReservation(t *testing.T) {
+	db := newTestDB(t)
+	f := newTestFixtures(t, db)
+	ctx := context.Background()
+	service := NewStockReservationService(db)
+
+	warehouse := f.warehouse()
+	product := f.product(false)
+	f.receive(product, warehouse, 10)
+
+	reserve := func(t *testing.T, req models.CreateStockReservationRequest) *models.StockReservation {
+		t.Helper()
+		req.ProductID, req.WarehouseID = product, warehouse
+		reservation, err := service.CreateStockReservation(ctx, req, nil)
+		require.NoError(t, err)
+		return reservation
+	}
+
+	t.Run("consumes a hold with an out movement", func(t *testing.T) {
+		reservation := reserve(t, models.CreateStockReservationRequest{Quantity: 3})
+		quantity := 2
+		reservation, err := service.ConsumeStockReservation(ctx, reservation.ID, models.ConsumeStockReservationRequest{Quantity: &quantity}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reservation.Quantity)
+		assert.Equal(t, 2, reservation.ConsumedQuantity)
+
+		onHand, reserved := f.stockLevel(product, warehouse)
+		assert.Equal(t, 8, onHand)
+		assert.Equal(t, 1, reserved)
+
+		_, err = service.ReleaseStockReservation(ctx, reservation.ID, models.ReleaseStockReservationRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses unknown owner types", func(t *testing.T) {
+		ownerType := "quote"
+		ownerID := uuid.New()
+		_, err := service.CreateStockReservation(ctx, models.CreateStockReservationRequest{
+			ProductID:   product,
+			WarehouseID: warehouse,
+			Quantity:    1,
+			OwnerType:   &ownerType,
+			OwnerID:     &ownerID,
+		}, nil)
+		assert.EqualError(t, err, `unknown reservation owner type "quote"`)
+	})
+
+	t.Run("refuses holds owned by a document", func(t *testing.T) {
+		ownerType := models.SalesOrderReferenceType
+		ownerID := uuid.New()
+		reservation := reserve(t, models.CreateStockReservationRequest{Quantity: 1, OwnerType: &ownerType, OwnerID: &ownerID})
+
+		_, err := service.ConsumeStockReservation(ctx, reservation.ID, models.ConsumeStockReservationRequest{}, nil)
+		assert.True(t, errors.Is(err, ErrInvalidStatusTransition))
+
+		_, err = service.ReleaseStockReservation(ctx, reservation.ID, models.ReleaseStockReservationRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("refuses expired holds", func(t *testing.T) {
+		reservation := reserve(t, models.CreateStockReservationRequest{Quantity: 1})
+		_, err := db.Exec(ctx, `UPDATE stock_reservations SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, reservation.ID)
+		require.NoError(t, err)
+
+		_, err = service.ConsumeStockReservation(ctx, reservation.ID, models.ConsumeStockReservationRequest{}, nil)
+		assert.True(t, errors.Is(err, ErrInvalidStatusTransition))
+
+		released, err := service.ReleaseExpiredReservations(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, released)
+		_, reserved := f.stockLevel(product, warehouse)
+		assert.Zero(t, reserved)
+	})
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/config"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ReverseStockMovement undoes a movement by posting its equal and opposite,
+// linked to it through reversal_of_id. A movement can be reversed once. A leg
+// of an immediate warehouse transfer is reversed with the rest of the transfer
+// through ReverseStockMovementsByReference.
+func (s *StockService) ReverseStockMovement(ctx context.Context, id uuid.UUID, req models.ReverseStockMovementRequest, userID *uuid.UUID) ([]models.StockMovement, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	original, err := q.GetStockMovementForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if models.IsReversedByReference(original.ReferenceType) {
+		return nil, fmt.Errorf("movement %s is one leg of a warehouse transfer; reverse its reference %s instead", id, utils.PgxUUIDToUUID(original.ReferenceID))
+	}
+
+	result, err := reverseStockMovements(ctx, q, s.receiving, []*sqlc.StockMovement{original}, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReverseStockMovementsByReference undoes every movement sharing a reference
+// ID, such as all lines of a bulk receipt, every receipt against a purchase
+// order or both legs of a warehouse transfer, in one transaction: either every
+// line is reversed or none is
+func (s *StockService) ReverseStockMovementsByReference(ctx context.Context, referenceID uuid.UUID, req models.ReverseStockMovementRequest, userID *uuid.UUID) ([]models.StockMovement, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	originals, err := q.ListStockMovementsByReferenceForUpdate(ctx, utils.UUIDToPgxUUID(referenceID))
+	if err != nil {
+		return nil, err
+	}
+	if len(originals) == 0 {
+		return nil, fmt.Errorf("no stock movements with reference %s: %w", referenceID, pgx.ErrNoRows)
+	}
+
+	result, err := reverseStockMovements(ctx, q, s.receiving, originals, req, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// reverseStockMovements posts a reversal for each of the locked originals
+// under one shared reversal reference. Each reversal moves the same lots,
+// serial numbers and bins back. A reversed issue comes back at the cost it
+// was issued at; a reversed receipt is issued like any other issue under the
+// costing method. Reversed purchase order receipts come off the order's
+// received quantities, which moves the order back to partially received or
+// ordered once it falls outside the under-receipt tolerance.
+func reverseStockMovements(ctx context.Context, q *sqlc.Queries, tolerance config.ReceivingConfig, originals []*sqlc.StockMovement, req models.ReverseStockMovementRequest, userID *uuid.UUID) ([]models.StockMovement, error) {
+	keys := make([]stockKey, len(originals))
+	var purchaseOrderIDs []uuid.UUID
+	for i, original := range originals {
+		id := utils.PgxUUIDToUUID(original.ID)
+		if original.ReversalOfID.Valid {
+			return nil, fmt.Errorf("%w: movement %s is a reversal; post the movement again instead", ErrInvalidStatusTransition, id)
+		}
+		if !models.IsReversible(original.ReferenceType) {
+			return nil, fmt.Errorf("movement %s was posted by a %s and must be corrected there", id, *original.ReferenceType)
+		}
+		reversal, err := q.GetStockMovementReversal(ctx, original.ID)
+		if err == nil {
+			return nil, fmt.Errorf("%w: movement %s was already reversed by %s", ErrInvalidStatusTransition, id, utils.PgxUUIDToUUID(reversal.ID))
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		keys[i] = stockKey{ProductID: utils.PgxUUIDToUUID(original.ProductID), WarehouseID: utils.PgxUUIDToUUID(original.WarehouseID)}
+		if isPurchaseOrderReceipt(original) {
+			purchaseOrderIDs = append(purchaseOrderIDs, utils.PgxUUIDToUUID(original.ReferenceID))
+		}
+	}
+
+	// Purchase orders are locked before the stock levels, as receiving does
+	sort.Slice(purchaseOrderIDs, func(i, j int) bool {
+		return bytes.Compare(purchaseOrderIDs[i][:], purchaseOrderIDs[j][:]) < 0
+	})
+	orders := make(map[uuid.UUID]*purchaseOrderReversal, len(purchaseOrderIDs))
+	for _, id := range purchaseOrderIDs {
+		if orders[id] != nil {
+			continue
+		}
+		po, err := q.GetPurchaseOrderForUpdate(ctx, utils.UUIDToPgxUUID(id))
+		if err != nil {
+			return nil, err
+		}
+		items, err := q.ListPurchaseOrderItems(ctx, po.ID)
+		if err != nil {
+			return nil, err
+		}
+		orders[id] = &purchaseOrderReversal{po: po, items: items, reversed: make(map[uuid.UUID]int32)}
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	// Receipts are reversed first so that serial numbers a transfer's in leg
+	// brought to the destination leave it before its out leg brings them back
+	sorted := make([]*sqlc.StockMovement, len(originals))
+	copy(sorted, originals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MovementType == "in" && sorted[j].MovementType != "in"
+	})
+
+	referenceType := models.ReversalReferenceType
+	referenceID := uuid.New()
+	reasonCode := models.ReversalReasonCode
+	processedDate := time.Now()
+
+	result := make([]models.StockMovement, 0, len(originals))
+	for _, original := range sorted {
+		p, err := reversalPosting(ctx, q, original)
+		if err != nil {
+			return nil, err
+		}
+		p.ReferenceType = &referenceType
+		p.ReferenceID = &referenceID
+		p.ReferenceNumber = original.ReferenceNumber
+		p.Reason = req.Reason
+		if p.MovementType == "adjustment" {
+			p.ReasonCode = &reasonCode
+		}
+		p.UserID = userID
+		p.ProcessedDate = processedDate
+
+		movement, err := postStockMovement(ctx, q, p)
+		if err != nil {
+			return nil, err
+		}
+		reversed, err := stockMovementWithTracking(ctx, q, movement)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, reversed)
+
+		if isPurchaseOrderReceipt(original) {
+			orders[utils.PgxUUIDToUUID(original.ReferenceID)].reversed[utils.PgxUUIDToUUID(original.ProductID)] += original.Quantity
+		}
+	}
+
+	for i, id := range purchaseOrderIDs {
+		if i > 0 && id == purchaseOrderIDs[i-1] {
+			continue
+		}
+		if err := orders[id].apply(ctx, q, tolerance); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// isPurchaseOrderReceipt reports whether a movement was received against a
+// purchase order
+func isPurchaseOrderReceipt(m *sqlc.StockMovement) bool {
+	return m.MovementType == "in" && m.ReferenceType != nil && *m.ReferenceType == models.PurchaseOrderReferenceType && m.ReferenceID.Valid
+}
+
+// purchaseOrderReversal collects the receipts reversed against one locked
+// purchase order, by product
+type purchaseOrderReversal struct {
+	po       *sqlc.PurchaseOrder
+	items    []*sqlc.ListPurchaseOrderItemsRow
+	reversed map[uuid.UUID]int32
+}
+
+// apply takes the reversed quantities off the order's received quantities and
+// recomputes its status. Receipts do not record the line they were received
+// against, so a product ordered on several lines comes off its last lines
+// first.
+func (r *purchaseOrderReversal) apply(ctx context.Context, q *sqlc.Queries, tolerance config.ReceivingConfig) error {
+	received := make(map[uuid.UUID]int32, len(r.items))
+	for _, item := range r.items {
+		if item.ReceivedQuantity != nil {
+			received[utils.PgxUUIDToUUID(item.ID)] = *item.ReceivedQuantity
+		}
+	}
+
+	for productID, quantity := range r.reversed {
+		for i := len(r.items) - 1; i >= 0 && quantity > 0; i-- {
+			item := r.items[i]
+			if utils.PgxUUIDToUUID(item.ProductID) != productID {
+				continue
+			}
+			id := utils.PgxUUIDToUUID(item.ID)
+			taken := min(quantity, received[id])
+			received[id] -= taken
+			quantity -= taken
+		}
+		if quantity > 0 {
+			return fmt.Errorf("reversing %d more of product %s than purchase order %s has received", quantity, productID, r.po.PoNumber)
+		}
+	}
+	return updatePurchaseOrderReceipts(ctx, q, tolerance, r.po, r.items, received)
+}
+
+// reversalPosting builds the posting that undoes a movement: the opposite
+// direction, the same quantity, and the lots, serial numbers and bin changes
+// the original recorded
+func reversalPosting(ctx context.Context, q *sqlc.Queries, original *sqlc.StockMovement) (stockPosting, error) {
+	id := utils.PgxUUIDToUUID(original.ID)
+	p := stockPosting{
+		ProductID:   utils.PgxUUIDToUUID(original.ProductID),
+		WarehouseID: utils.PgxUUIDToUUID(original.WarehouseID),
+		Quantity:    int(original.Quantity),
+		ReversalOf:  &id,
+	}
+
+	var unitCost *float64
+	if original.UnitCost.Valid {
+		cost := utils.PgxNumericToFloat64(original.UnitCost)
+		unitCost = &cost
+	}
+	switch original.MovementType {
+	case "in":
+		p.MovementType = "out"
+	case "out":
+		p.MovementType = "in"
+		p.CostPrice = unitCost
+	case "adjustment":
+		p.MovementType = "adjustment"
+		p.Quantity = -p.Quantity
+		if p.Quantity > 0 {
+			p.CostPrice = unitCost
+		}
+	case "transfer":
+		p.MovementType = "transfer"
+		p.FromLocationID = utils.OptionalPgxUUIDToUUID(original.ToLocationID)
+		p.ToLocationID = utils.OptionalPgxUUIDToUUID(original.FromLocationID)
+	default:
+		return p, fmt.Errorf("movements of type %s cannot be reversed", original.MovementType)
+	}
+
+	lots, err := q.ListStockMovementLots(ctx, original.ID)
+	if err != nil {
+		return p, err
+	}
+	for _, lot := range lots {
+		p.Lots = append(p.Lots, lotAllocation{LotID: utils.PgxUUIDToUUID(lot.LotID), Quantity: int(lot.Quantity)})
+	}
+
+	if p.Serials, err = q.ListStockMovementSerials(ctx, original.ID); err != nil {
+		return p, err
+	}
+
+	locations, err := q.ListStockMovementLocations(ctx, original.ID)
+	if err != nil {
+		return p, err
+	}
+	for _, location := range locations {
+		p.Locations = append(p.Locations, binAllocation{LocationID: utils.PgxUUIDToUUID(location.LocationID), Quantity: -location.Quantity})
+	}
+	return p, nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"inventory-system/internal/config"
+	"inventory-system/internal/models"
+)
+
+func TestReversePurchaseOrderReceipts(t *testing.T) {
+	db := newTestDB(t)
+	f := newTestFixtures(t, db)
+	ctx := context.Background()
+	orders := NewPurchaseOrderService(db, config.ReceivingConfig{}, config.ApprovalConfig{ManagerThreshold: 1000000, AdminThreshold: 1000000})
+	stock := NewStockService(db, config.ReceivingConfig{})
+	invoices := NewSupplierInvoiceService(db, config.MatchingConfig{})
+	userID := f.user("admin")
+
+	warehouse := f.warehouse()
+	product := f.product(false)
+	po, err := orders.CreatePurchaseOrder(models.CreatePurchaseOrderRequest{
+		PoNumber:     fmt.Sprintf("PO-%s", uuid.NewString()),
+		SupplierName: "Supplier",
+		OrderDate:    time.Now(),
+		WarehouseID:  &warehouse,
+		CreatedBy:    userID.String(),
+		Items:        []models.PurchaseOrderItem{{ProductID: product.String(), Quantity: 10, UnitPrice: 5}},
+	})
+	require.NoError(t, err)
+	poID := uuid.MustParse(po.ID)
+	itemID := uuid.MustParse(po.Items[0].ID)
+
+	receive := func(quantity int) uuid.UUID {
+		t.Helper()
+		_, err := orders.ReceivePurchaseOrder(po.ID, models.ReceivePurchaseOrderRequest{
+			Lines: []models.ReceivePurchaseOrderLine{{ItemID: itemID, Quantity: quantity}},
+		}, userID)
+		require.NoError(t, err)
+		var movementID uuid.UUID
+		require.NoError(t, db.QueryRow(ctx,
+			`SELECT id FROM stock_movements WHERE reference_id = $1 ORDER BY created_at DESC LIMIT 1`, poID).Scan(&movementID))
+		return movementID
+	}
+	receivedQuantity := func() int {
+		t.Helper()
+		po, err := orders.GetPurchaseOrder(po.ID)
+		require.NoError(t, err)
+		return po.Items[0].ReceivedQuantity
+	}
+
+	receive(6)
+	second := receive(4)
+	assert.Equal(t, 10, receivedQuantity())
+
+	_, err = stock.ReverseStockMovement(ctx, second, models.ReverseStockMovementRequest{}, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, 6, receivedQuantity())
+
+	t.Run("an invoice is matched against the receipts left", func(t *testing.T) {
+		invoice, err := invoices.CreateSupplierInvoice(ctx, models.CreateSupplierInvoiceRequest{
+			InvoiceNumber:   fmt.Sprintf("INV-%s", uuid.NewString()),
+			PurchaseOrderID: poID,
+			Items:           []models.SupplierInvoiceItem{{PurchaseOrderItemID: itemID, Quantity: 10, UnitPrice: 5}},
+		}, userID)
+		require.NoError(t, err)
+
+		require.Len(t, invoice.Items, 1)
+		assert.Equal(t, 6, invoice.Items[0].ReceivedQuantity)
+		assert.NotEqual(t, models.SupplierInvoiceStatusMatched, invoice.Status)
+	})
+
+	t.Run("reversing a return leaves the received quantity alone", func(t *testing.T) {
+		referenceType := models.PurchaseOrderReferenceType
+		returned, err := stock.CreateStockMovement(ctx, models.CreateStockMovementRequest{
+			ProductID:     product,
+			WarehouseID:   warehouse,
+			MovementType:  "out",
+			Quantity:      2,
+			ReferenceType: &referenceType,
+			ReferenceID:   &poID,
+		}, &userID)
+		require.NoError(t, err)
+
+		_, err = stock.ReverseStockMovement(ctx, returned.ID, models.ReverseStockMovementRequest{}, &userID)
+		require.NoError(t, err)
+		assert.Equal(t, 6, receivedQuantity())
+	})
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"inventory-system/internal/config"
+	"inventory-system/internal/costing"
 	"inventory-system/internal/database"
 	sqlc "inventory-system/internal/database/sqlc"
 	"inventory-system/internal/models"
 	"inventory-system/internal/utils"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,11 +18,12 @@ import (
 )
 
 type StockService struct {
-	db *database.DB
+	db        *database.DB
+	receiving config.ReceivingConfig
 }
 
-func NewStockService(db *database.DB) *StockService {
-	return &StockService{db: db}
+func NewStockService(db *database.DB, receiving config.ReceivingConfig) *StockService {
+	return &StockService{db: db, receiving: receiving}
 }
 
 // Helper function to convert optional UUID pointer to pgtype.UUID
@@ -48,106 +52,202 @@ func optionalTimeToPgxTimestamp(t *time.Time) pgtype.Timestamp {
 
 
 func (s *StockService) CreateStockMovement(ctx context.Context, req models.CreateStockMovementRequest, userID *uuid.UUID) (*models.StockMovement, error) {
+	// Transfers touch two warehouses or two bins and are posted through
+	// TransferStock or MoveBinStock
+	if req.MovementType == "transfer" {
+		return nil, errors.New("transfers must be created through the stock transfer or bin move endpoints")
+	}
+
 	tx, err := s.db.BeginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
 
-	// Calculate total amount if cost price is provided
-	var totalAmount *float64
-	if req.CostPrice != nil {
-		amount := float64(req.Quantity) * *req.CostPrice
-		totalAmount = &amount
-	}
-
-	// Create stock movement
-	stockMovement, err := s.db.CreateStockMovement(ctx, &sqlc.CreateStockMovementParams{
-		ProductID:     utils.UUIDToPgxUUID(req.ProductID),
-		WarehouseID:   utils.UUIDToPgxUUID(req.WarehouseID),
-		MovementType:  req.MovementType,
-		Quantity:      int32(req.Quantity),
-		CostPrice:     utils.OptionalFloat64ToPgxNumeric(req.CostPrice),
-		TotalAmount:   utils.OptionalFloat64ToPgxNumeric(totalAmount),
-		ReferenceType: req.ReferenceType,
-		ReferenceID:   utils.OptionalUUIDToPgxUUID(req.ReferenceID),
-		Reason:        req.Reason,
-		UserID:        utils.UUIDToPgxUUID(*userID),
-		ProcessedBy:   utils.UUIDToPgxUUID(*userID), // Default to current user
-		ProcessedDate: utils.TimeToPgxTimestamptz(time.Now()),
-	})
+	var result models.StockMovement
+	claim, replayed, err := claimIdempotencyKey(ctx, q, userID, idempotencyStockMovement, req.IdempotencyKey, req, &result)
 	if err != nil {
 		return nil, err
 	}
-
-	// Update stock level
-	var newQuantity int32
-	if req.MovementType == "in" || req.MovementType == "adjustment" {
-		newQuantity = int32(req.Quantity)
-	} else if req.MovementType == "out" {
-		newQuantity = -int32(req.Quantity)
+	if replayed {
+		return &result, nil
 	}
 
-	// Get current stock level
-	currentStock, err := s.db.GetStockLevel(ctx, &sqlc.GetStockLevelParams{
-		ProductID:   utils.UUIDToPgxUUID(req.ProductID),
-		WarehouseID: utils.UUIDToPgxUUID(req.WarehouseID),
-	})
+	// Quantities given in another unit are posted in base units
+	uom, err := resolveProductUOM(ctx, q, req.ProductID, req.UOM)
 	if err != nil {
-		// If stock level doesn't exist, create it
-		if req.MovementType == "in" || req.MovementType == "adjustment" {
-			_, err = s.db.CreateStockLevel(ctx, &sqlc.CreateStockLevelParams{
-				ProductID:       utils.UUIDToPgxUUID(req.ProductID),
-				WarehouseID:     utils.UUIDToPgxUUID(req.WarehouseID),
-				Quantity:        int32(req.Quantity),
-				ReservedQuantity: 0,
-				MinStockLevel:   &[]int32{0}[0],
-				MaxStockLevel:   &[]int32{0}[0],
-			})
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			return nil, errors.New("insufficient stock: stock level does not exist")
+		return nil, err
+	}
+	req.Quantity = uom.toBase(req.Quantity)
+	if req.CountedQuantity != nil {
+		counted := uom.toBase(*req.CountedQuantity)
+		req.CountedQuantity = &counted
+	}
+	req.CostPrice = uom.priceToBase(req.CostPrice)
+
+	quantity := req.Quantity
+	if req.MovementType == "adjustment" {
+		quantity, err = s.adjustmentDelta(ctx, q, req)
+		if err != nil {
+			return nil, err
 		}
 	} else {
-		// Check if we have enough stock for outgoing movements
-		if req.MovementType == "out" && currentStock.Quantity < int32(req.Quantity) {
-			return nil, errors.New("insufficient stock")
+		if req.ReasonCode != nil {
+			return nil, errors.New("reason codes only apply to adjustments")
+		}
+		if quantity <= 0 {
+			return nil, errors.New("quantity must be greater than zero")
 		}
+	}
 
-		// Update existing stock level
-		_, err = s.db.UpdateStockQuantity(ctx, &sqlc.UpdateStockQuantityParams{
-			ProductID:   utils.UUIDToPgxUUID(req.ProductID),
-			WarehouseID: utils.UUIDToPgxUUID(req.WarehouseID),
-			Quantity:    int32(currentStock.Quantity + newQuantity),
-		})
-		if err != nil {
+	lotID := req.LotID
+	if req.LotNumber != nil || req.ManufactureDate != nil || req.ExpiryDate != nil {
+		if lotID != nil {
+			return nil, errors.New("give either lot_id or lot_number, not both")
+		}
+		if req.MovementType == "out" || quantity < 0 {
+			return nil, errors.New("lot_number books receipts; use lot_id to pick the lot stock is taken from")
+		}
+		if lotID, err = resolveLot(ctx, q, req.ProductID, req.LotNumber, req.ManufactureDate, req.ExpiryDate); err != nil {
+			return nil, err
+		}
+	}
+	if req.MovementType == "in" {
+		if err := requireExpiryLot(ctx, q, req.ProductID, lotID); err != nil {
 			return nil, err
 		}
 	}
 
+	var processedDate time.Time
+	if req.ProcessedDate != nil {
+		if req.ProcessedDate.After(time.Now()) {
+			return nil, errors.New("processed date cannot be in the future")
+		}
+		processedDate = *req.ProcessedDate
+	}
+
+	stockMovement, err := postStockMovement(ctx, q, stockPosting{
+		ProductID:      req.ProductID,
+		WarehouseID:    req.WarehouseID,
+		MovementType:   req.MovementType,
+		Quantity:       quantity,
+		CostPrice:      req.CostPrice,
+		ReferenceType:  req.ReferenceType,
+		ReferenceID:    req.ReferenceID,
+		Reason:         req.Reason,
+		ReasonCode:     req.ReasonCode,
+		UserID:         userID,
+		ProcessedDate:  processedDate,
+		Lots:           singleLot(lotID, quantity),
+		Serials:        req.SerialNumbers,
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		UOM:            uom,
+		Counted:        req.MovementType == "adjustment" && req.CountedQuantity != nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result, err = stockMovementWithTracking(ctx, q, stockMovement); err != nil {
+		return nil, err
+	}
+	if err := completeIdempotencyKey(ctx, q, claim, result); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
-	referenceID := utils.PgxUUIDToUUID(stockMovement.ReferenceID)
-	userIDValue := utils.PgxUUIDToUUID(stockMovement.UserID)
-	processedByValue := utils.PgxUUIDToUUID(stockMovement.ProcessedBy)
-	processedDateValue := utils.PgxTimestamptzToTime(stockMovement.ProcessedDate)
-	return &models.StockMovement{
-		ID:            utils.PgxUUIDToUUID(stockMovement.ID),
-		ProductID:     utils.PgxUUIDToUUID(stockMovement.ProductID),
-		WarehouseID:   utils.PgxUUIDToUUID(stockMovement.WarehouseID),
-		MovementType:  stockMovement.MovementType,
-		Quantity:      int(stockMovement.Quantity),
-		ReferenceType: stockMovement.ReferenceType,
-		ReferenceID:   &referenceID,
-		Reason:        stockMovement.Reason,
-		UserID:        &userIDValue,
-		ProcessedBy:   &processedByValue,
-		ProcessedDate: &processedDateValue,
-		CreatedAt:     utils.PgxTimestamptzToTime(stockMovement.CreatedAt),
+	return &result, nil
+}
+
+// adjustmentDelta validates an adjustment's reason code and returns the signed
+// quantity to post, derived from the counted quantity when one is given
+func (s *StockService) adjustmentDelta(ctx context.Context, q *sqlc.Queries, req models.CreateStockMovementRequest) (int, error) {
+	if req.ReasonCode == nil || *req.ReasonCode == "" {
+		return 0, errors.New("reason code is required for adjustments")
+	}
+	reasonCode, err := q.GetAdjustmentReasonCodeByCode(ctx, *req.ReasonCode)
+	if err != nil || reasonCode.IsActive == nil || !*reasonCode.IsActive {
+		return 0, fmt.Errorf("unknown reason code %q", *req.ReasonCode)
+	}
+
+	if req.CountedQuantity == nil {
+		if req.Quantity == 0 {
+			return 0, errors.New("adjustment quantity cannot be zero")
+		}
+		return req.Quantity, nil
+	}
+
+	if *req.CountedQuantity < 0 {
+		return 0, errors.New("counted quantity cannot be negative")
+	}
+	// Lock the balance so the delta is computed against the quantity it is applied to
+	current, err := lockStockLevel(ctx, q, req.ProductID, req.WarehouseID)
+	if err != nil {
+		return 0, err
+	}
+
+	delta := *req.CountedQuantity - int(current.Quantity)
+	if delta == 0 {
+		return 0, errors.New("counted quantity matches on-hand quantity; nothing to adjust")
+	}
+	return delta, nil
+}
+
+// ListStockLevelsAsOf rebuilds the balances held at filter.AsOf from the
+// latest stock snapshots before it and the movements since
+func (s *StockService) ListStockLevelsAsOf(ctx context.Context, filter models.StockLevelAsOfFilter) (*models.StockLevelAsOfListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListStockLevelsAsOf(ctx, &sqlc.ListStockLevelsAsOfParams{
+		Column1: utils.TimeToPgxTimestamptz(filter.AsOf),
+		Column2: optionalUUIDToPgxUUID(filter.ProductID),
+		Column3: optionalUUIDToPgxUUID(filter.WarehouseID),
+		Column4: optionalStringToString(filter.ProductName),
+		Column5: optionalStringToString(filter.ProductSKU),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountStockLevelsAsOf(ctx, &sqlc.CountStockLevelsAsOfParams{
+		Column1: utils.TimeToPgxTimestamptz(filter.AsOf),
+		Column2: optionalUUIDToPgxUUID(filter.ProductID),
+		Column3: optionalUUIDToPgxUUID(filter.WarehouseID),
+		Column4: optionalStringToString(filter.ProductName),
+		Column5: optionalStringToString(filter.ProductSKU),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockLevelAsOf, len(rows))
+	for i, row := range rows {
+		result[i] = models.StockLevelAsOf{
+			ProductID:     utils.PgxUUIDToUUID(row.ProductID),
+			WarehouseID:   utils.PgxUUIDToUUID(row.WarehouseID),
+			Quantity:      int(row.Quantity),
+			ProductName:   &row.ProductName,
+			ProductSKU:    &row.Sku,
+			WarehouseName: &row.WarehouseName,
+		}
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.StockLevelAsOfListResponse{
+		AsOf:        filter.AsOf,
+		StockLevels: result,
+		Total:       total,
+		Page:        filter.Page,
+		Limit:       filter.Limit,
+		Pages:       pages,
 	}, nil
 }
 
@@ -160,6 +260,14 @@ func (s *StockService) GetStockLevel(ctx context.Context, productID, warehouseID
 		return nil, err
 	}
 
+	bins, err := listBinStockLevels(ctx, s.db.Queries, models.BinStockLevelFilter{
+		WarehouseID: &warehouseID,
+		ProductID:   &productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	maxStockLevel := int(*stockLevel.MaxStockLevel)
 	return &models.StockLevel{
 		ID:                utils.PgxUUIDToUUID(stockLevel.ID),
@@ -176,6 +284,7 @@ func (s *StockService) GetStockLevel(ctx context.Context, productID, warehouseID
 		ProductName:       &stockLevel.ProductName,
 		ProductSKU:        &stockLevel.Sku,
 		WarehouseName:     &stockLevel.WarehouseName,
+		Bins:              bins,
 	}, nil
 }
 
@@ -259,7 +368,7 @@ func (s *StockService) ListStockMovements(ctx context.Context, filter models.Sto
 	var total int64
 	var err error
 
-	if filter.ProductID != nil || filter.WarehouseID != nil || filter.MovementType != nil || filter.DateFrom != nil || filter.DateTo != nil {
+	if filter.ProductID != nil || filter.WarehouseID != nil || filter.MovementType != nil || filter.ReasonCode != nil || filter.DateFrom != nil || filter.DateTo != nil {
 		// Use filtered query
 		stockMovementRows, err = s.db.ListStockMovementsWithFilter(ctx, &sqlc.ListStockMovementsWithFilterParams{
 			Column1:  utils.OptionalUUIDToPgxUUID(filter.ProductID),
@@ -267,6 +376,7 @@ func (s *StockService) ListStockMovements(ctx context.Context, filter models.Sto
 			Column3:  utils.OptionalStringToString(filter.MovementType),
 			Column4:  utils.OptionalTimeToPgxTimestamp(filter.DateFrom),
 			Column5:  utils.OptionalTimeToPgxTimestamp(filter.DateTo),
+			Column6:  utils.OptionalStringToString(filter.ReasonCode),
 			Limit:    int32(filter.Limit),
 			Offset:   int32(offset),
 		})
@@ -280,6 +390,7 @@ func (s *StockService) ListStockMovements(ctx context.Context, filter models.Sto
 			Column3: utils.OptionalStringToString(filter.MovementType),
 			Column4: utils.OptionalTimeToPgxTimestamp(filter.DateFrom),
 			Column5: utils.OptionalTimeToPgxTimestamp(filter.DateTo),
+			Column6: utils.OptionalStringToString(filter.ReasonCode),
 		})
 	} else {
 		// Use basic query when no filters
@@ -307,8 +418,14 @@ func (s *StockService) ListStockMovements(ctx context.Context, filter models.Sto
 				CreatedAt:            row.CreatedAt,
 				ProcessedBy:          row.ProcessedBy,
 				ProcessedDate:        row.ProcessedDate,
+				FromLocationID:       row.FromLocationID,
+				ToLocationID:         row.ToLocationID,
 				CostPrice:            row.CostPrice,
 				TotalAmount:          row.TotalAmount,
+				UnitCost:             row.UnitCost,
+				TotalCost:            row.TotalCost,
+				ReferenceNumber:      row.ReferenceNumber,
+				ReasonCode:           row.ReasonCode,
 				ProductName:          row.ProductName,
 				Sku:                  row.Sku,
 				WarehouseName:        row.WarehouseName,
@@ -316,6 +433,12 @@ func (s *StockService) ListStockMovements(ctx context.Context, filter models.Sto
 				LastName:             row.LastName,
 				ProcessedByFirstName: row.ProcessedByFirstName,
 				ProcessedByLastName:  row.ProcessedByLastName,
+				SupplierName:         row.SupplierName,
+				UomID:                row.UomID,
+				UomQuantity:          row.UomQuantity,
+				UomFactor:            row.UomFactor,
+				Uom:                  row.Uom,
+				ReversalOfID:         row.ReversalOfID,
 			}
 		}
 
@@ -339,13 +462,23 @@ func (s *StockService) ListStockMovements(ctx context.Context, filter models.Sto
 			Quantity:      int(movement.Quantity),
 			CostPrice:     utils.OptionalPgxNumericToFloat64Ptr(movement.CostPrice),
 			TotalAmount:   utils.OptionalPgxNumericToFloat64Ptr(movement.TotalAmount),
+			UnitCost:      utils.OptionalPgxNumericToFloat64Ptr(movement.UnitCost),
+			TotalCost:     utils.OptionalPgxNumericToFloat64Ptr(movement.TotalCost),
 			ReferenceType: movement.ReferenceType,
 			ReferenceID:   &referenceID,
 			ReferenceNumber: movement.ReferenceNumber,
 			Reason:        movement.Reason,
+			ReasonCode:    movement.ReasonCode,
 			UserID:        &userID,
 			ProcessedBy:   processedBy,
 			ProcessedDate: processedDate,
+			FromLocationID: utils.OptionalPgxUUIDToUUID(movement.FromLocationID),
+			ToLocationID:   utils.OptionalPgxUUIDToUUID(movement.ToLocationID),
+			UOMID:          utils.OptionalPgxUUIDToUUID(movement.UomID),
+			UOM:            movement.Uom,
+			UOMQuantity:    utils.OptionalInt32PtrToInt(movement.UomQuantity),
+			UOMFactor:      utils.OptionalInt32PtrToInt(movement.UomFactor),
+			ReversalOfID:   utils.OptionalPgxUUIDToUUID(movement.ReversalOfID),
 			CreatedAt:     utils.PgxTimestamptzToTime(movement.CreatedAt),
 			ProductName:   &movement.ProductName,
 			ProductSKU:    &movement.Sku,
@@ -378,18 +511,33 @@ func (s *StockService) GetSOHReport(ctx context.Context) ([]models.SOHReport, er
 		return nil, err
 	}
 
+	// Stock dispatched on a transfer but not yet received is reported
+	// against the destination warehouse
+	inTransitRows, err := s.db.ListInTransitQuantities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inTransit := make(map[[2]uuid.UUID]int, len(inTransitRows))
+	for _, row := range inTransitRows {
+		key := [2]uuid.UUID{utils.PgxUUIDToUUID(row.ProductID), utils.PgxUUIDToUUID(row.WarehouseID)}
+		inTransit[key] = int(row.InTransitQuantity)
+	}
+
 	result := make([]models.SOHReport, len(stockLevels))
 	for i, stockLevel := range stockLevels {
 		maxLevel := int(*stockLevel.MaxStockLevel)
+		productID := utils.PgxUUIDToUUID(stockLevel.ProductID)
+		warehouseID := utils.PgxUUIDToUUID(stockLevel.WarehouseID)
 		result[i] = models.SOHReport{
-			ProductID:     utils.PgxUUIDToUUID(stockLevel.ProductID),
+			ProductID:     productID,
 			ProductName:   stockLevel.ProductName,
 			ProductSKU:    stockLevel.Sku,
-			WarehouseID:   utils.PgxUUIDToUUID(stockLevel.WarehouseID),
+			WarehouseID:   warehouseID,
 			WarehouseName: stockLevel.WarehouseName,
 			Quantity:      int(stockLevel.Quantity),
 			ReservedQty:   int(stockLevel.ReservedQuantity),
 			AvailableQty:  int(*stockLevel.AvailableQuantity),
+			InTransitQty:  inTransit[[2]uuid.UUID{productID, warehouseID}],
 			MinLevel:      int(*stockLevel.MinStockLevel),
 			MaxLevel:      &maxLevel,
 			LastUpdated:   utils.PgxTimestamptzToTime(stockLevel.LastUpdated),
@@ -399,158 +547,480 @@ func (s *StockService) GetSOHReport(ctx context.Context) ([]models.SOHReport, er
 	return result, nil
 }
 
-// CreateBulkStockMovement creates multiple stock movements for a supplier
+// GetStockValuationReport values stock per product and warehouse as of
+// filter.AsOf. Quantities are rebuilt from the movement ledger and valued at
+// the cost costing stamped on each movement, falling back to its cost price
+// for movements that were never costed. Lines are subtotalled per category.
+func (s *StockService) GetStockValuationReport(ctx context.Context, filter models.StockValuationFilter) (*models.StockValuationReport, error) {
+	asOf := utils.TimeToPgxTimestamptz(filter.AsOf)
+	warehouseID := utils.OptionalUUIDToPgxUUID(filter.WarehouseID)
+	categoryID := utils.OptionalUUIDToPgxUUID(filter.CategoryID)
+
+	onHand, err := s.db.GetStockValuationAsOf(ctx, &sqlc.GetStockValuationAsOfParams{
+		Column1: asOf,
+		Column2: warehouseID,
+		Column3: categoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	inTransit, err := s.db.ListInTransitValuationAsOf(ctx, &sqlc.ListInTransitValuationAsOfParams{
+		Column1: asOf,
+		Column2: warehouseID,
+		Column3: categoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.StockValuationLine, 0, len(onHand))
+	lineIndex := make(map[stockKey]int, len(onHand))
+	for _, row := range onHand {
+		key := stockKey{ProductID: utils.PgxUUIDToUUID(row.ProductID), WarehouseID: utils.PgxUUIDToUUID(row.WarehouseID)}
+		lineIndex[key] = len(lines)
+		lines = append(lines, models.StockValuationLine{
+			ProductID:     key.ProductID,
+			ProductName:   row.ProductName,
+			ProductSKU:    row.Sku,
+			CategoryID:    utils.OptionalPgxUUIDToUUID(row.CategoryID),
+			CategoryName:  row.CategoryName,
+			WarehouseID:   key.WarehouseID,
+			WarehouseName: row.WarehouseName,
+			Quantity:      int(row.Quantity),
+			Value:         utils.PgxNumericToFloat64(row.TotalValue),
+		})
+	}
+	for _, row := range inTransit {
+		key := stockKey{ProductID: utils.PgxUUIDToUUID(row.ProductID), WarehouseID: utils.PgxUUIDToUUID(row.WarehouseID)}
+		i, ok := lineIndex[key]
+		if !ok {
+			i = len(lines)
+			lineIndex[key] = i
+			lines = append(lines, models.StockValuationLine{
+				ProductID:     key.ProductID,
+				ProductName:   row.ProductName,
+				ProductSKU:    row.Sku,
+				CategoryID:    utils.OptionalPgxUUIDToUUID(row.CategoryID),
+				CategoryName:  row.CategoryName,
+				WarehouseID:   key.WarehouseID,
+				WarehouseName: row.WarehouseName,
+			})
+		}
+		lines[i].InTransitQuantity = int(row.Quantity)
+		lines[i].InTransitValue = utils.PgxNumericToFloat64(row.TotalValue)
+	}
+
+	// Order by category (uncategorised last), product and warehouse
+	sort.SliceStable(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if (a.CategoryName == nil) != (b.CategoryName == nil) {
+			return b.CategoryName == nil
+		}
+		if a.CategoryName != nil && *a.CategoryName != *b.CategoryName {
+			return *a.CategoryName < *b.CategoryName
+		}
+		if a.ProductName != b.ProductName {
+			return a.ProductName < b.ProductName
+		}
+		return a.WarehouseName < b.WarehouseName
+	})
+
+	report := &models.StockValuationReport{
+		AsOf:       filter.AsOf,
+		Lines:      lines,
+		Categories: []models.StockValuationSubtotal{},
+	}
+	for i := range lines {
+		line := &lines[i]
+		line.TotalValue = costing.Round(line.Value + line.InTransitValue)
+
+		n := len(report.Categories)
+		if n == 0 || !sameCategory(report.Categories[n-1].CategoryID, line.CategoryID) {
+			report.Categories = append(report.Categories, models.StockValuationSubtotal{
+				CategoryID:   line.CategoryID,
+				CategoryName: line.CategoryName,
+			})
+			n++
+		}
+		addValuationLine(&report.Categories[n-1], line)
+		addValuationLine(&report.Totals, line)
+	}
+
+	return report, nil
+}
+
+// addValuationLine adds a valuation line to a subtotal
+func addValuationLine(subtotal *models.StockValuationSubtotal, line *models.StockValuationLine) {
+	subtotal.Quantity += line.Quantity
+	subtotal.Value = costing.Round(subtotal.Value + line.Value)
+	subtotal.InTransitQuantity += line.InTransitQuantity
+	subtotal.InTransitValue = costing.Round(subtotal.InTransitValue + line.InTransitValue)
+	subtotal.TotalValue = costing.Round(subtotal.TotalValue + line.TotalValue)
+}
+
+func sameCategory(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// CreateBulkStockMovement books a stock-in of several items. With a purchase
+// order the items are received against its lines; otherwise they are posted as
+// a standalone "stock_in" receipt sharing one reference ID.
 func (s *StockService) CreateBulkStockMovement(ctx context.Context, req models.BulkStockMovementRequest, userID *uuid.UUID) ([]models.StockMovement, error) {
 	tx, err := s.db.BeginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
 
 	var stockMovements []models.StockMovement
-	var purchaseOrderID *uuid.UUID
-	var totalOrderAmount float64
+	claim, replayed, err := claimIdempotencyKey(ctx, q, userID, idempotencyBulkStockMovement, req.IdempotencyKey, req, &stockMovements)
+	if err != nil {
+		return nil, err
+	}
+	if replayed {
+		return stockMovements, nil
+	}
+
+	// Defaulted after the key is claimed so retries hash the same request
+	if req.ProcessedDate.IsZero() {
+		req.ProcessedDate = time.Now()
+	}
 
-	// Create purchase order first if this is a purchase order type
-	referenceType := "purchase_order"
 	if req.SupplierID != uuid.Nil {
-		// Get supplier information
-		supplier, err := s.db.GetSupplier(ctx, utils.UUIDToPgxUUID(req.SupplierID))
-		if err != nil {
+		if _, err := q.GetSupplier(ctx, utils.UUIDToPgxUUID(req.SupplierID)); err != nil {
 			return nil, fmt.Errorf("failed to get supplier: %w", err)
 		}
+	}
+	// Items given in another unit are converted to base units; the request
+	// is left as it was hashed for the idempotency key
+	items := make([]models.BulkStockMovementItem, len(req.Items))
+	uoms := make([]*productUOM, len(req.Items))
+	for i, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, errors.New("quantity must be greater than zero")
+		}
+		if uoms[i], err = resolveProductUOM(ctx, q, item.ProductID, item.UOM); err != nil {
+			return nil, err
+		}
+		item.Quantity = uoms[i].toBase(item.Quantity)
+		item.CostPrice = uoms[i].priceToBase(item.CostPrice)
+		items[i] = item
+	}
 
-		// Generate PO number
-		poNumber := fmt.Sprintf("PO-%d", time.Now().Unix())
+	processedBy := userID
+	if req.ProcessedBy != uuid.Nil {
+		processedBy = &req.ProcessedBy
+	}
+	posting := stockPosting{
+		ReferenceNumber: req.ReferenceNumber,
+		UserID:          userID,
+		ProcessedBy:     processedBy,
+		ProcessedDate:   req.ProcessedDate,
+	}
 
-		// Create purchase order
-		notes := "Created from stock movement"
-		purchaseOrder, err := s.db.CreatePurchaseOrder(ctx, &sqlc.CreatePurchaseOrderParams{
-			PoNumber:             poNumber,
-			SupplierName:         supplier.Name,
-			SupplierContact:      supplier.ContactPerson,
-			OrderDate:            pgtype.Date{Time: req.ProcessedDate, Valid: true},
-			ExpectedDeliveryDate: pgtype.Date{Time: req.ProcessedDate, Valid: true},
-			Notes:                &notes,
-			CreatedBy:            utils.UUIDToPgxUUID(*userID),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create purchase order: %w", err)
+	var movements []*sqlc.StockMovement
+	if req.PurchaseOrderID != nil {
+		movements, err = s.receiveBulkAgainstPurchaseOrder(ctx, q, *req.PurchaseOrderID, items, uoms, posting)
+	} else {
+		movements, err = postBulkStockIn(ctx, q, items, uoms, posting)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stockMovements = make([]models.StockMovement, len(movements))
+	for i, movement := range movements {
+		if stockMovements[i], err = stockMovementWithTracking(ctx, q, movement); err != nil {
+			return nil, err
 		}
-		purchaseOrderID = utils.OptionalPgxUUIDToUUID(purchaseOrder.ID)
 	}
 
-	// Use the purchase order ID as the reference ID for all movements in this bulk operation
-	// If no purchase order was created, generate a UUID as fallback
-	var bulkReferenceID uuid.UUID
-	if purchaseOrderID != nil {
-		bulkReferenceID = *purchaseOrderID
-	} else {
-		bulkReferenceID = uuid.New()
+	if err := completeIdempotencyKey(ctx, q, claim, stockMovements); err != nil {
+		return nil, err
 	}
 
-	for _, item := range req.Items {
-		// Calculate total amount if cost price is provided
-		var totalAmount *float64
-		if item.CostPrice != nil {
-			amount := float64(item.Quantity) * *item.CostPrice
-			totalAmount = &amount
-			totalOrderAmount += amount
-		}
-
-		// Create stock movement with processed_by set to current user
-		stockMovement, err := s.db.CreateStockMovement(ctx, &sqlc.CreateStockMovementParams{
-			ProductID:       utils.UUIDToPgxUUID(item.ProductID),
-			WarehouseID:     utils.UUIDToPgxUUID(item.WarehouseID),
-			MovementType:    "in", // Bulk movements are always "in"
-			Quantity:        int32(item.Quantity),
-			CostPrice:       utils.OptionalFloat64ToPgxNumeric(item.CostPrice),
-			TotalAmount:     utils.OptionalFloat64ToPgxNumeric(totalAmount),
-			ReferenceType:   &referenceType,
-			ReferenceID:     utils.UUIDToPgxUUID(bulkReferenceID), // Use the same reference ID for all movements
-			ReferenceNumber: req.ReferenceNumber,
-			Reason:          item.Reason,
-			UserID:          utils.OptionalUUIDToPgxUUID(userID),
-			ProcessedBy:     utils.UUIDToPgxUUID(req.ProcessedBy), // Use the provided processed_by
-			ProcessedDate:   utils.TimeToPgxTimestamptz(req.ProcessedDate),
-		})
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return stockMovements, nil
+}
+
+// receiveBulkAgainstPurchaseOrder matches bulk items to the order's lines by
+// product and receives them. An item is spread over the product's lines in
+// order of their outstanding quantity; anything left over is booked to the
+// last of them and is subject to the over-receipt tolerance.
+func (s *StockService) receiveBulkAgainstPurchaseOrder(ctx context.Context, q *sqlc.Queries, purchaseOrderID uuid.UUID, items []models.BulkStockMovementItem, uoms []*productUOM, posting stockPosting) ([]*sqlc.StockMovement, error) {
+	po, lines, err := lockReceivablePurchaseOrder(ctx, q, purchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if posting.ReferenceNumber == nil || *posting.ReferenceNumber == "" {
+		posting.ReferenceNumber = &po.PoNumber
+	}
+
+	outstanding := make(map[uuid.UUID]int, len(lines))
+	linesByProduct := make(map[uuid.UUID][]uuid.UUID)
+	for _, line := range lines {
+		id := utils.PgxUUIDToUUID(line.ID)
+		productID := utils.PgxUUIDToUUID(line.ProductID)
+		outstanding[id] = int(line.Quantity)
+		if line.ReceivedQuantity != nil {
+			outstanding[id] -= int(*line.ReceivedQuantity)
+		}
+		linesByProduct[productID] = append(linesByProduct[productID], id)
+	}
+
+	var receipts []purchaseOrderReceipt
+	for n, item := range items {
+		lineIDs := linesByProduct[item.ProductID]
+		if len(lineIDs) == 0 {
+			return nil, fmt.Errorf("product %s is not on purchase order %s", item.ProductID, po.PoNumber)
+		}
+		lotID, err := resolveReceiptLot(ctx, q, item.ProductID, item.LotNumber, item.ManufactureDate, item.ExpiryDate)
 		if err != nil {
 			return nil, err
 		}
 
-		// Update stock level
-		var newQuantity int32
-		if item.Quantity > 0 {
-			newQuantity = int32(item.Quantity)
+		// Serial numbers are handed out to the lines in the order given
+		if len(item.SerialNumbers) > 0 && len(item.SerialNumbers) != item.Quantity {
+			return nil, fmt.Errorf("%d serial numbers given for a quantity of %d", len(item.SerialNumbers), item.Quantity)
+		}
+		serials := item.SerialNumbers
+		remaining := item.Quantity
+		for i, lineID := range lineIDs {
+			quantity := min(remaining, max(outstanding[lineID], 0))
+			if i == len(lineIDs)-1 {
+				quantity = remaining
+			}
+			if quantity == 0 {
+				continue
+			}
+			receipt := purchaseOrderReceipt{
+				ItemID:      lineID,
+				WarehouseID: item.WarehouseID,
+				Quantity:    quantity,
+				CostPrice:   item.CostPrice,
+				Reason:      item.Reason,
+				LotID:       lotID,
+				LocationID:  item.LocationID,
+				UOM:         uoms[n],
+			}
+			if len(serials) > 0 {
+				receipt.Serials, serials = serials[:quantity], serials[quantity:]
+			}
+			receipts = append(receipts, receipt)
+			outstanding[lineID] -= quantity
+			remaining -= quantity
+		}
+	}
+
+	return receivePurchaseOrderLines(ctx, q, s.receiving, po, lines, receipts, posting)
+}
+
+// postBulkStockIn posts "in" movements for a receipt that is not tied to a
+// purchase order. The movements share a generated reference ID so the receipt
+// can be listed as one stock-in transaction.
+func postBulkStockIn(ctx context.Context, q *sqlc.Queries, items []models.BulkStockMovementItem, uoms []*productUOM, posting stockPosting) ([]*sqlc.StockMovement, error) {
+	keys := make([]stockKey, len(items))
+	for i, item := range items {
+		keys[i] = stockKey{ProductID: item.ProductID, WarehouseID: item.WarehouseID}
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	referenceType := "stock_in"
+	referenceID := uuid.New()
+	movements := make([]*sqlc.StockMovement, 0, len(items))
+	for i, item := range items {
+		lotID, err := resolveReceiptLot(ctx, q, item.ProductID, item.LotNumber, item.ManufactureDate, item.ExpiryDate)
+		if err != nil {
+			return nil, err
 		}
 
-		// Get current stock level
-		currentStock, err := s.db.GetStockLevel(ctx, &sqlc.GetStockLevelParams{
-			ProductID:   utils.UUIDToPgxUUID(item.ProductID),
-			WarehouseID: utils.UUIDToPgxUUID(item.WarehouseID),
-		})
+		p := posting
+		p.ProductID = item.ProductID
+		p.WarehouseID = item.WarehouseID
+		p.MovementType = "in"
+		p.Quantity = item.Quantity
+		p.CostPrice = item.CostPrice
+		p.ReferenceType = &referenceType
+		p.ReferenceID = &referenceID
+		p.Reason = item.Reason
+		p.Lots = singleLot(lotID, item.Quantity)
+		p.Serials = item.SerialNumbers
+		p.ToLocationID = item.LocationID
+		p.UOM = uoms[i]
+
+		movement, err := postStockMovement(ctx, q, p)
 		if err != nil {
-			// If stock level doesn't exist, create it
-			_, err = s.db.CreateStockLevel(ctx, &sqlc.CreateStockLevelParams{
-				ProductID:       utils.UUIDToPgxUUID(item.ProductID),
-				WarehouseID:     utils.UUIDToPgxUUID(item.WarehouseID),
-				Quantity:        int32(item.Quantity),
-				ReservedQuantity: 0,
-				MinStockLevel:   &[]int32{0}[0],
-				MaxStockLevel:   &[]int32{0}[0],
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+// TransferStock moves stock between two warehouses. Every item is written as a
+// paired out/in ledger entry sharing one "warehouse_transfer" reference, kept
+// apart from the transfer documents' "transfer" reference, and both
+// stock_levels rows are updated in the same transaction.
+func (s *StockService) TransferStock(ctx context.Context, req models.StockTransferRequest, userID *uuid.UUID) (*models.StockTransferResponse, error) {
+	if req.FromWarehouseID == uuid.Nil || req.ToWarehouseID == uuid.Nil {
+		return nil, errors.New("source and destination warehouses are required")
+	}
+	if req.FromWarehouseID == req.ToWarehouseID {
+		return nil, errors.New("source and destination warehouses must differ")
+	}
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, errors.New("transfer quantity must be greater than zero")
+		}
+	}
+	if req.ProcessedDate != nil && req.ProcessedDate.After(time.Now()) {
+		return nil, errors.New("processed date cannot be in the future")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	transferID := uuid.New()
+	referenceNumber := fmt.Sprintf("TRF-%d", time.Now().Unix())
+	if req.ReferenceNumber != nil && *req.ReferenceNumber != "" {
+		referenceNumber = *req.ReferenceNumber
+	}
+	referenceType := models.WarehouseTransferReferenceType
+	processedDate := time.Now()
+	if req.ProcessedDate != nil {
+		processedDate = *req.ProcessedDate
+	}
+
+	keys := make([]stockKey, 0, len(req.Items)*2)
+	for _, item := range req.Items {
+		keys = append(keys,
+			stockKey{ProductID: item.ProductID, WarehouseID: req.FromWarehouseID},
+			stockKey{ProductID: item.ProductID, WarehouseID: req.ToWarehouseID},
+		)
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	stockMovements := make([]models.StockMovement, 0, len(req.Items)*2)
+	for _, item := range req.Items {
+		legs := []struct {
+			movementType string
+			warehouseID  uuid.UUID
+		}{
+			{"out", req.FromWarehouseID},
+			{"in", req.ToWarehouseID},
+		}
+		for _, leg := range legs {
+			// The inbound leg puts back the lots the outbound leg took
+			var lots []lotAllocation
+			if leg.movementType == "in" {
+				if lots, err = carriedLots(ctx, q, referenceType, transferID, item.ProductID, item.Quantity); err != nil {
+					return nil, err
+				}
+			}
+
+			movement, err := postStockMovement(ctx, q, stockPosting{
+				ProductID:       item.ProductID,
+				WarehouseID:     leg.warehouseID,
+				MovementType:    leg.movementType,
+				Quantity:        item.Quantity,
+				ReferenceType:   &referenceType,
+				ReferenceID:     &transferID,
+				ReferenceNumber: &referenceNumber,
+				Reason:          req.Reason,
+				UserID:          userID,
+				ProcessedDate:   processedDate,
+				Lots:            lots,
+				Serials:         item.SerialNumbers,
 			})
+			if errors.Is(err, ErrInsufficientStock) {
+				return nil, fmt.Errorf("%w for product %s in source warehouse", ErrInsufficientStock, item.ProductID)
+			}
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			// Update existing stock level
-			_, err = s.db.UpdateStockQuantity(ctx, &sqlc.UpdateStockQuantityParams{
-				ProductID:   utils.UUIDToPgxUUID(item.ProductID),
-				WarehouseID: utils.UUIDToPgxUUID(item.WarehouseID),
-				Quantity:    int32(currentStock.Quantity + newQuantity),
-			})
+			model, err := stockMovementWithTracking(ctx, q, movement)
 			if err != nil {
 				return nil, err
 			}
+			stockMovements = append(stockMovements, model)
 		}
+	}
 
-		// Convert to model
-		stockMovements = append(stockMovements, models.StockMovement{
-			ID:              utils.PgxUUIDToUUID(stockMovement.ID),
-			ProductID:       utils.PgxUUIDToUUID(stockMovement.ProductID),
-			WarehouseID:     utils.PgxUUIDToUUID(stockMovement.WarehouseID),
-			MovementType:    stockMovement.MovementType,
-			Quantity:        int(stockMovement.Quantity),
-			CostPrice:       utils.OptionalPgxNumericToFloat64Ptr(stockMovement.CostPrice),
-			TotalAmount:     utils.OptionalPgxNumericToFloat64Ptr(stockMovement.TotalAmount),
-			ReferenceType:   stockMovement.ReferenceType,
-			ReferenceID:     utils.OptionalPgxUUIDToUUID(stockMovement.ReferenceID),
-			ReferenceNumber: stockMovement.ReferenceNumber,
-			Reason:          stockMovement.Reason,
-			UserID:          utils.OptionalPgxUUIDToUUID(stockMovement.UserID),
-			ProcessedBy:     utils.OptionalPgxUUIDToUUID(stockMovement.ProcessedBy),
-			ProcessedDate:   utils.OptionalPgxTimestamptzToTimePtr(stockMovement.ProcessedDate),
-			CreatedAt:       utils.PgxTimestamptzToTime(stockMovement.CreatedAt),
-		})
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
 	}
 
-	// Update purchase order total amount if we created one
-	if purchaseOrderID != nil && totalOrderAmount > 0 {
-		_, err = s.db.UpdatePurchaseOrderTotal(ctx, &sqlc.UpdatePurchaseOrderTotalParams{
-			ID:          utils.UUIDToPgxUUID(*purchaseOrderID),
-			TotalAmount: utils.Float64ToPgxNumeric(totalOrderAmount),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to update purchase order total: %w", err)
-		}
+	return &models.StockTransferResponse{
+		TransferID:      transferID,
+		ReferenceNumber: referenceNumber,
+		StockMovements:  stockMovements,
+	}, nil
+}
+
+// MoveBinStock moves stock between two bins of the same warehouse. The move is
+// a single "transfer" movement carrying both bins; the warehouse total, lots
+// and cost are unchanged.
+func (s *StockService) MoveBinStock(ctx context.Context, req models.BinMoveRequest, userID *uuid.UUID) (*models.StockMovement, error) {
+	if req.Quantity <= 0 {
+		return nil, errors.New("quantity must be greater than zero")
+	}
+	from, err := s.db.GetWarehouseLocation(ctx, utils.UUIDToPgxUUID(req.FromLocationID))
+	if err != nil {
+		return nil, fmt.Errorf("source bin %s not found", req.FromLocationID)
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	referenceType := models.BinMoveReferenceType
+	movement, err := postStockMovement(ctx, q, stockPosting{
+		ProductID:      req.ProductID,
+		WarehouseID:    utils.PgxUUIDToUUID(from.WarehouseID),
+		MovementType:   "transfer",
+		Quantity:       req.Quantity,
+		ReferenceType:  &referenceType,
+		Reason:         req.Reason,
+		UserID:         userID,
+		FromLocationID: &req.FromLocationID,
+		ToLocationID:   &req.ToLocationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := stockMovementWithTracking(ctx, q, movement)
+	if err != nil {
+		return nil, err
 	}
 
-	// Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
-	return stockMovements, nil
+	return &result, nil
 }
 
 // GetProductsBySupplier gets all products for a specific supplier
@@ -571,6 +1041,8 @@ func (s *StockService) GetProductsBySupplier(ctx context.Context, supplierID uui
 			CategoryID:  utils.OptionalPgxUUIDToUUID(product.CategoryID),
 			SupplierID:  utils.OptionalPgxUUIDToUUID(product.SupplierID),
 			IsActive:    *product.IsActive,
+			TracksExpiry: product.TracksExpiry,
+			Serialized:   product.Serialized,
 			CreatedAt:   utils.PgxTimestamptzToTime(product.CreatedAt),
 			UpdatedAt:   utils.PgxTimestamptzToTime(product.UpdatedAt),
 		}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"inventory-system/internal/config"
+	"inventory-system/internal/models"
+)
+
+func TestTransferStock(t *testing.T) {
+	db := newTestDB(t)
+	f := newTestFixtures(t, db)
+	ctx := context.Background()
+	service := NewStockService(db, config.ReceivingConfig{})
+
+	from, to := f.warehouse(), f.warehouse()
+	product := f.product(false)
+	f.receive(product, from, 10)
+
+	t.Run("refuses a processed date in the future", func(t *testing.T) {
+		tomorrow := time.Now().Add(24 * time.Hour)
+		_, err := service.TransferStock(ctx, models.StockTransferRequest{
+			FromWarehouseID: from,
+			ToWarehouseID:   to,
+			ProcessedDate:   &tomorrow,
+			Items:           []models.StockTransferItem{{ProductID: product, Quantity: 1}},
+		}, nil)
+		assert.EqualError(t, err, "processed date cannot be in the future")
+	})
+
+	t.Run("posts paired legs under its own reference type", func(t *testing.T) {
+		result, err := service.TransferStock(ctx, models.StockTransferRequest{
+			FromWarehouseID: from,
+			ToWarehouseID:   to,
+			Items:           []models.StockTransferItem{{ProductID: product, Quantity: 4}},
+		}, nil)
+		require.NoError(t, err)
+
+		require.Len(t, result.StockMovements, 2)
+		for _, movement := range result.StockMovements {
+			require.NotNil(t, movement.ReferenceType)
+			assert.Equal(t, models.WarehouseTransferReferenceType, *movement.ReferenceType)
+			assert.Equal(t, result.TransferID, *movement.ReferenceID)
+		}
+		fromQuantity, _ := f.stockLevel(product, from)
+		toQuantity, _ := f.stockLevel(product, to)
+		assert.Equal(t, 6, fromQuantity)
+		assert.Equal(t, 4, toQuantity)
+	})
+
+	t.Run("carries serial numbers across", func(t *testing.T) {
+		serialized := f.product(true)
+		f.receive(serialized, from, 2, "SN-1", "SN-2")
+
+		_, err := service.TransferStock(ctx, models.StockTransferRequest{
+			FromWarehouseID: from,
+			ToWarehouseID:   to,
+			Items:           []models.StockTransferItem{{ProductID: serialized, Quantity: 1, SerialNumbers: []string{"SN-2"}}},
+		}, nil)
+		require.NoError(t, err)
+
+		var status string
+		var warehouseID [16]byte
+		require.NoError(t, db.QueryRow(ctx, `SELECT status, warehouse_id FROM serial_numbers WHERE serial_number = 'SN-2'`).Scan(&status, &warehouseID))
+		assert.Equal(t, models.SerialNumberStatusInStock, status)
+		assert.Equal(t, [16]byte(to), warehouseID)
+	})
+}
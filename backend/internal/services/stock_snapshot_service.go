@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"inventory-system/internal/database"
+	"inventory-system/internal/utils"
+	"log"
+	"time"
+)
+
+// StockSnapshotService records every balance as the movement ledger stood at
+// regular cut-offs, so point-in-time stock queries replay only the movements
+// since the latest snapshot rather than the whole ledger
+type StockSnapshotService struct {
+	db *database.DB
+}
+
+func NewStockSnapshotService(db *database.DB) *StockSnapshotService {
+	return &StockSnapshotService{db: db}
+}
+
+// defaultSnapshotInterval is used when RunSnapshots is given no usable interval
+const defaultSnapshotInterval = 24 * time.Hour
+
+// TakeStockSnapshot records the balances as of at. Each balance is built on
+// its previous snapshot, so only the movements since it are read. A snapshot
+// already taken at the same time is left as it is. The snapshot lock is held
+// exclusively while it is taken, so postings in flight commit first and their
+// movements are included.
+func (s *StockSnapshotService) TakeStockSnapshot(ctx context.Context, at time.Time) error {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	if err := q.LockStockSnapshots(ctx); err != nil {
+		return err
+	}
+	if err := q.CreateStockSnapshot(ctx, utils.TimeToPgxTimestamptz(at)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RunSnapshots takes a snapshot at each multiple of interval since the zero
+// time (UTC midnight for a day) until ctx is done, starting with the latest
+// cut-off if it has not been taken yet. A non-positive interval falls back to
+// a day. Cut-offs lie in the past, so a snapshot only goes stale when a
+// movement is back-dated before it, and postStockMovement deletes those.
+func (s *StockSnapshotService) RunSnapshots(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		log.Printf("Invalid stock snapshot interval %s; using %s", interval, defaultSnapshotInterval)
+		interval = defaultSnapshotInterval
+	}
+	ticker := time.NewTicker(min(interval, time.Hour))
+	defer ticker.Stop()
+
+	for {
+		cutoff := time.Now().UTC().Truncate(interval)
+		latest, err := s.db.GetLatestStockSnapshotAt(ctx)
+		if err != nil {
+			log.Printf("Failed to read the latest stock snapshot: %v", err)
+		} else if !latest.Valid || latest.Time.Before(cutoff) {
+			if err := s.TakeStockSnapshot(ctx, cutoff); err != nil {
+				log.Printf("Failed to take stock snapshot: %v", err)
+			} else {
+				log.Printf("Took stock snapshot as of %s", cutoff.Format(time.RFC3339))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,576 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// StockTransferService manages transfer documents that move stock between
+// warehouses in two steps. Dispatch takes stock out of the source warehouse and
+// receipt books it into the destination; in between it is in transit and part
+// of neither warehouse's on-hand quantity.
+type StockTransferService struct {
+	db *database.DB
+}
+
+func NewStockTransferService(db *database.DB) *StockTransferService {
+	return &StockTransferService{db: db}
+}
+
+func (s *StockTransferService) CreateStockTransfer(ctx context.Context, req models.CreateStockTransferRequest, userID uuid.UUID) (*models.StockTransfer, error) {
+	if req.FromWarehouseID == uuid.Nil || req.ToWarehouseID == uuid.Nil {
+		return nil, errors.New("source and destination warehouses are required")
+	}
+	if req.FromWarehouseID == req.ToWarehouseID {
+		return nil, errors.New("source and destination warehouses must differ")
+	}
+	if err := validateTransferItems(req.Items); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	transferNumber := fmt.Sprintf("TRF-%d", time.Now().UnixMilli())
+	if req.TransferNumber != nil && *req.TransferNumber != "" {
+		transferNumber = *req.TransferNumber
+	}
+
+	transfer, err := q.CreateStockTransfer(ctx, &sqlc.CreateStockTransferParams{
+		TransferNumber:  transferNumber,
+		FromWarehouseID: utils.UUIDToPgxUUID(req.FromWarehouseID),
+		ToWarehouseID:   utils.UUIDToPgxUUID(req.ToWarehouseID),
+		Notes:           req.Notes,
+		CreatedBy:       utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createTransferItems(ctx, q, transfer.ID, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockTransfer(ctx, utils.PgxUUIDToUUID(transfer.ID))
+}
+
+func (s *StockTransferService) GetStockTransfer(ctx context.Context, id uuid.UUID) (*models.StockTransfer, error) {
+	transfer, err := s.db.GetStockTransfer(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.ListStockTransferItems(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.StockTransfer{
+		ID:                utils.PgxUUIDToUUID(transfer.ID),
+		TransferNumber:    transfer.TransferNumber,
+		FromWarehouseID:   utils.PgxUUIDToUUID(transfer.FromWarehouseID),
+		ToWarehouseID:     utils.PgxUUIDToUUID(transfer.ToWarehouseID),
+		Status:            transfer.Status,
+		Notes:             transfer.Notes,
+		CreatedBy:         utils.PgxUUIDToUUID(transfer.CreatedBy),
+		DispatchedBy:      utils.OptionalPgxUUIDToUUID(transfer.DispatchedBy),
+		DispatchedAt:      utils.OptionalPgxTimestamptzToTimePtr(transfer.DispatchedAt),
+		ReceivedBy:        utils.OptionalPgxUUIDToUUID(transfer.ReceivedBy),
+		ReceivedAt:        utils.OptionalPgxTimestamptzToTimePtr(transfer.ReceivedAt),
+		CreatedAt:         utils.PgxTimestamptzToTime(transfer.CreatedAt),
+		UpdatedAt:         utils.PgxTimestamptzToTime(transfer.UpdatedAt),
+		FromWarehouseName: &transfer.FromWarehouseName,
+		ToWarehouseName:   &transfer.ToWarehouseName,
+		Items:             make([]models.StockTransferLine, len(items)),
+	}
+
+	inTransit := transfer.Status == models.StockTransferStatusDispatched || transfer.Status == models.StockTransferStatusPartiallyReceived
+	for i, item := range items {
+		line := models.StockTransferLine{
+			ID:                  utils.PgxUUIDToUUID(item.ID),
+			ProductID:           utils.PgxUUIDToUUID(item.ProductID),
+			Quantity:            int(item.Quantity),
+			ReceivedQuantity:    int(item.ReceivedQuantity),
+			DiscrepancyQuantity: int(item.DiscrepancyQuantity),
+			DiscrepancyReason:   item.DiscrepancyReason,
+			ProductName:         &item.ProductName,
+			ProductSKU:          &item.Sku,
+		}
+		if len(item.SerialNumbers) > 0 {
+			line.SerialNumbers = item.SerialNumbers
+		}
+		if inTransit && item.Quantity > item.ReceivedQuantity {
+			line.InTransitQuantity = int(item.Quantity - item.ReceivedQuantity)
+		}
+		result.Items[i] = line
+	}
+
+	return result, nil
+}
+
+func (s *StockTransferService) ListStockTransfers(ctx context.Context, filter models.StockTransferFilter) (*models.StockTransferListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	transfers, err := s.db.ListStockTransfersWithFilter(ctx, &sqlc.ListStockTransfersWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.FromWarehouseID),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ToWarehouseID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountStockTransfersWithFilter(ctx, &sqlc.CountStockTransfersWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.FromWarehouseID),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ToWarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.StockTransfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = models.StockTransfer{
+			ID:                utils.PgxUUIDToUUID(transfer.ID),
+			TransferNumber:    transfer.TransferNumber,
+			FromWarehouseID:   utils.PgxUUIDToUUID(transfer.FromWarehouseID),
+			ToWarehouseID:     utils.PgxUUIDToUUID(transfer.ToWarehouseID),
+			Status:            transfer.Status,
+			Notes:             transfer.Notes,
+			CreatedBy:         utils.PgxUUIDToUUID(transfer.CreatedBy),
+			DispatchedBy:      utils.OptionalPgxUUIDToUUID(transfer.DispatchedBy),
+			DispatchedAt:      utils.OptionalPgxTimestamptzToTimePtr(transfer.DispatchedAt),
+			ReceivedBy:        utils.OptionalPgxUUIDToUUID(transfer.ReceivedBy),
+			ReceivedAt:        utils.OptionalPgxTimestamptzToTimePtr(transfer.ReceivedAt),
+			CreatedAt:         utils.PgxTimestamptzToTime(transfer.CreatedAt),
+			UpdatedAt:         utils.PgxTimestamptzToTime(transfer.UpdatedAt),
+			FromWarehouseName: &transfer.FromWarehouseName,
+			ToWarehouseName:   &transfer.ToWarehouseName,
+		}
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.StockTransferListResponse{
+		StockTransfers: result,
+		Total:          total,
+		Page:           filter.Page,
+		Limit:          filter.Limit,
+		Pages:          pages,
+	}, nil
+}
+
+// UpdateStockTransfer replaces the notes and lines of a draft transfer
+func (s *StockTransferService) UpdateStockTransfer(ctx context.Context, id uuid.UUID, req models.UpdateStockTransferRequest) (*models.StockTransfer, error) {
+	if err := validateTransferItems(req.Items); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	transfer, err := q.GetStockTransferForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != models.StockTransferStatusDraft {
+		return nil, fmt.Errorf("%w: only draft transfers can be edited", ErrInvalidStatusTransition)
+	}
+
+	if _, err := q.UpdateStockTransferNotes(ctx, &sqlc.UpdateStockTransferNotesParams{
+		ID:    transfer.ID,
+		Notes: req.Notes,
+	}); err != nil {
+		return nil, err
+	}
+	if err := q.DeleteStockTransferItems(ctx, transfer.ID); err != nil {
+		return nil, err
+	}
+	if err := createTransferItems(ctx, q, transfer.ID, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockTransfer(ctx, id)
+}
+
+// DispatchStockTransfer posts "out" movements from the source warehouse for
+// every line. From here until receipt the quantity is in transit.
+func (s *StockTransferService) DispatchStockTransfer(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.StockTransfer, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	transfer, err := q.GetStockTransferForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != models.StockTransferStatusDraft {
+		return nil, fmt.Errorf("%w: cannot dispatch a %s transfer", ErrInvalidStatusTransition, transfer.Status)
+	}
+
+	items, err := q.ListStockTransferItems(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceType := models.StockTransferReferenceType
+	fromWarehouseID := utils.PgxUUIDToUUID(transfer.FromWarehouseID)
+
+	keys := make([]stockKey, len(items))
+	for i, item := range items {
+		keys[i] = stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: fromWarehouseID}
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		productID := utils.PgxUUIDToUUID(item.ProductID)
+		_, err := postStockMovement(ctx, q, stockPosting{
+			ProductID:       productID,
+			WarehouseID:     fromWarehouseID,
+			MovementType:    "out",
+			Quantity:        int(item.Quantity),
+			ReferenceType:   &referenceType,
+			ReferenceID:     &id,
+			ReferenceNumber: &transfer.TransferNumber,
+			Reason:          transfer.Notes,
+			UserID:          &userID,
+			Serials:         item.SerialNumbers,
+		})
+		if errors.Is(err, ErrInsufficientStock) {
+			return nil, fmt.Errorf("%w for %s (%s) in source warehouse", ErrInsufficientStock, item.ProductName, item.Sku)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Make sure the destination has a stock level row so the inbound
+		// quantity shows up in the SOH report while in transit
+		if err := q.EnsureStockLevel(ctx, &sqlc.EnsureStockLevelParams{
+			ProductID:   item.ProductID,
+			WarehouseID: transfer.ToWarehouseID,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := q.MarkStockTransferDispatched(ctx, &sqlc.MarkStockTransferDispatchedParams{
+		ID:           transfer.ID,
+		DispatchedBy: utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockTransfer(ctx, id)
+}
+
+// ReceiveStockTransfer books received quantities into the destination
+// warehouse. Receipts may be split over several calls; the transfer is closed
+// once every line is fully received or the caller marks the receipt complete,
+// at which point any shortfall is recorded as a discrepancy. A line cannot be
+// received beyond its dispatched quantity.
+func (s *StockTransferService) ReceiveStockTransfer(ctx context.Context, id uuid.UUID, req models.ReceiveStockTransferRequest, userID uuid.UUID) (*models.StockTransfer, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	transfer, err := q.GetStockTransferForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != models.StockTransferStatusDispatched && transfer.Status != models.StockTransferStatusPartiallyReceived {
+		return nil, fmt.Errorf("%w: cannot receive a %s transfer", ErrInvalidStatusTransition, transfer.Status)
+	}
+
+	items, err := q.ListStockTransferItems(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	itemsByID := make(map[uuid.UUID]*sqlc.ListStockTransferItemsRow, len(items))
+	for _, item := range items {
+		itemsByID[utils.PgxUUIDToUUID(item.ID)] = item
+	}
+
+	referenceType := models.StockTransferReferenceType
+	toWarehouseID := utils.PgxUUIDToUUID(transfer.ToWarehouseID)
+	processedDate := time.Now()
+	if req.ProcessedDate != nil {
+		processedDate = *req.ProcessedDate
+	}
+	if processedDate.After(time.Now()) {
+		return nil, errors.New("processed date cannot be in the future")
+	}
+	if transfer.DispatchedAt.Valid && processedDate.Before(transfer.DispatchedAt.Time) {
+		return nil, errors.New("processed date cannot be before the transfer was dispatched")
+	}
+
+	keys := make([]stockKey, len(items))
+	for i, item := range items {
+		keys[i] = stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: toWarehouseID}
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	for _, line := range req.Lines {
+		item, ok := itemsByID[line.ItemID]
+		if !ok {
+			return nil, fmt.Errorf("item %s does not belong to this transfer", line.ItemID)
+		}
+		if line.Quantity < 0 {
+			return nil, errors.New("received quantity cannot be negative")
+		}
+		// Only what was dispatched is in transit; receiving more would book
+		// stock into the destination that never left the source
+		if item.ReceivedQuantity+int32(line.Quantity) > item.Quantity {
+			return nil, fmt.Errorf("%s (%s): receiving %d of %d dispatched exceeds the quantity in transit", item.ProductName, item.Sku, item.ReceivedQuantity+int32(line.Quantity), item.Quantity)
+		}
+		if line.DiscrepancyReason != nil {
+			item.DiscrepancyReason = line.DiscrepancyReason
+		}
+		if line.Quantity == 0 {
+			continue
+		}
+
+		// Put back the lots that were dispatched and are still in transit
+		productID := utils.PgxUUIDToUUID(item.ProductID)
+		lots, err := carriedLots(ctx, q, referenceType, id, productID, line.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := postStockMovement(ctx, q, stockPosting{
+			ProductID:       productID,
+			WarehouseID:     toWarehouseID,
+			MovementType:    "in",
+			Quantity:        line.Quantity,
+			ReferenceType:   &referenceType,
+			ReferenceID:     &id,
+			ReferenceNumber: &transfer.TransferNumber,
+			Reason:          line.DiscrepancyReason,
+			UserID:          &userID,
+			ProcessedDate:   processedDate,
+			Lots:            lots,
+			Serials:         line.SerialNumbers,
+		}); err != nil {
+			return nil, err
+		}
+		item.ReceivedQuantity += int32(line.Quantity)
+	}
+
+	complete := req.Complete
+	if !complete {
+		complete = true
+		for _, item := range items {
+			if item.ReceivedQuantity < item.Quantity {
+				complete = false
+				break
+			}
+		}
+	}
+
+	for _, item := range items {
+		var discrepancy int32
+		if complete {
+			discrepancy = item.Quantity - item.ReceivedQuantity
+			if err := writeOffTransitLoss(ctx, q, transfer, item, processedDate, userID); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := q.UpdateStockTransferItemReceipt(ctx, &sqlc.UpdateStockTransferItemReceiptParams{
+			ID:                  item.ID,
+			ReceivedQuantity:    item.ReceivedQuantity,
+			DiscrepancyQuantity: discrepancy,
+			DiscrepancyReason:   item.DiscrepancyReason,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	status := models.StockTransferStatusPartiallyReceived
+	if complete {
+		status = models.StockTransferStatusReceived
+	}
+	if _, err := q.MarkStockTransferReceived(ctx, &sqlc.MarkStockTransferReceivedParams{
+		ID:         transfer.ID,
+		Status:     status,
+		ReceivedBy: utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockTransfer(ctx, id)
+}
+
+// writeOffTransitLoss books the quantity a closed transfer never delivered
+// into the destination and writes it off again under the transit loss reason
+// code. The shortfall thereby leaves the in-transit quantity with the lots,
+// serial numbers and cost it was dispatched with.
+func writeOffTransitLoss(ctx context.Context, q *sqlc.Queries, transfer *sqlc.StockTransfer, item *sqlc.ListStockTransferItemsRow, processedDate time.Time, userID uuid.UUID) error {
+	shortfall := int(item.Quantity - item.ReceivedQuantity)
+	if shortfall == 0 {
+		return nil
+	}
+
+	referenceType := models.StockTransferReferenceType
+	id := utils.PgxUUIDToUUID(transfer.ID)
+	productID := utils.PgxUUIDToUUID(item.ProductID)
+	lots, err := carriedLots(ctx, q, referenceType, id, productID, shortfall)
+	if err != nil {
+		return err
+	}
+	// The dispatched units not received yet are the ones still in transit
+	// on this transfer
+	var serials []string
+	for _, serial := range item.SerialNumbers {
+		sn, err := q.GetSerialNumberForUpdate(ctx, &sqlc.GetSerialNumberForUpdateParams{
+			ProductID:    item.ProductID,
+			SerialNumber: serial,
+		})
+		if err != nil {
+			return err
+		}
+		if sn.Status == models.SerialNumberStatusInTransit && sameReference(sn.LastReferenceType, sn.LastReferenceID, &referenceType, &id) {
+			serials = append(serials, serial)
+		}
+	}
+
+	posting := stockPosting{
+		ProductID:       productID,
+		WarehouseID:     utils.PgxUUIDToUUID(transfer.ToWarehouseID),
+		MovementType:    "in",
+		Quantity:        shortfall,
+		ReferenceType:   &referenceType,
+		ReferenceID:     &id,
+		ReferenceNumber: &transfer.TransferNumber,
+		Reason:          item.DiscrepancyReason,
+		UserID:          &userID,
+		ProcessedDate:   processedDate,
+		Lots:            lots,
+		Serials:         serials,
+	}
+	if _, err := postStockMovement(ctx, q, posting); err != nil {
+		return err
+	}
+
+	lossReferenceType := models.StockTransferLossReferenceType
+	reasonCode := models.TransitLossReasonCode
+	posting.MovementType = "adjustment"
+	posting.Quantity = -shortfall
+	posting.ReferenceType = &lossReferenceType
+	posting.ReasonCode = &reasonCode
+	_, err = postStockMovement(ctx, q, posting)
+	return err
+}
+
+// CancelStockTransfer cancels a transfer that has not been dispatched yet
+func (s *StockTransferService) CancelStockTransfer(ctx context.Context, id uuid.UUID) (*models.StockTransfer, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	transfer, err := q.GetStockTransferForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != models.StockTransferStatusDraft {
+		return nil, fmt.Errorf("%w: only draft transfers can be cancelled", ErrInvalidStatusTransition)
+	}
+
+	if _, err := q.UpdateStockTransferStatus(ctx, &sqlc.UpdateStockTransferStatusParams{
+		ID:     transfer.ID,
+		Status: models.StockTransferStatusCancelled,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockTransfer(ctx, id)
+}
+
+func validateTransferItems(items []models.StockTransferItem) error {
+	if len(items) == 0 {
+		return errors.New("at least one item is required")
+	}
+	for _, item := range items {
+		if item.ProductID == uuid.Nil {
+			return errors.New("product is required for every item")
+		}
+		if item.Quantity <= 0 {
+			return errors.New("transfer quantity must be greater than zero")
+		}
+	}
+	return nil
+}
+
+// createTransferItems writes the transfer lines. Lines of serialized products
+// name the units to move, which are checked when the transfer is dispatched.
+func createTransferItems(ctx context.Context, q *sqlc.Queries, transferID pgtype.UUID, items []models.StockTransferItem) error {
+	for _, item := range items {
+		serials, _, err := checkSerials(ctx, q, item.ProductID, item.Quantity, item.SerialNumbers)
+		if err != nil {
+			return err
+		}
+		if serials == nil {
+			serials = []string{}
+		}
+		if _, err := q.CreateStockTransferItem(ctx, &sqlc.CreateStockTransferItemParams{
+			TransferID:    transferID,
+			ProductID:     utils.UUIDToPgxUUID(item.ProductID),
+			Quantity:      int32(item.Quantity),
+			SerialNumbers: serials,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"inventory-system/internal/models"
+)
+
+func TestReceiveStockTransfer(t *testing.T) {
+	db := newTestDB(t)
+	f := newTestFixtures(t, db)
+	ctx := context.Background()
+	service := NewStockTransferService(db)
+	userID := f.user("manager")
+
+	from, to := f.warehouse(), f.warehouse()
+	product := f.product(false)
+	serialized := f.product(true)
+	f.receive(product, from, 20)
+	f.receive(serialized, from, 3, "SN-1", "SN-2", "SN-3")
+
+	dispatch := func(t *testing.T, items ...models.StockTransferItem) *models.StockTransfer {
+		t.Helper()
+		number := fmt.Sprintf("TRF-%s", uuid.NewString())
+		transfer, err := service.CreateStockTransfer(ctx, models.CreateStockTransferRequest{
+			TransferNumber:  &number,
+			FromWarehouseID: from,
+			ToWarehouseID:   to,
+			Items:           items,
+		}, userID)
+		require.NoError(t, err)
+		transfer, err = service.DispatchStockTransfer(ctx, transfer.ID, userID)
+		require.NoError(t, err)
+		return transfer
+	}
+
+	t.Run("refuses a processed date in the future or before dispatch", func(t *testing.T) {
+		transfer := dispatch(t, models.StockTransferItem{ProductID: product, Quantity: 2})
+		line := []models.ReceiveStockTransferLine{{ItemID: transfer.Items[0].ID, Quantity: 2}}
+
+		tomorrow := time.Now().Add(24 * time.Hour)
+		_, err := service.ReceiveStockTransfer(ctx, transfer.ID, models.ReceiveStockTransferRequest{Lines: line, ProcessedDate: &tomorrow}, userID)
+		assert.EqualError(t, err, "processed date cannot be in the future")
+
+		beforeDispatch := transfer.DispatchedAt.Add(-time.Hour)
+		_, err = service.ReceiveStockTransfer(ctx, transfer.ID, models.ReceiveStockTransferRequest{Lines: line, ProcessedDate: &beforeDispatch}, userID)
+		assert.EqualError(t, err, "processed date cannot be before the transfer was dispatched")
+	})
+
+	t.Run("sets the receipt date once fully received", func(t *testing.T) {
+		transfer := dispatch(t, models.StockTransferItem{ProductID: product, Quantity: 4})
+		itemID := transfer.Items[0].ID
+
+		transfer, err := service.ReceiveStockTransfer(ctx, transfer.ID, models.ReceiveStockTransferRequest{
+			Lines: []models.ReceiveStockTransferLine{{ItemID: itemID, Quantity: 1}},
+		}, userID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StockTransferStatusPartiallyReceived, transfer.Status)
+		assert.Nil(t, transfer.ReceivedAt)
+
+		transfer, err = service.ReceiveStockTransfer(ctx, transfer.ID, models.ReceiveStockTransferRequest{
+			Lines: []models.ReceiveStockTransferLine{{ItemID: itemID, Quantity: 3}},
+		}, userID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StockTransferStatusReceived, transfer.Status)
+		assert.NotNil(t, transfer.ReceivedAt)
+	})
+
+	t.Run("writes off the shortfall of a transfer closed short", func(t *testing.T) {
+		before, _ := f.stockLevel(product, to)
+		transfer := dispatch(t,
+			models.StockTransferItem{ProductID: product, Quantity: 5},
+			models.StockTransferItem{ProductID: serialized, Quantity: 2, SerialNumbers: []string{"SN-1", "SN-2"}},
+		)
+		lines := make([]models.ReceiveStockTransferLine, len(transfer.Items))
+		for i, item := range transfer.Items {
+			lines[i] = models.ReceiveStockTransferLine{ItemID: item.ID, Quantity: 1}
+			if item.ProductID == serialized {
+				lines[i].SerialNumbers = []string{"SN-1"}
+			}
+		}
+
+		transfer, err := service.ReceiveStockTransfer(ctx, transfer.ID, models.ReceiveStockTransferRequest{Lines: lines, Complete: true}, userID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StockTransferStatusReceived, transfer.Status)
+		for _, item := range transfer.Items {
+			assert.Equal(t, item.Quantity-1, item.DiscrepancyQuantity)
+			assert.Zero(t, item.InTransitQuantity)
+		}
+
+		// Only the received quantity stays in the destination
+		after, _ := f.stockLevel(product, to)
+		assert.Equal(t, before+1, after)
+
+		var written int
+		require.NoError(t, db.QueryRow(ctx,
+			`SELECT COALESCE(-SUM(quantity), 0) FROM stock_movements
+			 WHERE reference_type = $1 AND reference_id = $2 AND movement_type = 'adjustment' AND reason_code = $3`,
+			models.StockTransferLossReferenceType, transfer.ID, models.TransitLossReasonCode).Scan(&written))
+		assert.Equal(t, 5, written)
+
+		var status string
+		require.NoError(t, db.QueryRow(ctx, `SELECT status FROM serial_numbers WHERE serial_number = 'SN-2'`).Scan(&status))
+		assert.Equal(t, models.SerialNumberStatusIssued, status)
+	})
+}
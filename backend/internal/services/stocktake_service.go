@@ -0,0 +1,695 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/costing"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// StocktakeService runs physical stock counts. Opening a stocktake freezes the
+// expected quantity of every product in scope; counters then submit counts,
+// which are reviewed and, on approval, the variances against the frozen
+// quantities are posted as count correction adjustments. Correcting by the
+// variance rather than to the counted quantity keeps movements made during
+// the count intact.
+type StocktakeService struct {
+	db *database.DB
+}
+
+func NewStocktakeService(db *database.DB) *StocktakeService {
+	return &StocktakeService{db: db}
+}
+
+// CreateStocktake opens a count session for a warehouse, optionally limited
+// to one category, and freezes the expected quantities
+func (s *StocktakeService) CreateStocktake(ctx context.Context, req models.CreateStocktakeRequest, userID uuid.UUID) (*models.Stocktake, error) {
+	if req.WarehouseID == uuid.Nil {
+		return nil, errors.New("warehouse is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktakeNumber := fmt.Sprintf("STK-%d", time.Now().UnixMilli())
+	if req.StocktakeNumber != nil && *req.StocktakeNumber != "" {
+		stocktakeNumber = *req.StocktakeNumber
+	}
+
+	stocktake, err := q.CreateStocktake(ctx, &sqlc.CreateStocktakeParams{
+		StocktakeNumber: stocktakeNumber,
+		WarehouseID:     utils.UUIDToPgxUUID(req.WarehouseID),
+		CategoryID:      utils.OptionalUUIDToPgxUUID(req.CategoryID),
+		Notes:           req.Notes,
+		CreatedBy:       utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := q.ListStocktakeCandidates(ctx, &sqlc.ListStocktakeCandidatesParams{
+		WarehouseID: stocktake.WarehouseID,
+		Column2:     stocktake.CategoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("no stocked products to count in this warehouse")
+	}
+	for _, candidate := range candidates {
+		if err := q.CreateStocktakeItem(ctx, &sqlc.CreateStocktakeItemParams{
+			StocktakeID:      stocktake.ID,
+			ProductID:        candidate.ProductID,
+			ExpectedQuantity: candidate.Quantity,
+			ExpectedSerials:  candidate.ExpectedSerials,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, utils.PgxUUIDToUUID(stocktake.ID))
+}
+
+// GetStocktake returns a stocktake with its lines, counts and variances
+func (s *StocktakeService) GetStocktake(ctx context.Context, id uuid.UUID) (*models.Stocktake, error) {
+	stocktake, err := s.db.GetStocktake(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.ListStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+	countRows, err := s.db.ListStocktakeCounts(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uuid.UUID][]models.StocktakeCount)
+	for _, row := range countRows {
+		itemID := utils.PgxUUIDToUUID(row.StocktakeItemID)
+		counts[itemID] = append(counts[itemID], models.StocktakeCount{
+			ID:               utils.PgxUUIDToUUID(row.ID),
+			CountedBy:        utils.PgxUUIDToUUID(row.CountedBy),
+			Quantity:         int(row.Quantity),
+			SerialNumbers:    row.SerialNumbers,
+			Notes:            row.Notes,
+			UpdatedAt:        utils.PgxTimestamptzToTime(row.UpdatedAt),
+			CounterFirstName: &row.FirstName,
+			CounterLastName:  &row.LastName,
+		})
+	}
+
+	result := toStocktakeModel(&sqlc.Stocktake{
+		ID:              stocktake.ID,
+		StocktakeNumber: stocktake.StocktakeNumber,
+		WarehouseID:     stocktake.WarehouseID,
+		CategoryID:      stocktake.CategoryID,
+		Status:          stocktake.Status,
+		Notes:           stocktake.Notes,
+		CreatedBy:       stocktake.CreatedBy,
+		SubmittedBy:     stocktake.SubmittedBy,
+		SubmittedAt:     stocktake.SubmittedAt,
+		ApprovedBy:      stocktake.ApprovedBy,
+		ApprovedAt:      stocktake.ApprovedAt,
+		CreatedAt:       stocktake.CreatedAt,
+		UpdatedAt:       stocktake.UpdatedAt,
+	})
+	result.WarehouseName = &stocktake.WarehouseName
+	result.CategoryName = stocktake.CategoryName
+	result.Items = make([]models.StocktakeLine, len(items))
+
+	for i, item := range items {
+		itemID := utils.PgxUUIDToUUID(item.ID)
+		line := models.StocktakeLine{
+			ID:               itemID,
+			ProductID:        utils.PgxUUIDToUUID(item.ProductID),
+			ExpectedQuantity: int(item.ExpectedQuantity),
+			CountedQuantity:  utils.OptionalInt32PtrToInt(item.CountedQuantity),
+			ResolvedBy:       utils.OptionalPgxUUIDToUUID(item.ResolvedBy),
+			AdjustedQuantity: utils.OptionalInt32PtrToInt(item.AdjustedQuantity),
+			Counts:           counts[itemID],
+			ProductName:      &item.ProductName,
+			ProductSKU:       &item.Sku,
+		}
+		if line.Counts == nil {
+			line.Counts = []models.StocktakeCount{}
+		}
+		if item.Serialized {
+			line.ExpectedSerials = item.ExpectedSerials
+			line.CountedSerials = item.CountedSerials
+		}
+		if line.CountedQuantity != nil {
+			variance := *line.CountedQuantity - line.ExpectedQuantity
+			value := costing.Round(float64(variance) * utils.PgxNumericToFloat64(item.UnitCost))
+			line.VarianceQuantity = &variance
+			line.VarianceValue = &value
+		} else if len(line.Counts) > 0 {
+			_, _, agreed := agreedStocktakeCount(line.Counts)
+			line.Conflict = !agreed
+		}
+		result.Items[i] = line
+	}
+
+	return &result, nil
+}
+
+func (s *StocktakeService) ListStocktakes(ctx context.Context, filter models.StocktakeFilter) (*models.StocktakeListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	rows, err := s.db.ListStocktakesWithFilter(ctx, &sqlc.ListStocktakesWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountStocktakesWithFilter(ctx, &sqlc.CountStocktakesWithFilterParams{
+		Column1: utils.OptionalStringToString(filter.Status),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.Stocktake, len(rows))
+	for i, row := range rows {
+		result[i] = toStocktakeModel(&sqlc.Stocktake{
+			ID:              row.ID,
+			StocktakeNumber: row.StocktakeNumber,
+			WarehouseID:     row.WarehouseID,
+			CategoryID:      row.CategoryID,
+			Status:          row.Status,
+			Notes:           row.Notes,
+			CreatedBy:       row.CreatedBy,
+			SubmittedBy:     row.SubmittedBy,
+			SubmittedAt:     row.SubmittedAt,
+			ApprovedBy:      row.ApprovedBy,
+			ApprovedAt:      row.ApprovedAt,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+		})
+		result[i].WarehouseName = &row.WarehouseName
+		result[i].CategoryName = row.CategoryName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.StocktakeListResponse{
+		Stocktakes: result,
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		Pages:      pages,
+	}, nil
+}
+
+// SubmitStocktakeCounts records the calling counter's counts. Counting a
+// product again replaces that counter's earlier count.
+func (s *StocktakeService) SubmitStocktakeCounts(ctx context.Context, id uuid.UUID, req models.SubmitStocktakeCountsRequest, userID uuid.UUID) (*models.Stocktake, error) {
+	if len(req.Lines) == 0 {
+		return nil, errors.New("at least one count is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktake, err := q.GetStocktakeForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != models.StocktakeStatusCounting {
+		return nil, fmt.Errorf("%w: cannot count a stocktake in %s", ErrInvalidStatusTransition, stocktake.Status)
+	}
+
+	items, err := q.ListStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+	itemsByProduct := make(map[uuid.UUID]*sqlc.ListStocktakeItemsRow, len(items))
+	for _, item := range items {
+		itemsByProduct[utils.PgxUUIDToUUID(item.ProductID)] = item
+	}
+
+	for _, line := range req.Lines {
+		item, ok := itemsByProduct[line.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("product %s is not part of this stocktake", line.ProductID)
+		}
+		if line.Quantity < 0 {
+			return nil, errors.New("counted quantity cannot be negative")
+		}
+		serials, _, err := checkSerials(ctx, q, line.ProductID, line.Quantity, line.SerialNumbers)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(serials)
+
+		if _, err := q.UpsertStocktakeCount(ctx, &sqlc.UpsertStocktakeCountParams{
+			StocktakeItemID: item.ID,
+			CountedBy:       utils.UUIDToPgxUUID(userID),
+			Quantity:        int32(line.Quantity),
+			SerialNumbers:   nonNilSerials(serials),
+			Notes:           line.Notes,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// SubmitStocktakeForReview closes counting. Lines whose counters agree take
+// the agreed count; lines they disagree on are left for a recount or for a
+// reviewer to resolve. Lines nobody counted are not corrected.
+func (s *StocktakeService) SubmitStocktakeForReview(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Stocktake, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktake, err := lockStocktakeForTransition(ctx, q, id, models.StocktakeStatusReview)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := stocktakeCountsByItem(ctx, q, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) == 0 {
+		return nil, errors.New("nothing has been counted yet")
+	}
+	for itemID, itemCounts := range counts {
+		quantity, serials, agreed := agreedStocktakeCount(itemCounts)
+		if !agreed {
+			continue
+		}
+		counted := int32(quantity)
+		if _, err := q.UpdateStocktakeItemCount(ctx, &sqlc.UpdateStocktakeItemCountParams{
+			ID:              utils.UUIDToPgxUUID(itemID),
+			CountedQuantity: &counted,
+			CountedSerials:  nonNilSerials(serials),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := q.MarkStocktakeSubmitted(ctx, &sqlc.MarkStocktakeSubmittedParams{
+		ID:          stocktake.ID,
+		SubmittedBy: utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// ReopenStocktake sends a stocktake under review back to counting for a
+// recount. Agreed and resolved counts are cleared and worked out again on the
+// next submission.
+func (s *StocktakeService) ReopenStocktake(ctx context.Context, id uuid.UUID) (*models.Stocktake, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktake, err := lockStocktakeForTransition(ctx, q, id, models.StocktakeStatusCounting)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.ClearStocktakeItemCounts(ctx, stocktake.ID); err != nil {
+		return nil, err
+	}
+	if _, err := q.UpdateStocktakeStatus(ctx, &sqlc.UpdateStocktakeStatusParams{
+		ID:     stocktake.ID,
+		Status: models.StocktakeStatusCounting,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// ResolveStocktakeItem sets the count of a line under review, typically one
+// the counters disagreed on
+func (s *StocktakeService) ResolveStocktakeItem(ctx context.Context, id, itemID uuid.UUID, req models.ResolveStocktakeItemRequest, userID uuid.UUID) (*models.Stocktake, error) {
+	if req.CountedQuantity < 0 {
+		return nil, errors.New("counted quantity cannot be negative")
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktake, err := q.GetStocktakeForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if stocktake.Status != models.StocktakeStatusReview {
+		return nil, fmt.Errorf("%w: counts can only be resolved while a stocktake is in review", ErrInvalidStatusTransition)
+	}
+
+	items, err := q.ListStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+	var item *sqlc.ListStocktakeItemsRow
+	for _, candidate := range items {
+		if utils.PgxUUIDToUUID(candidate.ID) == itemID {
+			item = candidate
+		}
+	}
+	if item == nil {
+		return nil, fmt.Errorf("item %s does not belong to this stocktake", itemID)
+	}
+
+	serials, _, err := checkSerials(ctx, q, utils.PgxUUIDToUUID(item.ProductID), req.CountedQuantity, req.SerialNumbers)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(serials)
+
+	counted := int32(req.CountedQuantity)
+	if _, err := q.UpdateStocktakeItemCount(ctx, &sqlc.UpdateStocktakeItemCountParams{
+		ID:              item.ID,
+		CountedQuantity: &counted,
+		CountedSerials:  nonNilSerials(serials),
+		ResolvedBy:      utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// ApproveStocktake posts a count correction adjustment for every counted line
+// that differs from its frozen quantity, all in one transaction. Serialized
+// lines write off the expected units that were not counted and book in the
+// counted units that were not expected, skipping units that have since moved.
+func (s *StocktakeService) ApproveStocktake(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*models.Stocktake, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktake, err := lockStocktakeForTransition(ctx, q, id, models.StocktakeStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := q.ListStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := stocktakeCountsByItem(ctx, q, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouseID := utils.PgxUUIDToUUID(stocktake.WarehouseID)
+	var keys []stockKey
+	conflicts := 0
+	for _, item := range items {
+		if item.CountedQuantity != nil {
+			keys = append(keys, stockKey{ProductID: utils.PgxUUIDToUUID(item.ProductID), WarehouseID: warehouseID})
+		} else if len(counts[utils.PgxUUIDToUUID(item.ID)]) > 0 {
+			conflicts++
+		}
+	}
+	if conflicts > 0 {
+		return nil, fmt.Errorf("%d lines have conflicting counts; resolve them or reopen the stocktake for a recount", conflicts)
+	}
+	if err := lockStockLevels(ctx, q, keys); err != nil {
+		return nil, err
+	}
+
+	stocktakeID := utils.PgxUUIDToUUID(stocktake.ID)
+	referenceType := models.StocktakeReferenceType
+	reasonCode := models.StocktakeReasonCode
+	posting := stockPosting{
+		WarehouseID:     warehouseID,
+		MovementType:    "adjustment",
+		ReferenceType:   &referenceType,
+		ReferenceID:     &stocktakeID,
+		ReferenceNumber: &stocktake.StocktakeNumber,
+		ReasonCode:      &reasonCode,
+		UserID:          &userID,
+		ProcessedDate:   time.Now(),
+		Counted:         true,
+	}
+
+	for _, item := range items {
+		if item.CountedQuantity == nil {
+			continue
+		}
+		posting.ProductID = utils.PgxUUIDToUUID(item.ProductID)
+
+		var corrections []stockPosting
+		if item.Serialized {
+			missing, found, err := stocktakeSerialVariance(ctx, q, item, stocktake.WarehouseID)
+			if err != nil {
+				return nil, err
+			}
+			if len(missing) > 0 {
+				p := posting
+				p.Quantity, p.Serials = -len(missing), missing
+				corrections = append(corrections, p)
+			}
+			if len(found) > 0 {
+				p := posting
+				p.Quantity, p.Serials = len(found), found
+				corrections = append(corrections, p)
+			}
+		} else if variance := int(*item.CountedQuantity - item.ExpectedQuantity); variance != 0 {
+			p := posting
+			p.Quantity = variance
+			corrections = append(corrections, p)
+		}
+
+		adjusted := int32(0)
+		for _, p := range corrections {
+			if _, err := postStockMovement(ctx, q, p); err != nil {
+				if errors.Is(err, ErrInsufficientStock) {
+					return nil, fmt.Errorf("%w to write off the count variance of %s (%s)", ErrInsufficientStock, item.ProductName, item.Sku)
+				}
+				return nil, err
+			}
+			adjusted += int32(p.Quantity)
+		}
+		if err := q.UpdateStocktakeItemAdjustment(ctx, &sqlc.UpdateStocktakeItemAdjustmentParams{
+			ID:               item.ID,
+			AdjustedQuantity: &adjusted,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := q.MarkStocktakeApproved(ctx, &sqlc.MarkStocktakeApprovedParams{
+		ID:         stocktake.ID,
+		ApprovedBy: utils.UUIDToPgxUUID(userID),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// CancelStocktake abandons a stocktake without posting anything
+func (s *StocktakeService) CancelStocktake(ctx context.Context, id uuid.UUID) (*models.Stocktake, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	stocktake, err := lockStocktakeForTransition(ctx, q, id, models.StocktakeStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := q.UpdateStocktakeStatus(ctx, &sqlc.UpdateStocktakeStatusParams{
+		ID:     stocktake.ID,
+		Status: models.StocktakeStatusCancelled,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetStocktake(ctx, id)
+}
+
+// lockStocktakeForTransition locks a stocktake and checks it may move to status
+func lockStocktakeForTransition(ctx context.Context, q *sqlc.Queries, id uuid.UUID, status string) (*sqlc.Stocktake, error) {
+	stocktake, err := q.GetStocktakeForUpdate(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	if !models.CanTransitionStocktake(stocktake.Status, status) {
+		return nil, fmt.Errorf("%w: cannot move a %s stocktake to %s", ErrInvalidStatusTransition, stocktake.Status, status)
+	}
+	return stocktake, nil
+}
+
+// stocktakeCountsByItem groups a stocktake's counts by line
+func stocktakeCountsByItem(ctx context.Context, q *sqlc.Queries, stocktakeID pgtype.UUID) (map[uuid.UUID][]models.StocktakeCount, error) {
+	rows, err := q.ListStocktakeCounts(ctx, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uuid.UUID][]models.StocktakeCount)
+	for _, row := range rows {
+		itemID := utils.PgxUUIDToUUID(row.StocktakeItemID)
+		counts[itemID] = append(counts[itemID], models.StocktakeCount{
+			Quantity:      int(row.Quantity),
+			SerialNumbers: row.SerialNumbers,
+		})
+	}
+	return counts, nil
+}
+
+// agreedStocktakeCount returns the count every counter agrees on, comparing
+// serial numbers as well as quantities
+func agreedStocktakeCount(counts []models.StocktakeCount) (int, []string, bool) {
+	if len(counts) == 0 {
+		return 0, nil, false
+	}
+	first := counts[0]
+	for _, count := range counts[1:] {
+		if count.Quantity != first.Quantity || !slices.Equal(count.SerialNumbers, first.SerialNumbers) {
+			return 0, nil, false
+		}
+	}
+	return first.Quantity, first.SerialNumbers, true
+}
+
+// stocktakeSerialVariance compares the serial numbers counted on a line with
+// those frozen when the stocktake opened. Missing units are only written off
+// while still in stock in the warehouse, and found units are only booked in
+// if they have not been received there since.
+func stocktakeSerialVariance(ctx context.Context, q *sqlc.Queries, item *sqlc.ListStocktakeItemsRow, warehouseID pgtype.UUID) ([]string, []string, error) {
+	inStockHere := func(serial string) (bool, error) {
+		sn, err := q.GetSerialNumberForUpdate(ctx, &sqlc.GetSerialNumberForUpdateParams{
+			ProductID:    item.ProductID,
+			SerialNumber: serial,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return sn.Status == models.SerialNumberStatusInStock && sn.WarehouseID == warehouseID, nil
+	}
+
+	var missing, found []string
+	for _, serial := range item.ExpectedSerials {
+		if slices.Contains(item.CountedSerials, serial) {
+			continue
+		}
+		ok, err := inStockHere(serial)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			missing = append(missing, serial)
+		}
+	}
+	for _, serial := range item.CountedSerials {
+		if slices.Contains(item.ExpectedSerials, serial) {
+			continue
+		}
+		ok, err := inStockHere(serial)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			found = append(found, serial)
+		}
+	}
+	return missing, found, nil
+}
+
+// nonNilSerials keeps serial number arrays from being written as NULL
+func nonNilSerials(serials []string) []string {
+	if serials == nil {
+		return []string{}
+	}
+	return serials
+}
+
+func toStocktakeModel(st *sqlc.Stocktake) models.Stocktake {
+	return models.Stocktake{
+		ID:              utils.PgxUUIDToUUID(st.ID),
+		StocktakeNumber: st.StocktakeNumber,
+		WarehouseID:     utils.PgxUUIDToUUID(st.WarehouseID),
+		CategoryID:      utils.OptionalPgxUUIDToUUID(st.CategoryID),
+		Status:          st.Status,
+		Notes:           st.Notes,
+		CreatedBy:       utils.PgxUUIDToUUID(st.CreatedBy),
+		SubmittedBy:     utils.OptionalPgxUUIDToUUID(st.SubmittedBy),
+		SubmittedAt:     utils.OptionalPgxTimestamptzToTimePtr(st.SubmittedAt),
+		ApprovedBy:      utils.OptionalPgxUUIDToUUID(st.ApprovedBy),
+		ApprovedAt:      utils.OptionalPgxTimestamptzToTimePtr(st.ApprovedAt),
+		CreatedAt:       utils.PgxTimestamptzToTime(st.CreatedAt),
+		UpdatedAt:       utils.PgxTimestamptzToTime(st.UpdatedAt),
+	}
+}
@@ -0,0 +1,512 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/config"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SupplierInvoiceService records supplier invoices against purchase orders and
+// runs the three-way match: every invoice line is compared with the PO line it
+// bills and with the goods received for that line through "purchase_order"
+// stock movements.
+type SupplierInvoiceService struct {
+	db       *database.DB
+	matching config.MatchingConfig
+}
+
+func NewSupplierInvoiceService(db *database.DB, matching config.MatchingConfig) *SupplierInvoiceService {
+	return &SupplierInvoiceService{
+		db:       db,
+		matching: matching,
+	}
+}
+
+// CreateSupplierInvoice records an invoice with its lines and matches it
+func (s *SupplierInvoiceService) CreateSupplierInvoice(ctx context.Context, req models.CreateSupplierInvoiceRequest, userID uuid.UUID) (*models.SupplierInvoice, error) {
+	if strings.TrimSpace(req.InvoiceNumber) == "" {
+		return nil, errors.New("invoice number is required")
+	}
+	if err := validateSupplierInvoiceItems(req.Items); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	po, err := lockInvoiceablePurchaseOrder(ctx, q, utils.UUIDToPgxUUID(req.PurchaseOrderID))
+	if err != nil {
+		return nil, err
+	}
+	if req.SupplierID != nil {
+		if _, err := q.GetSupplier(ctx, utils.UUIDToPgxUUID(*req.SupplierID)); err != nil {
+			return nil, errors.New("supplier not found")
+		}
+	}
+
+	poItems, err := q.ListPurchaseOrderItems(ctx, po.ID)
+	if err != nil {
+		return nil, err
+	}
+	onOrder := make(map[uuid.UUID]bool, len(poItems))
+	for _, item := range poItems {
+		onOrder[utils.PgxUUIDToUUID(item.ID)] = true
+	}
+	for _, item := range req.Items {
+		if !onOrder[item.PurchaseOrderItemID] {
+			return nil, fmt.Errorf("item %s does not belong to purchase order %s", item.PurchaseOrderItemID, po.PoNumber)
+		}
+	}
+
+	invoiceDate := time.Now()
+	if req.InvoiceDate != nil {
+		invoiceDate = *req.InvoiceDate
+	}
+
+	invoice, err := q.CreateSupplierInvoice(ctx, &sqlc.CreateSupplierInvoiceParams{
+		InvoiceNumber:   req.InvoiceNumber,
+		PurchaseOrderID: po.ID,
+		SupplierID:      utils.OptionalUUIDToPgxUUID(req.SupplierID),
+		InvoiceDate:     utils.TimeToPgxDate(invoiceDate),
+		DueDate:         utils.TimeToPgxDatePtr(req.DueDate),
+		Notes:           req.Notes,
+		CreatedBy:       utils.UUIDToPgxUUID(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range req.Items {
+		if _, err := q.CreateSupplierInvoiceItem(ctx, &sqlc.CreateSupplierInvoiceItemParams{
+			InvoiceID:           invoice.ID,
+			PurchaseOrderItemID: utils.UUIDToPgxUUID(item.PurchaseOrderItemID),
+			Quantity:            int32(item.Quantity),
+			UnitPrice:           utils.Float64ToPgxNumeric(item.UnitPrice),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	total, err := q.GetSupplierInvoiceItemsTotal(ctx, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := q.UpdateSupplierInvoiceTotal(ctx, &sqlc.UpdateSupplierInvoiceTotalParams{
+		ID:          invoice.ID,
+		TotalAmount: total,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := matchSupplierInvoice(ctx, q, s.matching, invoice.ID, po.ID, poItems); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSupplierInvoice(ctx, utils.PgxUUIDToUUID(invoice.ID))
+}
+
+func (s *SupplierInvoiceService) GetSupplierInvoice(ctx context.Context, id uuid.UUID) (*models.SupplierInvoice, error) {
+	invoice, err := s.db.GetSupplierInvoice(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.ListSupplierInvoiceItems(ctx, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toSupplierInvoiceModel(&sqlc.SupplierInvoice{
+		ID:              invoice.ID,
+		InvoiceNumber:   invoice.InvoiceNumber,
+		PurchaseOrderID: invoice.PurchaseOrderID,
+		SupplierID:      invoice.SupplierID,
+		InvoiceDate:     invoice.InvoiceDate,
+		DueDate:         invoice.DueDate,
+		TotalAmount:     invoice.TotalAmount,
+		Status:          invoice.Status,
+		Notes:           invoice.Notes,
+		CreatedBy:       invoice.CreatedBy,
+		MatchedAt:       invoice.MatchedAt,
+		CreatedAt:       invoice.CreatedAt,
+		UpdatedAt:       invoice.UpdatedAt,
+	})
+	result.PoNumber = &invoice.PoNumber
+	result.SupplierName = invoice.SupplierName
+
+	result.Items = make([]models.SupplierInvoiceLine, len(items))
+	for i, item := range items {
+		result.Items[i] = models.SupplierInvoiceLine{
+			ID:                         utils.PgxUUIDToUUID(item.ID),
+			PurchaseOrderItemID:        utils.PgxUUIDToUUID(item.PurchaseOrderItemID),
+			ProductID:                  utils.PgxUUIDToUUID(item.ProductID),
+			Quantity:                   int(item.Quantity),
+			UnitPrice:                  utils.PgxNumericToFloat64(item.UnitPrice),
+			TotalPrice:                 utils.PgxNumericToFloat64(item.TotalPrice),
+			OrderedQuantity:            int(item.OrderedQuantity),
+			ReceivedQuantity:           int(item.ReceivedQuantity),
+			PreviouslyInvoicedQuantity: int(item.PreviouslyInvoicedQuantity),
+			PoUnitPrice:                utils.PgxNumericToFloat64(item.PoUnitPrice),
+			QuantityVariance:           int(item.QuantityVariance),
+			PriceVariance:              utils.PgxNumericToFloat64(item.PriceVariance),
+			MatchStatus:                item.MatchStatus,
+			MatchNotes:                 item.MatchNotes,
+			ProductName:                &item.ProductName,
+			ProductSKU:                 &item.Sku,
+		}
+	}
+
+	return &result, nil
+}
+
+func (s *SupplierInvoiceService) ListSupplierInvoices(ctx context.Context, filter models.SupplierInvoiceFilter) (*models.SupplierInvoiceListResponse, error) {
+	offset := (filter.Page - 1) * filter.Limit
+
+	invoices, err := s.db.ListSupplierInvoicesWithFilter(ctx, &sqlc.ListSupplierInvoicesWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.PurchaseOrderID),
+		Column2: utils.OptionalStringToString(filter.Status),
+		Limit:   int32(filter.Limit),
+		Offset:  int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.db.CountSupplierInvoicesWithFilter(ctx, &sqlc.CountSupplierInvoicesWithFilterParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.PurchaseOrderID),
+		Column2: utils.OptionalStringToString(filter.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.SupplierInvoice, len(invoices))
+	for i, invoice := range invoices {
+		result[i] = toSupplierInvoiceModel(&sqlc.SupplierInvoice{
+			ID:              invoice.ID,
+			InvoiceNumber:   invoice.InvoiceNumber,
+			PurchaseOrderID: invoice.PurchaseOrderID,
+			SupplierID:      invoice.SupplierID,
+			InvoiceDate:     invoice.InvoiceDate,
+			DueDate:         invoice.DueDate,
+			TotalAmount:     invoice.TotalAmount,
+			Status:          invoice.Status,
+			Notes:           invoice.Notes,
+			CreatedBy:       invoice.CreatedBy,
+			MatchedAt:       invoice.MatchedAt,
+			CreatedAt:       invoice.CreatedAt,
+			UpdatedAt:       invoice.UpdatedAt,
+		})
+		result[i].PoNumber = &invoice.PoNumber
+		result[i].SupplierName = invoice.SupplierName
+	}
+
+	pages := int((total + int64(filter.Limit) - 1) / int64(filter.Limit))
+
+	return &models.SupplierInvoiceListResponse{
+		SupplierInvoices: result,
+		Total:            total,
+		Page:             filter.Page,
+		Limit:            filter.Limit,
+		Pages:            pages,
+	}, nil
+}
+
+// MatchSupplierInvoice re-runs the three-way match, e.g. after more goods have
+// been received against the purchase order
+func (s *SupplierInvoiceService) MatchSupplierInvoice(ctx context.Context, id uuid.UUID) (*models.SupplierInvoice, error) {
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	q := s.db.WithTx(tx)
+
+	invoice, err := q.GetSupplierInvoice(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+	// Lock the purchase order before the invoice, in the same order as
+	// CreateSupplierInvoice
+	po, err := lockInvoiceablePurchaseOrder(ctx, q, invoice.PurchaseOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := q.GetSupplierInvoiceForUpdate(ctx, invoice.ID); err != nil {
+		return nil, err
+	}
+
+	poItems, err := q.ListPurchaseOrderItems(ctx, po.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := matchSupplierInvoice(ctx, q, s.matching, invoice.ID, po.ID, poItems); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetSupplierInvoice(ctx, id)
+}
+
+func validateSupplierInvoiceItems(items []models.SupplierInvoiceItem) error {
+	if len(items) == 0 {
+		return errors.New("at least one item is required")
+	}
+	seen := make(map[uuid.UUID]bool, len(items))
+	for _, item := range items {
+		if item.PurchaseOrderItemID == uuid.Nil {
+			return errors.New("a purchase order line is required for every item")
+		}
+		if seen[item.PurchaseOrderItemID] {
+			return fmt.Errorf("purchase order line %s is invoiced more than once", item.PurchaseOrderItemID)
+		}
+		seen[item.PurchaseOrderItemID] = true
+		if item.Quantity <= 0 {
+			return errors.New("item quantity must be greater than zero")
+		}
+		if item.UnitPrice < 0 {
+			return errors.New("unit price cannot be negative")
+		}
+	}
+	return nil
+}
+
+// lockInvoiceablePurchaseOrder reads the order FOR UPDATE, which serialises
+// invoices raised against it, and checks that it can be invoiced
+func lockInvoiceablePurchaseOrder(ctx context.Context, q *sqlc.Queries, id pgtype.UUID) (*sqlc.PurchaseOrder, error) {
+	po, err := q.GetPurchaseOrderForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isApprovedPurchaseOrderStatus(po.Status) {
+		return nil, fmt.Errorf("%w: cannot invoice a %s purchase order", ErrInvalidStatusTransition, po.Status)
+	}
+	return po, nil
+}
+
+// matchSupplierInvoice compares every line of the invoice with its PO line and
+// with the quantity received for it, records the figures and outcome on the
+// line, and sets the invoice status to the worst line status.
+//
+// Received quantities come from the "purchase_order" movements posted against
+// the order less their reversals, netted per product and allocated to the
+// order's lines for that product in line order. Quantities billed by other invoices for the same line
+// count towards what this invoice bills.
+func matchSupplierInvoice(ctx context.Context, q *sqlc.Queries, tolerance config.MatchingConfig, invoiceID, poID pgtype.UUID, poItems []*sqlc.ListPurchaseOrderItemsRow) error {
+	receipts, err := q.ListPurchaseOrderReceiptQuantities(ctx, poID)
+	if err != nil {
+		return err
+	}
+	receivedByProduct := make(map[uuid.UUID]int32, len(receipts))
+	for _, receipt := range receipts {
+		receivedByProduct[utils.PgxUUIDToUUID(receipt.ProductID)] = receipt.ReceivedQuantity
+	}
+	received := allocatePurchaseOrderReceipts(poItems, receivedByProduct)
+
+	invoiced, err := q.ListInvoicedQuantitiesForPurchaseOrder(ctx, &sqlc.ListInvoicedQuantitiesForPurchaseOrderParams{
+		PurchaseOrderID: poID,
+		ID:              invoiceID,
+	})
+	if err != nil {
+		return err
+	}
+	previouslyInvoiced := make(map[uuid.UUID]int32, len(invoiced))
+	for _, row := range invoiced {
+		previouslyInvoiced[utils.PgxUUIDToUUID(row.PurchaseOrderItemID)] = row.InvoicedQuantity
+	}
+
+	poItemsByID := make(map[uuid.UUID]*sqlc.ListPurchaseOrderItemsRow, len(poItems))
+	for _, item := range poItems {
+		poItemsByID[utils.PgxUUIDToUUID(item.ID)] = item
+	}
+
+	lines, err := q.ListSupplierInvoiceItems(ctx, invoiceID)
+	if err != nil {
+		return err
+	}
+
+	status := models.SupplierInvoiceStatusMatched
+	for _, line := range lines {
+		itemID := utils.PgxUUIDToUUID(line.PurchaseOrderItemID)
+		poItem, ok := poItemsByID[itemID]
+		if !ok {
+			return fmt.Errorf("invoice line %s no longer matches a purchase order line", utils.PgxUUIDToUUID(line.ID))
+		}
+
+		match := matchSupplierInvoiceLine(tolerance, supplierInvoiceLineFigures{
+			Quantity:           line.Quantity,
+			UnitPrice:          utils.PgxNumericToFloat64(line.UnitPrice),
+			OrderedQuantity:    poItem.Quantity,
+			ReceivedQuantity:   received[itemID],
+			PreviouslyInvoiced: previouslyInvoiced[itemID],
+			PoUnitPrice:        utils.PgxNumericToFloat64(poItem.UnitPrice),
+		})
+
+		var notes *string
+		if len(match.Notes) > 0 {
+			joined := strings.Join(match.Notes, "; ")
+			notes = &joined
+		}
+		if _, err := q.UpdateSupplierInvoiceItemMatch(ctx, &sqlc.UpdateSupplierInvoiceItemMatchParams{
+			ID:                         line.ID,
+			OrderedQuantity:            poItem.Quantity,
+			ReceivedQuantity:           received[itemID],
+			PreviouslyInvoicedQuantity: previouslyInvoiced[itemID],
+			PoUnitPrice:                poItem.UnitPrice,
+			QuantityVariance:           match.QuantityVariance,
+			PriceVariance:              utils.Float64ToPgxNumeric(match.PriceVariance),
+			MatchStatus:                match.Status,
+			MatchNotes:                 notes,
+		}); err != nil {
+			return err
+		}
+
+		if matchSeverity(match.Status) > matchSeverity(status) {
+			status = match.Status
+		}
+	}
+
+	_, err = q.UpdateSupplierInvoiceStatus(ctx, &sqlc.UpdateSupplierInvoiceStatusParams{
+		ID:     invoiceID,
+		Status: status,
+	})
+	return err
+}
+
+// allocatePurchaseOrderReceipts spreads the net quantity received per product
+// over the order lines for that product, filling each line up to its ordered
+// quantity in turn. Anything received beyond that goes to the last line.
+func allocatePurchaseOrderReceipts(poItems []*sqlc.ListPurchaseOrderItemsRow, receivedByProduct map[uuid.UUID]int32) map[uuid.UUID]int32 {
+	lastItem := make(map[uuid.UUID]uuid.UUID, len(poItems))
+	for _, item := range poItems {
+		lastItem[utils.PgxUUIDToUUID(item.ProductID)] = utils.PgxUUIDToUUID(item.ID)
+	}
+
+	received := make(map[uuid.UUID]int32, len(poItems))
+	for _, item := range poItems {
+		id := utils.PgxUUIDToUUID(item.ID)
+		productID := utils.PgxUUIDToUUID(item.ProductID)
+		remaining := max(receivedByProduct[productID], 0)
+
+		quantity := min(remaining, item.Quantity)
+		if lastItem[productID] == id {
+			quantity = remaining
+		}
+		received[id] = quantity
+		receivedByProduct[productID] = remaining - quantity
+	}
+	return received
+}
+
+// supplierInvoiceLineFigures are the quantities and prices one invoice line is
+// matched on
+type supplierInvoiceLineFigures struct {
+	Quantity           int32
+	UnitPrice          float64
+	OrderedQuantity    int32
+	ReceivedQuantity   int32
+	PreviouslyInvoiced int32
+	PoUnitPrice        float64
+}
+
+type supplierInvoiceLineMatch struct {
+	Status           string
+	QuantityVariance int32   // billed to date minus received
+	PriceVariance    float64 // invoice unit price minus PO unit price
+	Notes            []string
+}
+
+// matchSupplierInvoiceLine decides the match status of one invoice line.
+// Billing more than has been received, beyond the quantity tolerance, blocks
+// the line. Billing more than was ordered, or at a unit price further from the
+// PO price than the price tolerance, is a variance for someone to review.
+func matchSupplierInvoiceLine(tolerance config.MatchingConfig, f supplierInvoiceLineFigures) supplierInvoiceLineMatch {
+	billed := f.PreviouslyInvoiced + f.Quantity
+	match := supplierInvoiceLineMatch{
+		Status:           models.SupplierInvoiceStatusMatched,
+		QuantityVariance: billed - f.ReceivedQuantity,
+		PriceVariance:    f.UnitPrice - f.PoUnitPrice,
+	}
+
+	flag := func(status, note string) {
+		if matchSeverity(status) > matchSeverity(match.Status) {
+			match.Status = status
+		}
+		match.Notes = append(match.Notes, note)
+	}
+
+	if f.ReceivedQuantity <= 0 {
+		flag(models.SupplierInvoiceStatusBlocked, "nothing has been received for this line")
+	} else if billed > f.ReceivedQuantity+f.ReceivedQuantity*int32(tolerance.QuantityTolerance)/100 {
+		flag(models.SupplierInvoiceStatusBlocked, fmt.Sprintf("invoiced %d but only %d received", billed, f.ReceivedQuantity))
+	}
+
+	if billed > f.OrderedQuantity+f.OrderedQuantity*int32(tolerance.QuantityTolerance)/100 {
+		flag(models.SupplierInvoiceStatusVariance, fmt.Sprintf("invoiced %d but only %d ordered", billed, f.OrderedQuantity))
+	}
+
+	// Compare in cents so the tolerance is not thrown off by float rounding
+	priceCents := int64(math.Round(f.UnitPrice * 100))
+	poPriceCents := int64(math.Round(f.PoUnitPrice * 100))
+	diff := priceCents - poPriceCents
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff*100 > poPriceCents*int64(tolerance.PriceTolerance) {
+		flag(models.SupplierInvoiceStatusVariance, fmt.Sprintf("unit price %.2f differs from PO price %.2f", f.UnitPrice, f.PoUnitPrice))
+	}
+
+	return match
+}
+
+// matchSeverity orders match statuses from best to worst
+func matchSeverity(status string) int {
+	switch status {
+	case models.SupplierInvoiceStatusMatched:
+		return 0
+	case models.SupplierInvoiceStatusVariance:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func toSupplierInvoiceModel(i *sqlc.SupplierInvoice) models.SupplierInvoice {
+	return models.SupplierInvoice{
+		ID:              utils.PgxUUIDToUUID(i.ID),
+		InvoiceNumber:   i.InvoiceNumber,
+		PurchaseOrderID: utils.PgxUUIDToUUID(i.PurchaseOrderID),
+		SupplierID:      utils.OptionalPgxUUIDToUUID(i.SupplierID),
+		InvoiceDate:     utils.PgxDateToTime(i.InvoiceDate),
+		DueDate:         utils.PgxDateToTimePtr(i.DueDate),
+		TotalAmount:     utils.PgxNumericToFloat64(i.TotalAmount),
+		Status:          i.Status,
+		Notes:           i.Notes,
+		CreatedBy:       utils.PgxUUIDToUUID(i.CreatedBy),
+		MatchedAt:       utils.OptionalPgxTimestamptzToTimePtr(i.MatchedAt),
+		CreatedAt:       utils.PgxTimestamptzToTime(i.CreatedAt),
+		UpdatedAt:       utils.PgxTimestamptzToTime(i.UpdatedAt),
+	}
+}
@@ -20,6 +20,10 @@ func NewSupplierService(db *database.DB) *SupplierService {
 }
 
 func (s *SupplierService) CreateSupplier(ctx context.Context, req models.CreateSupplierRequest) (*models.Supplier, error) {
+	if req.LeadTimeDays < 0 {
+		return nil, errors.New("lead time cannot be negative")
+	}
+
 	// Check if supplier already exists
 	existingSupplier, err := s.db.GetSupplierByName(ctx, req.Name)
 	if err == nil && existingSupplier != nil {
@@ -38,6 +42,7 @@ func (s *SupplierService) CreateSupplier(ctx context.Context, req models.CreateS
 		Country:      &req.Country,
 		PostalCode:   &req.PostalCode,
 		IsActive:     &[]bool{true}[0],
+		LeadTimeDays: int32(req.LeadTimeDays),
 	})
 	if err != nil {
 		return nil, err
@@ -89,6 +94,7 @@ func (s *SupplierService) CreateSupplier(ctx context.Context, req models.CreateS
 		Country:      country,
 		PostalCode:   postalCode,
 		IsActive:     *supplier.IsActive,
+		LeadTimeDays: int(supplier.LeadTimeDays),
 		CreatedAt:    utils.PgxTimestamptzToTime(supplier.CreatedAt),
 		UpdatedAt:    utils.PgxTimestamptzToTime(supplier.UpdatedAt),
 	}, nil
@@ -146,6 +152,7 @@ func (s *SupplierService) GetSupplier(ctx context.Context, id uuid.UUID) (*model
 		Country:      country,
 		PostalCode:   postalCode,
 		IsActive:     *supplier.IsActive,
+		LeadTimeDays: int(supplier.LeadTimeDays),
 		CreatedAt:    utils.PgxTimestamptzToTime(supplier.CreatedAt),
 		UpdatedAt:    utils.PgxTimestamptzToTime(supplier.UpdatedAt),
 	}, nil
@@ -205,6 +212,7 @@ func (s *SupplierService) ListSuppliers(ctx context.Context) ([]models.Supplier,
 			Country:      country,
 			PostalCode:   postalCode,
 			IsActive:     *supplier.IsActive,
+			LeadTimeDays: int(supplier.LeadTimeDays),
 			CreatedAt:    utils.PgxTimestamptzToTime(supplier.CreatedAt),
 			UpdatedAt:    utils.PgxTimestamptzToTime(supplier.UpdatedAt),
 		}
@@ -312,6 +320,7 @@ func (s *SupplierService) ListSuppliersWithFilter(ctx context.Context, filter mo
 			Country:      country,
 			PostalCode:   postalCode,
 			IsActive:     *supplier.IsActive,
+			LeadTimeDays: int(supplier.LeadTimeDays),
 			CreatedAt:    utils.PgxTimestamptzToTime(supplier.CreatedAt),
 			UpdatedAt:    utils.PgxTimestamptzToTime(supplier.UpdatedAt),
 		}
@@ -321,6 +330,10 @@ func (s *SupplierService) ListSuppliersWithFilter(ctx context.Context, filter mo
 }
 
 func (s *SupplierService) UpdateSupplier(ctx context.Context, id uuid.UUID, req models.UpdateSupplierRequest) (*models.Supplier, error) {
+	if req.LeadTimeDays < 0 {
+		return nil, errors.New("lead time cannot be negative")
+	}
+
 	supplier, err := s.db.UpdateSupplier(ctx, &sqlc.UpdateSupplierParams{
 		ID:           utils.UUIDToPgxUUID(id),
 		Name:         req.Name,
@@ -333,6 +346,7 @@ func (s *SupplierService) UpdateSupplier(ctx context.Context, id uuid.UUID, req
 		Country:      &req.Country,
 		PostalCode:   &req.PostalCode,
 		IsActive:     &[]bool{true}[0],
+		LeadTimeDays: int32(req.LeadTimeDays),
 	})
 	if err != nil {
 		return nil, err
@@ -384,6 +398,7 @@ func (s *SupplierService) UpdateSupplier(ctx context.Context, id uuid.UUID, req
 		Country:      country,
 		PostalCode:   postalCode,
 		IsActive:     *supplier.IsActive,
+		LeadTimeDays: int(supplier.LeadTimeDays),
 		CreatedAt:    utils.PgxTimestamptzToTime(supplier.CreatedAt),
 		UpdatedAt:    utils.PgxTimestamptzToTime(supplier.UpdatedAt),
 	}, nil
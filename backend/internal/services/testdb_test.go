@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/config"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newTestDB returns a database with every migration applied in a schema of
+// its own, dropped again when the test ends. Tests that need a database are
+// skipped unless TEST_DATABASE_URL names one to create the schemas in.
+func newTestDB(t *testing.T) *database.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := admin.Exec(ctx, `CREATE EXTENSION IF NOT EXISTS "uuid-ossp" SCHEMA public`); err != nil {
+		t.Fatalf("create extension: %v", err)
+	}
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close(context.Background())
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema + ",public"
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrations, err := filepath.Glob("../../migrations/*.up.sql")
+	if err != nil {
+		t.Fatalf("list migrations: %v", err)
+	}
+	sort.Strings(migrations)
+	for _, migration := range migrations {
+		sql, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatalf("read %s: %v", migration, err)
+		}
+		if _, err := pool.Exec(ctx, string(sql)); err != nil {
+			t.Fatalf("apply %s: %v", filepath.Base(migration), err)
+		}
+	}
+
+	return &database.DB{Pool: pool, Queries: sqlc.New(pool)}
+}
+
+// testFixtures creates the users, warehouses and products tests post against
+type testFixtures struct {
+	t  *testing.T
+	db *database.DB
+	n  int
+}
+
+func newTestFixtures(t *testing.T, db *database.DB) *testFixtures {
+	return &testFixtures{t: t, db: db}
+}
+
+func (f *testFixtures) next() int {
+	f.n++
+	return f.n
+}
+
+func (f *testFixtures) user(role string) uuid.UUID {
+	f.t.Helper()
+	var id uuid.UUID
+	if err := f.db.QueryRow(context.Background(),
+		`INSERT INTO users (email, password_hash, first_name, last_name, role)
+		 VALUES ($1, 'x', 'Test', 'User', $2) RETURNING id`,
+		fmt.Sprintf("user%d@example.com", f.next()), role).Scan(&id); err != nil {
+		f.t.Fatalf("create user: %v", err)
+	}
+	return id
+}
+
+func (f *testFixtures) warehouse() uuid.UUID {
+	f.t.Helper()
+	var id uuid.UUID
+	if err := f.db.QueryRow(context.Background(),
+		`INSERT INTO warehouses (name, location) VALUES ($1, 'Test') RETURNING id`,
+		fmt.Sprintf("Warehouse %d", f.next())).Scan(&id); err != nil {
+		f.t.Fatalf("create warehouse: %v", err)
+	}
+	return id
+}
+
+func (f *testFixtures) product(serialized bool) uuid.UUID {
+	f.t.Helper()
+	n := f.next()
+	product, err := NewProductService(f.db).CreateProduct(context.Background(), models.CreateProductRequest{
+		SKU:        fmt.Sprintf("SKU-%d", n),
+		Name:       fmt.Sprintf("Product %d", n),
+		UnitPrice:  10,
+		Serialized: serialized,
+	})
+	if err != nil {
+		f.t.Fatalf("create product: %v", err)
+	}
+	return product.ID
+}
+
+// receive books quantity of a product into a warehouse at unit cost 5
+func (f *testFixtures) receive(productID, warehouseID uuid.UUID, quantity int, serials ...string) {
+	f.t.Helper()
+	cost := 5.0
+	if _, err := NewStockService(f.db, config.ReceivingConfig{}).CreateStockMovement(context.Background(), models.CreateStockMovementRequest{
+		ProductID:     productID,
+		WarehouseID:   warehouseID,
+		MovementType:  "in",
+		Quantity:      quantity,
+		CostPrice:     &cost,
+		SerialNumbers: serials,
+	}, nil); err != nil {
+		f.t.Fatalf("receive stock: %v", err)
+	}
+}
+
+// stockLevel returns the on-hand and reserved quantity of a product in a warehouse
+func (f *testFixtures) stockLevel(productID, warehouseID uuid.UUID) (int, int) {
+	f.t.Helper()
+	var quantity, reserved int
+	err := f.db.QueryRow(context.Background(),
+		`SELECT quantity, reserved_quantity FROM stock_levels WHERE product_id = $1 AND warehouse_id = $2`,
+		productID, warehouseID).Scan(&quantity, &reserved)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		f.t.Fatalf("read stock level: %v", err)
+	}
+	return quantity, reserved
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type UOMService struct {
+	db *database.DB
+}
+
+func NewUOMService(db *database.DB) *UOMService {
+	return &UOMService{db: db}
+}
+
+func (s *UOMService) CreateUnitOfMeasure(ctx context.Context, req models.CreateUnitOfMeasureRequest) (*models.UnitOfMeasure, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" || len(code) > 20 {
+		return nil, errors.New("code is required and must be at most 20 characters")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+
+	existing, err := s.db.GetUnitOfMeasureByCode(ctx, code)
+	if err == nil && existing != nil {
+		return nil, errors.New("unit of measure already exists")
+	}
+
+	uom, err := s.db.CreateUnitOfMeasure(ctx, &sqlc.CreateUnitOfMeasureParams{
+		Code: code,
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toUnitOfMeasureModel(uom)
+	return &result, nil
+}
+
+func (s *UOMService) GetUnitOfMeasure(ctx context.Context, id uuid.UUID) (*models.UnitOfMeasure, error) {
+	uom, err := s.db.GetUnitOfMeasure(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toUnitOfMeasureModel(uom)
+	return &result, nil
+}
+
+func (s *UOMService) ListUnitsOfMeasure(ctx context.Context) ([]models.UnitOfMeasure, error) {
+	uoms, err := s.db.ListUnitsOfMeasure(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.UnitOfMeasure, len(uoms))
+	for i, uom := range uoms {
+		result[i] = toUnitOfMeasureModel(uom)
+	}
+
+	return result, nil
+}
+
+// UpdateUnitOfMeasure renames or deactivates a unit. The code is immutable
+// because movements and order lines refer to it; inactive units can no longer
+// be used for new quantities.
+func (s *UOMService) UpdateUnitOfMeasure(ctx context.Context, id uuid.UUID, req models.UpdateUnitOfMeasureRequest) (*models.UnitOfMeasure, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+
+	uom, err := s.db.UpdateUnitOfMeasure(ctx, &sqlc.UpdateUnitOfMeasureParams{
+		ID:       utils.UUIDToPgxUUID(id),
+		Name:     req.Name,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toUnitOfMeasureModel(uom)
+	return &result, nil
+}
+
+func (s *UOMService) GetProductUOMs(ctx context.Context, productID uuid.UUID) ([]models.ProductUOM, error) {
+	if _, err := s.db.GetProduct(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.ListProductUOMs(ctx, utils.UUIDToPgxUUID(productID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.ProductUOM, len(rows))
+	for i, row := range rows {
+		result[i] = toProductUOMModel(row)
+	}
+
+	return result, nil
+}
+
+// SetProductUOMs replaces the units a product can be handled in. Documents
+// already posted keep the factor they were entered with.
+func (s *UOMService) SetProductUOMs(ctx context.Context, productID uuid.UUID, req models.SetProductUOMsRequest) ([]models.ProductUOM, error) {
+	for i := range req.UOMs {
+		req.UOMs[i].UOM = strings.ToUpper(strings.TrimSpace(req.UOMs[i].UOM))
+	}
+	if err := models.ValidateProductUOMs(req.UOMs); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := s.db.WithTx(tx)
+
+	if _, err := q.GetProduct(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+
+	if err := q.DeleteProductUOMs(ctx, utils.UUIDToPgxUUID(productID)); err != nil {
+		return nil, err
+	}
+
+	for _, input := range req.UOMs {
+		uom, err := q.GetUnitOfMeasureByCode(ctx, input.UOM)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("unit of measure %s not found", input.UOM)
+			}
+			return nil, err
+		}
+		if !uom.IsActive {
+			return nil, fmt.Errorf("unit of measure %s is inactive", input.UOM)
+		}
+
+		if _, err := q.CreateProductUOM(ctx, &sqlc.CreateProductUOMParams{
+			ProductID: utils.UUIDToPgxUUID(productID),
+			UomID:     uom.ID,
+			Factor:    int32(input.Factor),
+			IsBase:    input.IsBase,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetProductUOMs(ctx, productID)
+}
+
+// productUOM is the unit a quantity on a movement or order line was given
+// in. A nil *productUOM means base units, so its methods are nil-safe.
+type productUOM struct {
+	ID     uuid.UUID
+	Code   string
+	Factor int
+}
+
+// resolveProductUOM looks up one of a product's units by code. It returns nil
+// when no code is given.
+func resolveProductUOM(ctx context.Context, q *sqlc.Queries, productID uuid.UUID, code *string) (*productUOM, error) {
+	if code == nil || strings.TrimSpace(*code) == "" {
+		return nil, nil
+	}
+
+	normalized := strings.ToUpper(strings.TrimSpace(*code))
+	row, err := q.GetProductUOMByCode(ctx, &sqlc.GetProductUOMByCodeParams{
+		ProductID: utils.UUIDToPgxUUID(productID),
+		Code:      normalized,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("product has no unit %s", normalized)
+		}
+		return nil, err
+	}
+
+	return &productUOM{
+		ID:     utils.PgxUUIDToUUID(row.UomID),
+		Code:   row.Code,
+		Factor: int(row.Factor),
+	}, nil
+}
+
+// toBase converts a quantity in this unit to base units
+func (u *productUOM) toBase(quantity int) int {
+	if u == nil {
+		return quantity
+	}
+	return quantity * u.Factor
+}
+
+// priceToBase converts a price per this unit to a price per base unit
+func (u *productUOM) priceToBase(price *float64) *float64 {
+	if u == nil || price == nil {
+		return price
+	}
+	perBase := *price / float64(u.Factor)
+	return &perBase
+}
+
+// columns returns the uom_id, uom_quantity and uom_factor recorded alongside
+// a base quantity. Nothing is recorded for base units or for a quantity that
+// is not a whole number of this unit, such as a partial receipt counted in
+// base units.
+func (u *productUOM) columns(baseQuantity int) (pgtype.UUID, *int32, *int32) {
+	if u == nil || u.Factor <= 0 || baseQuantity%u.Factor != 0 {
+		return pgtype.UUID{}, nil, nil
+	}
+	quantity := int32(baseQuantity / u.Factor)
+	factor := int32(u.Factor)
+	return utils.UUIDToPgxUUID(u.ID), &quantity, &factor
+}
+
+// uomFromColumns rebuilds the unit an order line was entered in from its
+// snapshot columns, or nil when it was entered in base units
+func uomFromColumns(id pgtype.UUID, code *string, factor *int32) *productUOM {
+	if !id.Valid || factor == nil || *factor <= 0 {
+		return nil
+	}
+	uom := &productUOM{ID: utils.PgxUUIDToUUID(id), Factor: int(*factor)}
+	if code != nil {
+		uom.Code = *code
+	}
+	return uom
+}
+
+// uomPrice returns the price per entered unit of a line, derived from its
+// total so it matches what was ordered
+func uomPrice(totalPrice float64, uomQuantity *int32) *float64 {
+	if uomQuantity == nil || *uomQuantity == 0 {
+		return nil
+	}
+	price := totalPrice / float64(*uomQuantity)
+	return &price
+}
+
+func toUnitOfMeasureModel(u *sqlc.UnitsOfMeasure) models.UnitOfMeasure {
+	return models.UnitOfMeasure{
+		ID:        utils.PgxUUIDToUUID(u.ID),
+		Code:      u.Code,
+		Name:      u.Name,
+		IsActive:  u.IsActive,
+		CreatedAt: utils.PgxTimestamptzToTime(u.CreatedAt),
+		UpdatedAt: utils.PgxTimestamptzToTime(u.UpdatedAt),
+	}
+}
+
+func toProductUOMModel(r *sqlc.ListProductUOMsRow) models.ProductUOM {
+	return models.ProductUOM{
+		ID:        utils.PgxUUIDToUUID(r.ID),
+		ProductID: utils.PgxUUIDToUUID(r.ProductID),
+		UOMID:     utils.PgxUUIDToUUID(r.UomID),
+		UOM:       r.Code,
+		UOMName:   r.UomName,
+		Factor:    int(r.Factor),
+		IsBase:    r.IsBase,
+		CreatedAt: utils.PgxTimestamptzToTime(r.CreatedAt),
+		UpdatedAt: utils.PgxTimestamptzToTime(r.UpdatedAt),
+	}
+}
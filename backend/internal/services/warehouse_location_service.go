@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-system/internal/database"
+	sqlc "inventory-system/internal/database/sqlc"
+	"inventory-system/internal/models"
+	"inventory-system/internal/utils"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// WarehouseLocationService manages the zones, aisles and bins inside a
+// warehouse and exposes bin balances. Bin balances are maintained by
+// postStockMovement.
+type WarehouseLocationService struct {
+	db *database.DB
+}
+
+func NewWarehouseLocationService(db *database.DB) *WarehouseLocationService {
+	return &WarehouseLocationService{db: db}
+}
+
+// CreateWarehouseLocation adds a zone, aisle or bin to a warehouse
+func (s *WarehouseLocationService) CreateWarehouseLocation(ctx context.Context, warehouseID uuid.UUID, req models.CreateWarehouseLocationRequest) (*models.WarehouseLocation, error) {
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		return nil, errors.New("location code is required")
+	}
+	if _, err := s.db.GetWarehouse(ctx, utils.UUIDToPgxUUID(warehouseID)); err != nil {
+		return nil, err
+	}
+
+	parentType := ""
+	if req.ParentID != nil {
+		parent, err := s.db.GetWarehouseLocation(ctx, utils.UUIDToPgxUUID(*req.ParentID))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("parent location %s not found", *req.ParentID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if utils.PgxUUIDToUUID(parent.WarehouseID) != warehouseID {
+			return nil, fmt.Errorf("parent location %s is in another warehouse", parent.Code)
+		}
+		if !parent.IsActive {
+			return nil, fmt.Errorf("parent location %s is inactive", parent.Code)
+		}
+		parentType = parent.LocationType
+	}
+	if !models.CanNestLocation(parentType, req.LocationType) {
+		if parentType == "" {
+			return nil, fmt.Errorf("a %s must be placed inside another location", req.LocationType)
+		}
+		return nil, fmt.Errorf("a %s cannot be placed inside a %s", req.LocationType, parentType)
+	}
+
+	location, err := s.db.CreateWarehouseLocation(ctx, &sqlc.CreateWarehouseLocationParams{
+		WarehouseID:  utils.UUIDToPgxUUID(warehouseID),
+		ParentID:     utils.OptionalUUIDToPgxUUID(req.ParentID),
+		LocationType: req.LocationType,
+		Code:         code,
+		Name:         req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toWarehouseLocationModel(location)
+	return &result, nil
+}
+
+// GetWarehouseLocation returns a location with the stock held in it
+func (s *WarehouseLocationService) GetWarehouseLocation(ctx context.Context, id uuid.UUID) (*models.WarehouseLocation, error) {
+	location, err := s.db.GetWarehouseLocation(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toWarehouseLocationModel(location)
+	if location.LocationType == models.LocationTypeBin {
+		result.Stock, err = listBinStockLevels(ctx, s.db.Queries, models.BinStockLevelFilter{LocationID: &id})
+		if err != nil {
+			return nil, err
+		}
+		for _, level := range result.Stock {
+			result.Quantity += level.Quantity
+		}
+	}
+	return &result, nil
+}
+
+// ListWarehouseLocations returns the locations of a warehouse in code order
+func (s *WarehouseLocationService) ListWarehouseLocations(ctx context.Context, warehouseID uuid.UUID, includeInactive bool) ([]models.WarehouseLocation, error) {
+	locations, err := s.db.ListWarehouseLocations(ctx, &sqlc.ListWarehouseLocationsParams{
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+		Column2:     includeInactive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.WarehouseLocation, len(locations))
+	for i, location := range locations {
+		result[i] = toWarehouseLocationModel(&sqlc.WarehouseLocation{
+			ID:           location.ID,
+			WarehouseID:  location.WarehouseID,
+			ParentID:     location.ParentID,
+			LocationType: location.LocationType,
+			Code:         location.Code,
+			Name:         location.Name,
+			IsActive:     location.IsActive,
+			CreatedAt:    location.CreatedAt,
+			UpdatedAt:    location.UpdatedAt,
+		})
+		result[i].Quantity = int(location.Quantity)
+	}
+	return result, nil
+}
+
+// UpdateWarehouseLocation renames or (de)activates a location. A bin still
+// holding stock, or a zone or aisle with active locations inside it, cannot
+// be deactivated.
+func (s *WarehouseLocationService) UpdateWarehouseLocation(ctx context.Context, id uuid.UUID, req models.UpdateWarehouseLocationRequest) (*models.WarehouseLocation, error) {
+	location, err := s.db.GetWarehouseLocation(ctx, utils.UUIDToPgxUUID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	if location.IsActive && !req.IsActive {
+		quantity, err := s.db.GetLocationStockQuantity(ctx, location.ID)
+		if err != nil {
+			return nil, err
+		}
+		if quantity > 0 {
+			return nil, fmt.Errorf("location %s still holds %d units", location.Code, quantity)
+		}
+		children, err := s.db.CountActiveChildLocations(ctx, location.ID)
+		if err != nil {
+			return nil, err
+		}
+		if children > 0 {
+			return nil, fmt.Errorf("location %s still contains active locations", location.Code)
+		}
+	}
+
+	updated, err := s.db.UpdateWarehouseLocation(ctx, &sqlc.UpdateWarehouseLocationParams{
+		ID:       location.ID,
+		Name:     req.Name,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toWarehouseLocationModel(updated)
+	return &result, nil
+}
+
+// ListBinStockLevels returns non-zero bin balances
+func (s *WarehouseLocationService) ListBinStockLevels(ctx context.Context, filter models.BinStockLevelFilter) ([]models.BinStockLevel, error) {
+	return listBinStockLevels(ctx, s.db.Queries, filter)
+}
+
+func listBinStockLevels(ctx context.Context, q *sqlc.Queries, filter models.BinStockLevelFilter) ([]models.BinStockLevel, error) {
+	levels, err := q.ListBinStockLevels(ctx, &sqlc.ListBinStockLevelsParams{
+		Column1: utils.OptionalUUIDToPgxUUID(filter.WarehouseID),
+		Column2: utils.OptionalUUIDToPgxUUID(filter.LocationID),
+		Column3: utils.OptionalUUIDToPgxUUID(filter.ProductID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.BinStockLevel, len(levels))
+	for i, level := range levels {
+		result[i] = models.BinStockLevel{
+			LocationID:   utils.PgxUUIDToUUID(level.LocationID),
+			LocationCode: level.Code,
+			WarehouseID:  utils.PgxUUIDToUUID(level.WarehouseID),
+			ProductID:    utils.PgxUUIDToUUID(level.ProductID),
+			Quantity:     int(level.Quantity),
+			UpdatedAt:    utils.PgxTimestamptzToTime(level.UpdatedAt),
+			ProductName:  &level.ProductName,
+			ProductSKU:   &level.Sku,
+		}
+	}
+	return result, nil
+}
+
+// binAllocation is the signed change a posting makes to one bin balance
+type binAllocation struct {
+	LocationID uuid.UUID
+	Quantity   int32
+}
+
+// postMovementLocations applies a posting to bin balances and records the
+// bins the movement changed. Receipts go into ToLocationID or stay unbinned;
+// issues come from FromLocationID, or from unbinned stock first and then from
+// the bins in code order. Bin moves take from one bin and put into the other.
+// Explicit Locations are applied as given. onHand is the stock level
+// quantity before the posting.
+func postMovementLocations(ctx context.Context, q *sqlc.Queries, p stockPosting, movementID pgtype.UUID, onHand int32) error {
+	delta := p.delta()
+
+	var allocations []binAllocation
+	switch {
+	case len(p.Locations) > 0:
+		allocations = p.Locations
+	case p.MovementType == "transfer":
+		if p.FromLocationID == nil || p.ToLocationID == nil {
+			return errors.New("bin moves need a source and a destination bin")
+		}
+		if *p.FromLocationID == *p.ToLocationID {
+			return errors.New("source and destination bins must be different")
+		}
+		allocations = []binAllocation{
+			{LocationID: *p.FromLocationID, Quantity: -int32(p.Quantity)},
+			{LocationID: *p.ToLocationID, Quantity: int32(p.Quantity)},
+		}
+	case delta > 0:
+		if p.FromLocationID != nil {
+			return errors.New("receipts are put into a bin; use to_location_id")
+		}
+		if p.ToLocationID != nil {
+			allocations = []binAllocation{{LocationID: *p.ToLocationID, Quantity: delta}}
+		}
+	case delta < 0:
+		if p.ToLocationID != nil {
+			return errors.New("issues are taken from a bin; use from_location_id")
+		}
+		if p.FromLocationID != nil {
+			allocations = []binAllocation{{LocationID: *p.FromLocationID, Quantity: delta}}
+			break
+		}
+		auto, err := allocateBins(ctx, q, p.ProductID, p.WarehouseID, -delta, onHand)
+		if err != nil {
+			return err
+		}
+		allocations = auto
+	}
+
+	for _, a := range allocations {
+		if err := applyBinDelta(ctx, q, p.ProductID, p.WarehouseID, a.LocationID, a.Quantity); err != nil {
+			return err
+		}
+		if _, err := q.CreateStockMovementLocation(ctx, &sqlc.CreateStockMovementLocationParams{
+			StockMovementID: movementID,
+			LocationID:      utils.UUIDToPgxUUID(a.LocationID),
+			Quantity:        a.Quantity,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocateBins picks the bins an issue without a bin is taken from. Stock not
+// yet put away is used first so the bin balances never exceed on-hand stock.
+func allocateBins(ctx context.Context, q *sqlc.Queries, productID, warehouseID uuid.UUID, quantity, onHand int32) ([]binAllocation, error) {
+	binned, err := q.GetBinnedStockQuantity(ctx, &sqlc.GetBinnedStockQuantityParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	need := quantity - max(onHand-binned, 0)
+	if need <= 0 {
+		return nil, nil
+	}
+
+	levels, err := q.ListBinStockLevelsForAllocation(ctx, &sqlc.ListBinStockLevelsForAllocationParams{
+		ProductID:   utils.UUIDToPgxUUID(productID),
+		WarehouseID: utils.UUIDToPgxUUID(warehouseID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []binAllocation
+	for _, level := range levels {
+		if need == 0 {
+			break
+		}
+		take := min(need, level.Quantity)
+		allocations = append(allocations, binAllocation{LocationID: utils.PgxUUIDToUUID(level.LocationID), Quantity: -take})
+		need -= take
+	}
+	if need > 0 {
+		return nil, ErrInsufficientStock
+	}
+	return allocations, nil
+}
+
+// applyBinDelta adds delta to a product's balance in a bin under a row lock.
+// Stock can only be put into active bins of the posting's warehouse.
+func applyBinDelta(ctx context.Context, q *sqlc.Queries, productID, warehouseID, locationID uuid.UUID, delta int32) error {
+	location, err := q.GetWarehouseLocation(ctx, utils.UUIDToPgxUUID(locationID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("location %s not found", locationID)
+	}
+	if err != nil {
+		return err
+	}
+	if utils.PgxUUIDToUUID(location.WarehouseID) != warehouseID {
+		return fmt.Errorf("location %s is not in the movement's warehouse", location.Code)
+	}
+	if location.LocationType != models.LocationTypeBin {
+		return fmt.Errorf("location %s is a %s; stock is held in bins", location.Code, location.LocationType)
+	}
+	if delta > 0 && !location.IsActive {
+		return fmt.Errorf("bin %s is inactive", location.Code)
+	}
+
+	key := &sqlc.EnsureBinStockLevelParams{
+		LocationID: location.ID,
+		ProductID:  utils.UUIDToPgxUUID(productID),
+	}
+	if err := q.EnsureBinStockLevel(ctx, key); err != nil {
+		return err
+	}
+	level, err := q.GetBinStockLevelForUpdate(ctx, &sqlc.GetBinStockLevelForUpdateParams{
+		LocationID: key.LocationID,
+		ProductID:  key.ProductID,
+	})
+	if err != nil {
+		return err
+	}
+	if level.Quantity+delta < 0 {
+		return fmt.Errorf("bin %s holds only %d units", location.Code, level.Quantity)
+	}
+
+	_, err = q.UpdateBinStockLevelQuantity(ctx, &sqlc.UpdateBinStockLevelQuantityParams{
+		LocationID: key.LocationID,
+		ProductID:  key.ProductID,
+		Quantity:   level.Quantity + delta,
+	})
+	return err
+}
+
+func toWarehouseLocationModel(l *sqlc.WarehouseLocation) models.WarehouseLocation {
+	return models.WarehouseLocation{
+		ID:           utils.PgxUUIDToUUID(l.ID),
+		WarehouseID:  utils.PgxUUIDToUUID(l.WarehouseID),
+		ParentID:     utils.OptionalPgxUUIDToUUID(l.ParentID),
+		LocationType: l.LocationType,
+		Code:         l.Code,
+		Name:         l.Name,
+		IsActive:     l.IsActive,
+		CreatedAt:    utils.PgxTimestamptzToTime(l.CreatedAt),
+		UpdatedAt:    utils.PgxTimestamptzToTime(l.UpdatedAt),
+	}
+}
@@ -27,10 +27,25 @@ func PgxNumericToFloat64(numeric pgtype.Numeric) float64 {
 
 func Float64ToPgxNumeric(value float64) pgtype.Numeric {
 	// Convert to cents to avoid floating point precision issues
-	cents := int64(value * 100)
+	cents := int64(math.Round(value * 100))
 	return pgtype.Numeric{Int: big.NewInt(cents), Exp: -2, Valid: true}
 }
 
+// CostToPgxNumeric converts a unit cost or valuation, which are kept to four
+// decimal places rather than cents
+func CostToPgxNumeric(value float64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(int64(math.Round(value * 10000))), Exp: -4, Valid: true}
+}
+
+// OptionalCostToPgxNumeric converts an optional unit cost or price kept to
+// four decimal places
+func OptionalCostToPgxNumeric(value *float64) pgtype.Numeric {
+	if value == nil {
+		return pgtype.Numeric{Valid: false}
+	}
+	return CostToPgxNumeric(*value)
+}
+
 func PgxTimestamptzToTime(ts pgtype.Timestamptz) time.Time {
 	return ts.Time
 }
@@ -80,6 +95,14 @@ func OptionalPgxTimestamptzToTimePtr(ts pgtype.Timestamptz) *time.Time {
 	return &ts.Time
 }
 
+// Helper function to convert optional time pointer to pgtype.Timestamptz
+func OptionalTimeToPgxTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
 // Helper function to convert optional pgtype.Numeric to float64 pointer
 func OptionalPgxNumericToFloat64Ptr(numeric pgtype.Numeric) *float64 {
 	if !numeric.Valid {
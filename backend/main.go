@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"inventory-system/internal/auth"
 	"inventory-system/internal/config"
 	"inventory-system/internal/database"
 	"inventory-system/internal/handlers"
+	"inventory-system/internal/models"
+	"inventory-system/internal/notify"
 	"inventory-system/internal/services"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -39,12 +43,39 @@ func main() {
 	// Initialize services
 	userService := services.NewUserService(db)
 	productService := services.NewProductService(db)
-	stockService := services.NewStockService(db)
+	stockService := services.NewStockService(db, cfg.Receiving)
 	categoryService := services.NewCategoryService(db)
 	supplierService := services.NewSupplierService(db)
 	warehouseService := services.NewWarehouseService(db)
-	purchaseOrderService := services.NewPurchaseOrderService(db)
+	purchaseOrderService := services.NewPurchaseOrderService(db, cfg.Receiving, cfg.Approvals)
 	documentService := services.NewDocumentService(db)
+	stockTransferService := services.NewStockTransferService(db)
+	reasonCodeService := services.NewAdjustmentReasonCodeService(db)
+	reservationService := services.NewStockReservationService(db)
+	salesOrderService := services.NewSalesOrderService(db)
+	supplierInvoiceService := services.NewSupplierInvoiceService(db, cfg.Matching)
+	stockLotService := services.NewStockLotService(db)
+	serialNumberService := services.NewSerialNumberService(db)
+	costingService := services.NewCostingService(db)
+	stocktakeService := services.NewStocktakeService(db)
+	locationService := services.NewWarehouseLocationService(db)
+	replenishmentService := services.NewReplenishmentService(db, purchaseOrderService)
+	uomService := services.NewUOMService(db)
+	assemblyService := services.NewAssemblyService(db)
+	reconciliationService := services.NewReconciliationService(db)
+	snapshotService := services.NewStockSnapshotService(db)
+	accountingPeriodService := services.NewAccountingPeriodService(db)
+
+	// Stock alerts always reach the in-app inbox; email and webhook
+	// delivery are enabled by configuring them
+	notifiers := []notify.Notifier{notify.NewInboxNotifier(db)}
+	if cfg.Alerts.SMTPHost != "" && len(cfg.Alerts.EmailRecipients) > 0 {
+		notifiers = append(notifiers, notify.NewEmailNotifier(cfg.Alerts))
+	}
+	if cfg.Alerts.WebhookURL != "" {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(cfg.Alerts.WebhookURL, cfg.Alerts.WebhookSecret))
+	}
+	alertService := services.NewAlertService(db, cfg.Alerts, notifiers...)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(userService, jwtService)
@@ -55,6 +86,36 @@ func main() {
 	warehouseHandler := handlers.NewWarehouseHandler(warehouseService)
 	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchaseOrderService)
 	documentHandler := handlers.NewDocumentHandler(documentService)
+	stockTransferHandler := handlers.NewStockTransferHandler(stockTransferService)
+	reasonCodeHandler := handlers.NewAdjustmentReasonCodeHandler(reasonCodeService)
+	reservationHandler := handlers.NewStockReservationHandler(reservationService)
+	salesOrderHandler := handlers.NewSalesOrderHandler(salesOrderService)
+	supplierInvoiceHandler := handlers.NewSupplierInvoiceHandler(supplierInvoiceService)
+	stockLotHandler := handlers.NewStockLotHandler(stockLotService)
+	serialNumberHandler := handlers.NewSerialNumberHandler(serialNumberService)
+	costingHandler := handlers.NewCostingHandler(costingService)
+	stocktakeHandler := handlers.NewStocktakeHandler(stocktakeService)
+	locationHandler := handlers.NewWarehouseLocationHandler(locationService)
+	replenishmentHandler := handlers.NewReplenishmentHandler(replenishmentService)
+	alertHandler := handlers.NewAlertHandler(alertService)
+	uomHandler := handlers.NewUOMHandler(uomService)
+	assemblyHandler := handlers.NewAssemblyHandler(assemblyService)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+	accountingPeriodHandler := handlers.NewAccountingPeriodHandler(accountingPeriodService)
+
+	// Release expired stock reservations in the background
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	go reservationService.RunExpirySweeper(sweeperCtx, time.Duration(cfg.Reservations.SweepInterval)*time.Second)
+
+	// Evaluate stock alerts as postings commit and on a schedule
+	go alertService.RunEvaluator(sweeperCtx, time.Duration(cfg.Alerts.EvaluateInterval)*time.Second)
+
+	// Report stock balances that have drifted from the movement ledger
+	go reconciliationService.RunReconciliation(sweeperCtx, time.Duration(cfg.Reconciliation.Interval)*time.Second)
+
+	// Snapshot stock balances for point-in-time queries
+	go snapshotService.RunSnapshots(sweeperCtx, time.Duration(cfg.Snapshots.Interval)*time.Second)
 
 	// Setup Gin router
 	router := gin.Default()
@@ -63,7 +124,7 @@ func main() {
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, Idempotency-Key")
 		
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -104,6 +165,11 @@ func main() {
 				products.GET("/:id", productHandler.GetProduct)
 				products.PUT("/:id", productHandler.UpdateProduct)
 				products.DELETE("/:id", productHandler.DeleteProduct)
+				products.GET("/:id/uoms", uomHandler.GetProductUOMs)
+				products.PUT("/:id/uoms", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), uomHandler.SetProductUOMs)
+				products.GET("/:id/bom", assemblyHandler.GetBOM)
+				products.PUT("/:id/bom", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), assemblyHandler.SetBOM)
+				products.GET("/:id/bom/availability", assemblyHandler.GetKitAvailability)
 			}
 
 
@@ -135,12 +201,28 @@ func main() {
 				warehouses.GET("/:id", warehouseHandler.GetWarehouse)
 				warehouses.PUT("/:id", warehouseHandler.UpdateWarehouse)
 				warehouses.DELETE("/:id", warehouseHandler.DeleteWarehouse)
+				warehouses.GET("/:id/locations", locationHandler.ListWarehouseLocations)
+				warehouses.POST("/:id/locations", locationHandler.CreateWarehouseLocation)
+			}
+
+			// Zones, aisles and bins inside warehouses
+			locations := protected.Group("/warehouse-locations")
+			{
+				locations.GET("/:id", locationHandler.GetWarehouseLocation)
+				locations.PUT("/:id", locationHandler.UpdateWarehouseLocation)
+			}
+
+			// Bin stock levels
+			binStock := protected.Group("/bin-stock-levels")
+			{
+				binStock.GET("", locationHandler.ListBinStockLevels)
 			}
 
 			// Stock levels
 			stock := protected.Group("/stock-levels")
 			{
 				stock.GET("", stockHandler.ListStockLevels)
+				stock.GET("/as-of", stockHandler.ListStockLevelsAsOf)
 				stock.GET("/:product_id/:warehouse_id", stockHandler.GetStockLevel)
 			}
 
@@ -150,6 +232,58 @@ func main() {
 				movements.GET("", stockHandler.ListStockMovements)
 				movements.POST("", stockHandler.CreateStockMovement)
 				movements.POST("/bulk", stockHandler.CreateBulkStockMovement)
+				movements.POST("/transfer", stockHandler.CreateStockTransfer)
+				movements.POST("/bin-move", stockHandler.MoveBinStock)
+				movements.POST("/:id/reverse", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), stockHandler.ReverseStockMovement)
+				movements.POST("/references/:reference_id/reverse", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), stockHandler.ReverseStockMovementsByReference)
+			}
+
+			// Adjustment reason codes
+			reasonCodes := protected.Group("/adjustment-reasons")
+			{
+				reasonCodes.GET("", reasonCodeHandler.ListAdjustmentReasonCodes)
+				reasonCodes.POST("", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), reasonCodeHandler.CreateAdjustmentReasonCode)
+				reasonCodes.GET("/:id", reasonCodeHandler.GetAdjustmentReasonCode)
+				reasonCodes.PUT("/:id", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), reasonCodeHandler.UpdateAdjustmentReasonCode)
+				reasonCodes.DELETE("/:id", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), reasonCodeHandler.DeleteAdjustmentReasonCode)
+			}
+
+			// Stock reservations
+			reservations := protected.Group("/stock-reservations")
+			{
+				reservations.GET("", reservationHandler.ListStockReservations)
+				reservations.POST("", reservationHandler.CreateStockReservation)
+				reservations.GET("/:id", reservationHandler.GetStockReservation)
+				reservations.POST("/:id/extend", reservationHandler.ExtendStockReservation)
+				reservations.POST("/:id/release", reservationHandler.ReleaseStockReservation)
+				reservations.POST("/:id/consume", reservationHandler.ConsumeStockReservation)
+			}
+
+			// Stock lots
+			stockLots := protected.Group("/stock-lots")
+			{
+				stockLots.GET("", stockLotHandler.ListStockLots)
+				stockLots.GET("/:id", stockLotHandler.GetStockLot)
+				stockLots.GET("/:id/movements", stockLotHandler.ListStockLotMovements)
+			}
+
+			// Serial numbers
+			serialNumbers := protected.Group("/serial-numbers")
+			{
+				serialNumbers.GET("", serialNumberHandler.ListSerialNumbers)
+				serialNumbers.GET("/:serial_number", serialNumberHandler.LookupSerialNumber)
+			}
+
+			// Stock transfers
+			transfers := protected.Group("/stock-transfers")
+			{
+				transfers.GET("", stockTransferHandler.ListStockTransfers)
+				transfers.POST("", stockTransferHandler.CreateStockTransfer)
+				transfers.GET("/:id", stockTransferHandler.GetStockTransfer)
+				transfers.PUT("/:id", stockTransferHandler.UpdateStockTransfer)
+				transfers.POST("/:id/dispatch", stockTransferHandler.DispatchStockTransfer)
+				transfers.POST("/:id/receive", stockTransferHandler.ReceiveStockTransfer)
+				transfers.POST("/:id/cancel", stockTransferHandler.CancelStockTransfer)
 			}
 
 			// Purchase orders
@@ -159,6 +293,125 @@ func main() {
 				purchaseOrders.POST("", purchaseOrderHandler.CreatePurchaseOrder)
 				purchaseOrders.GET("/:id", purchaseOrderHandler.GetPurchaseOrder)
 				purchaseOrders.PUT("/:id", purchaseOrderHandler.UpdatePurchaseOrder)
+				purchaseOrders.POST("/:id/approve", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), purchaseOrderHandler.ApprovePurchaseOrder)
+				purchaseOrders.POST("/:id/reject", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), purchaseOrderHandler.RejectPurchaseOrder)
+				purchaseOrders.POST("/:id/receive", purchaseOrderHandler.ReceivePurchaseOrder)
+			}
+
+			// Sales orders
+			salesOrders := protected.Group("/sales-orders")
+			{
+				salesOrders.GET("", salesOrderHandler.ListSalesOrders)
+				salesOrders.POST("", salesOrderHandler.CreateSalesOrder)
+				salesOrders.GET("/:id", salesOrderHandler.GetSalesOrder)
+				salesOrders.PUT("/:id", salesOrderHandler.UpdateSalesOrder)
+				salesOrders.DELETE("/:id", salesOrderHandler.DeleteSalesOrder)
+				salesOrders.POST("/:id/confirm", salesOrderHandler.ConfirmSalesOrder)
+				salesOrders.POST("/:id/ship", salesOrderHandler.ShipSalesOrder)
+				salesOrders.POST("/:id/deliver", salesOrderHandler.DeliverSalesOrder)
+				salesOrders.POST("/:id/cancel", salesOrderHandler.CancelSalesOrder)
+			}
+
+			// Supplier invoices
+			supplierInvoices := protected.Group("/supplier-invoices")
+			{
+				supplierInvoices.GET("", supplierInvoiceHandler.ListSupplierInvoices)
+				supplierInvoices.POST("", supplierInvoiceHandler.CreateSupplierInvoice)
+				supplierInvoices.GET("/:id", supplierInvoiceHandler.GetSupplierInvoice)
+				supplierInvoices.POST("/:id/match", supplierInvoiceHandler.MatchSupplierInvoice)
+			}
+
+			// Stocktakes
+			stocktakes := protected.Group("/stocktakes")
+			{
+				stocktakes.GET("", stocktakeHandler.ListStocktakes)
+				stocktakes.POST("", stocktakeHandler.CreateStocktake)
+				stocktakes.GET("/:id", stocktakeHandler.GetStocktake)
+				stocktakes.POST("/:id/counts", stocktakeHandler.SubmitStocktakeCounts)
+				stocktakes.POST("/:id/submit", stocktakeHandler.SubmitStocktakeForReview)
+				stocktakes.POST("/:id/reopen", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), stocktakeHandler.ReopenStocktake)
+				stocktakes.PUT("/:id/items/:item_id", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), stocktakeHandler.ResolveStocktakeItem)
+				stocktakes.POST("/:id/approve", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), stocktakeHandler.ApproveStocktake)
+				stocktakes.POST("/:id/cancel", stocktakeHandler.CancelStocktake)
+			}
+
+			// Kit assembly and disassembly
+			assemblyOrders := protected.Group("/assembly-orders")
+			{
+				assemblyOrders.GET("", assemblyHandler.ListAssemblyOrders)
+				assemblyOrders.POST("", assemblyHandler.CreateAssemblyOrder)
+				assemblyOrders.GET("/:id", assemblyHandler.GetAssemblyOrder)
+				assemblyOrders.POST("/:id/complete", assemblyHandler.CompleteAssemblyOrder)
+				assemblyOrders.POST("/:id/cancel", assemblyHandler.CancelAssemblyOrder)
+			}
+
+			// Units of measure
+			uoms := protected.Group("/uoms")
+			{
+				uoms.GET("", uomHandler.ListUnitsOfMeasure)
+				uoms.POST("", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), uomHandler.CreateUnitOfMeasure)
+				uoms.GET("/:id", uomHandler.GetUnitOfMeasure)
+				uoms.PUT("/:id", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), uomHandler.UpdateUnitOfMeasure)
+			}
+
+			// Reorder rules
+			reorderRules := protected.Group("/reorder-rules")
+			{
+				reorderRules.GET("", replenishmentHandler.ListReorderRules)
+				reorderRules.POST("", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), replenishmentHandler.SaveReorderRule)
+				reorderRules.GET("/:id", replenishmentHandler.GetReorderRule)
+				reorderRules.PUT("/:id", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), replenishmentHandler.UpdateReorderRule)
+				reorderRules.DELETE("/:id", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), replenishmentHandler.DeleteReorderRule)
+			}
+
+			// Replenishment
+			replenishment := protected.Group("/replenishment")
+			{
+				replenishment.GET("/suggestions", replenishmentHandler.GetReorderSuggestions)
+				replenishment.POST("/convert", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), replenishmentHandler.ConvertReorderSuggestions)
+			}
+
+			// Stock alerts
+			stockAlerts := protected.Group("/stock-alerts")
+			{
+				stockAlerts.GET("", alertHandler.ListStockAlerts)
+				stockAlerts.POST("/evaluate", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), alertHandler.EvaluateStockAlerts)
+				stockAlerts.GET("/:id", alertHandler.GetStockAlert)
+				stockAlerts.POST("/:id/acknowledge", alertHandler.AcknowledgeStockAlert)
+			}
+
+			// In-app notification inbox
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("", alertHandler.ListNotifications)
+				notifications.POST("/read-all", alertHandler.MarkAllNotificationsRead)
+				notifications.POST("/:id/read", alertHandler.MarkNotificationRead)
+			}
+
+			// Inventory costing
+			costing := protected.Group("/costing")
+			{
+				costing.GET("/settings", costingHandler.GetCostingSettings)
+				costing.PUT("/settings", auth.RequireRole(models.UserRoleAdmin), costingHandler.UpdateCostingSettings)
+				costing.GET("/products/:product_id", costingHandler.GetProductCost)
+			}
+
+			// Accounting periods
+			accountingPeriods := protected.Group("/accounting-periods")
+			{
+				accountingPeriods.GET("", accountingPeriodHandler.ListAccountingPeriods)
+				accountingPeriods.POST("", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), accountingPeriodHandler.CreateAccountingPeriod)
+				accountingPeriods.GET("/:id", accountingPeriodHandler.GetAccountingPeriod)
+				accountingPeriods.GET("/:id/balances", accountingPeriodHandler.GetAccountingPeriodBalances)
+				accountingPeriods.POST("/:id/close", auth.RequireRole(models.UserRoleAdmin, models.UserRoleManager), accountingPeriodHandler.CloseAccountingPeriod)
+				accountingPeriods.POST("/:id/reopen", auth.RequireRole(models.UserRoleAdmin), accountingPeriodHandler.ReopenAccountingPeriod)
+			}
+
+			// Stock ledger reconciliation
+			reconciliation := protected.Group("/stock-reconciliation", auth.RequireRole(models.UserRoleAdmin))
+			{
+				reconciliation.GET("", reconciliationHandler.GetStockReconciliation)
+				reconciliation.POST("/repair", reconciliationHandler.RepairStockReconciliation)
 			}
 
 			// Documents
@@ -179,6 +432,8 @@ func main() {
 			reports := protected.Group("/reports")
 			{
 				reports.GET("/soh", stockHandler.GetSOHReport)
+				reports.GET("/valuation", stockHandler.GetStockValuationReport)
+				reports.GET("/expiring", stockLotHandler.GetExpiringStockReport)
 			}
 		}
 	}
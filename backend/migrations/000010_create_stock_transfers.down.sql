@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_stock_transfer_items_updated_at ON stock_transfer_items;
+DROP TRIGGER IF EXISTS update_stock_transfers_updated_at ON stock_transfers;
+
+DROP INDEX IF EXISTS idx_stock_transfer_items_transfer;
+DROP INDEX IF EXISTS idx_stock_transfers_to_warehouse;
+DROP INDEX IF EXISTS idx_stock_transfers_from_warehouse;
+DROP INDEX IF EXISTS idx_stock_transfers_status;
+
+DROP TABLE IF EXISTS stock_transfer_items;
+DROP TABLE IF EXISTS stock_transfers;
@@ -0,0 +1,41 @@
+-- Create stock_transfers table for multi-step inter-warehouse transfers
+CREATE TABLE stock_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_number VARCHAR(100) UNIQUE NOT NULL,
+    from_warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    to_warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'dispatched', 'partially_received', 'received', 'cancelled')),
+    notes TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    dispatched_by UUID REFERENCES users(id),
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    received_by UUID REFERENCES users(id),
+    received_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (from_warehouse_id <> to_warehouse_id)
+);
+
+-- Create stock_transfer_items table
+-- discrepancy_quantity is dispatched minus received once the transfer is closed (positive = short)
+CREATE TABLE stock_transfer_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transfer_id UUID NOT NULL REFERENCES stock_transfers(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    discrepancy_quantity INTEGER NOT NULL DEFAULT 0,
+    discrepancy_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for better performance
+CREATE INDEX idx_stock_transfers_status ON stock_transfers(status);
+CREATE INDEX idx_stock_transfers_from_warehouse ON stock_transfers(from_warehouse_id);
+CREATE INDEX idx_stock_transfers_to_warehouse ON stock_transfers(to_warehouse_id);
+CREATE INDEX idx_stock_transfer_items_transfer ON stock_transfer_items(transfer_id);
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_stock_transfers_updated_at BEFORE UPDATE ON stock_transfers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_stock_transfer_items_updated_at BEFORE UPDATE ON stock_transfer_items FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
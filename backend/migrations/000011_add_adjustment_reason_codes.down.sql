@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_stock_movements_reason_code;
+
+ALTER TABLE stock_movements
+DROP COLUMN reason_code;
+
+DROP TRIGGER IF EXISTS update_adjustment_reason_codes_updated_at ON adjustment_reason_codes;
+
+DROP TABLE IF EXISTS adjustment_reason_codes;
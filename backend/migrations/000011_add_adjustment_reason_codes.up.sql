@@ -0,0 +1,26 @@
+-- Create adjustment_reason_codes table for the managed list of adjustment reasons
+CREATE TABLE adjustment_reason_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Adjustments record the reason code they were posted under.
+-- Adjustment quantities are signed: negative values write stock off.
+ALTER TABLE stock_movements
+ADD COLUMN reason_code VARCHAR(50) REFERENCES adjustment_reason_codes(code) ON UPDATE CASCADE;
+
+CREATE INDEX idx_stock_movements_reason_code ON stock_movements(reason_code);
+
+CREATE TRIGGER update_adjustment_reason_codes_updated_at BEFORE UPDATE ON adjustment_reason_codes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Insert the default reason codes
+INSERT INTO adjustment_reason_codes (code, name, description) VALUES
+('damage', 'Damage', 'Stock damaged and no longer sellable'),
+('theft', 'Theft', 'Stock lost to theft or shrinkage'),
+('count_correction', 'Count Correction', 'Correction after a physical stock count'),
+('expiry', 'Expiry', 'Stock past its expiry date');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_idempotency_keys_created_at;
+
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,14 @@
+-- Create idempotency_keys table so client retries of stock postings are not applied twice.
+-- A key is claimed inside the posting transaction and stores the response to replay.
+CREATE TABLE idempotency_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    idempotency_key VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id),
+    endpoint VARCHAR(100) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    response_body JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(user_id, endpoint, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
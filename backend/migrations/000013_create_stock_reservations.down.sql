@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS update_stock_reservations_updated_at ON stock_reservations;
+
+DROP INDEX IF EXISTS idx_stock_reservations_active_expiry;
+DROP INDEX IF EXISTS idx_stock_reservations_owner;
+DROP INDEX IF EXISTS idx_stock_reservations_product_warehouse;
+
+DROP TABLE IF EXISTS stock_reservations;
@@ -0,0 +1,25 @@
+-- Create stock_reservations table for holds against stock_levels.reserved_quantity.
+-- quantity is what the reservation still holds; consumed_quantity has been shipped out of it.
+CREATE TABLE stock_reservations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    consumed_quantity INTEGER NOT NULL DEFAULT 0 CHECK (consumed_quantity >= 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'released', 'consumed', 'expired')),
+    owner_type VARCHAR(50), -- 'sales_order', etc.
+    owner_id UUID,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for better performance
+CREATE INDEX idx_stock_reservations_product_warehouse ON stock_reservations(product_id, warehouse_id);
+CREATE INDEX idx_stock_reservations_owner ON stock_reservations(owner_type, owner_id);
+CREATE INDEX idx_stock_reservations_active_expiry ON stock_reservations(expires_at) WHERE status = 'active';
+
+-- Create triggers for updated_at
+CREATE TRIGGER update_stock_reservations_updated_at BEFORE UPDATE ON stock_reservations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_purchase_order_items_purchase_order_id;
+
+UPDATE purchase_orders SET status = 'pending' WHERE status = 'draft';
+UPDATE purchase_orders SET status = 'approved' WHERE status = 'partially_received';
+
+ALTER TABLE purchase_orders
+DROP CONSTRAINT IF EXISTS purchase_orders_status_check;
+
+ALTER TABLE purchase_orders
+ADD CONSTRAINT purchase_orders_status_check
+CHECK (status IN ('pending', 'approved', 'received', 'cancelled'));
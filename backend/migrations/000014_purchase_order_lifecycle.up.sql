@@ -0,0 +1,10 @@
+-- Purchase orders move through draft/pending -> approved -> partially_received
+-- -> received, or are cancelled. Widen the status CHECK to the full lifecycle.
+ALTER TABLE purchase_orders
+DROP CONSTRAINT IF EXISTS purchase_orders_status_check;
+
+ALTER TABLE purchase_orders
+ADD CONSTRAINT purchase_orders_status_check
+CHECK (status IN ('draft', 'pending', 'approved', 'partially_received', 'received', 'cancelled'));
+
+CREATE INDEX idx_purchase_order_items_purchase_order_id ON purchase_order_items(purchase_order_id);
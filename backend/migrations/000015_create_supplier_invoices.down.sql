@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS update_supplier_invoice_items_updated_at ON supplier_invoice_items;
+DROP TRIGGER IF EXISTS update_supplier_invoices_updated_at ON supplier_invoices;
+
+DROP INDEX IF EXISTS idx_stock_movements_reference;
+
+DROP TABLE IF EXISTS supplier_invoice_items;
+DROP TABLE IF EXISTS supplier_invoices;
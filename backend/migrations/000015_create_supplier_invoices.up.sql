@@ -0,0 +1,49 @@
+-- Create supplier_invoices table for invoices received against purchase orders.
+-- Each invoice is matched against the PO and the goods received for it; status
+-- records the outcome of the last match.
+CREATE TABLE supplier_invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_number VARCHAR(100) NOT NULL,
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id),
+    supplier_id UUID REFERENCES suppliers(id),
+    invoice_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    due_date DATE,
+    total_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'blocked' CHECK (status IN ('matched', 'variance', 'blocked')),
+    notes TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    matched_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(purchase_order_id, invoice_number)
+);
+
+-- Invoice lines bill a purchase order line. The ordered, received and
+-- previously invoiced quantities and the PO price are captured at match time.
+CREATE TABLE supplier_invoice_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invoice_id UUID NOT NULL REFERENCES supplier_invoices(id) ON DELETE CASCADE,
+    purchase_order_item_id UUID NOT NULL REFERENCES purchase_order_items(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10,2) NOT NULL CHECK (unit_price >= 0),
+    total_price DECIMAL(12,2) NOT NULL CHECK (total_price >= 0),
+    ordered_quantity INTEGER NOT NULL DEFAULT 0,
+    received_quantity INTEGER NOT NULL DEFAULT 0,
+    previously_invoiced_quantity INTEGER NOT NULL DEFAULT 0,
+    po_unit_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    quantity_variance INTEGER NOT NULL DEFAULT 0,
+    price_variance DECIMAL(10,2) NOT NULL DEFAULT 0,
+    match_status VARCHAR(20) NOT NULL DEFAULT 'blocked' CHECK (match_status IN ('matched', 'variance', 'blocked')),
+    match_notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_supplier_invoices_purchase_order_id ON supplier_invoices(purchase_order_id);
+CREATE INDEX idx_supplier_invoices_status ON supplier_invoices(status);
+CREATE INDEX idx_supplier_invoice_items_invoice_id ON supplier_invoice_items(invoice_id);
+CREATE INDEX idx_supplier_invoice_items_purchase_order_item_id ON supplier_invoice_items(purchase_order_item_id);
+CREATE INDEX idx_stock_movements_reference ON stock_movements(reference_type, reference_id);
+
+CREATE TRIGGER update_supplier_invoices_updated_at BEFORE UPDATE ON supplier_invoices FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_supplier_invoice_items_updated_at BEFORE UPDATE ON supplier_invoice_items FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
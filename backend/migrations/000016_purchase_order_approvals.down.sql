@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_purchase_order_approvals_purchase_order_id;
+DROP TABLE IF EXISTS purchase_order_approvals;
+
+UPDATE purchase_orders SET status = 'pending' WHERE status = 'rejected';
+UPDATE purchase_orders SET status = 'approved' WHERE status = 'ordered';
+
+ALTER TABLE purchase_orders
+DROP CONSTRAINT IF EXISTS purchase_orders_status_check;
+
+ALTER TABLE purchase_orders
+ADD CONSTRAINT purchase_orders_status_check
+CHECK (status IN ('draft', 'pending', 'approved', 'partially_received', 'received', 'cancelled'));
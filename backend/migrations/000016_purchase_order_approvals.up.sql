@@ -0,0 +1,22 @@
+-- Purchase orders above the configured spend thresholds must be approved by a
+-- manager or admin before they can be ordered or received. Add the ordered and
+-- rejected statuses and record every approval decision.
+ALTER TABLE purchase_orders
+DROP CONSTRAINT IF EXISTS purchase_orders_status_check;
+
+ALTER TABLE purchase_orders
+ADD CONSTRAINT purchase_orders_status_check
+CHECK (status IN ('draft', 'pending', 'approved', 'rejected', 'ordered', 'partially_received', 'received', 'cancelled'));
+
+CREATE TABLE purchase_order_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    purchase_order_id UUID NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('approved', 'rejected')),
+    required_role VARCHAR(20) CHECK (required_role IN ('admin', 'manager')),
+    total_amount DECIMAL(12,2) NOT NULL,
+    comment TEXT,
+    acted_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_purchase_order_approvals_purchase_order_id ON purchase_order_approvals(purchase_order_id);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS update_stock_lot_levels_updated_at ON stock_lot_levels;
+DROP TRIGGER IF EXISTS update_stock_lots_updated_at ON stock_lots;
+DROP TABLE IF EXISTS stock_movement_lots;
+DROP TABLE IF EXISTS stock_lot_levels;
+DROP TABLE IF EXISTS stock_lots;
@@ -0,0 +1,45 @@
+-- Create stock_lots table for batch tracking. A lot number is unique per
+-- product and carries the manufacture and expiry dates of the batch.
+CREATE TABLE stock_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    manufacture_date DATE,
+    expiry_date DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(product_id, lot_number),
+    CHECK (expiry_date IS NULL OR manufacture_date IS NULL OR expiry_date >= manufacture_date)
+);
+
+-- Per-lot balances alongside stock_levels. The lot balances of a product in a
+-- warehouse never exceed its stock_levels quantity; the difference is stock
+-- that was received without a lot.
+CREATE TABLE stock_lot_levels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    lot_id UUID NOT NULL REFERENCES stock_lots(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(lot_id, warehouse_id)
+);
+
+-- The lots a stock movement took from or put into. Quantities are unsigned;
+-- the direction is that of the movement.
+CREATE TABLE stock_movement_lots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stock_movement_id UUID NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    lot_id UUID NOT NULL REFERENCES stock_lots(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_lots_product_id ON stock_lots(product_id);
+CREATE INDEX idx_stock_lots_expiry_date ON stock_lots(expiry_date);
+CREATE INDEX idx_stock_lot_levels_warehouse_id ON stock_lot_levels(warehouse_id);
+CREATE INDEX idx_stock_movement_lots_stock_movement_id ON stock_movement_lots(stock_movement_id);
+CREATE INDEX idx_stock_movement_lots_lot_id ON stock_movement_lots(lot_id);
+
+CREATE TRIGGER update_stock_lots_updated_at BEFORE UPDATE ON stock_lots FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_stock_lot_levels_updated_at BEFORE UPDATE ON stock_lot_levels FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
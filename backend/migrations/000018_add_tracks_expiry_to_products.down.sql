@@ -0,0 +1,2 @@
+-- Remove tracks_expiry field from products table
+ALTER TABLE products DROP COLUMN tracks_expiry;
@@ -0,0 +1,3 @@
+-- Products with a shelf life must be received into lots with an expiry date
+-- and are issued earliest-expiry first (FEFO)
+ALTER TABLE products ADD COLUMN tracks_expiry BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS update_serial_numbers_updated_at ON serial_numbers;
+ALTER TABLE stock_transfer_items DROP COLUMN serial_numbers;
+DROP TABLE IF EXISTS stock_movement_serials;
+DROP TABLE IF EXISTS serial_numbers;
+ALTER TABLE products DROP COLUMN serialized;
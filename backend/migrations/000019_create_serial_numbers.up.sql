@@ -0,0 +1,37 @@
+-- Serialized products are tracked unit by unit: every movement names the
+-- serial numbers it moves
+ALTER TABLE products ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT false;
+
+-- Serial number registry. A serial is unique per product; warehouse_id is the
+-- warehouse holding the unit while it is in stock.
+CREATE TABLE serial_numbers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_stock' CHECK (status IN ('in_stock', 'in_transit', 'issued')),
+    warehouse_id UUID REFERENCES warehouses(id),
+    last_movement_id UUID REFERENCES stock_movements(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(product_id, serial_number),
+    CHECK ((status = 'in_stock') = (warehouse_id IS NOT NULL))
+);
+
+-- The serial numbers a stock movement moved
+CREATE TABLE stock_movement_serials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stock_movement_id UUID NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    serial_number_id UUID NOT NULL REFERENCES serial_numbers(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(stock_movement_id, serial_number_id)
+);
+
+-- Serial numbers picked for a transfer line, moved when it is dispatched
+ALTER TABLE stock_transfer_items ADD COLUMN serial_numbers TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_serial_numbers_serial_number ON serial_numbers(serial_number);
+CREATE INDEX idx_serial_numbers_warehouse_id ON serial_numbers(warehouse_id);
+CREATE INDEX idx_stock_movement_serials_stock_movement_id ON stock_movement_serials(stock_movement_id);
+CREATE INDEX idx_stock_movement_serials_serial_number_id ON stock_movement_serials(serial_number_id);
+
+CREATE TRIGGER update_serial_numbers_updated_at BEFORE UPDATE ON serial_numbers FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
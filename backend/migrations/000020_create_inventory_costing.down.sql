@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS update_product_costs_updated_at ON product_costs;
+DROP TRIGGER IF EXISTS update_costing_settings_updated_at ON costing_settings;
+DROP INDEX IF EXISTS idx_stock_movements_product_processed_date;
+DROP TABLE IF EXISTS cost_layers;
+DROP TABLE IF EXISTS product_costs;
+ALTER TABLE stock_movements DROP COLUMN total_cost;
+ALTER TABLE stock_movements DROP COLUMN unit_cost;
+DROP TABLE IF EXISTS costing_settings;
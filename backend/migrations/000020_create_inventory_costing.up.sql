@@ -0,0 +1,49 @@
+-- Inventory costing. The method is a company-wide setting held in a single
+-- row; each product is costed across all of its warehouses.
+CREATE TABLE costing_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    method VARCHAR(20) NOT NULL DEFAULT 'fifo' CHECK (method IN ('fifo', 'average')),
+    updated_by UUID REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO costing_settings DEFAULT VALUES;
+
+-- Cost the movement was valued at: the receipt cost for incoming stock and
+-- the cost of goods issued for outgoing stock
+ALTER TABLE stock_movements ADD COLUMN unit_cost DECIMAL(12,4);
+ALTER TABLE stock_movements ADD COLUMN total_cost DECIMAL(14,4);
+
+-- Running cost position of a product. method records the method the position
+-- was built under so a change of method rebuilds it; costed_through is the
+-- processed date of the latest movement applied, so an earlier one is known
+-- to be back-dated.
+CREATE TABLE product_costs (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    method VARCHAR(20) NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL DEFAULT 0,
+    total_value DECIMAL(14,4) NOT NULL DEFAULT 0,
+    unit_cost DECIMAL(12,4) NOT NULL DEFAULT 0,
+    costed_through TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Open FIFO cost layers, one per receipt that still has quantity left
+CREATE TABLE cost_layers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    stock_movement_id UUID NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    received_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    remaining_quantity INTEGER NOT NULL CHECK (remaining_quantity > 0 AND remaining_quantity <= quantity),
+    unit_cost DECIMAL(12,4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_movements_product_processed_date ON stock_movements(product_id, processed_date);
+CREATE INDEX idx_cost_layers_product_id ON cost_layers(product_id);
+
+CREATE TRIGGER update_costing_settings_updated_at BEFORE UPDATE ON costing_settings FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_product_costs_updated_at BEFORE UPDATE ON product_costs FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_stocktake_counts_updated_at ON stocktake_counts;
+DROP TRIGGER IF EXISTS update_stocktake_items_updated_at ON stocktake_items;
+DROP TRIGGER IF EXISTS update_stocktakes_updated_at ON stocktakes;
+DROP TABLE IF EXISTS stocktake_counts;
+DROP TABLE IF EXISTS stocktake_items;
+DROP TABLE IF EXISTS stocktakes;
@@ -0,0 +1,57 @@
+-- Stocktake (cycle count) sessions for a warehouse, optionally limited to a
+-- category
+CREATE TABLE stocktakes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stocktake_number VARCHAR(50) UNIQUE NOT NULL,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    category_id UUID REFERENCES categories(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'counting' CHECK (status IN ('counting', 'review', 'approved', 'cancelled')),
+    notes TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    submitted_by UUID REFERENCES users(id),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    approved_by UUID REFERENCES users(id),
+    approved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Products in a stocktake with the quantity, and for serialized products the
+-- serial numbers, frozen when the session was opened. counted_quantity is the
+-- agreed or resolved count; adjusted_quantity is what approval posted.
+CREATE TABLE stocktake_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stocktake_id UUID NOT NULL REFERENCES stocktakes(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id),
+    expected_quantity INTEGER NOT NULL,
+    expected_serials TEXT[] NOT NULL DEFAULT '{}',
+    counted_quantity INTEGER CHECK (counted_quantity >= 0),
+    counted_serials TEXT[] NOT NULL DEFAULT '{}',
+    resolved_by UUID REFERENCES users(id),
+    adjusted_quantity INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(stocktake_id, product_id)
+);
+
+-- Counts submitted by each counter; a counter's later count replaces theirs
+CREATE TABLE stocktake_counts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stocktake_item_id UUID NOT NULL REFERENCES stocktake_items(id) ON DELETE CASCADE,
+    counted_by UUID NOT NULL REFERENCES users(id),
+    quantity INTEGER NOT NULL CHECK (quantity >= 0),
+    serial_numbers TEXT[] NOT NULL DEFAULT '{}',
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(stocktake_item_id, counted_by)
+);
+
+CREATE INDEX idx_stocktakes_warehouse_id ON stocktakes(warehouse_id);
+CREATE INDEX idx_stocktakes_status ON stocktakes(status);
+CREATE INDEX idx_stocktake_items_stocktake_id ON stocktake_items(stocktake_id);
+CREATE INDEX idx_stocktake_counts_stocktake_item_id ON stocktake_counts(stocktake_item_id);
+
+CREATE TRIGGER update_stocktakes_updated_at BEFORE UPDATE ON stocktakes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_stocktake_items_updated_at BEFORE UPDATE ON stocktake_items FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_stocktake_counts_updated_at BEFORE UPDATE ON stocktake_counts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS update_bin_stock_levels_updated_at ON bin_stock_levels;
+DROP TRIGGER IF EXISTS update_warehouse_locations_updated_at ON warehouse_locations;
+DROP TABLE IF EXISTS stock_movement_locations;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS to_location_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS from_location_id;
+DROP TABLE IF EXISTS bin_stock_levels;
+DROP TABLE IF EXISTS warehouse_locations;
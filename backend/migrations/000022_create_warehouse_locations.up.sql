@@ -0,0 +1,51 @@
+-- Storage locations inside a warehouse: zones contain aisles, aisles contain
+-- bins. Stock is held in bins; zones and aisles only group them. Codes are
+-- unique within a warehouse.
+CREATE TABLE warehouse_locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES warehouse_locations(id),
+    location_type VARCHAR(10) NOT NULL CHECK (location_type IN ('zone', 'aisle', 'bin')),
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(warehouse_id, code)
+);
+
+-- Per-bin balances alongside stock_levels. The bin balances of a product in a
+-- warehouse never exceed its stock_levels quantity; the difference is stock
+-- that has not been put away into a bin.
+CREATE TABLE bin_stock_levels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    location_id UUID NOT NULL REFERENCES warehouse_locations(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(location_id, product_id)
+);
+
+-- The bins a movement was asked to take from and put into. Bin-to-bin moves
+-- inside a warehouse are 'transfer' movements carrying both.
+ALTER TABLE stock_movements ADD COLUMN from_location_id UUID REFERENCES warehouse_locations(id);
+ALTER TABLE stock_movements ADD COLUMN to_location_id UUID REFERENCES warehouse_locations(id);
+
+-- The bin balances a movement changed, including bins picked automatically
+-- for issues. Quantities are signed: negative was taken out of the bin.
+CREATE TABLE stock_movement_locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    stock_movement_id UUID NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL REFERENCES warehouse_locations(id),
+    quantity INTEGER NOT NULL CHECK (quantity <> 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_warehouse_locations_parent_id ON warehouse_locations(parent_id);
+CREATE INDEX idx_bin_stock_levels_product_id ON bin_stock_levels(product_id);
+CREATE INDEX idx_stock_movement_locations_stock_movement_id ON stock_movement_locations(stock_movement_id);
+CREATE INDEX idx_stock_movement_locations_location_id ON stock_movement_locations(location_id);
+
+CREATE TRIGGER update_warehouse_locations_updated_at BEFORE UPDATE ON warehouse_locations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_bin_stock_levels_updated_at BEFORE UPDATE ON bin_stock_levels FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_reorder_rules_updated_at ON reorder_rules;
+DROP TABLE IF EXISTS reorder_rules;
+ALTER TABLE purchase_orders DROP COLUMN IF EXISTS warehouse_id;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS lead_time_days;
@@ -0,0 +1,26 @@
+-- Days a supplier takes to deliver after an order is placed
+ALTER TABLE suppliers ADD COLUMN lead_time_days INTEGER NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0);
+
+-- Warehouse a purchase order is delivered to. Receipts default to it, and the
+-- open quantity of its lines counts as on order for that warehouse.
+ALTER TABLE purchase_orders ADD COLUMN warehouse_id UUID REFERENCES warehouses(id);
+
+-- Reorder rules per product and warehouse. When available plus on-order
+-- stock falls to the reorder point, multiples of the reorder quantity are
+-- suggested until it is above the point again.
+CREATE TABLE reorder_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    reorder_point INTEGER NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity INTEGER NOT NULL CHECK (reorder_quantity > 0),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(product_id, warehouse_id)
+);
+
+CREATE INDEX idx_reorder_rules_warehouse_id ON reorder_rules(warehouse_id);
+CREATE INDEX idx_purchase_orders_warehouse_id ON purchase_orders(warehouse_id);
+
+CREATE TRIGGER update_reorder_rules_updated_at BEFORE UPDATE ON reorder_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
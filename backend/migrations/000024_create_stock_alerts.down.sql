@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS notify_stock_level_change ON stock_levels;
+DROP FUNCTION IF EXISTS notify_stock_level_change();
+DROP TRIGGER IF EXISTS update_stock_alerts_updated_at ON stock_alerts;
+DROP TABLE IF EXISTS user_notifications;
+DROP TABLE IF EXISTS stock_alerts;
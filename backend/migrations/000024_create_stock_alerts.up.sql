@@ -0,0 +1,53 @@
+-- Stock alerts raised by the alert evaluator. At most one alert of a kind is
+-- unresolved per product and warehouse, so repeated evaluations of the same
+-- condition do not raise duplicates.
+CREATE TABLE stock_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    alert_type VARCHAR(30) NOT NULL CHECK (alert_type IN ('low_stock', 'stock_out', 'over_max', 'negative_attempt')),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'acknowledged', 'resolved')),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    threshold INTEGER,
+    message TEXT NOT NULL,
+    occurrences INTEGER NOT NULL DEFAULT 1,
+    last_triggered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    acknowledged_by UUID REFERENCES users(id),
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_stock_alerts_unresolved ON stock_alerts(alert_type, product_id, warehouse_id) WHERE status <> 'resolved';
+CREATE INDEX idx_stock_alerts_status ON stock_alerts(status);
+CREATE INDEX idx_stock_alerts_product_warehouse ON stock_alerts(product_id, warehouse_id);
+
+-- In-app inbox. The inbox notifier gives every active admin and manager a
+-- notification for each new alert.
+CREATE TABLE user_notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stock_alert_id UUID REFERENCES stock_alerts(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_notifications_user_id ON user_notifications(user_id, created_at DESC);
+
+CREATE TRIGGER update_stock_alerts_updated_at BEFORE UPDATE ON stock_alerts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Announce every balance change on the stock_level_changes channel as
+-- "<product_id>:<warehouse_id>". Notifications are only delivered when the
+-- posting transaction commits, so the evaluator never sees rolled back stock.
+CREATE OR REPLACE FUNCTION notify_stock_level_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('stock_level_changes', NEW.product_id::text || ':' || NEW.warehouse_id::text);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER notify_stock_level_change AFTER INSERT OR UPDATE OF quantity, reserved_quantity, min_stock_level, max_stock_level ON stock_levels FOR EACH ROW EXECUTE FUNCTION notify_stock_level_change();
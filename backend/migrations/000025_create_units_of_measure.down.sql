@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_product_uoms_updated_at ON product_uoms;
+DROP TRIGGER IF EXISTS update_units_of_measure_updated_at ON units_of_measure;
+ALTER TABLE sales_order_items ALTER COLUMN unit_price TYPE DECIMAL(10,2);
+ALTER TABLE purchase_order_items ALTER COLUMN unit_price TYPE DECIMAL(10,2);
+ALTER TABLE stock_movements ALTER COLUMN cost_price TYPE DECIMAL(10,2);
+ALTER TABLE sales_order_items DROP COLUMN IF EXISTS uom_factor, DROP COLUMN IF EXISTS uom_quantity, DROP COLUMN IF EXISTS uom_id;
+ALTER TABLE purchase_order_items DROP COLUMN IF EXISTS uom_factor, DROP COLUMN IF EXISTS uom_quantity, DROP COLUMN IF EXISTS uom_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS uom_factor, DROP COLUMN IF EXISTS uom_quantity, DROP COLUMN IF EXISTS uom_id;
+DROP TABLE IF EXISTS product_uoms;
+DROP TABLE IF EXISTS units_of_measure;
@@ -0,0 +1,54 @@
+-- Units of measure products can be bought, sold and moved in
+CREATE TABLE units_of_measure (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO units_of_measure (code, name) VALUES ('EA', 'Each');
+
+-- Units a product can be handled in and how many base units each holds.
+-- Stock levels are always kept in the base unit; a product has at most one
+-- unit marked as its base, which must have a factor of 1.
+CREATE TABLE product_uoms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    uom_id UUID NOT NULL REFERENCES units_of_measure(id),
+    factor INTEGER NOT NULL CHECK (factor > 0),
+    is_base BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(product_id, uom_id),
+    CHECK (NOT is_base OR factor = 1)
+);
+
+CREATE UNIQUE INDEX idx_product_uoms_base ON product_uoms(product_id) WHERE is_base;
+
+-- Quantities entered in another unit are converted to base units for
+-- quantity, and the entered unit, quantity and factor are kept alongside for
+-- display. The factor is copied so later changes to it leave documents as
+-- they were.
+ALTER TABLE stock_movements
+    ADD COLUMN uom_id UUID REFERENCES units_of_measure(id),
+    ADD COLUMN uom_quantity INTEGER,
+    ADD COLUMN uom_factor INTEGER CHECK (uom_factor > 0);
+ALTER TABLE purchase_order_items
+    ADD COLUMN uom_id UUID REFERENCES units_of_measure(id),
+    ADD COLUMN uom_quantity INTEGER,
+    ADD COLUMN uom_factor INTEGER CHECK (uom_factor > 0);
+ALTER TABLE sales_order_items
+    ADD COLUMN uom_id UUID REFERENCES units_of_measure(id),
+    ADD COLUMN uom_quantity INTEGER,
+    ADD COLUMN uom_factor INTEGER CHECK (uom_factor > 0);
+
+-- Prices per base unit come from dividing a price per case or pack, so keep
+-- them to four decimal places like unit costs
+ALTER TABLE stock_movements ALTER COLUMN cost_price TYPE DECIMAL(12,4);
+ALTER TABLE purchase_order_items ALTER COLUMN unit_price TYPE DECIMAL(12,4);
+ALTER TABLE sales_order_items ALTER COLUMN unit_price TYPE DECIMAL(12,4);
+
+CREATE TRIGGER update_units_of_measure_updated_at BEFORE UPDATE ON units_of_measure FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_product_uoms_updated_at BEFORE UPDATE ON product_uoms FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_assembly_order_components_updated_at ON assembly_order_components;
+DROP TRIGGER IF EXISTS update_assembly_orders_updated_at ON assembly_orders;
+DROP TRIGGER IF EXISTS update_bom_components_updated_at ON bom_components;
+DROP TABLE IF EXISTS assembly_order_components;
+DROP TABLE IF EXISTS assembly_orders;
+DROP TABLE IF EXISTS bom_components;
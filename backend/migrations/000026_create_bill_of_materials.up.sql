@@ -0,0 +1,59 @@
+-- Bill of materials: a kit product is made of component products, each with
+-- the quantity of base units one kit takes. Kits may themselves be
+-- components of other kits but never of themselves, directly or indirectly.
+CREATE TABLE bom_components (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(product_id, component_id),
+    CHECK (product_id <> component_id)
+);
+
+-- Assembly orders build kits from their components; disassembly orders break
+-- kits back into them. Completing an order posts all of its movements in one
+-- transaction, referenced as 'assembly_order'.
+CREATE TABLE assembly_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_number VARCHAR(50) UNIQUE NOT NULL,
+    order_type VARCHAR(20) NOT NULL CHECK (order_type IN ('assembly', 'disassembly')),
+    product_id UUID NOT NULL REFERENCES products(id),
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'completed', 'cancelled')),
+    unit_cost DECIMAL(12,4),
+    total_cost DECIMAL(12,4),
+    notes TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    completed_by UUID REFERENCES users(id),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Components of an order, copied from the bill of materials when the order
+-- was created so later changes to it leave open orders as they were. Costs
+-- are what completion consumed or allocated.
+CREATE TABLE assembly_order_components (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    assembly_order_id UUID NOT NULL REFERENCES assembly_orders(id) ON DELETE CASCADE,
+    component_id UUID NOT NULL REFERENCES products(id),
+    quantity_per INTEGER NOT NULL CHECK (quantity_per > 0),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost DECIMAL(12,4),
+    total_cost DECIMAL(12,4),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(assembly_order_id, component_id)
+);
+
+CREATE INDEX idx_bom_components_component_id ON bom_components(component_id);
+CREATE INDEX idx_assembly_orders_product_id ON assembly_orders(product_id);
+CREATE INDEX idx_assembly_orders_status ON assembly_orders(status);
+CREATE INDEX idx_assembly_order_components_order_id ON assembly_order_components(assembly_order_id);
+
+CREATE TRIGGER update_bom_components_updated_at BEFORE UPDATE ON bom_components FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_assembly_orders_updated_at BEFORE UPDATE ON assembly_orders FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_assembly_order_components_updated_at BEFORE UPDATE ON assembly_order_components FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Corrections already posted keep their reason code, so it is only removed
+-- when unused
+DELETE FROM adjustment_reason_codes
+WHERE code = 'ledger_correction'
+  AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE reason_code = 'ledger_correction');
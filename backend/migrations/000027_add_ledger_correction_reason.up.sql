@@ -0,0 +1,5 @@
+-- Reason code for the adjustments stock reconciliation writes to bring the
+-- movement ledger back in line with stock_levels
+INSERT INTO adjustment_reason_codes (code, name, description) VALUES
+('ledger_correction', 'Ledger Correction', 'Ledger entry for stock held without a matching movement, posted by stock reconciliation')
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_stock_movements_product_warehouse_effective_date;
+DROP TABLE IF EXISTS stock_snapshots;
@@ -0,0 +1,18 @@
+-- Periodic snapshots of every product/warehouse balance as the movement
+-- ledger stood at snapshot_at. Point-in-time queries start from the latest
+-- snapshot at or before the requested time and replay only the movements
+-- after it. Back-dated movements delete the snapshots they fall before.
+CREATE TABLE stock_snapshots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    snapshot_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(product_id, warehouse_id, snapshot_at)
+);
+
+CREATE INDEX idx_stock_snapshots_snapshot_at ON stock_snapshots(snapshot_at);
+
+-- Movements are replayed per balance by the time they took effect
+CREATE INDEX idx_stock_movements_product_warehouse_effective_date ON stock_movements(product_id, warehouse_id, (COALESCE(processed_date, created_at)));
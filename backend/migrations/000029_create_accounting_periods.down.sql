@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_accounting_periods_updated_at ON accounting_periods;
+DROP TABLE IF EXISTS accounting_period_balances;
+DROP TABLE IF EXISTS accounting_periods;
@@ -0,0 +1,34 @@
+-- Monthly accounting periods. A period covers [starts_at, ends_at); once
+-- closed, no movement may be processed inside it until an admin reopens it.
+CREATE TABLE accounting_periods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(20) NOT NULL UNIQUE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL UNIQUE,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    closed_by UUID REFERENCES users(id),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    reopened_by UUID REFERENCES users(id),
+    reopened_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+-- Quantity and value of every product/warehouse at the end of a period,
+-- recorded when it is closed
+CREATE TABLE accounting_period_balances (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    period_id UUID NOT NULL REFERENCES accounting_periods(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    total_value DECIMAL(14,4) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(period_id, product_id, warehouse_id)
+);
+
+CREATE INDEX idx_accounting_periods_ends_at ON accounting_periods(ends_at);
+
+CREATE TRIGGER update_accounting_periods_updated_at BEFORE UPDATE ON accounting_periods FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_stock_movements_reference_id;
+DROP INDEX IF EXISTS idx_stock_movements_reversal_of_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS reversal_of_id;
+
+DELETE FROM adjustment_reason_codes
+WHERE code = 'reversal'
+  AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE reason_code = 'reversal');
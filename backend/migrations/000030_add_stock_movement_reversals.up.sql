@@ -0,0 +1,10 @@
+-- A reversal is an equal-and-opposite movement pointing at the movement it
+-- undoes. Each movement can be reversed once.
+ALTER TABLE stock_movements ADD COLUMN reversal_of_id UUID REFERENCES stock_movements(id);
+
+CREATE UNIQUE INDEX idx_stock_movements_reversal_of_id ON stock_movements(reversal_of_id) WHERE reversal_of_id IS NOT NULL;
+CREATE INDEX idx_stock_movements_reference_id ON stock_movements(reference_id);
+
+INSERT INTO adjustment_reason_codes (code, name, description) VALUES
+('reversal', 'Reversal', 'Equal-and-opposite entry undoing a mistaken movement')
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,3 @@
+UPDATE stock_movements
+SET reference_type = 'transfer'
+WHERE reference_type = 'warehouse_transfer';
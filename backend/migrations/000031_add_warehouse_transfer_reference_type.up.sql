@@ -0,0 +1,8 @@
+-- Immediate transfers were posted under the same 'transfer' reference type
+-- as transfer documents. Their legs reference no stock_transfers row, so
+-- they are moved to a reference type of their own.
+UPDATE stock_movements sm
+SET reference_type = 'warehouse_transfer'
+WHERE sm.reference_type = 'transfer'
+  AND sm.movement_type IN ('in', 'out')
+  AND NOT EXISTS (SELECT 1 FROM stock_transfers st WHERE st.id = sm.reference_id);
@@ -0,0 +1,2 @@
+DELETE FROM cost_layers WHERE stock_movement_id IS NULL;
+ALTER TABLE cost_layers ALTER COLUMN stock_movement_id SET NOT NULL;
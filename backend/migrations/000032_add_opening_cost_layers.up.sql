@@ -0,0 +1,4 @@
+-- Recosting starts from the balances recorded at the end of the latest
+-- closed accounting period. Under FIFO that opening stock is one layer that
+-- belongs to no receipt.
+ALTER TABLE cost_layers ALTER COLUMN stock_movement_id DROP NOT NULL;
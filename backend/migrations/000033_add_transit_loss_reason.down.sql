@@ -0,0 +1,5 @@
+-- Write-offs already posted keep their reason code, so it is only removed
+-- when unused
+DELETE FROM adjustment_reason_codes
+WHERE code = 'transit_loss'
+  AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE reason_code = 'transit_loss');
@@ -0,0 +1,5 @@
+-- Reason code for the adjustments that write off the shortfall of a stock
+-- transfer received short and closed
+INSERT INTO adjustment_reason_codes (code, name, description) VALUES
+('transit_loss', 'Transit Loss', 'Stock dispatched on a transfer that never arrived, written off when the transfer is closed')
+ON CONFLICT (code) DO NOTHING;
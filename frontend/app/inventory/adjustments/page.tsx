@@ -35,9 +35,16 @@ interface AdjustmentItem {
   warehouse_id: string
   warehouse_name: string
   quantity: number
+  reason_code: string
   reason: string
 }
 
+interface ReasonCode {
+  id: string
+  code: string
+  name: string
+}
+
 interface Adjustment {
   id: string
   reference_id: string
@@ -56,6 +63,7 @@ export default function AdjustmentsPage() {
   const [adjustments, setAdjustments] = useState<Adjustment[]>([])
   const [products, setProducts] = useState<Product[]>([])
   const [warehouses, setWarehouses] = useState<Warehouse[]>([])
+  const [reasonCodes, setReasonCodes] = useState<ReasonCode[]>([])
   const [isLoadingData, setIsLoadingData] = useState(false)
   const [searchTerm, setSearchTerm] = useState('')
   const [filteredAdjustments, setFilteredAdjustments] = useState<Adjustment[]>([])
@@ -70,6 +78,7 @@ export default function AdjustmentsPage() {
   const [selectedProduct, setSelectedProduct] = useState<Product | null>(null)
   const [selectedWarehouse, setSelectedWarehouse] = useState<Warehouse | null>(null)
   const [adjustmentQuantity, setAdjustmentQuantity] = useState(1)
+  const [adjustmentReasonCode, setAdjustmentReasonCode] = useState('')
   const [adjustmentReason, setAdjustmentReason] = useState('')
   const [adjustmentDate, setAdjustmentDate] = useState(new Date().toISOString().split('T')[0])
 
@@ -85,6 +94,7 @@ export default function AdjustmentsPage() {
       loadAdjustments()
       loadProducts()
       loadWarehouses()
+      loadReasonCodes()
     }
   }, [user])
 
@@ -124,7 +134,7 @@ export default function AdjustmentsPage() {
       const adjustmentsMap = new Map<string, Adjustment>()
       
       movements
-        .filter((movement: any) => movement.reference_type === 'adjustment')
+        .filter((movement: any) => movement.movement_type === 'adjustment' || movement.reference_type === 'adjustment')
         .forEach((movement: any) => {
           const refId = movement.reference_id
           if (!refId) return
@@ -150,6 +160,7 @@ export default function AdjustmentsPage() {
             warehouse_id: movement.warehouse_id,
             warehouse_name: movement.warehouse_name,
             quantity: movement.quantity,
+            reason_code: movement.reason_code || '',
             reason: movement.reason || 'No reason provided'
           })
         })
@@ -180,6 +191,15 @@ export default function AdjustmentsPage() {
     }
   }
 
+  const loadReasonCodes = async () => {
+    try {
+      const response = await api.get('/adjustment-reasons')
+      setReasonCodes(response.data.reason_codes || [])
+    } catch (error) {
+      console.error('Error loading reason codes:', error)
+    }
+  }
+
   const formatDate = (dateString: string) => {
     return new Date(dateString).toLocaleDateString('en-US', {
       year: 'numeric',
@@ -201,7 +221,7 @@ export default function AdjustmentsPage() {
   }
 
   const handleAddItem = () => {
-    if (!selectedProduct || !selectedWarehouse || adjustmentQuantity === 0 || !adjustmentReason.trim()) {
+    if (!selectedProduct || !selectedWarehouse || adjustmentQuantity === 0 || !adjustmentReasonCode) {
       alert('Please fill in all required fields')
       return
     }
@@ -213,6 +233,7 @@ export default function AdjustmentsPage() {
       warehouse_id: selectedWarehouse.id,
       warehouse_name: selectedWarehouse.name,
       quantity: adjustmentQuantity,
+      reason_code: adjustmentReasonCode,
       reason: adjustmentReason
     }
 
@@ -222,6 +243,7 @@ export default function AdjustmentsPage() {
     setSelectedProduct(null)
     setSelectedWarehouse(null)
     setAdjustmentQuantity(1)
+    setAdjustmentReasonCode('')
     setAdjustmentReason('')
   }
 
@@ -241,10 +263,11 @@ export default function AdjustmentsPage() {
         await api.post('/stock-movements', {
           product_id: item.product_id,
           warehouse_id: item.warehouse_id,
-          movement_type: item.quantity > 0 ? 'in' : 'out',
-          quantity: Math.abs(item.quantity),
+          movement_type: 'adjustment',
+          quantity: item.quantity,
           reference_type: 'adjustment',
-          reason: item.reason,
+          reason_code: item.reason_code,
+          reason: item.reason || undefined,
           processed_date: adjustmentDate
         })
       }
@@ -651,15 +674,31 @@ export default function AdjustmentsPage() {
                   </div>
                   
                   <div className="space-y-2">
-                    <Label htmlFor="reason">Reason *</Label>
-                    <Input
-                      id="reason"
-                      value={adjustmentReason}
-                      onChange={(e) => setAdjustmentReason(e.target.value)}
-                      placeholder="Reason for adjustment"
-                    />
+                    <Label>Reason Code *</Label>
+                    <Select value={adjustmentReasonCode} onValueChange={setAdjustmentReasonCode}>
+                      <SelectTrigger>
+                        <SelectValue placeholder="Select a reason" />
+                      </SelectTrigger>
+                      <SelectContent>
+                        {reasonCodes.map((reasonCode) => (
+                          <SelectItem key={reasonCode.id} value={reasonCode.code}>
+                            {reasonCode.name}
+                          </SelectItem>
+                        ))}
+                      </SelectContent>
+                    </Select>
                   </div>
                 </div>
+
+                <div className="space-y-2">
+                  <Label htmlFor="reason">Notes</Label>
+                  <Input
+                    id="reason"
+                    value={adjustmentReason}
+                    onChange={(e) => setAdjustmentReason(e.target.value)}
+                    placeholder="Additional details"
+                  />
+                </div>
                 
                 <Button onClick={handleAddItem} className="w-full bg-[#52a852] hover:bg-[#4a964a] text-white">
                   Add Item
@@ -40,6 +40,8 @@ interface Product {
   description?: string
   unit_price: number
   min_stock_level: number
+  tracks_expiry?: boolean
+  serialized?: boolean
 }
 
 interface StockInItem {
@@ -50,6 +52,9 @@ interface StockInItem {
   quantity: number
   cost_price: number
   reason?: string
+  lot_number?: string
+  expiry_date?: string
+  serial_numbers?: string[]
   selected: boolean
 }
 
@@ -87,6 +92,9 @@ export default function NewStockMovementPage() {
   const [productQuantity, setProductQuantity] = useState(0)
   const [productCost, setProductCost] = useState(0)
   const [productCostDisplay, setProductCostDisplay] = useState('')
+  const [productLotNumber, setProductLotNumber] = useState('')
+  const [productExpiryDate, setProductExpiryDate] = useState('')
+  const [productSerials, setProductSerials] = useState('')
   const [isSubmitting, setIsSubmitting] = useState(false)
   const [showSuccessMessage, setShowSuccessMessage] = useState(false)
   const [showCancelDialog, setShowCancelDialog] = useState(false)
@@ -238,6 +246,9 @@ export default function NewStockMovementPage() {
     setProductQuantity(0)
     setProductCost(0)
     setProductCostDisplay('')
+    setProductLotNumber('')
+    setProductExpiryDate('')
+    setProductSerials('')
     // Focus on quantity field after product selection
     setTimeout(() => {
       const quantityInput = document.querySelector('input[type="number"]') as HTMLInputElement
@@ -254,6 +265,21 @@ export default function NewStockMovementPage() {
       return
     }
 
+    // Products with a shelf life are received into a lot with an expiry date
+    if (selectedProduct.tracks_expiry && (!productLotNumber.trim() || !productExpiryDate)) {
+      alert(`${selectedProduct.name} tracks expiry; enter a lot number and expiry date`)
+      return
+    }
+    const lotNumber = productLotNumber.trim() || undefined
+    const expiryDate = productExpiryDate || undefined
+
+    // Serialized products are received with one serial number per unit
+    const serialNumbers = productSerials.split(/[\n,]/).map(s => s.trim()).filter(Boolean)
+    if (selectedProduct.serialized && serialNumbers.length !== productQuantity) {
+      alert(`${selectedProduct.name} is serialized; enter ${productQuantity} serial number(s)`)
+      return
+    }
+
     // Validate cost price is lower than unit price
     if (productCost >= selectedProduct.unit_price) {
       alert(`Cost price ($${productCost.toFixed(2)}) must be lower than unit price ($${selectedProduct.unit_price.toFixed(2)})`)
@@ -264,7 +290,9 @@ export default function NewStockMovementPage() {
     console.log('Current stockInItems before add:', stockInItems)
 
     // Check if product already exists in stockInItems
-    const existingItemIndex = stockInItems.findIndex(item => item.product_id === selectedProduct.id)
+    const existingItemIndex = stockInItems.findIndex(
+      item => item.product_id === selectedProduct.id && item.lot_number === lotNumber
+    )
     
     if (existingItemIndex >= 0) {
       // Update existing item
@@ -275,6 +303,10 @@ export default function NewStockMovementPage() {
                 ...item, 
                 quantity: item.quantity + productQuantity,
                 cost_price: productCost,
+                expiry_date: expiryDate ?? item.expiry_date,
+                serial_numbers: selectedProduct.serialized
+                  ? [...(item.serial_numbers || []), ...serialNumbers]
+                  : undefined,
                 selected: true
               }
             : item
@@ -292,6 +324,9 @@ export default function NewStockMovementPage() {
         quantity: productQuantity,
         cost_price: productCost,
         reason: '',
+        lot_number: lotNumber,
+        expiry_date: expiryDate,
+        serial_numbers: selectedProduct.serialized ? serialNumbers : undefined,
         selected: true,
       }
       setStockInItems(prev => {
@@ -306,6 +341,9 @@ export default function NewStockMovementPage() {
     setProductQuantity(0)
     setProductCost(0)
     setProductCostDisplay('')
+    setProductLotNumber('')
+    setProductExpiryDate('')
+    setProductSerials('')
     setSearchTerm('')
     setIsProductDialogOpen(false)
     
@@ -407,21 +445,33 @@ export default function NewStockMovementPage() {
     try {
       setIsSubmitting(true)
       
-      // Create purchase order first
+      // Create purchase order first, with the received items as its lines
+      const supplier = suppliers.find(s => s.id === data.supplier_id)
+      const supplierContact = supplier?.email || supplier?.phone || undefined
       const purchaseOrderData = {
         po_number: data.reference_number,
-        supplier_name: data.supplier_name,
-        supplier_contact: data.supplier_contact,
-        order_date: new Date().toISOString().split('T')[0],
-        expected_delivery_date: data.expected_delivery_date,
+        supplier_name: supplier?.name || '',
+        supplier_contact: supplierContact,
+        order_date: new Date().toISOString(),
         notes: data.notes || '',
         created_by: user?.id || '',
+        items: selectedItems.map(item => ({
+          product_id: item.product_id,
+          quantity: item.quantity,
+          unit_price: item.cost_price > 0 ? item.cost_price : 0,
+        })),
       }
 
       console.log('Creating purchase order:', purchaseOrderData)
       const purchaseOrderResponse = await api.post('/purchase-orders', purchaseOrderData)
       const purchaseOrderId = purchaseOrderResponse.data.id
 
+      // Goods can only be received against an approved order. Orders within the
+      // approval limit are approved on creation; larger ones need a manager or admin.
+      if (purchaseOrderResponse.data.status === 'pending') {
+        await api.post(`/purchase-orders/${purchaseOrderId}/approve`, {})
+      }
+
       // Upload documents if any
       if (uploadedDocuments.length > 0) {
         const formData = new FormData()
@@ -441,6 +491,7 @@ export default function NewStockMovementPage() {
       // Create stock movements
       const bulkRequest = {
         supplier_id: data.supplier_id,
+        purchase_order_id: purchaseOrderId,
         reference_number: data.reference_number || undefined,
         processed_by: user?.id || data.processed_by,
         processed_date: new Date(data.received_date).toISOString(),
@@ -450,6 +501,9 @@ export default function NewStockMovementPage() {
           quantity: item.quantity,
           cost_price: item.cost_price > 0 ? item.cost_price : undefined,
           reason: item.reason || undefined,
+          lot_number: item.lot_number,
+          expiry_date: item.expiry_date ? new Date(item.expiry_date).toISOString() : undefined,
+          serial_numbers: item.serial_numbers,
         }))
       }
 
@@ -833,6 +887,38 @@ export default function NewStockMovementPage() {
                                       )}
                                     </div>
                                   </div>
+                                  {selectedProduct?.tracks_expiry && (
+                                    <div className="grid grid-cols-2 gap-4">
+                                      <div>
+                                        <Label htmlFor="lot_number">Lot Number *</Label>
+                                        <Input
+                                          id="lot_number"
+                                          value={productLotNumber}
+                                          onChange={(e) => setProductLotNumber(e.target.value)}
+                                        />
+                                      </div>
+                                      <div>
+                                        <Label htmlFor="expiry_date">Expiry Date *</Label>
+                                        <Input
+                                          id="expiry_date"
+                                          type="date"
+                                          value={productExpiryDate}
+                                          onChange={(e) => setProductExpiryDate(e.target.value)}
+                                        />
+                                      </div>
+                                    </div>
+                                  )}
+                                  {selectedProduct?.serialized && (
+                                    <div>
+                                      <Label htmlFor="serial_numbers">Serial Numbers * (one per line)</Label>
+                                      <Textarea
+                                        id="serial_numbers"
+                                        value={productSerials}
+                                        onChange={(e) => setProductSerials(e.target.value)}
+                                        placeholder="One serial number per unit received"
+                                      />
+                                    </div>
+                                  )}
                                   <Button
                                     type="button"
                                     onClick={handleAddProduct}
@@ -885,6 +971,11 @@ export default function NewStockMovementPage() {
                               <TableCell>
                                 <div>
                                   <div className="font-medium">{item.product_name}</div>
+                                  {item.lot_number && (
+                                    <div className="text-sm text-gray-500">
+                                      Lot {item.lot_number}{item.expiry_date ? `, expires ${item.expiry_date}` : ''}
+                                    </div>
+                                  )}
                                 </div>
                               </TableCell>
                               <TableCell className="font-mono">{item.product_sku}</TableCell>
@@ -20,6 +20,7 @@ interface Product {
   name: string
   sku: string
   unit_price: number
+  serialized?: boolean
 }
 
 interface Warehouse {
@@ -38,6 +39,7 @@ interface TransferItem {
   to_warehouse_name: string
   quantity: number
   reason: string
+  serial_numbers?: string[]
 }
 
 interface Transfer {
@@ -74,6 +76,7 @@ export default function TransfersPage() {
   const [toWarehouse, setToWarehouse] = useState<Warehouse | null>(null)
   const [transferQuantity, setTransferQuantity] = useState(1)
   const [transferReason, setTransferReason] = useState('')
+  const [transferSerials, setTransferSerials] = useState('')
   const [transferDate, setTransferDate] = useState(new Date().toISOString().split('T')[0])
 
   useEffect(() => {
@@ -127,7 +130,7 @@ export default function TransfersPage() {
       const transfersMap = new Map<string, Transfer>()
       
       movements
-        .filter((movement: any) => movement.reference_type === 'transfer')
+        .filter((movement: any) => movement.reference_type === 'warehouse_transfer')
         .forEach((movement: any) => {
           const refId = movement.reference_id
           if (!refId) return
@@ -216,6 +219,13 @@ export default function TransfersPage() {
       return
     }
 
+    // Serialized products move named units, one serial number per unit
+    const serialNumbers = transferSerials.split(/[\n,]/).map(s => s.trim()).filter(Boolean)
+    if (selectedProduct.serialized && serialNumbers.length !== transferQuantity) {
+      alert(`${selectedProduct.name} is serialized; enter ${transferQuantity} serial number(s)`)
+      return
+    }
+
     const newItem: TransferItem = {
       product_id: selectedProduct.id,
       product_name: selectedProduct.name,
@@ -225,7 +235,8 @@ export default function TransfersPage() {
       to_warehouse_id: toWarehouse.id,
       to_warehouse_name: toWarehouse.name,
       quantity: transferQuantity,
-      reason: transferReason
+      reason: transferReason,
+      serial_numbers: selectedProduct.serialized ? serialNumbers : undefined,
     }
 
     setTransferItems([...transferItems, newItem])
@@ -236,6 +247,7 @@ export default function TransfersPage() {
     setToWarehouse(null)
     setTransferQuantity(1)
     setTransferReason('')
+    setTransferSerials('')
   }
 
   const handleRemoveItem = (index: number) => {
@@ -249,28 +261,20 @@ export default function TransfersPage() {
     }
 
     try {
-      // Create stock movements for each item (out from source, in to destination)
+      // Post each item as an atomic transfer (paired out/in movements)
       for (const item of transferItems) {
-        // Create outgoing movement from source warehouse
-        await api.post('/stock-movements', {
-          product_id: item.product_id,
-          warehouse_id: item.from_warehouse_id,
-          movement_type: 'out',
-          quantity: item.quantity,
-          reference_type: 'transfer',
-          reason: item.reason,
-          processed_date: transferDate
-        })
-
-        // Create incoming movement to destination warehouse
-        await api.post('/stock-movements', {
-          product_id: item.product_id,
-          warehouse_id: item.to_warehouse_id,
-          movement_type: 'in',
-          quantity: item.quantity,
-          reference_type: 'transfer',
+        await api.post('/stock-movements/transfer', {
+          from_warehouse_id: item.from_warehouse_id,
+          to_warehouse_id: item.to_warehouse_id,
           reason: item.reason,
-          processed_date: transferDate
+          processed_date: new Date(transferDate).toISOString(),
+          items: [
+            {
+              product_id: item.product_id,
+              quantity: item.quantity,
+              serial_numbers: item.serial_numbers,
+            },
+          ],
         })
       }
 
@@ -709,6 +713,18 @@ export default function TransfersPage() {
                     placeholder="Reason for transfer"
                   />
                 </div>
+
+                {selectedProduct?.serialized && (
+                  <div className="space-y-2">
+                    <Label htmlFor="serial_numbers">Serial Numbers * (one per line)</Label>
+                    <Textarea
+                      id="serial_numbers"
+                      value={transferSerials}
+                      onChange={(e) => setTransferSerials(e.target.value)}
+                      placeholder="Serial numbers of the units to transfer"
+                    />
+                  </div>
+                )}
                 
                 <Button onClick={handleAddItem} className="w-full bg-[#52a852] hover:bg-[#4a964a] text-white">
                   Add Item
@@ -18,7 +18,7 @@ interface PurchaseOrder {
   supplier_name: string
   supplier_contact?: string
   total_amount: number
-  status: 'pending' | 'approved' | 'received' | 'cancelled'
+  status: 'draft' | 'pending' | 'approved' | 'rejected' | 'ordered' | 'partially_received' | 'received' | 'cancelled'
   order_date: string
   expected_delivery_date?: string
   received_date?: string
@@ -118,10 +118,18 @@ export default function PurchaseOrdersPage() {
 
   const getStatusColor = (status: string) => {
     switch (status) {
+      case 'draft':
+        return 'bg-gray-100 text-gray-800'
       case 'pending':
         return 'bg-yellow-100 text-yellow-800'
       case 'approved':
         return 'bg-blue-100 text-blue-800'
+      case 'rejected':
+        return 'bg-orange-100 text-orange-800'
+      case 'ordered':
+        return 'bg-cyan-100 text-cyan-800'
+      case 'partially_received':
+        return 'bg-indigo-100 text-indigo-800'
       case 'received':
         return 'bg-green-100 text-green-800'
       case 'cancelled':
@@ -298,7 +306,7 @@ export default function PurchaseOrdersPage() {
                         </div>
                         <div className="flex items-center gap-2">
                           <Badge className={getStatusColor(order.status)}>
-                            {order.status.charAt(0).toUpperCase() + order.status.slice(1)}
+                            {order.status.charAt(0).toUpperCase() + order.status.slice(1).replace('_', ' ')}
                           </Badge>
                           <span className="text-lg font-semibold text-gray-900">
                             ${order.total_amount.toFixed(2)}
@@ -9,6 +9,7 @@ import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
 import { Label } from '@/components/ui/label'
 import { Textarea } from '@/components/ui/textarea'
+import { Checkbox } from '@/components/ui/checkbox'
 import {
   Dialog,
   DialogContent,
@@ -47,6 +48,8 @@ const productSchema = z.object({
   supplier_id: z.string().min(1, 'Supplier is required'),
   unit_price: z.number().min(0, 'Unit price must be greater than or equal to 0'),
   min_stock_level: z.number().min(0, 'Minimum stock level must be greater than or equal to 0'),
+  tracks_expiry: z.boolean(),
+  serialized: z.boolean(),
 })
 
 type ProductForm = z.infer<typeof productSchema>
@@ -62,6 +65,8 @@ interface Product {
   supplier?: string
   unit_price: number
   min_stock_level?: number
+  tracks_expiry?: boolean
+  serialized?: boolean
   is_active: boolean
   created_at: string
   updated_at: string
@@ -121,6 +126,8 @@ export default function ProductsPage() {
       supplier_id: '',
       unit_price: 0,
       min_stock_level: 0,
+      tracks_expiry: false,
+      serialized: false,
     },
   })
 
@@ -218,6 +225,8 @@ export default function ProductsPage() {
         supplier_id: data.supplier_id,
         unit_price: data.unit_price,
         min_stock_level: data.min_stock_level,
+        tracks_expiry: data.tracks_expiry,
+        serialized: data.serialized,
       }
       
       await api.post('/products', createData)
@@ -241,6 +250,8 @@ export default function ProductsPage() {
         supplier_id: product.supplier_id,
         unit_price: product.unit_price,
         min_stock_level: product.min_stock_level || 0,
+        tracks_expiry: product.tracks_expiry || false,
+        serialized: product.serialized || false,
       })
     }, 0)
   }
@@ -257,6 +268,8 @@ export default function ProductsPage() {
         supplier_id: data.supplier_id,
         unit_price: data.unit_price,
         min_stock_level: data.min_stock_level,
+        tracks_expiry: data.tracks_expiry,
+        serialized: data.serialized,
       }
       
       await api.put(`/products/${editingProduct.id}`, updateData)
@@ -332,6 +345,8 @@ export default function ProductsPage() {
                     supplier_id: '',
                     unit_price: 0,
                     min_stock_level: 0,
+                    tracks_expiry: false,
+                    serialized: false,
                   })
                 }
               }}>
@@ -460,6 +475,22 @@ export default function ProductsPage() {
                         </p>
                       )}
                     </div>
+                    <div className="flex items-center gap-2">
+                      <Checkbox
+                        id="tracks_expiry"
+                        checked={form.watch('tracks_expiry')}
+                        onCheckedChange={(checked) => form.setValue('tracks_expiry', checked === true)}
+                      />
+                      <Label htmlFor="tracks_expiry">Track expiry dates (receipts need a lot and expiry date)</Label>
+                    </div>
+                    <div className="flex items-center gap-2">
+                      <Checkbox
+                        id="serialized"
+                        checked={form.watch('serialized')}
+                        onCheckedChange={(checked) => form.setValue('serialized', checked === true)}
+                      />
+                      <Label htmlFor="serialized">Serialized (every movement names the serial numbers moved)</Label>
+                    </div>
                     <DialogFooter>
                       <Button
                         type="button"
@@ -597,6 +628,22 @@ export default function ProductsPage() {
                         </p>
                       )}
                     </div>
+                    <div className="flex items-center gap-2">
+                      <Checkbox
+                        id="edit-tracks_expiry"
+                        checked={form.watch('tracks_expiry')}
+                        onCheckedChange={(checked) => form.setValue('tracks_expiry', checked === true)}
+                      />
+                      <Label htmlFor="edit-tracks_expiry">Track expiry dates (receipts need a lot and expiry date)</Label>
+                    </div>
+                    <div className="flex items-center gap-2">
+                      <Checkbox
+                        id="edit-serialized"
+                        checked={form.watch('serialized')}
+                        onCheckedChange={(checked) => form.setValue('serialized', checked === true)}
+                      />
+                      <Label htmlFor="edit-serialized">Serialized (every movement names the serial numbers moved)</Label>
+                    </div>
                     <DialogFooter>
                       <Button
                         type="button"